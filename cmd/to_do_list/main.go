@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,13 +14,19 @@ import (
 	"toDoList/internal/repository/inmemory"
 	"toDoList/internal/server"
 	auth "toDoList/internal/server/auth/user_auth"
+	"toDoList/internal/server/eventbus"
+	"toDoList/internal/server/presence"
 	"toDoList/internal/server/workers"
+	"toDoList/pkg/blob"
 	"toDoList/pkg/logger"
 
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+// eventReplaySize - сколько последних событий хранится для возобновления потоков SSE.
+const eventReplaySize = 1000
+
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -34,11 +41,12 @@ func main() {
 	cfg := internal.ReadConfig()
 
 	log := logger.Init(cfg.Debug)
-	log.Debug().Any("config", cfg).Send()
+	log.Debug().Object("config", cfg).Send()
 
 	log.Info().Msg("Server starting...")
 
 	var database server.Storage
+	var runInTx server.TxRunner
 
 	// конфигураця и создание хранилища
 	postgresDB, err := db.NewStorage(cfg.DNS)
@@ -46,8 +54,14 @@ func main() {
 		log.Err(err).Msg("Postgres недоступен (%v), используем in-memory storage")
 		inmemoryDB := inmemory.NewInMemoryStorage()
 		database = inmemoryDB
+		runInTx = func(fn func(tx server.Storage) error) error {
+			return inmemoryDB.InTx(func(tx *inmemory.Storage) error { return fn(tx) })
+		}
 	} else {
 		database = postgresDB
+		runInTx = func(fn func(tx server.Storage) error) error {
+			return postgresDB.InTx(func(tx *db.Storage) error { return fn(tx) })
+		}
 		// запуск миграции
 		if err = db.Migrations(cfg.DNS, cfg.MigratePath); err != nil {
 			cancel()
@@ -56,6 +70,8 @@ func main() {
 		}
 	}
 	taskDeleter := workers.NewTaskBatchDeleter(ctx, database, cfg.TaskCapacity, log)
+	reminderNotifier := workers.LogNotifier{Log: log}
+	reminderScheduler := workers.NewReminderScheduler(ctx, database, reminderNotifier, internal.MinOne, log)
 
 	signer := auth.HS256Signer{
 		Secret:     []byte("ultraSecretKey123"),
@@ -65,7 +81,34 @@ func main() {
 		RefreshTTL: internal.WeekOne,
 	}
 
-	srv := server.NewServer(cfg, database, signer, taskDeleter)
+	webhookDispatcher := workers.NewWebhookDispatcher(ctx, database, internal.SecFive, log)
+	eventBus := eventbus.NewBus(eventReplaySize)
+	outboxPublisher := workers.MultiPublisher{workers.LogPublisher{Log: log}, eventBus, webhookDispatcher}
+	outboxRelay := workers.NewOutboxRelay(ctx, database, outboxPublisher, internal.SecTwo, log)
+
+	presenceHub := presence.NewHub(ctx, eventBus, log)
+
+	idempotencyCleaner := workers.NewIdempotencyKeyCleaner(ctx, database, internal.MinFive, log)
+
+	blobs, err := newBlobStore(cfg)
+	if err != nil {
+		cancel()
+		log.Error().Err(err).Msg("failed to configure attachment storage")
+		return
+	}
+	blobCollector := workers.NewBlobCollector(ctx, database, blobs, internal.MinOne, log)
+
+	srv := server.NewServer(
+		cfg,
+		database,
+		runInTx,
+		signer,
+		taskDeleter,
+		webhookDispatcher,
+		eventBus,
+		presenceHub,
+		blobs,
+	)
 
 	wg := sync.WaitGroup{}
 
@@ -87,6 +130,42 @@ func main() {
 		taskDeleter.Start()
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		reminderScheduler.Start()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		webhookDispatcher.Start()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		outboxRelay.Start()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		presenceHub.Start()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		idempotencyCleaner.Start()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		blobCollector.Start()
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -110,3 +189,21 @@ func main() {
 
 	wg.Wait()
 }
+
+// newBlobStore - хранилище файлов вложений по cfg.BlobDriver.
+func newBlobStore(cfg internal.Config) (blob.Store, error) {
+	switch cfg.BlobDriver {
+	case internal.BlobDriverLocal:
+		return blob.NewLocal(cfg.BlobDir)
+	case internal.BlobDriverS3:
+		return blob.NewS3(blob.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob driver %q", cfg.BlobDriver)
+	}
+}
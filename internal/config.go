@@ -4,9 +4,11 @@ import (
 	"cmp"
 	"encoding/json"
 	"flag"
+	"net/url"
 	"os"
 	"strconv"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -18,6 +20,16 @@ const (
 	defMigrationsPath = "migrations"
 	defTaskCapacity   = 10
 	defSecureProtocol = false
+	defBlobDriver     = BlobDriverLocal
+	defBlobDir        = "attachments"
+	// defMaxAttachmentSize - 25 МиБ.
+	defMaxAttachmentSize = 25 << 20
+)
+
+// Драйверы хранилища файлов вложений.
+const (
+	BlobDriverLocal = "local"
+	BlobDriverS3    = "s3"
 )
 
 type Config struct {
@@ -30,19 +42,80 @@ type Config struct {
 	SecureProtocol bool   `json:"secure_protocol"`
 	CertCert       string `json:"cert_cert"`
 	KeyCert        string `json:"key_cert"`
+	// BlobDriver - local хранит вложения в каталоге BlobDir, s3 - в бакете S3-совместимого хранилища.
+	BlobDriver        string `json:"blob_driver"`
+	BlobDir           string `json:"blob_dir"`
+	S3Endpoint        string `json:"s3_endpoint"`
+	S3Region          string `json:"s3_region"`
+	S3Bucket          string `json:"s3_bucket"`
+	S3AccessKey       string `json:"s3_access_key"`
+	S3SecretKey       string `json:"s3_secret_key"`
+	MaxAttachmentSize int64  `json:"max_attachment_size"`
+}
+
+// redacted - значение секрета в логах.
+const redacted = "xxxxx"
+
+// MarshalZerologObject - конфиг для логов: пароль из строки подключения и секретный ключ S3 скрыты.
+func (c Config) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("host", c.Host).
+		Int("port", c.Port).
+		Str("dns", redactDNS(c.DNS)).
+		Str("migrate_path", c.MigratePath).
+		Bool("debug", c.Debug).
+		Int("task_capacity", c.TaskCapacity).
+		Bool("secure_protocol", c.SecureProtocol).
+		Str("cert_cert", c.CertCert).
+		Str("key_cert", c.KeyCert).
+		Str("blob_driver", c.BlobDriver).
+		Str("blob_dir", c.BlobDir).
+		Str("s3_endpoint", c.S3Endpoint).
+		Str("s3_region", c.S3Region).
+		Str("s3_bucket", c.S3Bucket).
+		Str("s3_access_key", c.S3AccessKey).
+		Str("s3_secret_key", redactSecret(c.S3SecretKey)).
+		Int64("max_attachment_size", c.MaxAttachmentSize)
+}
+
+// redactDNS - строка подключения без пароля. Строку, которая не разбирается как URL, целиком не показываем:
+// в формате key=value пароль не вырезать надёжно.
+func redactDNS(dns string) string {
+	u, err := url.Parse(dns)
+	if err != nil || u.Scheme == "" {
+		return redactSecret(dns)
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	return u.String()
+}
+
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
 }
 
 type Flags struct {
-	ConfigPath     string
-	Host           string
-	Port           int
-	DNS            string
-	MigratePath    string
-	Debug          bool
-	TaskCapacity   int
-	SecureProtocol bool
-	CertCert       string
-	KeyCert        string
+	ConfigPath        string
+	Host              string
+	Port              int
+	DNS               string
+	MigratePath       string
+	Debug             bool
+	TaskCapacity      int
+	SecureProtocol    bool
+	CertCert          string
+	KeyCert           string
+	BlobDriver        string
+	BlobDir           string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKey       string
+	S3SecretKey       string
+	MaxAttachmentSize int64
 }
 
 // Дефолты не указывал, так как заданы отдельно.
@@ -59,6 +132,14 @@ func parseFlags() Flags {
 	flag.BoolVar(&flags.SecureProtocol, "s", false, "Use HTTPS")
 	flag.StringVar(&flags.CertCert, "cert", "", "Path to Cert file")
 	flag.StringVar(&flags.KeyCert, "key-cert", "", "Path to Cert Key file")
+	flag.StringVar(&flags.BlobDriver, "blob-driver", "", "Attachment storage driver: local or s3")
+	flag.StringVar(&flags.BlobDir, "blob-dir", "", "Attachments folder for local driver")
+	flag.StringVar(&flags.S3Endpoint, "s3-endpoint", "", "S3 endpoint URL")
+	flag.StringVar(&flags.S3Region, "s3-region", "", "S3 region")
+	flag.StringVar(&flags.S3Bucket, "s3-bucket", "", "S3 bucket")
+	flag.StringVar(&flags.S3AccessKey, "s3-access-key", "", "S3 access key")
+	flag.StringVar(&flags.S3SecretKey, "s3-secret-key", "", "S3 secret key")
+	flag.Int64Var(&flags.MaxAttachmentSize, "max-attachment-size", 0, "Max attachment size in bytes")
 
 	flag.Parse()
 
@@ -67,15 +148,23 @@ func parseFlags() Flags {
 
 func configFromFlags(flags *Flags) Config {
 	return Config{
-		Host:           flags.Host,
-		Port:           flags.Port,
-		DNS:            flags.DNS,
-		MigratePath:    flags.MigratePath,
-		Debug:          flags.Debug,
-		TaskCapacity:   flags.TaskCapacity,
-		SecureProtocol: flags.SecureProtocol,
-		CertCert:       flags.CertCert,
-		KeyCert:        flags.KeyCert,
+		Host:              flags.Host,
+		Port:              flags.Port,
+		DNS:               flags.DNS,
+		MigratePath:       flags.MigratePath,
+		Debug:             flags.Debug,
+		TaskCapacity:      flags.TaskCapacity,
+		SecureProtocol:    flags.SecureProtocol,
+		CertCert:          flags.CertCert,
+		KeyCert:           flags.KeyCert,
+		BlobDriver:        flags.BlobDriver,
+		BlobDir:           flags.BlobDir,
+		S3Endpoint:        flags.S3Endpoint,
+		S3Region:          flags.S3Region,
+		S3Bucket:          flags.S3Bucket,
+		S3AccessKey:       flags.S3AccessKey,
+		S3SecretKey:       flags.S3SecretKey,
+		MaxAttachmentSize: flags.MaxAttachmentSize,
 	}
 }
 
@@ -91,6 +180,14 @@ func configFromEnv() Config {
 	cfg.SecureProtocol, _ = strconv.ParseBool(os.Getenv("SECURE_PROTOCOL"))
 	cfg.CertCert = os.Getenv("CERT_FILE")
 	cfg.KeyCert = os.Getenv("KEY_FILE")
+	cfg.BlobDriver = os.Getenv("BLOB_DRIVER")
+	cfg.BlobDir = os.Getenv("BLOB_DIR")
+	cfg.S3Endpoint = os.Getenv("S3_ENDPOINT")
+	cfg.S3Region = os.Getenv("S3_REGION")
+	cfg.S3Bucket = os.Getenv("S3_BUCKET")
+	cfg.S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+	cfg.S3SecretKey = os.Getenv("S3_SECRET_KEY")
+	cfg.MaxAttachmentSize, _ = strconv.ParseInt(os.Getenv("MAX_ATTACHMENT_SIZE"), 10, 64)
 
 	return cfg
 }
@@ -121,13 +218,16 @@ func configFromFile(path string) Config {
 
 func defaultConfig() Config {
 	return Config{
-		Host:           defHost,
-		Port:           defPort,
-		DNS:            defDNS,
-		MigratePath:    defMigrationsPath,
-		Debug:          defDebug,
-		TaskCapacity:   defTaskCapacity,
-		SecureProtocol: defSecureProtocol,
+		Host:              defHost,
+		Port:              defPort,
+		DNS:               defDNS,
+		MigratePath:       defMigrationsPath,
+		Debug:             defDebug,
+		TaskCapacity:      defTaskCapacity,
+		SecureProtocol:    defSecureProtocol,
+		BlobDriver:        defBlobDriver,
+		BlobDir:           defBlobDir,
+		MaxAttachmentSize: defMaxAttachmentSize,
 	}
 }
 
@@ -205,6 +305,62 @@ func ReadConfig() Config {
 		defCfg.KeyCert,
 	)
 
+	config.BlobDriver = cmp.Or(
+		flagCfg.BlobDriver,
+		envCfg.BlobDriver,
+		fileCfg.BlobDriver,
+		defCfg.BlobDriver,
+	)
+
+	config.BlobDir = cmp.Or(
+		flagCfg.BlobDir,
+		envCfg.BlobDir,
+		fileCfg.BlobDir,
+		defCfg.BlobDir,
+	)
+
+	config.S3Endpoint = cmp.Or(
+		flagCfg.S3Endpoint,
+		envCfg.S3Endpoint,
+		fileCfg.S3Endpoint,
+		defCfg.S3Endpoint,
+	)
+
+	config.S3Region = cmp.Or(
+		flagCfg.S3Region,
+		envCfg.S3Region,
+		fileCfg.S3Region,
+		defCfg.S3Region,
+	)
+
+	config.S3Bucket = cmp.Or(
+		flagCfg.S3Bucket,
+		envCfg.S3Bucket,
+		fileCfg.S3Bucket,
+		defCfg.S3Bucket,
+	)
+
+	config.S3AccessKey = cmp.Or(
+		flagCfg.S3AccessKey,
+		envCfg.S3AccessKey,
+		fileCfg.S3AccessKey,
+		defCfg.S3AccessKey,
+	)
+
+	config.S3SecretKey = cmp.Or(
+		flagCfg.S3SecretKey,
+		envCfg.S3SecretKey,
+		fileCfg.S3SecretKey,
+		defCfg.S3SecretKey,
+	)
+
+	config.MaxAttachmentSize = cmp.Or(
+		flagCfg.MaxAttachmentSize,
+		envCfg.MaxAttachmentSize,
+		fileCfg.MaxAttachmentSize,
+		defCfg.MaxAttachmentSize,
+	)
+
 	if config.CertCert == "" || config.KeyCert == "" {
 		config.SecureProtocol = false
 	}
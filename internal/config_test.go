@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_MarshalZerologObjectRedactsSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    []string
+		secrets []string
+	}{
+		{
+			name: "url dns and s3 secret",
+			cfg: Config{
+				DNS:         "postgres://app:db-password@db:5432/todo?sslmode=disable",
+				S3AccessKey: "AKIAEXAMPLE",
+				S3SecretKey: "s3-secret",
+			},
+			want:    []string{"postgres://app:xxxxx@db:5432/todo?sslmode=disable", "AKIAEXAMPLE"},
+			secrets: []string{"db-password", "s3-secret"},
+		},
+		{
+			name:    "key value dns",
+			cfg:     Config{DNS: "host=db user=app password=db-password"},
+			secrets: []string{"db-password"},
+		},
+		{
+			name: "no password",
+			cfg:  Config{DNS: "postgres://app@db/todo"},
+			want: []string{"postgres://app@db/todo", `"s3_secret_key":""`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := zerolog.New(&buf)
+			logger.Debug().Object("config", tt.cfg).Send()
+
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+			for _, secret := range tt.secrets {
+				assert.NotContains(t, buf.String(), secret)
+			}
+		})
+	}
+}
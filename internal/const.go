@@ -5,6 +5,7 @@ import "time"
 const (
 	MinFifteen            = 15 * time.Minute
 	WeekOne               = 24 * 7 * time.Hour
+	DayOne                = 24 * time.Hour
 	SecTen                = 10 * time.Second
 	SecFive               = 5 * time.Second
 	MinOne                = 60 * time.Second
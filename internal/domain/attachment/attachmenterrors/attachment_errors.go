@@ -0,0 +1,13 @@
+package attachmenterrors
+
+import "errors"
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrAttachmentType     = errors.New("attachment type is not allowed")
+	ErrWrongAttachment    = errors.New("wrong attachment")
+	ErrNotAttachmentOwner = errors.New("only the uploader or the task owner can delete the attachment")
+	// ErrBlobStorage - сбой хранилища объектов, а не ошибка в запросе.
+	ErrBlobStorage = errors.New("blob storage failed")
+)
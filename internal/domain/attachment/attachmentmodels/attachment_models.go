@@ -0,0 +1,29 @@
+package attachmentmodels
+
+import "time"
+
+// DefaultMaxSize - ограничение размера вложения, если в конфиге не задано другое.
+const DefaultMaxSize = 25 << 20
+
+// AllowedContentTypes - типы, которые можно загружать. Тип определяется по содержимому файла,
+// а не по заголовку клиента.
+var AllowedContentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+// Attachment - файл, приложенный к задаче. Содержимое лежит в хранилище объектов под BlobKey.
+type Attachment struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	UserID      string    `json:"user_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	BlobKey     string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,9 @@
+package commenterrors
+
+import "errors"
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrNotCommentAuthor = errors.New("only the author can change the comment")
+	ErrWrongComment     = errors.New("wrong comment")
+)
@@ -0,0 +1,30 @@
+package commentmodels
+
+import "time"
+
+// MaxBodyLength - ограничение длины текста комментария в символах.
+const MaxBodyLength = 10000
+
+// Comment - комментарий к задаче, Body - текст в Markdown. Ответ ссылается на комментарий
+// той же задачи через ParentID. Mentions - ID упомянутых участников проекта задачи.
+// Удалённый комментарий остаётся в ветке без текста, чтобы ответы на него не потерялись.
+type Comment struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	ParentID  string     `json:"parent_id,omitempty"`
+	AuthorID  string     `json:"author_id"`
+	Body      string     `json:"body"`
+	Mentions  []string   `json:"mentions,omitempty"`
+	Deleted   bool       `json:"deleted"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+}
+
+type CommentRequest struct {
+	Body     string `json:"body"`
+	ParentID string `json:"parent_id"`
+}
+
+type CommentUpdateRequest struct {
+	Body string `json:"body"`
+}
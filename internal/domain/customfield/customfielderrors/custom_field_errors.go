@@ -0,0 +1,11 @@
+package customfielderrors
+
+import "errors"
+
+var (
+	ErrCustomFieldNotFound       = errors.New("custom field not found")
+	ErrCustomFieldIsAlreadyExist = errors.New("custom field with this name already exists in the project")
+	ErrWrongCustomField          = errors.New("wrong custom field")
+	ErrWrongCustomFieldValue     = errors.New("wrong custom field value")
+	ErrWrongCustomFieldSort      = errors.New("wrong custom field sort")
+)
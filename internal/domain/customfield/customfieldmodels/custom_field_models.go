@@ -0,0 +1,64 @@
+package customfieldmodels
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	MaxFieldsPerProject = 50
+	MaxNameLength       = 50
+	MaxOptions          = 100
+	MaxOptionLength     = 100
+	MaxTextLength       = 2000
+)
+
+// DateLayout - значения полей типа date хранятся датой без времени.
+const DateLayout = time.DateOnly
+
+type FieldType string
+
+const (
+	TypeText        FieldType = "text"
+	TypeNumber      FieldType = "number"
+	TypeDate        FieldType = "date"
+	TypeSelect      FieldType = "select"
+	TypeMultiSelect FieldType = "multi_select"
+	// TypeUser - ID участника проекта.
+	TypeUser FieldType = "user"
+)
+
+func (t FieldType) IsValid() bool {
+	switch t {
+	case TypeText, TypeNumber, TypeDate, TypeSelect, TypeMultiSelect, TypeUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasOptions - значение выбирается из Options.
+func (t FieldType) HasOptions() bool {
+	return t == TypeSelect || t == TypeMultiSelect
+}
+
+// CustomField - определение пользовательского поля проекта. Значения полей хранятся в атрибутах
+// задачи по ID определения.
+type CustomField struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"project_id"`
+	Name      string    `json:"name"`
+	Type      FieldType `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (f CustomField) HasOption(option string) bool {
+	return slices.Contains(f.Options, option)
+}
+
+type CustomFieldRequest struct {
+	Name    string    `json:"name"    validate:"required"`
+	Type    FieldType `json:"type"    validate:"required"`
+	Options []string  `json:"options"`
+}
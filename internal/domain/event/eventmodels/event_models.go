@@ -0,0 +1,102 @@
+package eventmodels
+
+import (
+	"encoding/json"
+	"time"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/user/usermodels"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	TaskCreated       EventType = "task.created"
+	TaskUpdated       EventType = "task.updated"
+	TaskStatusChanged EventType = "task.status_changed"
+	TaskDeleted       EventType = "task.deleted"
+	UserCreated       EventType = "user.created"
+	UserUpdated       EventType = "user.updated"
+	UserDeleted       EventType = "user.deleted"
+	CommentMentioned  EventType = "comment.mentioned"
+)
+
+const (
+	AggregateTask    = "task"
+	AggregateUser    = "user"
+	AggregateComment = "comment"
+)
+
+const (
+	// MaxPublishAttempts - после стольких неудачных публикаций событие получает отметку dead: оно остаётся
+	// в outbox для разбора и больше не задерживает события своего агрегата.
+	MaxPublishAttempts = 10
+	publishBaseBackoff = time.Second
+	publishMaxBackoff  = 10 * time.Minute
+)
+
+// Event - доменное событие из outbox. Seq растёт в порядке записи, события одного агрегата
+// публикуются строго по возрастанию Seq.
+type Event struct {
+	Seq           int64           `json:"seq"`
+	ID            string          `json:"id"`
+	Type          EventType       `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	UserID        string          `json:"user_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+// UserPayload - пользователь в событиях, без пароля.
+type UserPayload struct {
+	UUID  string `json:"uuid"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// NewTaskEvent - событие задачи, получатель - её владелец.
+func NewTaskEvent(eventType EventType, task taskmodels.Task) (Event, error) {
+	return newEvent(eventType, AggregateTask, task.ID, task.UserID, task)
+}
+
+func NewUserEvent(eventType EventType, user usermodels.User) (Event, error) {
+	return newEvent(eventType, AggregateUser, user.UUID, user.UUID,
+		UserPayload{UUID: user.UUID, Name: user.Name, Email: user.Email})
+}
+
+// NewMentionEvent - событие об упоминании в комментарии, получатель - упомянутый пользователь.
+func NewMentionEvent(comment commentmodels.Comment, userID string) (Event, error) {
+	return newEvent(CommentMentioned, AggregateComment, comment.ID, userID, comment)
+}
+
+func newEvent(eventType EventType, aggregateType string, aggregateID string, userID string, payload any) (
+	Event, error,
+) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:            uuid.New().String(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		UserID:        userID,
+		Payload:       data,
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
+
+// PublishBackoff - через сколько повторить публикацию после attempts неудачных: задержка удваивается
+// с каждой попыткой, пока не дойдёт до publishMaxBackoff.
+func PublishBackoff(attempts int) time.Duration {
+	delay := publishBaseBackoff
+	for i := 1; i < attempts && delay < publishMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, publishMaxBackoff)
+}
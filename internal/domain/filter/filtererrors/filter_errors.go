@@ -0,0 +1,9 @@
+package filtererrors
+
+import "errors"
+
+var (
+	ErrFilterNotFound       = errors.New("filter not found")
+	ErrFilterIsAlreadyExist = errors.New("filter with this name is already exist")
+	ErrWrongFilterQuery     = errors.New("wrong filter query")
+)
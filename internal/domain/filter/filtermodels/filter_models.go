@@ -0,0 +1,81 @@
+package filtermodels
+
+import (
+	"time"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+// Filter - сохранённый фильтр задач. Query хранится как есть и разбирается при каждом применении,
+// поэтому относительные даты вроде due<7d отсчитываются от момента запроса.
+type Filter struct {
+	ID        string    `json:"id"         validate:"required"`
+	UserID    string    `json:"user_id"    validate:"required"`
+	Name      string    `json:"name"       validate:"required"`
+	Query     string    `json:"query"      validate:"required"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FilterRequest struct {
+	Name  string `json:"name"  validate:"required,min=1,max=64"`
+	Query string `json:"query" validate:"required,min=1,max=1000"`
+}
+
+// Field - поле задачи, по которому фильтрует условие.
+type Field string
+
+const (
+	FieldStatus   Field = "status"
+	FieldPriority Field = "priority"
+	FieldTag      Field = "tag"
+	FieldProject  Field = "project"
+	FieldAssignee Field = "assignee"
+	FieldDue      Field = "due"
+	// FieldText - полнотекстовый поиск по заголовку и описанию, к нему относятся и слова без поля.
+	FieldText Field = "text"
+)
+
+// Operator - сравнение в условии, порядок есть только у FieldDue.
+type Operator string
+
+const (
+	OpEq        Operator = ":"
+	OpLess      Operator = "<"
+	OpLessEq    Operator = "<="
+	OpGreater   Operator = ">"
+	OpGreaterEq Operator = ">="
+)
+
+// Expr - проверенное выражение фильтра: And, Or, Not или Condition.
+type Expr interface {
+	isExpr()
+}
+
+type And struct {
+	Left  Expr
+	Right Expr
+}
+
+type Or struct {
+	Left  Expr
+	Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+// Condition - условие на поле задачи. Пустой Value у project, assignee и due с OpEq означает,
+// что поле не заполнено. Для сравнений due граница лежит в Time, а Value не используется.
+// Language - конфигурация, которой разбирается текст условия FieldText.
+type Condition struct {
+	Field    Field
+	Op       Operator
+	Value    string
+	Time     time.Time
+	Language taskmodels.SearchLanguage
+}
+
+func (And) isExpr()       {}
+func (Or) isExpr()        {}
+func (Not) isExpr()       {}
+func (Condition) isExpr() {}
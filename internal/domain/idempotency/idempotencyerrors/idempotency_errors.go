@@ -0,0 +1,10 @@
+package idempotencyerrors
+
+import "errors"
+
+var (
+	ErrWrongKey          = errors.New("idempotency key must be from 1 to 255 characters")
+	ErrKeyReused         = errors.New("idempotency key is already used with another request")
+	ErrRequestInProgress = errors.New("request with this idempotency key is still in progress")
+	ErrKeyNotFound       = errors.New("idempotency key not found")
+)
@@ -0,0 +1,29 @@
+package idempotencymodels
+
+import "time"
+
+const (
+	// KeyHeader - заголовок, которым клиент помечает повторы одного и того же запроса.
+	KeyHeader = "Idempotency-Key"
+	// ReplayedHeader - выставляется в ответе, повторённом из сохранённого.
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+const MaxKeyLength = 255
+
+// Record - ключ идемпотентности пользователя вместе с отпечатком запроса и сохранённым ответом.
+// Пока запрос выполняется, StatusCode равен нулю.
+type Record struct {
+	UserID      string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	Header      map[string]string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (r Record) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,9 @@
+package presenceerrors
+
+import "errors"
+
+var (
+	ErrUnknownMessage = errors.New("unknown message type")
+	ErrWrongActivity  = errors.New("activity must be viewing, typing or editing")
+	ErrNotInRoom      = errors.New("join the task room first")
+)
@@ -0,0 +1,61 @@
+package presencemodels
+
+import "toDoList/internal/domain/task/taskmodels"
+
+type MessageType string
+
+// Сообщения клиента.
+const (
+	MessageJoin     MessageType = "join"
+	MessageLeave    MessageType = "leave"
+	MessageActivity MessageType = "activity"
+)
+
+// Сообщения сервера. Изменения задачи приходят с типом доменного события, например task.updated.
+const (
+	MessagePresence MessageType = "presence"
+	MessageJoined   MessageType = "joined"
+	MessageLeft     MessageType = "left"
+	MessageError    MessageType = "error"
+)
+
+type Activity string
+
+const (
+	ActivityViewing Activity = "viewing"
+	ActivityTyping  Activity = "typing"
+	ActivityEditing Activity = "editing"
+)
+
+func (a Activity) IsValid() bool {
+	switch a {
+	case ActivityViewing, ActivityTyping, ActivityEditing:
+		return true
+	default:
+		return false
+	}
+}
+
+// ClientMessage - {"type": "join", "task_id": "..."} или {"type": "activity", "task_id": "...", "activity": "typing"}.
+type ClientMessage struct {
+	Type     MessageType `json:"type"`
+	TaskID   string      `json:"task_id"`
+	Activity Activity    `json:"activity,omitempty"`
+}
+
+// Member - участник комнаты задачи.
+type Member struct {
+	UserID   string   `json:"user_id"`
+	Activity Activity `json:"activity"`
+}
+
+// ServerMessage - Task имеет то же представление, что и в REST API.
+type ServerMessage struct {
+	Type     MessageType      `json:"type"`
+	TaskID   string           `json:"task_id,omitempty"`
+	UserID   string           `json:"user_id,omitempty"`
+	Activity Activity         `json:"activity,omitempty"`
+	Members  []Member         `json:"members,omitempty"`
+	Task     *taskmodels.Task `json:"task,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
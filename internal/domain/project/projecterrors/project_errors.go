@@ -0,0 +1,12 @@
+package projecterrors
+
+import "errors"
+
+var (
+	ErrProjectNotFound       = errors.New("project not found")
+	ErrProjectIsAlreadyExist = errors.New("project is already exist")
+	ErrNotProjectMember      = errors.New("user is not a member of the project")
+	ErrNotProjectOwner       = errors.New("only project owner can manage members")
+	ErrMemberIsAlreadyExist  = errors.New("user is already a member of the project")
+	ErrCantRemoveOwner       = errors.New("project owner can't be removed from members")
+)
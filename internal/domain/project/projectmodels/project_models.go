@@ -0,0 +1,16 @@
+package projectmodels
+
+type Project struct {
+	ID      string   `json:"id"                validate:"required"`
+	OwnerID string   `json:"owner_id"          validate:"required"`
+	Name    string   `json:"name"              validate:"required"`
+	Members []string `json:"members,omitempty"`
+}
+
+type ProjectRequest struct {
+	Name string `json:"name" validate:"required,min=1"`
+}
+
+type ProjectMemberRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+}
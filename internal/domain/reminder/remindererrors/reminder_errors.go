@@ -0,0 +1,10 @@
+package remindererrors
+
+import "errors"
+
+var (
+	ErrReminderNotFound     = errors.New("reminder not found")
+	ErrWrongReminder        = errors.New("expected either at or non-negative before_minutes")
+	ErrReminderInPast       = errors.New("reminder time is in the past")
+	ErrReminderNeedsDueDate = errors.New("relative reminder requires task due date")
+)
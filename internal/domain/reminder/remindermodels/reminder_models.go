@@ -0,0 +1,44 @@
+package remindermodels
+
+import "time"
+
+type ReminderStatus string
+
+const (
+	StatusPending   ReminderStatus = "pending"
+	StatusSent      ReminderStatus = "sent"
+	StatusCancelled ReminderStatus = "cancelled"
+	StatusFailed    ReminderStatus = "failed"
+)
+
+// MaxAttempts - после стольких неудачных отправок напоминание помечается failed.
+const MaxAttempts = 5
+
+// Reminder - напоминание о задаче. Задаётся либо абсолютным временем At,
+// либо сдвигом BeforeMinutes относительно срока задачи: тогда перенос срока переносит и напоминание.
+type Reminder struct {
+	ID            string         `json:"id"`
+	TaskID        string         `json:"task_id"`
+	UserID        string         `json:"user_id"`
+	At            *time.Time     `json:"at,omitempty"`
+	BeforeMinutes *int           `json:"before_minutes,omitempty"`
+	Status        ReminderStatus `json:"status"`
+	Attempts      int            `json:"attempts"`
+	SentAt        *time.Time     `json:"sent_at,omitempty"`
+}
+
+// ReminderRequest - нужно указать ровно одно из полей.
+type ReminderRequest struct {
+	At            *time.Time `json:"at"`
+	BeforeMinutes *int       `json:"before_minutes"`
+}
+
+// Notification - то, что получает Notifier при срабатывании напоминания.
+type Notification struct {
+	ReminderID string     `json:"reminder_id"`
+	TaskID     string     `json:"task_id"`
+	UserID     string     `json:"user_id"`
+	TaskTitle  string     `json:"task_title"`
+	DueDate    *time.Time `json:"due_date,omitempty"`
+	FireAt     time.Time  `json:"fire_at"`
+}
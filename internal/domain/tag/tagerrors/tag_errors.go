@@ -0,0 +1,9 @@
+package tagerrors
+
+import "errors"
+
+var (
+	ErrTagNotFound       = errors.New("tag not found")
+	ErrTagIsAlreadyExist = errors.New("tag with this name is already exist")
+	ErrWrongTagMode      = errors.New("wrong tag mode, expected any or all")
+)
@@ -0,0 +1,20 @@
+package tagmodels
+
+// DefaultColor - цвет тега, если клиент его не указал.
+const DefaultColor = "#808080"
+
+type Tag struct {
+	ID     string `json:"id"      validate:"required"`
+	UserID string `json:"user_id" validate:"required"`
+	Name   string `json:"name"    validate:"required"`
+	Color  string `json:"color"   validate:"required,hexcolor"`
+}
+
+type TagRequest struct {
+	Name  string `json:"name"  validate:"required,min=1,max=64"`
+	Color string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+type TaskTagsRequest struct {
+	TagIDs []string `json:"tag_ids"`
+}
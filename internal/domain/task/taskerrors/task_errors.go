@@ -9,4 +9,35 @@ var (
 	ErrTaskIsAlreadyExist = errors.New("task is already exist")
 	ErrDBOnGet            = errors.New("db error on get")
 	ErrDBOnUpdate         = errors.New("db error on update")
+	ErrAssigneeNotMember  = errors.New("assignee is not a member of the task's project")
+	ErrWatcherNotMember   = errors.New("watcher is not a member of the task's project")
+	ErrWatcherIsExist     = errors.New("user is already watching the task")
+	ErrWatcherNotFound    = errors.New("user is not watching the task")
+	ErrWrongPriority      = errors.New("wrong priority")
+	ErrWrongMove          = errors.New("wrong move, expected before and/or after task in the right order")
+	ErrWrongParent        = errors.New("parent task must belong to the same project")
+	ErrSubtaskCycle       = errors.New("task can't be a subtask of itself or of its subtasks")
+	ErrSubtaskTooDeep     = errors.New("subtasks nesting is too deep")
+	ErrChecklistNotFound  = errors.New("checklist item not found")
+	ErrDependencyCycle    = errors.New("dependency would create a cycle")
+	ErrDependencyIsExist  = errors.New("dependency is already exist")
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrTaskBlocked        = errors.New("task is blocked by unfinished tasks")
+	ErrWrongRRule         = errors.New("wrong recurrence rule")
+	ErrRRuleNeedsDueDate  = errors.New("recurring task must have a due date")
+	ErrWrongEditScope     = errors.New("wrong edit scope, expected this or series")
+	ErrVersionConflict    = errors.New("task was modified by someone else, reload it and try again")
+	ErrWrongPatchType     = errors.New("wrong patch type, expected merge-patch+json or json-patch+json")
+	ErrWrongPatch         = errors.New("wrong patch")
+	ErrPatchTestFailed    = errors.New("patch test operation failed")
+	ErrWrongBulkMode      = errors.New("wrong bulk mode, expected atomic or best_effort")
+	ErrWrongBulkSize      = errors.New("wrong number of bulk operations")
+	ErrWrongBulkOperation = errors.New("wrong bulk operation")
+	ErrEmptySearchQuery   = errors.New("search query has no words to search for")
+	ErrWrongSearchLang    = errors.New("wrong search language, expected simple, english or russian")
+	ErrWrongSearchLimit   = errors.New("wrong search limit")
+	ErrRevisionNotFound   = errors.New("task revision not found")
+	ErrTransitionDenied   = errors.New("status transition is not allowed by the project workflow")
+	ErrWIPLimitReached    = errors.New("WIP limit of the status is reached")
+	ErrWrongBoardMove     = errors.New("wrong board move, expected status or neighbour tasks")
 )
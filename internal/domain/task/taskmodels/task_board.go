@@ -0,0 +1,27 @@
+package taskmodels
+
+// Board - доска проекта: по колонке на каждый статус workflow в его порядке.
+type Board struct {
+	ProjectID string        `json:"project_id"`
+	Columns   []BoardColumn `json:"columns"`
+}
+
+// BoardColumn - задачи статуса в ручном порядке. WIPLimit 0 - без ограничения.
+type BoardColumn struct {
+	Status   TaskStatus     `json:"status"`
+	Category StatusCategory `json:"category"`
+	WIPLimit int            `json:"wip_limit,omitempty"`
+	Count    int            `json:"count"`
+	Tasks    []Task         `json:"tasks"`
+}
+
+// BoardMoveRequest - перенести задачу в колонку Status и поставить её перед Before и/или после After.
+// Пустой Status оставляет задачу в её колонке, без Before и After позиция не меняется.
+// Ненулевая Version проверяется так же, как If-Match.
+type BoardMoveRequest struct {
+	TaskID  string     `json:"task_id"           validate:"required"`
+	Status  TaskStatus `json:"status,omitempty"`
+	Before  string     `json:"before,omitempty"`
+	After   string     `json:"after,omitempty"`
+	Version int64      `json:"version,omitempty"`
+}
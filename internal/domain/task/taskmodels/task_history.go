@@ -0,0 +1,207 @@
+package taskmodels
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+type HistoryAction string
+
+const (
+	HistoryCreated HistoryAction = "created"
+	HistoryUpdated HistoryAction = "updated"
+	HistoryDeleted HistoryAction = "deleted"
+)
+
+// FieldChange - значения поля до и после изменения в том виде, в каком поле отдаётся в JSON.
+type FieldChange struct {
+	Field  TaskField       `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// HistoryEntry - запись истории задачи. Revision - версия задачи после изменения,
+// так что откат к ревизии возвращает атрибуты, которые были у задачи этой версии.
+type HistoryEntry struct {
+	ID        string        `json:"id"`
+	TaskID    string        `json:"task_id"`
+	Revision  int64         `json:"revision"`
+	ActorID   string        `json:"actor_id"`
+	Action    HistoryAction `json:"action"`
+	Changes   []FieldChange `json:"changes"`
+	ChangedAt time.Time     `json:"changed_at"`
+}
+
+type RevertRequest struct {
+	Revision int64 `json:"revision"`
+}
+
+// seriesValue - значение FieldSeries, у которого две колонки.
+type seriesValue struct {
+	SeriesID   string `json:"series_id"`
+	Occurrence int    `json:"occurrence"`
+}
+
+// ChangedFields - поля, значения которых у old и updated различаются, в порядке AllTaskFields.
+func ChangedFields(old Task, updated Task) []TaskField {
+	a, b := old.Attributes, updated.Attributes
+
+	changed := map[TaskField]bool{
+		FieldStatus:       a.Status != b.Status,
+		FieldTitle:        a.Title != b.Title,
+		FieldDescription:  a.Description != b.Description,
+		FieldProjectID:    a.ProjectID != b.ProjectID,
+		FieldAssigneeID:   a.AssigneeID != b.AssigneeID,
+		FieldPriority:     a.Priority != b.Priority,
+		FieldParentID:     a.ParentID != b.ParentID,
+		FieldAutoComplete: a.AutoComplete != b.AutoComplete,
+		FieldDueDate: (a.DueDate == nil) != (b.DueDate == nil) ||
+			a.DueDate != nil && !a.DueDate.Equal(*b.DueDate),
+		FieldRRule:        a.RRule != b.RRule,
+		FieldSeries:       old.SeriesID != updated.SeriesID || old.Occurrence != updated.Occurrence,
+		FieldCustomFields: !EqualCustomFields(a.CustomFields, b.CustomFields),
+		FieldEstimate:     a.Estimate != b.Estimate,
+	}
+
+	var fields []TaskField
+	for _, field := range AllTaskFields {
+		if changed[field] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// EqualCustomFields - одинаковые ли значения пользовательских полей, nil и пустой набор не различаются.
+func EqualCustomFields(a map[string]any, b map[string]any) bool {
+	return len(a) == 0 && len(b) == 0 || reflect.DeepEqual(a, b)
+}
+
+func (t Task) fieldValue(field TaskField) (any, error) {
+	switch field {
+	case FieldStatus:
+		return t.Attributes.Status, nil
+	case FieldTitle:
+		return t.Attributes.Title, nil
+	case FieldDescription:
+		return t.Attributes.Description, nil
+	case FieldProjectID:
+		return t.Attributes.ProjectID, nil
+	case FieldAssigneeID:
+		return t.Attributes.AssigneeID, nil
+	case FieldPriority:
+		return t.Attributes.Priority, nil
+	case FieldParentID:
+		return t.Attributes.ParentID, nil
+	case FieldAutoComplete:
+		return t.Attributes.AutoComplete, nil
+	case FieldDueDate:
+		return t.Attributes.DueDate, nil
+	case FieldRRule:
+		return t.Attributes.RRule, nil
+	case FieldSeries:
+		return seriesValue{SeriesID: t.SeriesID, Occurrence: t.Occurrence}, nil
+	case FieldCustomFields:
+		return t.Attributes.CustomFields, nil
+	case FieldEstimate:
+		return t.Attributes.Estimate, nil
+	default:
+		return nil, fmt.Errorf("unknown task field %q", field)
+	}
+}
+
+func (t Task) marshalField(field TaskField) (json.RawMessage, error) {
+	value, err := t.fieldValue(field)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// SetField - записывает в поле задачи значение, сохранённое в FieldChange.
+func (t *Task) SetField(field TaskField, value json.RawMessage) error {
+	var dst any
+	var series seriesValue
+	switch field {
+	case FieldStatus:
+		dst = &t.Attributes.Status
+	case FieldTitle:
+		dst = &t.Attributes.Title
+	case FieldDescription:
+		dst = &t.Attributes.Description
+	case FieldProjectID:
+		dst = &t.Attributes.ProjectID
+	case FieldAssigneeID:
+		dst = &t.Attributes.AssigneeID
+	case FieldPriority:
+		dst = &t.Attributes.Priority
+	case FieldParentID:
+		dst = &t.Attributes.ParentID
+	case FieldAutoComplete:
+		dst = &t.Attributes.AutoComplete
+	case FieldDueDate:
+		t.Attributes.DueDate = nil
+		dst = &t.Attributes.DueDate
+	case FieldRRule:
+		dst = &t.Attributes.RRule
+	case FieldSeries:
+		dst = &series
+	case FieldCustomFields:
+		t.Attributes.CustomFields = nil
+		dst = &t.Attributes.CustomFields
+	case FieldEstimate:
+		dst = &t.Attributes.Estimate
+	default:
+		return fmt.Errorf("unknown task field %q", field)
+	}
+
+	if err := json.Unmarshal(value, dst); err != nil {
+		return err
+	}
+	if field == FieldSeries {
+		t.SeriesID, t.Occurrence = series.SeriesID, series.Occurrence
+	}
+	return nil
+}
+
+// NewHistoryEntry - запись об изменении задачи с old на updated. Учитываются только поля из fields,
+// значения которых действительно изменились. Ревизия и время берутся из updated и now,
+// ID записи назначает хранилище.
+func NewHistoryEntry(
+	action HistoryAction,
+	actorID string,
+	old Task,
+	updated Task,
+	fields []TaskField,
+	now time.Time,
+) (HistoryEntry, error) {
+	entry := HistoryEntry{
+		TaskID:    updated.ID,
+		Revision:  updated.Version,
+		ActorID:   actorID,
+		Action:    action,
+		Changes:   []FieldChange{},
+		ChangedAt: now.UTC(),
+	}
+
+	for _, field := range ChangedFields(old, updated) {
+		if !slices.Contains(fields, field) {
+			continue
+		}
+
+		before, err := old.marshalField(field)
+		if err != nil {
+			return HistoryEntry{}, err
+		}
+		after, err := updated.marshalField(field)
+		if err != nil {
+			return HistoryEntry{}, err
+		}
+		change := FieldChange{Field: field, Before: before, After: after}
+		entry.Changes = append(entry.Changes, change)
+	}
+	return entry, nil
+}
@@ -1,5 +1,12 @@
 package taskmodels
 
+import (
+	"time"
+	"toDoList/internal/domain/tag/tagmodels"
+)
+
+// TaskStatus - статус из workflow проекта задачи. Набор статусов задаёт workflow,
+// константы ниже - статусы workflow по умолчанию.
 type TaskStatus string
 
 const (
@@ -8,24 +15,310 @@ const (
 	StatusCompleted  TaskStatus = "Done"
 )
 
-func (s TaskStatus) IsValid() bool {
-	switch s {
-	case StatusNew, StatusInProgress, StatusCompleted:
+// StatusCategory - категория статуса: не начата, в работе или завершена. По категории,
+// а не по названию статуса, работают блокировки, напоминания, повторения и автозавершение.
+type StatusCategory string
+
+const (
+	CategoryTodo  StatusCategory = "todo"
+	CategoryDoing StatusCategory = "doing"
+	CategoryDone  StatusCategory = "done"
+)
+
+func (c StatusCategory) IsValid() bool {
+	switch c {
+	case CategoryTodo, CategoryDoing, CategoryDone:
+		return true
+	default:
+		return false
+	}
+}
+
+type TaskPriority string
+
+const (
+	PriorityNone   TaskPriority = "none"
+	PriorityLow    TaskPriority = "low"
+	PriorityMedium TaskPriority = "medium"
+	PriorityHigh   TaskPriority = "high"
+	PriorityUrgent TaskPriority = "urgent"
+)
+
+func (p TaskPriority) IsValid() bool {
+	switch p {
+	case PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
 		return true
 	default:
 		return false
 	}
 }
 
+// AssigneeMe - значение фильтра assignee, подставляющее текущего пользователя.
+const AssigneeMe = "me"
+
+type TagMode string
+
+const (
+	TagModeAny TagMode = "any"
+	TagModeAll TagMode = "all"
+)
+
+func (m TagMode) IsValid() bool {
+	return m == TagModeAny || m == TagModeAll
+}
+
+// MaxTaskDepth - максимальная вложенность подзадач, у корневой задачи глубина 1.
+const MaxTaskDepth = 5
+
 type Task struct {
 	ID         string         `json:"id,omitempty"         validate:"required"`
 	UserID     string         `json:"user_uid,omitempty"   validate:"required"`
 	Attributes TaskAttributes `json:"attributes,omitempty" validate:"required"`
-	Deleted    bool           `json:"-"`
+	// Category - категория статуса задачи в workflow её проекта, её проставляет сервис.
+	Category   StatusCategory  `json:"status_category,omitempty"`
+	Tags       []tagmodels.Tag `json:"tags,omitempty"`
+	Position   string          `json:"position,omitempty"`
+	Checklist  []ChecklistItem `json:"checklist,omitempty"`
+	Subtasks   []Task          `json:"subtasks,omitempty"`
+	BlockedBy  []string        `json:"blocked_by,omitempty"`
+	Blocking   []string        `json:"blocking,omitempty"`
+	SeriesID   string          `json:"series_id,omitempty"`
+	Occurrence int             `json:"occurrence,omitempty"`
+	// Version - растёт при каждом изменении задачи, из неё строится ETag.
+	Version int64 `json:"version"`
+	Deleted bool  `json:"-"`
+	// ChangedBy - кто меняет задачу, хранилище пишет его в историю. Пустой - владелец задачи.
+	ChangedBy string `json:"-"`
+}
+
+// EditScope - что менять у повторяющейся задачи: только это повторение или всю серию.
+type EditScope string
+
+const (
+	EditScopeThis   EditScope = "this"
+	EditScopeSeries EditScope = "series"
+)
+
+func (s EditScope) IsValid() bool {
+	return s == EditScopeThis || s == EditScopeSeries
+}
+
+// DependencyRequest - задача BlockerID должна быть завершена раньше текущей.
+type DependencyRequest struct {
+	BlockerID string `json:"blocker_id" validate:"required"`
+}
+
+// ChecklistItem - шаг внутри задачи, хранится вместе с задачей.
+type ChecklistItem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+type ChecklistItemRequest struct {
+	Title string `json:"title" validate:"required,min=1"`
+	Done  bool   `json:"done"`
+}
+
+// TaskFilter - фильтры списка задач. Пустой фильтр означает все задачи пользователя.
+type TaskFilter struct {
+	ProjectID  string
+	AssigneeID string
+	Tags       []string
+	TagMode    TagMode
+	// CustomFields - значения пользовательских полей проекта ProjectID по ID определения. Сервис
+	// приводит их к типу поля, для multi_select значение - срез из одного варианта, который должен
+	// быть среди выбранных.
+	CustomFields map[string]any
+	// SortField - ID пользовательского поля, по значению которого сортируются задачи. Задачи без
+	// значения идут последними.
+	SortField string
+	SortDesc  bool
+}
+
+func (f TaskFilter) IsEmpty() bool {
+	return f.ProjectID == "" && f.AssigneeID == "" && len(f.Tags) == 0 && len(f.CustomFields) == 0 &&
+		f.SortField == ""
 }
 
 type TaskAttributes struct {
-	Status      TaskStatus `json:"status"      validate:"required"`
-	Title       string     `json:"title"       validate:"required,min=1"`
-	Description string     `json:"description" validate:"required,min=1"`
+	Status      TaskStatus   `json:"status"                validate:"required"`
+	Title       string       `json:"title"                 validate:"required,min=1"`
+	Description string       `json:"description"           validate:"required,min=1"`
+	ProjectID   string       `json:"project_id,omitempty"`
+	AssigneeID  string       `json:"assignee_id,omitempty"`
+	Priority    TaskPriority `json:"priority,omitempty"`
+	// ParentID - родительская задача, пустая строка у корневых задач.
+	ParentID string `json:"parent_id,omitempty"`
+	// AutoComplete - завершить задачу, когда завершены все её подзадачи.
+	AutoComplete bool       `json:"auto_complete,omitempty"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	// RRule - правило повторения RFC 5545, например "FREQ=WEEKLY;BYDAY=MO".
+	RRule string `json:"rrule,omitempty"`
+	// CustomFields - значения пользовательских полей проекта по ID определения поля.
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+	// Estimate - оценка трудозатрат в минутах, 0 - без оценки.
+	Estimate int `json:"estimate,omitempty" validate:"min=0"`
+}
+
+// TaskField - изменяемое поле задачи. По списку полей хранилище пишет только изменённые колонки.
+type TaskField string
+
+const (
+	FieldStatus       TaskField = "status"
+	FieldTitle        TaskField = "title"
+	FieldDescription  TaskField = "description"
+	FieldProjectID    TaskField = "project_id"
+	FieldAssigneeID   TaskField = "assignee_id"
+	FieldPriority     TaskField = "priority"
+	FieldParentID     TaskField = "parent_id"
+	FieldAutoComplete TaskField = "auto_complete"
+	FieldDueDate      TaskField = "due_date"
+	FieldRRule        TaskField = "rrule"
+	// FieldSeries - серия задачи вместе с номером повторения.
+	FieldSeries       TaskField = "series"
+	FieldCustomFields TaskField = "custom_fields"
+	FieldEstimate     TaskField = "estimate"
+)
+
+// AllTaskFields - все изменяемые поля, полная замена атрибутов.
+var AllTaskFields = []TaskField{
+	FieldStatus, FieldTitle, FieldDescription, FieldProjectID, FieldAssigneeID, FieldPriority,
+	FieldParentID, FieldAutoComplete, FieldDueDate, FieldRRule, FieldSeries, FieldCustomFields, FieldEstimate,
+}
+
+// PatchType - формат тела PATCH, совпадает с Content-Type запроса.
+type PatchType string
+
+const (
+	PatchTypeMerge PatchType = "application/merge-patch+json"
+	PatchTypeJSON  PatchType = "application/json-patch+json"
+)
+
+func (t PatchType) IsValid() bool {
+	return t == PatchTypeMerge || t == PatchTypeJSON
+}
+
+// Assignment - запись о смене исполнителя задачи, пригодится для уведомлений.
+type Assignment struct {
+	TaskID     string    `json:"task_id"`
+	AssigneeID string    `json:"assignee_id"`
+	AssignedBy string    `json:"assigned_by"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// MoveTaskRequest - переместить задачу перед Before и/или после After (ID задач).
+type MoveTaskRequest struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// MaxBulkOperations - сколько операций можно передать в одном запросе POST /tasks/bulk.
+const MaxBulkOperations = 100
+
+type BulkAction string
+
+const (
+	BulkCreate BulkAction = "create"
+	BulkUpdate BulkAction = "update"
+	BulkStatus BulkAction = "status"
+	BulkDelete BulkAction = "delete"
+	BulkMove   BulkAction = "move"
+)
+
+// BulkMode - atomic выполняет все операции одной транзакцией, best_effort - каждую отдельно.
+type BulkMode string
+
+const (
+	BulkModeAtomic     BulkMode = "atomic"
+	BulkModeBestEffort BulkMode = "best_effort"
+)
+
+func (m BulkMode) IsValid() bool {
+	return m == BulkModeAtomic || m == BulkModeBestEffort
+}
+
+// BulkOperation - одна операция пакета. Attributes нужны для create и update, Status - для status,
+// Move - для move. Ненулевая Version проверяется так же, как If-Match.
+type BulkOperation struct {
+	Action     BulkAction       `json:"action"               validate:"required"`
+	TaskID     string           `json:"task_id,omitempty"`
+	Attributes *TaskAttributes  `json:"attributes,omitempty"`
+	Status     TaskStatus       `json:"status,omitempty"`
+	Move       *MoveTaskRequest `json:"move,omitempty"`
+	Version    int64            `json:"version,omitempty"`
+}
+
+type BulkRequest struct {
+	Mode       BulkMode        `json:"mode"`
+	Operations []BulkOperation `json:"operations"`
+}
+
+type BulkItemStatus string
+
+const (
+	BulkItemDone   BulkItemStatus = "done"
+	BulkItemFailed BulkItemStatus = "failed"
+	// BulkItemRolledBack - операция выполнилась, но транзакцию atomic откатила ошибка другой операции.
+	BulkItemRolledBack BulkItemStatus = "rolled_back"
+	// BulkItemSkipped - операция не выполнялась, потому что раньше неё в atomic случилась ошибка.
+	BulkItemSkipped BulkItemStatus = "skipped"
+)
+
+// BulkResult - результат операции с тем же индексом, что и в запросе.
+type BulkResult struct {
+	Index  int            `json:"index"`
+	TaskID string         `json:"task_id,omitempty"`
+	Status BulkItemStatus `json:"status"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// SearchLanguage - конфигурация полнотекстового поиска Postgres, по которой разбирается запрос.
+type SearchLanguage string
+
+const (
+	SearchSimple  SearchLanguage = "simple"
+	SearchEnglish SearchLanguage = "english"
+	// SearchRussian - русские слова сводятся к основе русским стеммером, латинские - английским.
+	SearchRussian SearchLanguage = "russian"
+	// DefaultSearchLanguage - конфигурация поиска, если язык не задан: поиск задач и текстовые условия фильтров.
+	DefaultSearchLanguage = SearchRussian
+)
+
+func (l SearchLanguage) IsValid() bool {
+	switch l {
+	case SearchSimple, SearchEnglish, SearchRussian:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	// SearchSnippetWords - сколько слов описания попадает во фрагмент результата.
+	SearchSnippetWords = 35
+	HighlightStart     = "<mark>"
+	HighlightStop      = "</mark>"
+)
+
+// SearchQuery - запрос в синтаксисе websearch_to_tsquery: слова, "фразы", or и -исключения.
+type SearchQuery struct {
+	Query    string
+	Language SearchLanguage
+	Limit    int
+}
+
+// SearchResult - найденная задача. Совпадения в заголовке весят больше, чем в описании.
+// Title и Snippet - заголовок и фрагмент описания с совпадениями, обёрнутыми в <mark>.
+type SearchResult struct {
+	Task    Task    `json:"task"`
+	Rank    float64 `json:"rank"`
+	Title   string  `json:"title"`
+	Snippet string  `json:"snippet"`
+}
+
+type WatcherRequest struct {
+	UserID string `json:"user_id"`
 }
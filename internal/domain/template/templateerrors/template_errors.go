@@ -0,0 +1,11 @@
+package templateerrors
+
+import "errors"
+
+var (
+	ErrTemplateNotFound        = errors.New("template not found")
+	ErrTemplateIsAlreadyExist  = errors.New("template with this name is already exist")
+	ErrNotTemplateOwner        = errors.New("only the template author can delete it")
+	ErrWrongTemplate           = errors.New("wrong template")
+	ErrMissingTemplateVariable = errors.New("template variable is not set")
+)
@@ -0,0 +1,88 @@
+package templatemodels
+
+import (
+	"regexp"
+	"slices"
+	"time"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+// MaxTemplateTasks - ограничение числа задач в шаблоне вместе с корневой.
+const MaxTemplateTasks = 100
+
+// Placeholder - плейсхолдер {{name}} в заголовке или описании задачи шаблона, пробелы внутри скобок
+// допускаются. Первая группа - имя переменной.
+var Placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Template - дерево задач для повторного создания. Личный шаблон без ProjectID видит только автор,
+// шаблон проекта - все участники проекта.
+type Template struct {
+	ID        string       `json:"id"`
+	UserID    string       `json:"user_id"`
+	ProjectID string       `json:"project_id,omitempty"`
+	Name      string       `json:"name"`
+	Task      TemplateTask `json:"task"`
+	// Variables - имена переменных из плейсхолдеров шаблона, их нужно передать при создании задач.
+	Variables []string  `json:"variables"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TemplateTask - задача шаблона. Checklist - заголовки пунктов, Tags - названия тегов.
+// DueOffset - срок в минутах от начала отсчёта, nil - задача без срока.
+type TemplateTask struct {
+	Title        string                  `json:"title"`
+	Description  string                  `json:"description"`
+	Priority     taskmodels.TaskPriority `json:"priority,omitempty"`
+	AutoComplete bool                    `json:"auto_complete,omitempty"`
+	Estimate     int                     `json:"estimate,omitempty"`
+	DueOffset    *int                    `json:"due_offset,omitempty"`
+	Checklist    []string                `json:"checklist,omitempty"`
+	Tags         []string                `json:"tags,omitempty"`
+	Subtasks     []TemplateTask          `json:"subtasks,omitempty"`
+}
+
+// Count - число задач в дереве вместе с самой задачей.
+func (t TemplateTask) Count() int {
+	count := 1
+	for _, subtask := range t.Subtasks {
+		count += subtask.Count()
+	}
+	return count
+}
+
+// Variables - отсортированные имена переменных из заголовков и описаний всего дерева.
+func (t TemplateTask) Variables() []string {
+	names := []string{}
+	var collect func(task TemplateTask)
+	collect = func(task TemplateTask) {
+		for _, text := range []string{task.Title, task.Description} {
+			for _, match := range Placeholder.FindAllStringSubmatch(text, -1) {
+				names = append(names, match[1])
+			}
+		}
+		for _, subtask := range task.Subtasks {
+			collect(subtask)
+		}
+	}
+	collect(t)
+
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// TemplateRequest - шаблон из задачи TaskID со всеми подзадачами. Сроки задач сохраняются смещениями
+// от Start, по умолчанию - от момента сохранения. С ProjectID шаблон доступен участникам проекта.
+type TemplateRequest struct {
+	TaskID    string     `json:"task_id"    validate:"required"`
+	Name      string     `json:"name"       validate:"required,min=1,max=64"`
+	ProjectID string     `json:"project_id"`
+	Start     *time.Time `json:"start"`
+}
+
+// InstantiateRequest - Variables подставляются в плейсхолдеры, сроки отсчитываются от Start,
+// по умолчанию - от момента запроса. Без ProjectID задачи создаются в проекте шаблона.
+type InstantiateRequest struct {
+	Variables map[string]string `json:"variables"`
+	Start     *time.Time        `json:"start"`
+	ProjectID string            `json:"project_id"`
+}
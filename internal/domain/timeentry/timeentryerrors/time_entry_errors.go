@@ -0,0 +1,13 @@
+package timeentryerrors
+
+import "errors"
+
+var (
+	ErrTimeEntryNotFound    = errors.New("time entry not found")
+	ErrNotTimeEntryOwner    = errors.New("only the author can change the time entry")
+	ErrWrongTimeEntry       = errors.New("wrong time entry")
+	ErrTimerAlreadyRunning  = errors.New("user already has a running timer")
+	ErrTimerNotRunning      = errors.New("no running timer")
+	ErrTimeEntryIsRunning   = errors.New("running timer can't be edited, stop it first")
+	ErrWrongTimeReportQuery = errors.New("wrong time report query")
+)
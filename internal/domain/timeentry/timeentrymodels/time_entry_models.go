@@ -0,0 +1,94 @@
+package timeentrymodels
+
+import "time"
+
+const (
+	MaxNoteLength = 1000
+	// MaxEntryDuration - ограничение длительности ручной записи.
+	MaxEntryDuration = 24 * time.Hour
+	// MaxReportDays - ограничение периода отчёта.
+	MaxReportDays = 366
+)
+
+// ReportDateLayout - границы периода отчёта и ключи группировки по дням - даты без времени в UTC.
+const ReportDateLayout = time.DateOnly
+
+// TimeEntry - время, потраченное пользователем на задачу. Запущенный таймер - запись без EndedAt,
+// у пользователя он может быть только один. Таймеры хранятся вместе с остальными записями,
+// поэтому переживают перезапуск сервера.
+type TimeEntry struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	UserID    string     `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Note      string     `json:"note,omitempty"`
+}
+
+func (e TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Duration - у запущенного таймера время считается до now.
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	if e.EndedAt == nil {
+		return now.Sub(e.StartedAt)
+	}
+	return e.EndedAt.Sub(e.StartedAt)
+}
+
+// TimerRequest - запуск таймера, Note можно не указывать.
+type TimerRequest struct {
+	Note string `json:"note"`
+}
+
+// TimeEntryRequest - ручная запись или изменение записи.
+type TimeEntryRequest struct {
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	EndedAt   time.Time `json:"ended_at"   validate:"required"`
+	Note      string    `json:"note"`
+}
+
+type GroupBy string
+
+const (
+	GroupByProject GroupBy = "project"
+	GroupByTask    GroupBy = "task"
+	GroupByDay     GroupBy = "day"
+)
+
+func (g GroupBy) IsValid() bool {
+	return g == GroupByProject || g == GroupByTask || g == GroupByDay
+}
+
+// ReportQuery - From и To - даты ReportDateLayout, обе включительно.
+type ReportQuery struct {
+	From    string
+	To      string
+	GroupBy GroupBy
+}
+
+// ReportEntry - запись времени вместе с задачей, к которой она относится.
+type ReportEntry struct {
+	TimeEntry
+	ProjectID    string
+	TaskTitle    string
+	TaskEstimate int
+}
+
+// ReportRow - суммарное время группы. Key - ID проекта или задачи либо дата, у задач без проекта
+// Key пустой. Estimate - оценка задачи в минутах, только при группировке по задачам.
+type ReportRow struct {
+	Key      string `json:"key"`
+	Title    string `json:"title,omitempty"`
+	Seconds  int64  `json:"seconds"`
+	Estimate int    `json:"estimate,omitempty"`
+}
+
+type Report struct {
+	From         string      `json:"from"`
+	To           string      `json:"to"`
+	GroupBy      GroupBy     `json:"group_by"`
+	Rows         []ReportRow `json:"rows"`
+	TotalSeconds int64       `json:"total_seconds"`
+}
@@ -0,0 +1,13 @@
+package webhookerrors
+
+import "errors"
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrWrongEventType   = errors.New("wrong event type")
+	ErrWrongWebhookURL  = errors.New("webhook url must be absolute http or https url")
+	ErrDeliveryNotDead  = errors.New("only dead deliveries can be redelivered")
+
+	ErrWebhookAddressNotAllowed = errors.New("webhook url must resolve to public addresses")
+)
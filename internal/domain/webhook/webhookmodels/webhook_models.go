@@ -0,0 +1,107 @@
+package webhookmodels
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"time"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+type EventType string
+
+const (
+	EventTaskCreated       EventType = "task.created"
+	EventTaskUpdated       EventType = "task.updated"
+	EventTaskStatusChanged EventType = "task.status_changed"
+	EventTaskDeleted       EventType = "task.deleted"
+)
+
+func (e EventType) IsValid() bool {
+	switch e {
+	case EventTaskCreated, EventTaskUpdated, EventTaskStatusChanged, EventTaskDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead - доставка исчерпала попытки, повторить её можно только вручную.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// MaxDeliveryAttempts - после стольких неудачных попыток доставка становится dead.
+const MaxDeliveryAttempts = 8
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// Webhook - адрес, на который отправляются события задач пользователя.
+// Secret отдаётся только при создании, им подписывается тело запроса.
+type Webhook struct {
+	ID        string      `json:"id"`
+	UserID    string      `json:"user_id"`
+	URL       string      `json:"url"`
+	Secret    string      `json:"secret,omitempty"`
+	Events    []EventType `json:"events"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type WebhookRequest struct {
+	URL    string      `json:"url"    validate:"required,url"`
+	Events []EventType `json:"events" validate:"required,min=1"`
+	Secret string      `json:"secret" validate:"omitempty,min=16"`
+}
+
+// Event - тело запроса вебхука. UserID - владелец задачи, ID совпадает с ID доменного события.
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	UserID     string          `json:"user_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Task       taskmodels.Task `json:"task"`
+}
+
+// Delivery - отправка одного события на один вебхук. Payload сохраняется целиком,
+// чтобы повторные попытки отправляли байт в байт то же тело и ту же подпись.
+type Delivery struct {
+	ID            string          `json:"id"`
+	WebhookID     string          `json:"webhook_id"`
+	EventID       string          `json:"event_id"`
+	EventType     EventType       `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        DeliveryStatus  `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty"`
+	ResponseCode  int             `json:"response_code,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	// URL и Secret заполняются хранилищем при выборке доставок для отправки.
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// Sign - значение заголовка SignatureHeader: HMAC-SHA256 тела запроса в hex с префиксом "sha256=".
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// PublicIP - можно ли отправлять вебхуки на адрес: loopback, частные, link-local и служебные адреса
+// ведут во внутреннюю сеть сервера.
+func PublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsMulticast() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast()
+}
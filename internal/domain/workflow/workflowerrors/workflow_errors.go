@@ -0,0 +1,9 @@
+package workflowerrors
+
+import "errors"
+
+var (
+	ErrWorkflowNotFound = errors.New("workflow not found")
+	ErrWrongWorkflow    = errors.New("wrong workflow")
+	ErrStatusInUse      = errors.New("status is used by project tasks")
+)
@@ -0,0 +1,87 @@
+package workflowmodels
+
+import (
+	"slices"
+	"time"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+const (
+	MaxStatuses         = 30
+	MaxStatusNameLength = 50
+)
+
+// Status - статус и колонка доски. WIPLimit - сколько задач проекта может быть в статусе
+// одновременно, 0 - без ограничения.
+type Status struct {
+	Name     taskmodels.TaskStatus     `json:"name"`
+	Category taskmodels.StatusCategory `json:"category"`
+	WIPLimit int                       `json:"wip_limit,omitempty"`
+}
+
+// Transition - задачу можно перевести из статуса From в статус To.
+type Transition struct {
+	From taskmodels.TaskStatus `json:"from"`
+	To   taskmodels.TaskStatus `json:"to"`
+}
+
+// Workflow - упорядоченные статусы проекта и разрешённые переходы между ними.
+// Первый статус - начальный: его получают новые повторения задач.
+type Workflow struct {
+	ProjectID   string       `json:"project_id,omitempty"`
+	Statuses    []Status     `json:"statuses"`
+	Transitions []Transition `json:"transitions"`
+	UpdatedAt   *time.Time   `json:"updated_at,omitempty"`
+}
+
+type WorkflowRequest struct {
+	Statuses    []Status     `json:"statuses"    validate:"required,min=1"`
+	Transitions []Transition `json:"transitions"`
+}
+
+// Default - workflow задач без проекта и проектов, где его не настраивали: New, In Progress и Done,
+// между которыми разрешены любые переходы.
+func Default() Workflow {
+	workflow := Workflow{
+		Statuses: []Status{
+			{Name: taskmodels.StatusNew, Category: taskmodels.CategoryTodo},
+			{Name: taskmodels.StatusInProgress, Category: taskmodels.CategoryDoing},
+			{Name: taskmodels.StatusCompleted, Category: taskmodels.CategoryDone},
+		},
+	}
+
+	for _, from := range workflow.Statuses {
+		for _, to := range workflow.Statuses {
+			if from != to {
+				workflow.Transitions = append(workflow.Transitions, Transition{From: from.Name, To: to.Name})
+			}
+		}
+	}
+	return workflow
+}
+
+func (w Workflow) Status(name taskmodels.TaskStatus) (Status, bool) {
+	i := slices.IndexFunc(w.Statuses, func(s Status) bool { return s.Name == name })
+	if i < 0 {
+		return Status{}, false
+	}
+	return w.Statuses[i], true
+}
+
+func (w Workflow) Initial() Status {
+	return w.Statuses[0]
+}
+
+// FirstInCategory - первый по порядку статус категории.
+func (w Workflow) FirstInCategory(category taskmodels.StatusCategory) (Status, bool) {
+	i := slices.IndexFunc(w.Statuses, func(s Status) bool { return s.Category == category })
+	if i < 0 {
+		return Status{}, false
+	}
+	return w.Statuses[i], true
+}
+
+// CanTransition - оставить задачу в том же статусе можно всегда.
+func (w Workflow) CanTransition(from taskmodels.TaskStatus, to taskmodels.TaskStatus) bool {
+	return from == to || slices.Contains(w.Transitions, Transition{From: from, To: to})
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"toDoList/internal"
+	"toDoList/internal/domain/attachment/attachmenterrors"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/task/taskerrors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type attachmentStorage struct {
+	db PgxIface
+}
+
+const attachmentColumns = "id, taskid, userid, filename, contenttype, size, blobkey, createdat"
+
+func scanAttachment(row pgx.Row) (attachmentmodels.Attachment, error) {
+	var attachment attachmentmodels.Attachment
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.UserID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.BlobKey,
+		&attachment.CreatedAt,
+	)
+	return attachment, err
+}
+
+func (as *attachmentStorage) AddAttachment(attachment attachmentmodels.Attachment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := as.db.Exec(
+		ctx,
+		"INSERT INTO attachments ("+attachmentColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		attachment.ID,
+		attachment.TaskID,
+		attachment.UserID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.BlobKey,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return taskerrors.ErrFoundNothing
+		}
+		return err
+	}
+	return nil
+}
+
+func (as *attachmentStorage) GetTaskAttachments(taskID string) ([]attachmentmodels.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := as.db.Query(
+		ctx,
+		"SELECT "+attachmentColumns+" FROM attachments WHERE taskid = $1 ORDER BY createdat, id",
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []attachmentmodels.Attachment
+	for rows.Next() {
+		attachment, errScan := scanAttachment(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (as *attachmentStorage) GetAttachmentByID(attachmentID string, taskID string) (
+	attachmentmodels.Attachment, error,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	attachment, err := scanAttachment(as.db.QueryRow(
+		ctx,
+		"SELECT "+attachmentColumns+" FROM attachments WHERE id = $1 AND taskid = $2",
+		attachmentID,
+		taskID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return attachmentmodels.Attachment{}, attachmenterrors.ErrAttachmentNotFound
+		}
+		return attachmentmodels.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment - ключ содержимого попадает в orphaned_blobs триггером, как и при каскадном
+// удалении вложений вместе с задачей.
+func (as *attachmentStorage) DeleteAttachment(attachmentID string, taskID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := as.db.Exec(ctx, "DELETE FROM attachments WHERE id = $1 AND taskid = $2", attachmentID, taskID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return attachmenterrors.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// GetOrphanedBlobKeys - до limit ключей содержимого удалённых вложений, самые старые первыми.
+func (as *attachmentStorage) GetOrphanedBlobKeys(limit int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := as.db.Query(
+		ctx,
+		"SELECT blobkey FROM orphaned_blobs ORDER BY orphanedat, blobkey LIMIT $1",
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// DeleteOrphanedBlobKey - ключ больше не нужен: сборщик удалил объект.
+func (as *attachmentStorage) DeleteOrphanedBlobKey(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := as.db.Exec(ctx, "DELETE FROM orphaned_blobs WHERE blobkey = $1", key)
+	return err
+}
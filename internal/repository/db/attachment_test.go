@@ -0,0 +1,83 @@
+package db
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/attachment/attachmenterrors"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/task/taskerrors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentStorage_AddAttachment(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	attachment := attachmentmodels.Attachment{
+		ID: "a1", TaskID: "t1", UserID: "u1", FileName: "notes.txt", ContentType: "text/plain; charset=utf-8",
+		Size: 5, BlobKey: "tasks/t1/a1", CreatedAt: now,
+	}
+
+	tests := []struct {
+		name    string
+		execErr error
+		wantErr error
+	}{
+		{name: "added"},
+		{name: "task not found", execErr: &pgconn.PgError{Code: "23503"}, wantErr: taskerrors.ErrFoundNothing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			as := &attachmentStorage{db: mock}
+
+			exec := mock.ExpectExec("INSERT INTO attachments").WithArgs(
+				"a1", "t1", "u1", "notes.txt", "text/plain; charset=utf-8", int64(5), "tasks/t1/a1", now,
+			)
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			require.ErrorIs(t, as.AddAttachment(attachment), tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachmentStorage_DeleteAttachment(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	as := &attachmentStorage{db: mock}
+
+	mock.ExpectExec("DELETE FROM attachments").WithArgs("a1", "t1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM attachments").WithArgs("a1", "t1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	require.NoError(t, as.DeleteAttachment("a1", "t1"))
+	assert.ErrorIs(t, as.DeleteAttachment("a1", "t1"), attachmenterrors.ErrAttachmentNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachmentStorage_OrphanedBlobKeys(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	as := &attachmentStorage{db: mock}
+
+	mock.ExpectQuery("SELECT blobkey FROM orphaned_blobs").WithArgs(100).
+		WillReturnRows(pgxmock.NewRows([]string{"blobkey"}).AddRow("tasks/t1/a1").AddRow("tasks/t1/a2"))
+	mock.ExpectExec("DELETE FROM orphaned_blobs").WithArgs("tasks/t1/a1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	keys, err := as.GetOrphanedBlobKeys(100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tasks/t1/a1", "tasks/t1/a2"}, keys)
+	require.NoError(t, as.DeleteOrphanedBlobKey("tasks/t1/a1"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
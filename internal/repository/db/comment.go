@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"toDoList/internal"
+	"toDoList/internal/domain/comment/commenterrors"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskerrors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type commentStorage struct {
+	db PgxIface
+}
+
+const commentColumns = "id, taskid, parentid, authorid, body, mentions, deleted, createdat, editedat"
+
+func scanComment(row pgx.Row) (commentmodels.Comment, error) {
+	var comment commentmodels.Comment
+	err := row.Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&comment.ParentID,
+		&comment.AuthorID,
+		&comment.Body,
+		&comment.Mentions,
+		&comment.Deleted,
+		&comment.CreatedAt,
+		&comment.EditedAt,
+	)
+	return comment, err
+}
+
+func mentionsOrEmpty(mentions []string) []string {
+	if mentions == nil {
+		return []string{}
+	}
+	return mentions
+}
+
+// mentionEvents - события для каждого из упомянутых пользователей.
+func mentionEvents(comment commentmodels.Comment, userIDs []string) ([]eventmodels.Event, error) {
+	events := make([]eventmodels.Event, 0, len(userIDs))
+	for _, userID := range userIDs {
+		event, err := eventmodels.NewMentionEvent(comment, userID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (cs *commentStorage) AddComment(comment commentmodels.Comment) error {
+	return inTx(cs.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		_, err := tx.Exec(
+			ctx,
+			"INSERT INTO comments (id, taskid, parentid, authorid, body, mentions, createdat) "+
+				"VALUES ($1, $2, $3, $4, $5, $6, $7)",
+			comment.ID,
+			comment.TaskID,
+			comment.ParentID,
+			comment.AuthorID,
+			comment.Body,
+			mentionsOrEmpty(comment.Mentions),
+			comment.CreatedAt,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return nil, taskerrors.ErrFoundNothing
+			}
+			return nil, err
+		}
+
+		return mentionEvents(comment, comment.Mentions)
+	})
+}
+
+func (cs *commentStorage) GetTaskComments(taskID string) ([]commentmodels.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := cs.db.Query(
+		ctx,
+		"SELECT "+commentColumns+" FROM comments WHERE taskid = $1 ORDER BY createdat, id",
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []commentmodels.Comment
+	for rows.Next() {
+		comment, errScan := scanComment(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (cs *commentStorage) GetCommentByID(commentID string, taskID string) (commentmodels.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	comment, err := scanComment(cs.db.QueryRow(
+		ctx,
+		"SELECT "+commentColumns+" FROM comments WHERE id = $1 AND taskid = $2",
+		commentID,
+		taskID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return commentmodels.Comment{}, commenterrors.ErrCommentNotFound
+		}
+		return commentmodels.Comment{}, err
+	}
+	return comment, nil
+}
+
+// UpdateComment - меняет текст и упоминания, события получают только новые упомянутые.
+func (cs *commentStorage) UpdateComment(comment commentmodels.Comment) error {
+	return inTx(cs.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		var mentioned []string
+		err := tx.QueryRow(
+			ctx,
+			"SELECT mentions FROM comments WHERE id = $1 AND taskid = $2 AND NOT deleted FOR UPDATE",
+			comment.ID,
+			comment.TaskID,
+		).Scan(&mentioned)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, commenterrors.ErrCommentNotFound
+			}
+			return nil, err
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE comments SET body = $1, mentions = $2, editedat = $3 WHERE id = $4",
+			comment.Body,
+			mentionsOrEmpty(comment.Mentions),
+			comment.EditedAt,
+			comment.ID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var added []string
+		for _, userID := range comment.Mentions {
+			if !slices.Contains(mentioned, userID) {
+				added = append(added, userID)
+			}
+		}
+		return mentionEvents(comment, added)
+	})
+}
+
+// DeleteComment - комментарий только помечается удалённым, ответы на него остаются в ветке.
+func (cs *commentStorage) DeleteComment(commentID string, taskID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := cs.db.Exec(
+		ctx,
+		"UPDATE comments SET deleted = true WHERE id = $1 AND taskid = $2 AND NOT deleted",
+		commentID,
+		taskID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return commenterrors.ErrCommentNotFound
+	}
+	return nil
+}
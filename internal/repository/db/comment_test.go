@@ -0,0 +1,140 @@
+package db
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/comment/commenterrors"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskerrors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentStorage_AddComment(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		comment  commentmodels.Comment
+		mentions []string
+		execErr  error
+		wantErr  error
+	}{
+		{
+			name:     "without mentions",
+			comment:  commentmodels.Comment{ID: "c1", TaskID: "t1", AuthorID: "u1", Body: "hi", CreatedAt: now},
+			mentions: []string{},
+		},
+		{
+			name: "with mentions",
+			comment: commentmodels.Comment{
+				ID: "c1", TaskID: "t1", AuthorID: "u1", Body: "@bob @carol", Mentions: []string{"u2", "u3"},
+				CreatedAt: now,
+			},
+			mentions: []string{"u2", "u3"},
+		},
+		{
+			name:     "task not found",
+			comment:  commentmodels.Comment{ID: "c1", TaskID: "404", AuthorID: "u1", Body: "hi", CreatedAt: now},
+			mentions: []string{},
+			execErr:  &pgconn.PgError{Code: "23503"},
+			wantErr:  taskerrors.ErrFoundNothing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			cs := &commentStorage{db: mock}
+
+			mock.ExpectBegin()
+			exec := mock.ExpectExec("INSERT INTO comments").
+				WithArgs(tt.comment.ID, tt.comment.TaskID, "", "u1", tt.comment.Body, tt.mentions, now)
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			events := make([]eventmodels.EventType, len(tt.comment.Mentions))
+			for i := range events {
+				events[i] = eventmodels.CommentMentioned
+			}
+			expectOutboxOrRollback(mock, tt.wantErr, events...)
+
+			err = cs.AddComment(tt.comment)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCommentStorage_UpdateComment(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	cs := &commentStorage{db: mock}
+
+	editedAt := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	comment := commentmodels.Comment{
+		ID: "c1", TaskID: "t1", Body: "@bob @carol", Mentions: []string{"u2", "u3"}, EditedAt: &editedAt,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT mentions FROM comments WHERE id = \\$1 AND taskid = \\$2 AND NOT deleted FOR UPDATE").
+		WithArgs("c1", "t1").
+		WillReturnRows(pgxmock.NewRows([]string{"mentions"}).AddRow([]string{"u2"}))
+	mock.ExpectExec("UPDATE comments SET body = \\$1, mentions = \\$2, editedat = \\$3 WHERE id = \\$4").
+		WithArgs("@bob @carol", []string{"u2", "u3"}, &editedAt, "c1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	// Событие только для нового упомянутого.
+	expectOutbox(mock, eventmodels.CommentMentioned)
+	mock.ExpectCommit()
+
+	require.NoError(t, cs.UpdateComment(comment))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentStorage_GetTaskComments(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	cs := &commentStorage{db: mock}
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "taskid", "parentid", "authorid", "body", "mentions", "deleted", "createdat", "editedat"}
+
+	mock.ExpectQuery("SELECT " + commentColumns + " FROM comments WHERE taskid = \\$1 ORDER BY createdat, id").
+		WithArgs("t1").
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow("c1", "t1", "", "u1", "@bob", []string{"u2"}, false, now, (*time.Time)(nil)).
+			AddRow("c2", "t1", "c1", "u2", "thanks", []string{}, false, now.Add(time.Minute), &now))
+
+	comments, err := cs.GetTaskComments("t1")
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, []string{"u2"}, comments[0].Mentions)
+	assert.Equal(t, "c1", comments[1].ParentID)
+	assert.Equal(t, &now, comments[1].EditedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentStorage_DeleteComment(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	cs := &commentStorage{db: mock}
+
+	mock.ExpectExec("UPDATE comments SET deleted = true WHERE id = \\$1 AND taskid = \\$2 AND NOT deleted").
+		WithArgs("c1", "t1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE comments SET deleted = true WHERE id = \\$1 AND taskid = \\$2 AND NOT deleted").
+		WithArgs("c1", "t1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	require.NoError(t, cs.DeleteComment("c1", "t1"))
+	require.ErrorIs(t, cs.DeleteComment("c1", "t1"), commenterrors.ErrCommentNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"toDoList/internal"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/project/projecterrors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type customFieldStorage struct {
+	db PgxIface
+}
+
+const customFieldColumns = "id, projectid, name, type, options, createdat"
+
+func scanCustomField(row pgx.Row) (customfieldmodels.CustomField, error) {
+	var field customfieldmodels.CustomField
+	err := row.Scan(&field.ID, &field.ProjectID, &field.Name, &field.Type, &field.Options, &field.CreatedAt)
+	return field, err
+}
+
+func (cs *customFieldStorage) AddCustomField(field customfieldmodels.CustomField) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := cs.db.Exec(
+		ctx,
+		"INSERT INTO custom_fields ("+customFieldColumns+") VALUES ($1, $2, $3, $4, $5, $6)",
+		field.ID,
+		field.ProjectID,
+		field.Name,
+		field.Type,
+		field.Options,
+		field.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return customfielderrors.ErrCustomFieldIsAlreadyExist
+			case "23503":
+				return projecterrors.ErrProjectNotFound
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (cs *customFieldStorage) GetCustomFields(projectID string) ([]customfieldmodels.CustomField, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := cs.db.Query(
+		ctx,
+		"SELECT "+customFieldColumns+" FROM custom_fields WHERE projectid = $1 ORDER BY createdat, id",
+		projectID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []customfieldmodels.CustomField
+	for rows.Next() {
+		field, errScan := scanCustomField(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		fields = append(fields, field)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// DeleteCustomField - значения поля удаляются из задач проекта в той же транзакции.
+func (cs *customFieldStorage) DeleteCustomField(fieldID string, projectID string) error {
+	return inTx(cs.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		cmd, err := tx.Exec(ctx, "DELETE FROM custom_fields WHERE id = $1 AND projectid = $2", fieldID, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if cmd.RowsAffected() == 0 {
+			return nil, customfielderrors.ErrCustomFieldNotFound
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE tasks SET customfields = customfields - $1::text, version = version + 1 "+
+				"WHERE projectid = $2 AND customfields ? $1",
+			fieldID,
+			projectID,
+		)
+		return nil, err
+	})
+}
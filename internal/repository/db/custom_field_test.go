@@ -0,0 +1,99 @@
+package db
+
+import (
+	"regexp"
+	"testing"
+	"time"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/project/projecterrors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFieldStorage_AddCustomField(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	field := customfieldmodels.CustomField{
+		ID: "f1", ProjectID: "p1", Name: "Size", Type: customfieldmodels.TypeSelect, Options: []string{"S", "M"},
+		CreatedAt: now,
+	}
+
+	tests := []struct {
+		name    string
+		execErr error
+		wantErr error
+	}{
+		{name: "added"},
+		{
+			name:    "duplicate name",
+			execErr: &pgconn.PgError{Code: "23505"},
+			wantErr: customfielderrors.ErrCustomFieldIsAlreadyExist,
+		},
+		{name: "project not found", execErr: &pgconn.PgError{Code: "23503"}, wantErr: projecterrors.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			cs := &customFieldStorage{db: mock}
+
+			exec := mock.ExpectExec("INSERT INTO custom_fields").WithArgs(
+				"f1", "p1", "Size", customfieldmodels.TypeSelect, []string{"S", "M"}, now,
+			)
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			require.ErrorIs(t, cs.AddCustomField(field), tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCustomFieldStorage_GetCustomFields(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	cs := &customFieldStorage{db: mock}
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT .+ FROM custom_fields WHERE projectid = \\$1 ORDER BY createdat, id").
+		WithArgs("p1").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "projectid", "name", "type", "options", "createdat"}).
+			AddRow("f1", "p1", "Estimate", customfieldmodels.TypeNumber, []string(nil), now))
+
+	fields, err := cs.GetCustomFields("p1")
+	require.NoError(t, err)
+	assert.Equal(t, []customfieldmodels.CustomField{
+		{ID: "f1", ProjectID: "p1", Name: "Estimate", Type: customfieldmodels.TypeNumber, CreatedAt: now},
+	}, fields)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomFieldStorage_DeleteCustomField(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	cs := &customFieldStorage{db: mock}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM custom_fields").WithArgs("f1", "p1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE tasks SET customfields = customfields - $1::text, version = version + 1")).
+		WithArgs("f1", "p1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM custom_fields").WithArgs("f1", "p1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	require.NoError(t, cs.DeleteCustomField("f1", "p1"))
+	assert.ErrorIs(t, cs.DeleteCustomField("f1", "p1"), customfielderrors.ErrCustomFieldNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"toDoList/internal"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib" //
 	"github.com/rs/zerolog/log"
 )
@@ -15,6 +17,19 @@ import (
 type Storage struct {
 	userStorage
 	taskStorage
+	projectStorage
+	tagStorage
+	reminderStorage
+	webhookStorage
+	outboxStorage
+	idempotencyStorage
+	filterStorage
+	commentStorage
+	attachmentStorage
+	workflowStorage
+	customFieldStorage
+	timeEntryStorage
+	templateStorage
 }
 
 // PgxIface - общий интерфейс для мока/адаптера.
@@ -26,39 +41,116 @@ type PgxIface interface {
 	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
-// pgxConnAdapter - адаптер для *pgx.Conn.
-type pgxConnAdapter struct {
-	*pgx.Conn
+// pgxPoolAdapter - адаптер для *pgxpool.Pool. Каждый запрос берёт соединение из пула, поэтому
+// обработчики и фоновые воркеры не мешают друг другу.
+type pgxPoolAdapter struct {
+	*pgxpool.Pool
 }
 
-func (a pgxConnAdapter) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
-	return a.Conn.Exec(ctx, sql, args...)
+func (a pgxPoolAdapter) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return a.Pool.Exec(ctx, sql, args...)
 }
 
-func (a pgxConnAdapter) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
-	return a.Conn.Query(ctx, sql, args...)
+func (a pgxPoolAdapter) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return a.Pool.Query(ctx, sql, args...)
 }
 
-func (a pgxConnAdapter) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
-	return a.Conn.QueryRow(ctx, sql, args...)
+func (a pgxPoolAdapter) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return a.Pool.QueryRow(ctx, sql, args...)
 }
 
-func (a pgxConnAdapter) Close(ctx context.Context) error {
-	return a.Conn.Close(ctx)
+// Begin - транзакция на отдельном соединении из пула: запросы других горутин в неё не попадают.
+// Соединение возвращается в пул после коммита или отката.
+func (a pgxPoolAdapter) Begin(ctx context.Context) (pgx.Tx, error) {
+	return a.Pool.BeginTx(ctx, pgx.TxOptions{})
+}
+
+func (a pgxPoolAdapter) Close(context.Context) error {
+	a.Pool.Close()
+	return nil
 }
 
 func NewStorage(connStr string) (*Storage, error) {
-	db, err := pgx.Connect(context.Background(), connStr)
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, connStr)
 	if err != nil {
 		return nil, err
 	}
 
-	adapter := pgxConnAdapter{Conn: db}
+	// Пул подключается лениво, а недоступную базу нужно увидеть при старте, как и раньше.
+	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return newStorage(pgxPoolAdapter{Pool: pool}), nil
+}
 
+func newStorage(db PgxIface) *Storage {
 	return &Storage{
-		userStorage: userStorage{db: adapter},
-		taskStorage: taskStorage{db: adapter},
-	}, nil
+		userStorage:        userStorage{db: db},
+		taskStorage:        taskStorage{db: db},
+		projectStorage:     projectStorage{db: db},
+		tagStorage:         tagStorage{db: db},
+		reminderStorage:    reminderStorage{db: db},
+		webhookStorage:     webhookStorage{db: db},
+		outboxStorage:      outboxStorage{db: db},
+		idempotencyStorage: idempotencyStorage{db: db},
+		filterStorage:      filterStorage{db: db},
+		commentStorage:     commentStorage{db: db},
+		attachmentStorage:  attachmentStorage{db: db},
+		workflowStorage:    workflowStorage{db: db},
+		customFieldStorage: customFieldStorage{db: db},
+		timeEntryStorage:   timeEntryStorage{db: db},
+		templateStorage:    templateStorage{db: db},
+	}
+}
+
+// pgxTxAdapter - транзакция в роли соединения. Транзакции методов хранилища внутри неё
+// становятся точками сохранения, а закрывает её InTx.
+type pgxTxAdapter struct {
+	pgx.Tx
+}
+
+func (a pgxTxAdapter) Close(context.Context) error {
+	return nil
+}
+
+// InTx - выполняет fn над хранилищем, все изменения которого идут одной транзакцией.
+// Ошибка fn откатывает транзакцию целиком. Транзакция держит своё соединение из пула до коммита
+// или отката, поэтому запросы других обработчиков и воркеров в неё не попадают. Общего срока у транзакции
+// нет: каждый запрос внутри получает свой тайм-аут в методе хранилища, так что пачка из сотен операций
+// не упирается в тайм-аут одного запроса.
+func (s *Storage) InTx(fn func(tx *Storage) error) error {
+	tx, err := s.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		errRollback := tx.Rollback(context.Background())
+		if errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			log.Error().Err(errRollback).Msg("Transaction rollback failed")
+		}
+	}()
+
+	if err = fn(newStorage(pgxTxAdapter{Tx: tx})); err != nil {
+		return err
+	}
+
+	commitCtx, commitCancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer commitCancel()
+	return tx.Commit(commitCtx)
+}
+
+// begin - тайм-аут только на BEGIN: после него контекст транзакции не нужен.
+func (s *Storage) begin() (pgx.Tx, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	return s.userStorage.db.Begin(ctx)
 }
 
 func (s *Storage) Close(ctx context.Context) error {
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"toDoList/internal/domain/event/eventmodels"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_InTx(t *testing.T) {
+	tests := []struct {
+		name    string
+		fnErr   error
+		wantErr error
+	}{
+		{"commit", nil, nil},
+		{"rollback", errors.New("bulk failed"), errors.New("bulk failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			s := newStorage(mock)
+
+			mock.ExpectBegin()
+			// Транзакция метода внутри InTx - точка сохранения во внешней транзакции.
+			mock.ExpectBegin()
+			mock.ExpectQuery("UPDATE tasks SET position = \\$1").
+				WithArgs("b", "1", "u1").
+				WillReturnRows(deletedTaskRows(1))
+			expectOutbox(mock, eventmodels.TaskUpdated)
+			mock.ExpectCommit()
+			if tt.wantErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err = s.InTx(func(tx *Storage) error {
+				if errUpdate := tx.UpdateTaskPosition("1", "u1", "b"); errUpdate != nil {
+					return errUpdate
+				}
+				return tt.fnErr
+			})
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
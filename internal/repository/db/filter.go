@@ -0,0 +1,231 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"toDoList/internal"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type filterStorage struct {
+	db PgxIface
+}
+
+func (fs *filterStorage) AddFilter(filter filtermodels.Filter) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := fs.db.Exec(
+		ctx,
+		"INSERT INTO filters (id, userid, name, query, createdat) VALUES ($1, $2, $3, $4, $5)",
+		filter.ID,
+		filter.UserID,
+		filter.Name,
+		filter.Query,
+		filter.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return filtererrors.ErrFilterIsAlreadyExist
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (fs *filterStorage) GetFiltersByUser(userID string) ([]filtermodels.Filter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := fs.db.Query(
+		ctx,
+		"SELECT id, userid, name, query, createdat FROM filters WHERE userid = $1 ORDER BY name",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var filters []filtermodels.Filter
+
+	for rows.Next() {
+		var filter filtermodels.Filter
+		err = rows.Scan(&filter.ID, &filter.UserID, &filter.Name, &filter.Query, &filter.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+func (fs *filterStorage) GetFilterByID(filterID string, userID string) (filtermodels.Filter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	var filter filtermodels.Filter
+	err := fs.db.QueryRow(
+		ctx,
+		"SELECT id, userid, name, query, createdat FROM filters WHERE id = $1 AND userid = $2",
+		filterID,
+		userID,
+	).Scan(&filter.ID, &filter.UserID, &filter.Name, &filter.Query, &filter.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return filtermodels.Filter{}, filtererrors.ErrFilterNotFound
+		}
+		return filtermodels.Filter{}, err
+	}
+
+	return filter, nil
+}
+
+func (fs *filterStorage) UpdateFilter(filter filtermodels.Filter) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := fs.db.Exec(
+		ctx,
+		"UPDATE filters SET name = $1, query = $2 WHERE id = $3 AND userid = $4",
+		filter.Name,
+		filter.Query,
+		filter.ID,
+		filter.UserID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return filtererrors.ErrFilterIsAlreadyExist
+			}
+		}
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return filtererrors.ErrFilterNotFound
+	}
+
+	return nil
+}
+
+func (fs *filterStorage) DeleteFilter(filterID string, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := fs.db.Exec(ctx, "DELETE FROM filters WHERE id = $1 AND userid = $2", filterID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return filtererrors.ErrFilterNotFound
+	}
+
+	return nil
+}
+
+// FindTasksByFilter - видимые пользователю неудалённые задачи, подходящие под выражение.
+func (fs *filterStorage) FindTasksByFilter(userID string, expr filtermodels.Expr) ([]taskmodels.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	args := []any{userID}
+	condition, err := filterCondition(expr, &args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := fs.db.Query(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE deleted = false AND "+visibleToUser(1)+
+			" AND "+condition+byPosition,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectTasks(rows)
+}
+
+// filterCondition - SQL-условие выражения, значения добавляются в args. $1 - ID пользователя.
+// Сравнения due явно отсекают задачи без срока, чтобы NOT давал тот же результат, что и в памяти.
+func filterCondition(expr filtermodels.Expr, args *[]any) (string, error) {
+	switch e := expr.(type) {
+	case filtermodels.And:
+		return binaryCondition(e.Left, e.Right, " AND ", args)
+	case filtermodels.Or:
+		return binaryCondition(e.Left, e.Right, " OR ", args)
+	case filtermodels.Not:
+		inner, err := filterCondition(e.Expr, args)
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + inner, nil
+	case filtermodels.Condition:
+		return conditionSQL(e, args)
+	default:
+		return "", fmt.Errorf("%w: unsupported expression %T", filtererrors.ErrWrongFilterQuery, expr)
+	}
+}
+
+func binaryCondition(left, right filtermodels.Expr, op string, args *[]any) (string, error) {
+	leftSQL, err := filterCondition(left, args)
+	if err != nil {
+		return "", err
+	}
+	rightSQL, err := filterCondition(right, args)
+	if err != nil {
+		return "", err
+	}
+	return "(" + leftSQL + op + rightSQL + ")", nil
+}
+
+func conditionSQL(cond filtermodels.Condition, args *[]any) (string, error) {
+	arg := func(value any) int {
+		*args = append(*args, value)
+		return len(*args)
+	}
+
+	switch cond.Field {
+	case filtermodels.FieldStatus:
+		return fmt.Sprintf("status = $%d", arg(cond.Value)), nil
+	case filtermodels.FieldPriority:
+		return fmt.Sprintf("priority = $%d", arg(cond.Value)), nil
+	case filtermodels.FieldProject:
+		return fmt.Sprintf("projectid = $%d", arg(cond.Value)), nil
+	case filtermodels.FieldAssignee:
+		return fmt.Sprintf("assigneeid = $%d", arg(cond.Value)), nil
+	case filtermodels.FieldTag:
+		return fmt.Sprintf(
+			"id IN (SELECT tt.taskid FROM task_tags tt JOIN tags t ON t.id = tt.tagid "+
+				"WHERE t.userid = $1 AND t.name = $%d)",
+			arg(cond.Value),
+		), nil
+	case filtermodels.FieldText:
+		language := arg(string(cond.Language))
+		return fmt.Sprintf("search @@ websearch_to_tsquery($%d::regconfig, $%d)", language, arg(cond.Value)), nil
+	case filtermodels.FieldDue:
+		if cond.Op == filtermodels.OpEq {
+			return "dueat IS NULL", nil
+		}
+		return fmt.Sprintf("(dueat IS NOT NULL AND dueat %s $%d)", cond.Op, arg(cond.Time)), nil
+	default:
+		return "", fmt.Errorf("%w: unknown field %q", filtererrors.ErrWrongFilterQuery, cond.Field)
+	}
+}
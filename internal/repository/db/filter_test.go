@@ -0,0 +1,95 @@
+package db
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterStorage_AddFilter(t *testing.T) {
+	tests := []struct {
+		name            string
+		shouldDuplicate bool
+		wantErr         error
+	}{
+		{name: "success"},
+		{name: "duplicate", shouldDuplicate: true, wantErr: filtererrors.ErrFilterIsAlreadyExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			fs := &filterStorage{db: mock}
+
+			filter := filtermodels.Filter{
+				ID: "f1", UserID: "u1", Name: "Urgent", Query: "tag:urgent", CreatedAt: time.Now().UTC(),
+			}
+			exec := mock.ExpectExec("INSERT INTO filters").
+				WithArgs(filter.ID, filter.UserID, filter.Name, filter.Query, filter.CreatedAt)
+			if tt.shouldDuplicate {
+				exec.WillReturnError(&pgconn.PgError{Code: "23505"})
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			err = fs.AddFilter(filter)
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilterStorage_FindTasksByFilter(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	fs := &filterStorage{db: mock}
+
+	dueBefore := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+	expr := filtermodels.And{
+		Left: filtermodels.Or{
+			Left:  filtermodels.Condition{Field: filtermodels.FieldStatus, Op: filtermodels.OpEq, Value: "New"},
+			Right: filtermodels.Condition{Field: filtermodels.FieldTag, Op: filtermodels.OpEq, Value: "urgent"},
+		},
+		Right: filtermodels.And{
+			Left: filtermodels.Not{Expr: filtermodels.Condition{
+				Field: filtermodels.FieldDue, Op: filtermodels.OpLess, Time: dueBefore,
+			}},
+			Right: filtermodels.Condition{
+				Field:    filtermodels.FieldText,
+				Op:       filtermodels.OpEq,
+				Value:    "invoices",
+				Language: taskmodels.SearchEnglish,
+			},
+		},
+	}
+	task := taskmodels.Task{
+		ID: "t1", UserID: "u1", Version: 1,
+		Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "Invoices", Description: "March"},
+	}
+
+	mock.ExpectQuery("SELECT .+ FROM tasks WHERE deleted = false AND .+ AND "+
+		"\\(\\(status = \\$2 OR id IN \\(SELECT tt.taskid FROM task_tags tt JOIN tags t ON t.id = tt.tagid "+
+		"WHERE t.userid = \\$1 AND t.name = \\$3\\)\\) AND "+
+		"\\(NOT \\(dueat IS NOT NULL AND dueat < \\$4\\) AND "+
+		"search @@ websearch_to_tsquery\\(\\$5::regconfig, \\$6\\)\\)\\) ORDER BY position").
+		WithArgs("u1", "New", "urgent", dueBefore, "english", "invoices").
+		WillReturnRows(addTaskRow(newTaskRows(), task))
+
+	tasks, err := fs.FindTasksByFilter("u1", expr)
+	require.NoError(t, err)
+	assert.Equal(t, []taskmodels.Task{task}, tasks)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/idempotency/idempotencyerrors"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type idempotencyStorage struct {
+	db PgxIface
+}
+
+// ReserveIdempotencyKey - сохраняет ключ, если у пользователя нет действующего ключа с тем же значением,
+// и возвращает true. Истёкший ключ перезаписывается. Иначе возвращает сохранённую запись и false.
+func (is *idempotencyStorage) ReserveIdempotencyKey(record idempotencymodels.Record) (
+	idempotencymodels.Record, bool, error,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := is.db.Exec(
+		ctx,
+		"INSERT INTO idempotency_keys (userid, key, fingerprint, createdat, expiresat) VALUES ($1, $2, $3, $4, $5) "+
+			"ON CONFLICT (userid, key) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, statuscode = 0, "+
+			"header = NULL, body = NULL, createdat = EXCLUDED.createdat, expiresat = EXCLUDED.expiresat "+
+			"WHERE idempotency_keys.expiresat <= EXCLUDED.createdat",
+		record.UserID,
+		record.Key,
+		record.Fingerprint,
+		record.CreatedAt,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		return idempotencymodels.Record{}, false, err
+	}
+
+	if cmd.RowsAffected() != 0 {
+		return record, true, nil
+	}
+
+	var saved idempotencymodels.Record
+	err = is.db.QueryRow(
+		ctx,
+		"SELECT userid, key, fingerprint, statuscode, header, body, createdat, expiresat FROM idempotency_keys "+
+			"WHERE userid = $1 AND key = $2",
+		record.UserID,
+		record.Key,
+	).Scan(
+		&saved.UserID,
+		&saved.Key,
+		&saved.Fingerprint,
+		&saved.StatusCode,
+		&saved.Header,
+		&saved.Body,
+		&saved.CreatedAt,
+		&saved.ExpiresAt,
+	)
+	// Ключ освободили между вставкой и чтением, клиенту достаточно повторить запрос.
+	if errors.Is(err, pgx.ErrNoRows) {
+		return idempotencymodels.Record{}, false, idempotencyerrors.ErrRequestInProgress
+	}
+	if err != nil {
+		return idempotencymodels.Record{}, false, err
+	}
+	return saved, false, nil
+}
+
+// CompleteIdempotencyKey - сохраняет ответ на запрос, для которого ключ был зарезервирован.
+func (is *idempotencyStorage) CompleteIdempotencyKey(record idempotencymodels.Record) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := is.db.Exec(
+		ctx,
+		"UPDATE idempotency_keys SET statuscode = $4, header = $5, body = $6 "+
+			"WHERE userid = $1 AND key = $2 AND fingerprint = $3 AND statuscode = 0",
+		record.UserID,
+		record.Key,
+		record.Fingerprint,
+		record.StatusCode,
+		record.Header,
+		record.Body,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return idempotencyerrors.ErrKeyNotFound
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey - удаляет ключ запроса, который ещё выполняется, чтобы его можно было повторить.
+func (is *idempotencyStorage) ReleaseIdempotencyKey(userID string, key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := is.db.Exec(
+		ctx,
+		"DELETE FROM idempotency_keys WHERE userid = $1 AND key = $2 AND statuscode = 0",
+		userID,
+		key,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return idempotencyerrors.ErrKeyNotFound
+	}
+	return nil
+}
+
+func (is *idempotencyStorage) DeleteExpiredIdempotencyKeys(now time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := is.db.Exec(ctx, "DELETE FROM idempotency_keys WHERE expiresat <= $1", now)
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
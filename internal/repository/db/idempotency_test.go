@@ -0,0 +1,132 @@
+package db
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/idempotency/idempotencyerrors"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyStorage_ReserveIdempotencyKey(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	record := idempotencymodels.Record{
+		UserID: "u1", Key: "k1", Fingerprint: "f1", CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	}
+	saved := record
+	saved.StatusCode = 201
+	saved.Header = map[string]string{"Content-Type": "application/json"}
+	saved.Body = []byte(`{"id":"t1"}`)
+
+	tests := []struct {
+		name         string
+		inserted     bool
+		rows         *pgxmock.Rows
+		want         idempotencymodels.Record
+		wantReserved bool
+		wantErr      error
+	}{
+		{
+			name:         "reserved",
+			inserted:     true,
+			want:         record,
+			wantReserved: true,
+		},
+		{
+			name:     "already used",
+			inserted: false,
+			rows: pgxmock.NewRows([]string{
+				"userid", "key", "fingerprint", "statuscode", "header", "body", "createdat", "expiresat",
+			}).AddRow("u1", "k1", "f1", 201, saved.Header, saved.Body, now, now.Add(time.Hour)),
+			want: saved,
+		},
+		{
+			name:     "released meanwhile",
+			inserted: false,
+			rows: pgxmock.NewRows([]string{
+				"userid", "key", "fingerprint", "statuscode", "header", "body", "createdat", "expiresat",
+			}),
+			wantErr: idempotencyerrors.ErrRequestInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			is := &idempotencyStorage{db: mock}
+
+			var affected int64
+			if tt.inserted {
+				affected = 1
+			}
+			mock.ExpectExec("INSERT INTO idempotency_keys .+ ON CONFLICT \\(userid, key\\) DO UPDATE .+ "+
+				"WHERE idempotency_keys.expiresat <= EXCLUDED.createdat").
+				WithArgs("u1", "k1", "f1", now, now.Add(time.Hour)).
+				WillReturnResult(pgxmock.NewResult("INSERT", affected))
+			if tt.rows != nil {
+				mock.ExpectQuery("SELECT .+ FROM idempotency_keys WHERE userid = \\$1 AND key = \\$2").
+					WithArgs("u1", "k1").
+					WillReturnRows(tt.rows)
+			}
+
+			got, reserved, err := is.ReserveIdempotencyKey(record)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantReserved, reserved)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyStorage_CompleteIdempotencyKey(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	is := &idempotencyStorage{db: mock}
+
+	record := idempotencymodels.Record{
+		UserID: "u1", Key: "k1", Fingerprint: "f1", StatusCode: 201,
+		Header: map[string]string{"Content-Type": "application/json"}, Body: []byte(`{}`),
+	}
+
+	mock.ExpectExec("UPDATE idempotency_keys SET statuscode = \\$4, header = \\$5, body = \\$6 "+
+		"WHERE userid = \\$1 AND key = \\$2 AND fingerprint = \\$3 AND statuscode = 0").
+		WithArgs("u1", "k1", "f1", 201, record.Header, record.Body).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	require.NoError(t, is.CompleteIdempotencyKey(record))
+
+	mock.ExpectExec("UPDATE idempotency_keys").
+		WithArgs("u1", "k1", "f1", 201, record.Header, record.Body).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	assert.ErrorIs(t, is.CompleteIdempotencyKey(record), idempotencyerrors.ErrKeyNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyStorage_DeleteIdempotencyKeys(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	is := &idempotencyStorage{db: mock}
+
+	mock.ExpectExec("DELETE FROM idempotency_keys WHERE userid = \\$1 AND key = \\$2 AND statuscode = 0").
+		WithArgs("u1", "k1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	require.NoError(t, is.ReleaseIdempotencyKey("u1", "k1"))
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	mock.ExpectExec("DELETE FROM idempotency_keys WHERE expiresat <= \\$1").
+		WithArgs(now).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	deleted, err := is.DeleteExpiredIdempotencyKeys(now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/event/eventmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+type outboxStorage struct {
+	db PgxIface
+}
+
+// outboxLockKey - ключ advisory-блокировки: события публикует только один экземпляр сервера за раз,
+// иначе два релея могли бы отправить события одного агрегата не по порядку.
+const outboxLockKey = 0x6f7574626f78 // "outbox" в ASCII
+
+// inTx - выполняет fn в транзакции, события, которые она вернула, пишутся в outbox той же транзакцией.
+// Изменение задачи блокирует её строку до коммита, поэтому seq событий одного агрегата
+// возрастает в порядке коммитов.
+func inTx(db PgxIface, fn func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func(tx pgx.Tx, ctx context.Context) {
+		errRollback := tx.Rollback(ctx)
+		if errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			log.Error().Err(errRollback).Msg("Transaction rollback failed")
+		}
+	}(tx, ctx)
+
+	events, err := fn(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO outbox (id, aggregatetype, aggregateid, eventtype, userid, payload, occurredat) "+
+				"VALUES ($1, $2, $3, $4, $5, $6, $7)",
+			event.ID,
+			event.AggregateType,
+			event.AggregateID,
+			event.Type,
+			event.UserID,
+			event.Payload,
+			event.OccurredAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// outboxRow - неопубликованное событие вместе с числом неудачных попыток.
+type outboxRow struct {
+	event    eventmodels.Event
+	attempts int
+}
+
+// RelayOutboxEvents - публикует до limit неопубликованных событий по порядку seq.
+// Если публикация события не удалась, следующие события того же агрегата ждут, пока не наступит время
+// его повторной попытки. После MaxPublishAttempts неудач событие получает отметку deadat и остаётся
+// в outbox для разбора, а события агрегата за ним публикуются дальше. Отметка о публикации коммитится
+// после publish, поэтому при падении между ними событие будет опубликовано повторно. Транзакция держит
+// своё соединение из пула, так что запросы подписчиков внутри publish идут через другие соединения и в неё
+// не попадают.
+func (obs *outboxStorage) RelayOutboxEvents(limit int, publish func(event eventmodels.Event) error) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecTen)
+	defer cancel()
+
+	tx, err := obs.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func(tx pgx.Tx, ctx context.Context) {
+		errRollback := tx.Rollback(ctx)
+		if errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			log.Error().Err(errRollback).Msg("Transaction rollback failed")
+		}
+	}(tx, ctx)
+
+	var locked bool
+	if err = tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxLockKey).Scan(&locked); err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	// Событие, у которого есть более раннее неопубликованное событие агрегата с отложенной попыткой,
+	// не выбирается: иначе оно обогнало бы его.
+	rows, err := tx.Query(
+		ctx,
+		"SELECT o.seq, o.id, o.aggregatetype, o.aggregateid, o.eventtype, o.userid, o.payload, o.occurredat, "+
+			"o.attempts FROM outbox o WHERE o.publishedat IS NULL AND o.deadat IS NULL AND o.nextattemptat <= $2 "+
+			"AND NOT EXISTS (SELECT 1 FROM outbox p WHERE p.aggregatetype = o.aggregatetype "+
+			"AND p.aggregateid = o.aggregateid AND p.seq < o.seq AND p.publishedat IS NULL AND p.deadat IS NULL "+
+			"AND p.nextattemptat > $2) ORDER BY o.seq LIMIT $1",
+		limit,
+		now,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	pending, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (outboxRow, error) {
+		var r outboxRow
+		e := &r.event
+		err := row.Scan(&e.Seq, &e.ID, &e.AggregateType, &e.AggregateID, &e.Type, &e.UserID, &e.Payload,
+			&e.OccurredAt, &r.attempts)
+		return r, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	failed := make(map[string]bool)
+
+	for _, r := range pending {
+		event := r.event
+		aggregate := event.AggregateType + ":" + event.AggregateID
+		if failed[aggregate] {
+			continue
+		}
+
+		if errPublish := publish(event); errPublish != nil {
+			failed[aggregate] = true
+			attempts := r.attempts + 1
+
+			var deadAt *time.Time
+			if attempts >= eventmodels.MaxPublishAttempts {
+				deadAt = &now
+				log.Error().Err(errPublish).Int64("seq", event.Seq).Msg("outbox event is dead")
+			} else {
+				log.Error().Err(errPublish).Int64("seq", event.Seq).Msg("failed to publish outbox event")
+			}
+
+			_, err = tx.Exec(
+				ctx,
+				"UPDATE outbox SET attempts = $2, lasterror = $3, nextattemptat = $4, deadat = $5 WHERE seq = $1",
+				event.Seq,
+				attempts,
+				errPublish.Error(),
+				now.Add(eventmodels.PublishBackoff(attempts)),
+				deadAt,
+			)
+		} else {
+			published++
+			_, err = tx.Exec(ctx, "UPDATE outbox SET publishedat = $2 WHERE seq = $1", event.Seq, now)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return published, tx.Commit(ctx)
+}
+
+// DeletePublishedOutboxEvents - удаляет события, опубликованные раньше before.
+func (obs *outboxStorage) DeletePublishedOutboxEvents(before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := obs.db.Exec(ctx, "DELETE FROM outbox WHERE publishedat < $1", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmd.RowsAffected(), nil
+}
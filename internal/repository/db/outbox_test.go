@@ -0,0 +1,110 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectOutbox - ожидание записи событий в outbox и коммита транзакции, типы проверяются по порядку.
+func expectOutbox(mock pgxmock.PgxConnIface, eventTypes ...eventmodels.EventType) {
+	for _, eventType := range eventTypes {
+		mock.ExpectExec("INSERT INTO outbox").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), eventType, pgxmock.AnyArg(),
+				pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+}
+
+// expectOutboxOrRollback - при ожидаемой ошибке транзакция откатывается и в outbox ничего не пишется.
+func expectOutboxOrRollback(mock pgxmock.PgxConnIface, wantErr error, eventTypes ...eventmodels.EventType) {
+	if wantErr != nil {
+		mock.ExpectRollback()
+		return
+	}
+	expectOutbox(mock, eventTypes...)
+	mock.ExpectCommit()
+}
+
+func newOutboxRows() *pgxmock.Rows {
+	return pgxmock.NewRows([]string{
+		"seq", "id", "aggregatetype", "aggregateid", "eventtype", "userid", "payload", "occurredat", "attempts",
+	})
+}
+
+func TestOutboxStorage_RelayOutboxEvents(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	obs := &outboxStorage{db: mock}
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	payload := json.RawMessage(`{}`)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\)").
+		WithArgs(outboxLockKey).
+		WillReturnRows(pgxmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery("SELECT o.seq, .+ FROM outbox o WHERE o.publishedat IS NULL AND o.deadat IS NULL "+
+		"AND o.nextattemptat <= \\$2 AND NOT EXISTS .+ ORDER BY o.seq LIMIT \\$1").
+		WithArgs(10, pgxmock.AnyArg()).
+		WillReturnRows(newOutboxRows().
+			AddRow(int64(1), "e1", "task", "t1", eventmodels.TaskCreated, "u1", payload, now, 0).
+			AddRow(int64(2), "e2", "task", "t2", eventmodels.TaskCreated, "u1", payload, now, 0).
+			AddRow(int64(3), "e3", "task", "t1", eventmodels.TaskUpdated, "u1", payload, now, 0).
+			AddRow(int64(4), "e4", "task", "t3", eventmodels.TaskCreated, "u1", payload, now,
+				eventmodels.MaxPublishAttempts-1))
+	mock.ExpectExec("UPDATE outbox SET attempts = \\$2, lasterror = \\$3, nextattemptat = \\$4, deadat = \\$5 "+
+		"WHERE seq = \\$1").
+		WithArgs(int64(1), 1, "broker is down", pgxmock.AnyArg(), (*time.Time)(nil)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE outbox SET publishedat = \\$2 WHERE seq = \\$1").
+		WithArgs(int64(2), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	// Последняя попытка события 4 не удалась, и оно больше не задерживает события задачи t3.
+	mock.ExpectExec("UPDATE outbox SET attempts = \\$2, lasterror = \\$3, nextattemptat = \\$4, deadat = \\$5 "+
+		"WHERE seq = \\$1").
+		WithArgs(int64(4), eventmodels.MaxPublishAttempts, "broker is down", pgxmock.AnyArg(),
+			pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	var got []int64
+	published, err := obs.RelayOutboxEvents(10, func(event eventmodels.Event) error {
+		got = append(got, event.Seq)
+		if event.AggregateID == "t1" || event.AggregateID == "t3" {
+			return errors.New("broker is down")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	// Событие 3 относится к той же задаче, что и неудавшееся 1, и ждёт следующего прохода.
+	assert.Equal(t, []int64{1, 2, 4}, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxStorage_RelayOutboxEventsLocked(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	obs := &outboxStorage{db: mock}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock").
+		WithArgs(outboxLockKey).
+		WillReturnRows(pgxmock.NewRows([]string{"locked"}).AddRow(false))
+	mock.ExpectRollback()
+
+	published, err := obs.RelayOutboxEvents(10, func(eventmodels.Event) error {
+		t.Fatal("events must not be published without the lock")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"toDoList/internal"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+)
+
+type projectStorage struct {
+	db PgxIface
+}
+
+// AddProject - создаёт проект и сразу добавляет владельца в участники.
+func (ps *projectStorage) AddProject(project projectmodels.Project) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	tx, err := ps.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err = tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error().Err(err).Msg("Transaction rollback failed")
+		}
+	}(tx, ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO projects (id, ownerid, name) VALUES ($1, $2, $3)",
+		project.ID,
+		project.OwnerID,
+		project.Name,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return projecterrors.ErrProjectIsAlreadyExist
+			}
+		}
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO project_members (projectid, userid) VALUES ($1, $2)",
+		project.ID,
+		project.OwnerID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (ps *projectStorage) GetProjectsByUser(userID string) ([]projectmodels.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := ps.db.Query(
+		ctx,
+		"SELECT p.id, p.ownerid, p.name FROM projects p "+
+			"JOIN project_members m ON m.projectid = p.id WHERE m.userid = $1 ORDER BY p.name",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []projectmodels.Project
+
+	for rows.Next() {
+		var project projectmodels.Project
+		if err = rows.Scan(&project.ID, &project.OwnerID, &project.Name); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (ps *projectStorage) GetProjectByID(projectID string) (projectmodels.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	var project projectmodels.Project
+	err := ps.db.QueryRow(ctx, "SELECT id, ownerid, name FROM projects WHERE id = $1", projectID).
+		Scan(&project.ID, &project.OwnerID, &project.Name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return projectmodels.Project{}, projecterrors.ErrProjectNotFound
+		}
+		return projectmodels.Project{}, err
+	}
+
+	rows, err := ps.db.Query(
+		ctx,
+		"SELECT userid FROM project_members WHERE projectid = $1 ORDER BY userid",
+		projectID,
+	)
+	if err != nil {
+		return projectmodels.Project{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		if err = rows.Scan(&userID); err != nil {
+			return projectmodels.Project{}, err
+		}
+		project.Members = append(project.Members, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return projectmodels.Project{}, err
+	}
+	return project, nil
+}
+
+func (ps *projectStorage) AddProjectMember(projectID string, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := ps.db.Exec(
+		ctx,
+		"INSERT INTO project_members (projectid, userid) VALUES ($1, $2)",
+		projectID,
+		userID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return projecterrors.ErrMemberIsAlreadyExist
+			case "23503":
+				return projecterrors.ErrProjectNotFound
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (ps *projectStorage) RemoveProjectMember(projectID string, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := ps.db.Exec(
+		ctx,
+		"DELETE FROM project_members WHERE projectid = $1 AND userid = $2",
+		projectID,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return projecterrors.ErrNotProjectMember
+	}
+
+	return nil
+}
+
+func (ps *projectStorage) IsProjectMember(projectID string, userID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	var isMember bool
+	err := ps.db.QueryRow(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM project_members WHERE projectid = $1 AND userid = $2)",
+		projectID,
+		userID,
+	).Scan(&isMember)
+	if err != nil {
+		return false, err
+	}
+
+	return isMember, nil
+}
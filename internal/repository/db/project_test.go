@@ -0,0 +1,151 @@
+package db
+
+import (
+	"testing"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectStorage_AddProject(t *testing.T) {
+	tests := []struct {
+		name            string
+		shouldDuplicate bool
+		wantErr         error
+	}{
+		{name: "success"},
+		{name: "duplicate", shouldDuplicate: true, wantErr: projecterrors.ErrProjectIsAlreadyExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ps := &projectStorage{db: mock}
+
+			project := projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend"}
+
+			mock.ExpectBegin()
+			insert := mock.ExpectExec("INSERT INTO projects").WithArgs(project.ID, project.OwnerID, project.Name)
+			if tt.shouldDuplicate {
+				insert.WillReturnError(&pgconn.PgError{Code: "23505"})
+				mock.ExpectRollback()
+			} else {
+				insert.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec("INSERT INTO project_members").
+					WithArgs(project.ID, project.OwnerID).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			}
+
+			err = ps.AddProject(project)
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectStorage_GetProjectByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockErr error
+		want    projectmodels.Project
+		wantErr error
+	}{
+		{
+			name: "success",
+			want: projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend", Members: []string{"u1", "u2"}},
+		},
+		{
+			name:    "not found",
+			mockErr: pgx.ErrNoRows,
+			wantErr: projecterrors.ErrProjectNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ps := &projectStorage{db: mock}
+
+			query := mock.ExpectQuery("SELECT id, ownerid, name FROM projects WHERE id = \\$1").WithArgs("p1")
+			if tt.mockErr != nil {
+				query.WillReturnError(tt.mockErr)
+			} else {
+				query.WillReturnRows(pgxmock.NewRows([]string{"id", "ownerid", "name"}).AddRow("p1", "u1", "Backend"))
+				mock.ExpectQuery("SELECT userid FROM project_members WHERE projectid = \\$1").
+					WithArgs("p1").
+					WillReturnRows(pgxmock.NewRows([]string{"userid"}).AddRow("u1").AddRow("u2"))
+			}
+
+			got, err := ps.GetProjectByID("p1")
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProjectStorage_AddProjectMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		pgCode  string
+		wantErr error
+	}{
+		{name: "success"},
+		{name: "duplicate", pgCode: "23505", wantErr: projecterrors.ErrMemberIsAlreadyExist},
+		{name: "no project", pgCode: "23503", wantErr: projecterrors.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ps := &projectStorage{db: mock}
+
+			exec := mock.ExpectExec("INSERT INTO project_members").WithArgs("p1", "u2")
+			if tt.pgCode != "" {
+				exec.WillReturnError(&pgconn.PgError{Code: tt.pgCode})
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			err = ps.AddProjectMember("p1", "u2")
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProjectStorage_IsProjectMember(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ps := &projectStorage{db: mock}
+
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs("p1", "u2").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	isMember, err := ps.IsProjectMember("p1", "u2")
+	require.NoError(t, err)
+	require.True(t, isMember)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/reminder/remindererrors"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+)
+
+type reminderStorage struct {
+	db PgxIface
+}
+
+// fireAt - момент срабатывания: абсолютное время или срок задачи минус сдвиг.
+const fireAt = "COALESCE(r.remindat, t.dueat - make_interval(mins => r.beforeminutes))"
+
+func (rs *reminderStorage) AddReminder(reminder remindermodels.Reminder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := rs.db.Exec(
+		ctx,
+		"INSERT INTO reminders (id, taskid, userid, remindat, beforeminutes, status) VALUES ($1, $2, $3, $4, $5, $6)",
+		reminder.ID,
+		reminder.TaskID,
+		reminder.UserID,
+		reminder.At,
+		reminder.BeforeMinutes,
+		reminder.Status,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return taskerrors.ErrFoundNothing
+		}
+		return err
+	}
+	return nil
+}
+
+func (rs *reminderStorage) GetTaskReminders(taskID string) ([]remindermodels.Reminder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := rs.db.Query(
+		ctx,
+		"SELECT id, taskid, userid, remindat, beforeminutes, status, attempts, sentat FROM reminders "+
+			"WHERE taskid = $1 ORDER BY id",
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reminders []remindermodels.Reminder
+
+	for rows.Next() {
+		var reminder remindermodels.Reminder
+		if err = rows.Scan(
+			&reminder.ID,
+			&reminder.TaskID,
+			&reminder.UserID,
+			&reminder.At,
+			&reminder.BeforeMinutes,
+			&reminder.Status,
+			&reminder.Attempts,
+			&reminder.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (rs *reminderStorage) DeleteReminder(reminderID string, taskID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := rs.db.Exec(ctx, "DELETE FROM reminders WHERE id = $1 AND taskid = $2", reminderID, taskID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return remindererrors.ErrReminderNotFound
+	}
+	return nil
+}
+
+// CancelStaleReminders - отменяет ожидающие напоминания удалённых и завершённых задач,
+// а также относительные напоминания задач, у которых убрали срок.
+func (rs *reminderStorage) CancelStaleReminders() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := rs.db.Exec(
+		ctx,
+		"UPDATE reminders r SET status = $1 FROM tasks t WHERE t.id = r.taskid AND r.status = $2 "+
+			"AND (t.deleted OR t.statuscategory = $3 OR (r.remindat IS NULL AND t.dueat IS NULL))",
+		remindermodels.StatusCancelled,
+		remindermodels.StatusPending,
+		taskmodels.CategoryDone,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmd.RowsAffected(), nil
+}
+
+// FireDueReminders - отправляет до limit наступивших напоминаний. Строки блокируются
+// FOR UPDATE SKIP LOCKED до конца транзакции, поэтому несколько экземпляров сервера
+// не отправят одно напоминание дважды, а статус sent фиксируется той же транзакцией.
+func (rs *reminderStorage) FireDueReminders(
+	now time.Time,
+	limit int,
+	fire func(notification remindermodels.Notification) error,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecTen)
+	defer cancel()
+
+	tx, err := rs.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func(tx pgx.Tx, ctx context.Context) {
+		errRollback := tx.Rollback(ctx)
+		if errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			log.Error().Err(errRollback).Msg("Transaction rollback failed")
+		}
+	}(tx, ctx)
+
+	rows, err := tx.Query(
+		ctx,
+		"SELECT r.id, r.taskid, r.userid, t.title, t.dueat, "+fireAt+" FROM reminders r "+
+			"JOIN tasks t ON t.id = r.taskid "+
+			"WHERE r.status = $1 AND t.deleted = false AND t.statuscategory <> $2 AND "+fireAt+" <= $3 "+
+			"ORDER BY "+fireAt+" LIMIT $4 FOR UPDATE OF r SKIP LOCKED",
+		remindermodels.StatusPending,
+		taskmodels.CategoryDone,
+		now,
+		limit,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	notifications, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (remindermodels.Notification, error) {
+		var n remindermodels.Notification
+		err := row.Scan(&n.ReminderID, &n.TaskID, &n.UserID, &n.TaskTitle, &n.DueDate, &n.FireAt)
+		return n, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	fired := 0
+	for _, notification := range notifications {
+		if errFire := fire(notification); errFire != nil {
+			log.Error().Err(errFire).Str("reminder", notification.ReminderID).Msg("failed to send reminder")
+			_, err = tx.Exec(
+				ctx,
+				"UPDATE reminders SET attempts = attempts + 1, "+
+					"status = CASE WHEN attempts + 1 >= $2 THEN $3 ELSE status END WHERE id = $1",
+				notification.ReminderID,
+				remindermodels.MaxAttempts,
+				remindermodels.StatusFailed,
+			)
+		} else {
+			fired++
+			_, err = tx.Exec(
+				ctx,
+				"UPDATE reminders SET status = $2, sentat = $3, attempts = attempts + 1 WHERE id = $1",
+				notification.ReminderID,
+				remindermodels.StatusSent,
+				now,
+			)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return fired, tx.Commit(ctx)
+}
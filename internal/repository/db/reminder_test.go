@@ -0,0 +1,65 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"toDoList/internal/domain/reminder/remindererrors"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderStorage_FireDueReminders(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	rs := &reminderStorage{db: mock}
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	due := now.Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT r.id, r.taskid, r.userid, t.title, t.dueat, .+ FOR UPDATE OF r SKIP LOCKED").
+		WithArgs(remindermodels.StatusPending, taskmodels.CategoryDone, now, 10).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "taskid", "userid", "title", "dueat", "fireat"}).
+			AddRow("r1", "t1", "u1", "Report", &due, now.Add(-time.Minute)).
+			AddRow("r2", "t2", "u1", "Call", (*time.Time)(nil), now))
+	mock.ExpectExec("UPDATE reminders SET status = \\$2, sentat = \\$3").
+		WithArgs("r1", remindermodels.StatusSent, now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE reminders SET attempts = attempts \\+ 1").
+		WithArgs("r2", remindermodels.MaxAttempts, remindermodels.StatusFailed).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	var got []remindermodels.Notification
+	fired, err := rs.FireDueReminders(now, 10, func(n remindermodels.Notification) error {
+		got = append(got, n)
+		if n.ReminderID == "r2" {
+			return errors.New("delivery failed")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, fired)
+	require.Len(t, got, 2)
+	assert.Equal(t, "Report", got[0].TaskTitle)
+	assert.Equal(t, &due, got[0].DueDate)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderStorage_DeleteReminder(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	rs := &reminderStorage{db: mock}
+
+	mock.ExpectExec("DELETE FROM reminders WHERE id = \\$1 AND taskid = \\$2").
+		WithArgs("r1", "t1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	require.ErrorIs(t, rs.DeleteReminder("r1", "t1"), remindererrors.ErrReminderNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
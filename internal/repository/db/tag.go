@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"toDoList/internal"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/tag/tagerrors"
+	"toDoList/internal/domain/tag/tagmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+)
+
+type tagStorage struct {
+	db PgxIface
+}
+
+func (tgs *tagStorage) AddTag(tag tagmodels.Tag) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := tgs.db.Exec(
+		ctx,
+		"INSERT INTO tags (id, userid, name, color) VALUES ($1, $2, $3, $4)",
+		tag.ID,
+		tag.UserID,
+		tag.Name,
+		tag.Color,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return tagerrors.ErrTagIsAlreadyExist
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (tgs *tagStorage) GetTagsByUser(userID string) ([]tagmodels.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := tgs.db.Query(
+		ctx,
+		"SELECT id, userid, name, color FROM tags WHERE userid = $1 ORDER BY name",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []tagmodels.Tag
+
+	for rows.Next() {
+		var tag tagmodels.Tag
+		if err = rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.Color); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (tgs *tagStorage) GetTagByID(tagID string, userID string) (tagmodels.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	var tag tagmodels.Tag
+	err := tgs.db.QueryRow(
+		ctx,
+		"SELECT id, userid, name, color FROM tags WHERE id = $1 AND userid = $2",
+		tagID,
+		userID,
+	).Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.Color)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return tagmodels.Tag{}, tagerrors.ErrTagNotFound
+		}
+		return tagmodels.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// UpdateTag - задачи ссылаются на тег по ID, поэтому переименование достаточно сделать в одном месте.
+// Теги входят в представление задачи, поэтому версии задач с тегом растут.
+func (tgs *tagStorage) UpdateTag(tag tagmodels.Tag) error {
+	return inTx(tgs.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		cmd, err := tx.Exec(
+			ctx,
+			"UPDATE tags SET name = $1, color = $2 WHERE id = $3 AND userid = $4",
+			tag.Name,
+			tag.Color,
+			tag.ID,
+			tag.UserID,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				if pgErr.Code == "23505" {
+					return nil, tagerrors.ErrTagIsAlreadyExist
+				}
+			}
+			return nil, err
+		}
+
+		if cmd.RowsAffected() == 0 {
+			return nil, tagerrors.ErrTagNotFound
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE tasks SET version = version + 1 WHERE id IN (SELECT taskid FROM task_tags WHERE tagid = $1)",
+			tag.ID,
+		)
+		return nil, err
+	})
+}
+
+// DeleteTag - версии задач с тегом растут до удаления, пока их связи с тегом ещё есть.
+func (tgs *tagStorage) DeleteTag(tagID string, userID string) error {
+	return inTx(tgs.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		_, err := tx.Exec(
+			ctx,
+			"UPDATE tasks SET version = version + 1 WHERE id IN (SELECT tt.taskid FROM task_tags tt "+
+				"JOIN tags t ON t.id = tt.tagid WHERE t.id = $1 AND t.userid = $2)",
+			tagID,
+			userID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd, err := tx.Exec(ctx, "DELETE FROM tags WHERE id = $1 AND userid = $2", tagID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if cmd.RowsAffected() == 0 {
+			return nil, tagerrors.ErrTagNotFound
+		}
+
+		return nil, nil
+	})
+}
+
+// SetTaskTags - заменяет теги пользователя на задаче, чужие теги не трогает.
+func (tgs *tagStorage) SetTaskTags(taskID string, userID string, tagIDs []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	tx, err := tgs.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err = tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error().Err(err).Msg("Transaction rollback failed")
+		}
+	}(tx, ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM task_tags WHERE taskid = $1 AND tagid IN (SELECT id FROM tags WHERE userid = $2)",
+		taskID,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, tagID := range tagIDs {
+		_, err = tx.Exec(ctx, "INSERT INTO task_tags (taskid, tagid) VALUES ($1, $2)", taskID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Теги входят в представление задачи, поэтому меняют её версию.
+	_, err = tx.Exec(ctx, "UPDATE tasks SET version = version + 1 WHERE id = $1", taskID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
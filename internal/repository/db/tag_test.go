@@ -0,0 +1,169 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"toDoList/internal/domain/tag/tagerrors"
+	"toDoList/internal/domain/tag/tagmodels"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagStorage_AddTag(t *testing.T) {
+	tests := []struct {
+		name            string
+		shouldDuplicate bool
+		wantErr         error
+	}{
+		{name: "success"},
+		{name: "duplicate", shouldDuplicate: true, wantErr: tagerrors.ErrTagIsAlreadyExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			tgs := &tagStorage{db: mock}
+
+			tag := tagmodels.Tag{ID: "t1", UserID: "u1", Name: "urgent", Color: "#ff0000"}
+			exec := mock.ExpectExec("INSERT INTO tags").WithArgs(tag.ID, tag.UserID, tag.Name, tag.Color)
+			if tt.shouldDuplicate {
+				exec.WillReturnError(&pgconn.PgError{Code: "23505"})
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			err = tgs.AddTag(tag)
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagStorage_UpdateTag(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{"success", 1, nil},
+		{"not found", 0, tagerrors.ErrTagNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			tgs := &tagStorage{db: mock}
+
+			tag := tagmodels.Tag{ID: "t1", UserID: "u1", Name: "defect", Color: "#ff0000"}
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE tags SET name = \\$1, color = \\$2").
+				WithArgs(tag.Name, tag.Color, tag.ID, tag.UserID).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rowsAffected))
+			if tt.wantErr == nil {
+				mock.ExpectExec("UPDATE tasks SET version = version \\+ 1 WHERE id IN \\(SELECT taskid FROM task_tags").
+					WithArgs(tag.ID).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+			}
+			expectOutboxOrRollback(mock, tt.wantErr)
+
+			err = tgs.UpdateTag(tag)
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagStorage_DeleteTag(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{"success", 1, nil},
+		{"not found", 0, tagerrors.ErrTagNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			tgs := &tagStorage{db: mock}
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE tasks SET version = version \\+ 1 WHERE id IN \\(SELECT tt.taskid FROM task_tags").
+				WithArgs("t1", "u1").
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rowsAffected))
+			mock.ExpectExec("DELETE FROM tags").
+				WithArgs("t1", "u1").
+				WillReturnResult(pgxmock.NewResult("DELETE", tt.rowsAffected))
+			expectOutboxOrRollback(mock, tt.wantErr)
+
+			err = tgs.DeleteTag("t1", "u1")
+			assert.ErrorIs(t, err, tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagStorage_SetTaskTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		insertErr error
+		wantErr   error
+	}{
+		{name: "success"},
+		{name: "insert error", insertErr: errors.New("insert failed"), wantErr: errors.New("insert failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			tgs := &tagStorage{db: mock}
+
+			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM task_tags").
+				WithArgs("task1", "u1").
+				WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			if tt.insertErr != nil {
+				mock.ExpectExec("INSERT INTO task_tags").WithArgs("task1", "t1").WillReturnError(tt.insertErr)
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec("INSERT INTO task_tags").
+					WithArgs("task1", "t1").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec("INSERT INTO task_tags").
+					WithArgs("task1", "t2").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec("UPDATE tasks SET version = version \\+ 1 WHERE id = \\$1").
+					WithArgs("task1").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mock.ExpectCommit()
+			}
+
+			err = tgs.SetTaskTags("task1", "u1", []string{"t1", "t2"})
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
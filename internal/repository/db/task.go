@@ -3,7 +3,10 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"toDoList/internal"
+	"toDoList/internal/domain/event/eventmodels"
 	"toDoList/internal/domain/task/taskerrors"
 	"toDoList/internal/domain/task/taskmodels"
 
@@ -16,55 +19,168 @@ type taskStorage struct {
 	db PgxIface
 }
 
+// taskColumns - общий список колонок задачи, порядок совпадает со scanTask.
+// Теги собираются подзапросом, поэтому переименование тега сразу видно во всех задачах.
+const taskColumns = "id, userid, status, statuscategory, title, description, deleted, projectid, assigneeid, " +
+	"priority, position, parentid, autocomplete, checklist, dueat, rrule, customfields, estimate, seriesid, " +
+	"occurrence, version, " +
+	"ARRAY(SELECT blockerid FROM task_dependencies WHERE taskid = tasks.id ORDER BY blockerid), " +
+	"ARRAY(SELECT taskid FROM task_dependencies WHERE blockerid = tasks.id ORDER BY taskid), " +
+	"COALESCE((SELECT json_agg(json_build_object('id', t.id, 'user_id', t.userid, 'name', t.name, " +
+	"'color', t.color) ORDER BY t.name) FROM task_tags tt JOIN tags t ON t.id = tt.tagid " +
+	"WHERE tt.taskid = tasks.id), '[]'::json)"
+
+// byPosition - ручной порядок задач, ранги сравниваются побайтно независимо от локали БД.
+const byPosition = ` ORDER BY position COLLATE "C", id`
+
+// visibleToUser - задача видна владельцу и участникам её проекта, arg - номер параметра с ID пользователя.
+func visibleToUser(arg int) string {
+	return fmt.Sprintf(
+		"(userid = $%[1]d OR (projectid <> '' AND projectid IN "+
+			"(SELECT projectid FROM project_members WHERE userid = $%[1]d)))",
+		arg,
+	)
+}
+
+// scanTask - extra - приёмники колонок, которые в запросе идут после taskColumns.
+func scanTask(row pgx.Row, extra ...any) (taskmodels.Task, error) {
+	var task taskmodels.Task
+	dest := []any{
+		&task.ID,
+		&task.UserID,
+		&task.Attributes.Status,
+		&task.Category,
+		&task.Attributes.Title,
+		&task.Attributes.Description,
+		&task.Deleted,
+		&task.Attributes.ProjectID,
+		&task.Attributes.AssigneeID,
+		&task.Attributes.Priority,
+		&task.Position,
+		&task.Attributes.ParentID,
+		&task.Attributes.AutoComplete,
+		&task.Checklist,
+		&task.Attributes.DueDate,
+		&task.Attributes.RRule,
+		&task.Attributes.CustomFields,
+		&task.Attributes.Estimate,
+		&task.SeriesID,
+		&task.Occurrence,
+		&task.Version,
+		&task.BlockedBy,
+		&task.Blocking,
+		&task.Tags,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	return task, err
+}
+
+func collectTasks(rows pgx.Rows) ([]taskmodels.Task, error) {
+	defer rows.Close()
+
+	var tasks []taskmodels.Task
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
 func (ts *taskStorage) GetAllTasks(userID string) ([]taskmodels.Task, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
 	defer cancel()
 	rows, err := ts.db.Query(
 		ctx,
-		"SELECT id, userid, status, title, description, deleted FROM tasks where userid = $1",
+		"SELECT "+taskColumns+" FROM tasks where userid = $1"+byPosition,
 		userID,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	var tasks []taskmodels.Task
+	return collectTasks(rows)
+}
 
-	for rows.Next() {
-		var task taskmodels.Task
-		if err = rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Attributes.Status,
-			&task.Attributes.Title,
-			&task.Attributes.Description,
-			&task.Deleted,
-		); err != nil {
-			return nil, err
+// FindTasks - задачи, видимые пользователю и подходящие под фильтр.
+// Фильтр по тегам учитывает только теги самого пользователя.
+func (ts *taskStorage) FindTasks(userID string, filter taskmodels.TaskFilter) ([]taskmodels.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	args := []any{userID}
+	conditions := []string{visibleToUser(1)}
+
+	if filter.ProjectID != "" {
+		args = append(args, filter.ProjectID)
+		conditions = append(conditions, fmt.Sprintf("projectid = $%d", len(args)))
+	}
+
+	if filter.AssigneeID != "" {
+		args = append(args, filter.AssigneeID)
+		conditions = append(conditions, fmt.Sprintf("assigneeid = $%d", len(args)))
+	}
+
+	if len(filter.Tags) != 0 {
+		args = append(args, filter.Tags)
+		tagCondition := fmt.Sprintf(
+			"id IN (SELECT tt.taskid FROM task_tags tt JOIN tags t ON t.id = tt.tagid "+
+				"WHERE t.userid = $1 AND t.name = ANY($%d)",
+			len(args),
+		)
+		if filter.TagMode == taskmodels.TagModeAll {
+			args = append(args, len(filter.Tags))
+			tagCondition += fmt.Sprintf(" GROUP BY tt.taskid HAVING COUNT(DISTINCT t.name) = $%d", len(args))
 		}
-		tasks = append(tasks, task)
+		conditions = append(conditions, tagCondition+")")
 	}
 
-	if err = rows.Err(); err != nil {
+	// Условие вхождения использует GIN-индекс по customfields.
+	if len(filter.CustomFields) != 0 {
+		args = append(args, filter.CustomFields)
+		conditions = append(conditions, fmt.Sprintf("customfields @> $%d::jsonb", len(args)))
+	}
+
+	order := byPosition
+	if filter.SortField != "" {
+		args = append(args, filter.SortField)
+		direction := ""
+		if filter.SortDesc {
+			direction = " DESC"
+		}
+		order = fmt.Sprintf(` ORDER BY customfields -> $%d::text%s NULLS LAST, position COLLATE "C", id`,
+			len(args), direction)
+	}
+
+	rows, err := ts.db.Query(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE "+strings.Join(conditions, " AND ")+order,
+		args...,
+	)
+	if err != nil {
 		return nil, err
 	}
-	return tasks, nil
+
+	return collectTasks(rows)
 }
 
 func (ts *taskStorage) GetTaskByID(taskID string, userID string) (taskmodels.Task, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
 	defer cancel()
 
-	var task taskmodels.Task
-	err := ts.db.QueryRow(ctx, "SELECT id, userid, status, title, description, deleted FROM tasks WHERE id = $1 AND userid = $2", taskID, userID).
-		Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Attributes.Status,
-			&task.Attributes.Title,
-			&task.Attributes.Description,
-			&task.Deleted,
-		)
+	task, err := scanTask(ts.db.QueryRow(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE id = $1 AND "+visibleToUser(2),
+		taskID,
+		userID,
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return taskmodels.Task{}, taskerrors.ErrFoundNothing
@@ -76,91 +192,367 @@ func (ts *taskStorage) GetTaskByID(taskID string, userID string) (taskmodels.Tas
 }
 
 func (ts *taskStorage) AddTask(newTask taskmodels.Task) error {
-	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
-	defer cancel()
+	return inTx(ts.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		return insertTask(ctx, tx, newTask)
+	})
+}
 
-	_, err := ts.db.Exec(
+func insertTask(ctx context.Context, tx pgx.Tx, newTask taskmodels.Task) ([]eventmodels.Event, error) {
+	_, err := tx.Exec(
 		ctx,
-		"INSERT INTO tasks (id, userid, status, title, description) VALUES ($1, $2, $3, $4, $5)",
+		"INSERT INTO tasks (id, userid, status, title, description, projectid, assigneeid, priority, position, "+
+			"parentid, autocomplete, dueat, rrule, seriesid, occurrence, checklist, statuscategory, customfields, "+
+			"estimate) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)",
 		newTask.ID,
 		newTask.UserID,
 		newTask.Attributes.Status,
 		newTask.Attributes.Title,
 		newTask.Attributes.Description,
+		newTask.Attributes.ProjectID,
+		newTask.Attributes.AssigneeID,
+		newTask.Attributes.Priority,
+		newTask.Position,
+		newTask.Attributes.ParentID,
+		newTask.Attributes.AutoComplete,
+		newTask.Attributes.DueDate,
+		newTask.Attributes.RRule,
+		newTask.SeriesID,
+		newTask.Occurrence,
+		checklistOrEmpty(newTask.Checklist),
+		newTask.Category,
+		customFieldsOrEmpty(newTask.Attributes.CustomFields),
+		newTask.Attributes.Estimate,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
-				return taskerrors.ErrTaskIsAlreadyExist
+				return nil, taskerrors.ErrTaskIsAlreadyExist
 			}
 		}
-		return err
+		return nil, err
+	}
+
+	newTask.Version = 1
+	err = insertHistory(ctx, tx, taskmodels.HistoryCreated, actorOf(newTask), taskmodels.Task{}, newTask,
+		taskmodels.AllTaskFields)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	event, err := eventmodels.NewTaskEvent(eventmodels.TaskCreated, newTask)
+	if err != nil {
+		return nil, err
+	}
+	return []eventmodels.Event{event}, nil
 }
 
+// UpdateTaskAttributes - кроме task.updated пишет task.status_changed, если статус изменился.
+// Ненулевая task.Version - версия, которую видел вызывающий: если задачу с тех пор изменили,
+// возвращается ErrVersionConflict. Строка блокируется до конца транзакции, поэтому проверка атомарна.
 func (ts *taskStorage) UpdateTaskAttributes(task taskmodels.Task) error {
+	return ts.UpdateTaskFields(task, taskmodels.AllTaskFields)
+}
+
+// UpdateTaskFields - как UpdateTaskAttributes, но пишет только колонки перечисленных полей.
+func (ts *taskStorage) UpdateTaskFields(task taskmodels.Task, fields []taskmodels.TaskField) error {
+	return inTx(ts.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		return updateTaskFields(ctx, tx, task, fields)
+	})
+}
+
+// taskFieldColumns - колонки поля задачи и их новые значения.
+func taskFieldColumns(task taskmodels.Task, field taskmodels.TaskField) ([]string, []any, error) {
+	switch field {
+	case taskmodels.FieldStatus:
+		return []string{"status", "statuscategory"}, []any{task.Attributes.Status, task.Category}, nil
+	case taskmodels.FieldTitle:
+		return []string{"title"}, []any{task.Attributes.Title}, nil
+	case taskmodels.FieldDescription:
+		return []string{"description"}, []any{task.Attributes.Description}, nil
+	case taskmodels.FieldProjectID:
+		return []string{"projectid"}, []any{task.Attributes.ProjectID}, nil
+	case taskmodels.FieldAssigneeID:
+		return []string{"assigneeid"}, []any{task.Attributes.AssigneeID}, nil
+	case taskmodels.FieldPriority:
+		return []string{"priority"}, []any{task.Attributes.Priority}, nil
+	case taskmodels.FieldParentID:
+		return []string{"parentid"}, []any{task.Attributes.ParentID}, nil
+	case taskmodels.FieldAutoComplete:
+		return []string{"autocomplete"}, []any{task.Attributes.AutoComplete}, nil
+	case taskmodels.FieldDueDate:
+		return []string{"dueat"}, []any{task.Attributes.DueDate}, nil
+	case taskmodels.FieldRRule:
+		return []string{"rrule"}, []any{task.Attributes.RRule}, nil
+	case taskmodels.FieldSeries:
+		return []string{"seriesid", "occurrence"}, []any{task.SeriesID, task.Occurrence}, nil
+	case taskmodels.FieldCustomFields:
+		return []string{"customfields"}, []any{customFieldsOrEmpty(task.Attributes.CustomFields)}, nil
+	case taskmodels.FieldEstimate:
+		return []string{"estimate"}, []any{task.Attributes.Estimate}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown task field %q", field)
+	}
+}
+
+func updateTaskFields(
+	ctx context.Context,
+	tx pgx.Tx,
+	task taskmodels.Task,
+	fields []taskmodels.TaskField,
+) ([]eventmodels.Event, error) {
+	var set strings.Builder
+	var args []any
+	for _, field := range fields {
+		columns, values, err := taskFieldColumns(task, field)
+		if err != nil {
+			return nil, err
+		}
+		for i, column := range columns {
+			args = append(args, values[i])
+			fmt.Fprintf(&set, "%s = $%d, ", column, len(args))
+		}
+	}
+	args = append(args, task.ID)
+
+	old, err := scanTask(tx.QueryRow(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = $1 FOR UPDATE", task.ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, taskerrors.ErrFoundNothing
+		}
+		return nil, err
+	}
+
+	if task.Version != 0 && task.Version != old.Version {
+		return nil, taskerrors.ErrVersionConflict
+	}
+
+	query := fmt.Sprintf("UPDATE tasks SET %sversion = version + 1 WHERE id = $%d RETURNING version",
+		set.String(), len(args))
+	err = tx.QueryRow(ctx, query, args...).Scan(&task.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	err = insertHistory(ctx, tx, taskmodels.HistoryUpdated, actorOf(task), old, task, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := []eventmodels.EventType{eventmodels.TaskUpdated}
+	if old.Attributes.Status != task.Attributes.Status {
+		eventTypes = append(eventTypes, eventmodels.TaskStatusChanged)
+	}
+
+	return taskEvents(eventTypes, task)
+}
+
+// taskEvents - по событию каждого типа для одной задачи.
+func taskEvents(eventTypes []eventmodels.EventType, task taskmodels.Task) ([]eventmodels.Event, error) {
+	events := make([]eventmodels.Event, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		event, err := eventmodels.NewTaskEvent(eventType, task)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// updateTaskReturning - изменение одной задачи запросом с RETURNING taskColumns и событие task.updated
+// с её новым состоянием.
+func updateTaskReturning(db PgxIface, query string, args ...any) error {
+	return inTx(db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		task, err := scanTask(tx.QueryRow(ctx, query+" RETURNING "+taskColumns, args...))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, taskerrors.ErrFoundNothing
+			}
+			return nil, err
+		}
+
+		return taskEvents([]eventmodels.EventType{eventmodels.TaskUpdated}, task)
+	})
+}
+
+// subtree - рекурсивный CTE subtree: задачи под условием root и все их потомки.
+func subtree(root string) string {
+	return "WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE " + root +
+		" UNION ALL SELECT t.id FROM tasks t JOIN subtree s ON t.parentid = s.id) "
+}
+
+// GetSubtasks - все неудалённые потомки задачи плоским списком, дерево собирает сервис.
+func (ts *taskStorage) GetSubtasks(taskID string) ([]taskmodels.Task, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
 	defer cancel()
 
-	cmd, err := ts.db.Exec(
+	rows, err := ts.db.Query(
 		ctx,
-		"UPDATE tasks SET status = $1, title = $2, description = $3 WHERE id = $4",
-		task.Attributes.Status,
-		task.Attributes.Title,
-		task.Attributes.Description,
-		task.ID,
+		subtree("parentid = $1")+"SELECT "+taskColumns+" FROM tasks "+
+			"WHERE id IN (SELECT id FROM subtree) AND deleted = false"+byPosition,
+		taskID,
 	)
-
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if cmd.RowsAffected() == 0 {
-		return taskerrors.ErrFoundNothing
+	return collectTasks(rows)
+}
+
+// GetSeriesTasks - неудалённые повторения серии по порядку.
+func (ts *taskStorage) GetSeriesTasks(seriesID string) ([]taskmodels.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := ts.db.Query(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE seriesid = $1 AND deleted = false ORDER BY occurrence",
+		seriesID,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return collectTasks(rows)
 }
 
-func (ts *taskStorage) DeleteTask(taskID string, userID string) error {
+// CountTasksInStatus - сколько неудалённых задач проекта в статусе, для WIP-лимитов.
+func (ts *taskStorage) CountTasksInStatus(projectID string, status taskmodels.TaskStatus) (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
 	defer cancel()
 
-	cmd, err := ts.db.Exec(ctx, "DELETE FROM tasks WHERE id = $1 AND userid = $2", taskID, userID)
+	var count int
+	err := ts.db.QueryRow(
+		ctx,
+		"SELECT count(*) FROM tasks WHERE projectid = $1 AND status = $2 AND deleted = false",
+		projectID,
+		status,
+	).Scan(&count)
+	return count, err
+}
 
-	if err != nil {
-		return err
+// customFieldsOrEmpty - nil записался бы в jsonb как null вместо пустого объекта.
+func customFieldsOrEmpty(values map[string]any) map[string]any {
+	if values == nil {
+		return map[string]any{}
 	}
+	return values
+}
 
-	if cmd.RowsAffected() == 0 {
-		return taskerrors.ErrFoundNothing
+// checklistOrEmpty - nil-срез записался бы в jsonb как null вместо пустого массива.
+func checklistOrEmpty(checklist []taskmodels.ChecklistItem) []taskmodels.ChecklistItem {
+	if checklist == nil {
+		return []taskmodels.ChecklistItem{}
 	}
+	return checklist
+}
 
-	return nil
+func (ts *taskStorage) UpdateTaskChecklist(taskID string, checklist []taskmodels.ChecklistItem) error {
+	return updateTaskReturning(
+		ts.db,
+		"UPDATE tasks SET checklist = $1, version = version + 1 WHERE id = $2",
+		checklistOrEmpty(checklist),
+		taskID,
+	)
 }
 
-func (ts *taskStorage) MarkTaskToDelete(taskID string, userID string) error {
+// GetLastTaskPosition - наибольший ранг среди задач пользователя, пустая строка если задач нет.
+func (ts *taskStorage) GetLastTaskPosition(userID string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
 	defer cancel()
 
-	cmd, err := ts.db.Exec(
+	var position string
+	err := ts.db.QueryRow(
 		ctx,
-		"UPDATE tasks SET deleted = true WHERE id = $1 AND userid = $2",
+		`SELECT COALESCE(MAX(position COLLATE "C"), '') FROM tasks WHERE userid = $1`,
+		userID,
+	).Scan(&position)
+	if err != nil {
+		return "", err
+	}
+
+	return position, nil
+}
+
+func (ts *taskStorage) UpdateTaskPosition(taskID string, userID string, position string) error {
+	return updateTaskReturning(
+		ts.db,
+		"UPDATE tasks SET position = $1, version = version + 1 WHERE id = $2 AND userid = $3",
+		position,
 		taskID,
 		userID,
 	)
+}
 
-	if err != nil {
-		return err
-	}
+func (ts *taskStorage) DeleteTask(taskID string, userID string) error {
+	return deleteTasksReturning(ts.db, userID, true, "DELETE FROM tasks WHERE id = $1 AND userid = $2", taskID, userID)
+}
 
-	if cmd.RowsAffected() == 0 {
-		return taskerrors.ErrFoundNothing
-	}
+// MarkTaskToDelete - вместе с задачей помечаются все её подзадачи, task.deleted пишется для каждой.
+func (ts *taskStorage) MarkTaskToDelete(taskID string, userID string) error {
+	return deleteTasksReturning(
+		ts.db,
+		userID,
+		false,
+		subtree("id = $1 AND userid = $2")+
+			"UPDATE tasks SET deleted = true, version = version + 1 WHERE id IN (SELECT id FROM subtree)",
+		taskID,
+		userID,
+	)
+}
+
+// deleteTasksReturning - удаление задач запросом с RETURNING taskColumns и task.deleted для каждой из них.
+// actorID - автор записей истории. hard - строки удаляются без увеличения версии,
+// поэтому ревизией удаления становится следующая версия. Без hard задачи только помечаются,
+// и вместе с ними помечаются их комментарии.
+func deleteTasksReturning(db PgxIface, actorID string, hard bool, query string, args ...any) error {
+	return inTx(db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		rows, err := tx.Query(ctx, query+" RETURNING "+taskColumns, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks, err := collectTasks(rows)
+		if err != nil {
+			return nil, err
+		}
 
-	return nil
+		if len(tasks) == 0 {
+			return nil, taskerrors.ErrFoundNothing
+		}
+
+		// Окончательно комментарии удаляются каскадом вместе с задачами.
+		if !hard {
+			ids := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			_, err = tx.Exec(ctx, "UPDATE comments SET deleted = true WHERE taskid = ANY($1)", ids)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var events []eventmodels.Event
+		for _, task := range tasks {
+			deleted := task
+			if hard {
+				deleted.Version++
+			}
+			err = insertHistory(ctx, tx, taskmodels.HistoryDeleted, actorID, task, deleted, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			taskEvent, errEvent := eventmodels.NewTaskEvent(eventmodels.TaskDeleted, task)
+			if errEvent != nil {
+				return nil, errEvent
+			}
+			events = append(events, taskEvent)
+		}
+		return events, nil
+	})
 }
 
 func (ts *taskStorage) DeleteMarkedTasks() error {
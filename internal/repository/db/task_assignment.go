@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"toDoList/internal"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (ts *taskStorage) AddTaskAssignment(assignment taskmodels.Assignment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := ts.db.Exec(
+		ctx,
+		"INSERT INTO task_assignments (taskid, assigneeid, assignedby, assignedat) VALUES ($1, $2, $3, $4)",
+		assignment.TaskID,
+		assignment.AssigneeID,
+		assignment.AssignedBy,
+		assignment.AssignedAt,
+	)
+	return err
+}
+
+func (ts *taskStorage) GetTaskAssignments(taskID string) ([]taskmodels.Assignment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := ts.db.Query(
+		ctx,
+		"SELECT taskid, assigneeid, assignedby, assignedat FROM task_assignments WHERE taskid = $1 ORDER BY id",
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignments []taskmodels.Assignment
+
+	for rows.Next() {
+		var assignment taskmodels.Assignment
+		if err = rows.Scan(
+			&assignment.TaskID,
+			&assignment.AssigneeID,
+			&assignment.AssignedBy,
+			&assignment.AssignedAt,
+		); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (ts *taskStorage) AddTaskWatcher(taskID string, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := ts.db.Exec(ctx, "INSERT INTO task_watchers (taskid, userid) VALUES ($1, $2)", taskID, userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return taskerrors.ErrWatcherIsExist
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (ts *taskStorage) RemoveTaskWatcher(taskID string, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := ts.db.Exec(ctx, "DELETE FROM task_watchers WHERE taskid = $1 AND userid = $2", taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return taskerrors.ErrWatcherNotFound
+	}
+
+	return nil
+}
+
+func (ts *taskStorage) GetTaskWatchers(taskID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := ts.db.Query(ctx, "SELECT userid FROM task_watchers WHERE taskid = $1 ORDER BY userid", taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var watchers []string
+
+	for rows.Next() {
+		var userID string
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return watchers, nil
+}
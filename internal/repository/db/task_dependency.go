@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"toDoList/internal"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// bumpDependencyVersions - зависимость видна в blocked_by одной задачи и blocking другой,
+// поэтому её изменение меняет версии обеих. dependency - CTE, возвращающий taskid и blockerid.
+func bumpDependencyVersions(dependency string) string {
+	return "WITH dependency AS (" + dependency + " RETURNING taskid, blockerid) " +
+		"UPDATE tasks SET version = version + 1 " +
+		"WHERE id IN (SELECT taskid FROM dependency UNION SELECT blockerid FROM dependency)"
+}
+
+func (ts *taskStorage) AddTaskDependency(taskID string, blockerID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := ts.db.Exec(
+		ctx,
+		bumpDependencyVersions("INSERT INTO task_dependencies (taskid, blockerid) VALUES ($1, $2)"),
+		taskID,
+		blockerID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return taskerrors.ErrDependencyIsExist
+			case "23503":
+				return taskerrors.ErrFoundNothing
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (ts *taskStorage) RemoveTaskDependency(taskID string, blockerID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := ts.db.Exec(
+		ctx,
+		bumpDependencyVersions("DELETE FROM task_dependencies WHERE taskid = $1 AND blockerid = $2"),
+		taskID,
+		blockerID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return taskerrors.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// HasDependencyPath - зависит ли задача fromID от toID напрямую или через цепочку блокеров.
+func (ts *taskStorage) HasDependencyPath(fromID string, toID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	var exists bool
+	err := ts.db.QueryRow(
+		ctx,
+		"WITH RECURSIVE blockers AS (SELECT blockerid FROM task_dependencies WHERE taskid = $1 "+
+			"UNION SELECT d.blockerid FROM task_dependencies d JOIN blockers b ON d.taskid = b.blockerid) "+
+			"SELECT EXISTS (SELECT 1 FROM blockers WHERE blockerid = $2)",
+		fromID,
+		toID,
+	).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// GetUnfinishedBlockers - ID блокирующих задач, статус которых ещё не в категории done.
+func (ts *taskStorage) GetUnfinishedBlockers(taskID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := ts.db.Query(
+		ctx,
+		"SELECT d.blockerid FROM task_dependencies d JOIN tasks t ON t.id = d.blockerid "+
+			"WHERE d.taskid = $1 AND t.statuscategory <> $2 AND t.deleted = false ORDER BY d.blockerid",
+		taskID,
+		taskmodels.CategoryDone,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blockers []string
+
+	for rows.Next() {
+		var blockerID string
+		if err = rows.Scan(&blockerID); err != nil {
+			return nil, err
+		}
+		blockers = append(blockers, blockerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return blockers, nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"testing"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskStorage_AddTaskDependency(t *testing.T) {
+	tests := []struct {
+		name    string
+		execErr error
+		wantErr error
+	}{
+		{"success", nil, nil},
+		{"duplicate", &pgconn.PgError{Code: "23505"}, taskerrors.ErrDependencyIsExist},
+		{"unknown task", &pgconn.PgError{Code: "23503"}, taskerrors.ErrFoundNothing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ts := &taskStorage{db: mock}
+
+			exec := mock.ExpectExec(
+				"^WITH dependency AS \\(INSERT INTO task_dependencies .+\\) UPDATE tasks SET version",
+			).WithArgs("1", "2")
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			err = ts.AddTaskDependency("1", "2")
+			require.ErrorIs(t, err, tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTaskStorage_RemoveTaskDependency(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	mock.ExpectExec("^WITH dependency AS \\(DELETE FROM task_dependencies WHERE taskid = \\$1 AND blockerid = \\$2 ").
+		WithArgs("1", "2").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	require.ErrorIs(t, ts.RemoveTaskDependency("1", "2"), taskerrors.ErrDependencyNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskStorage_HasDependencyPath(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	mock.ExpectQuery("WITH RECURSIVE blockers .+ SELECT EXISTS").
+		WithArgs("1", "3").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	hasPath, err := ts.HasDependencyPath("1", "3")
+	require.NoError(t, err)
+	require.True(t, hasPath)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskStorage_GetUnfinishedBlockers(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	mock.ExpectQuery("SELECT d.blockerid FROM task_dependencies d JOIN tasks t").
+		WithArgs("1", taskmodels.CategoryDone).
+		WillReturnRows(pgxmock.NewRows([]string{"blockerid"}).AddRow("2").AddRow("3"))
+
+	blockers, err := ts.GetUnfinishedBlockers("1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"2", "3"}, blockers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// actorOf - кто меняет задачу: task.ChangedBy, а если он не задан - владелец.
+func actorOf(task taskmodels.Task) string {
+	if task.ChangedBy != "" {
+		return task.ChangedBy
+	}
+	return task.UserID
+}
+
+// insertHistory - запись истории пишется в транзакции самого изменения задачи.
+func insertHistory(
+	ctx context.Context,
+	tx pgx.Tx,
+	action taskmodels.HistoryAction,
+	actorID string,
+	old taskmodels.Task,
+	updated taskmodels.Task,
+	fields []taskmodels.TaskField,
+) error {
+	entry, err := taskmodels.NewHistoryEntry(action, actorID, old, updated, fields, time.Now())
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO task_history (id, taskid, revision, actorid, action, changes, changedat) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		uuid.New().String(),
+		entry.TaskID,
+		entry.Revision,
+		entry.ActorID,
+		entry.Action,
+		entry.Changes,
+		entry.ChangedAt,
+	)
+	return err
+}
+
+// GetTaskHistory - история задачи по возрастанию ревизий.
+func (ts *taskStorage) GetTaskHistory(taskID string) ([]taskmodels.HistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := ts.db.Query(
+		ctx,
+		"SELECT id, taskid, revision, actorid, action, changes, changedat FROM task_history "+
+			"WHERE taskid = $1 ORDER BY revision, changedat",
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []taskmodels.HistoryEntry
+
+	for rows.Next() {
+		var entry taskmodels.HistoryEntry
+		err = rows.Scan(
+			&entry.ID,
+			&entry.TaskID,
+			&entry.Revision,
+			&entry.ActorID,
+			&entry.Action,
+			&entry.Changes,
+			&entry.ChangedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
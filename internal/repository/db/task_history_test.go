@@ -0,0 +1,91 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectHistory - n записей истории в транзакции изменения задачи.
+func expectHistory(mock pgxmock.PgxConnIface, n int) {
+	for range n {
+		mock.ExpectExec("INSERT INTO task_history").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+				pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+}
+
+// changesArg - аргумент с изменениями истории, сравнивается в виде JSON.
+type changesArg string
+
+func (a changesArg) Match(v any) bool {
+	data, err := json.Marshal(v)
+	return err == nil && string(data) == string(a)
+}
+
+func TestTaskStorage_UpdateTaskFieldsHistory(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	old := taskmodels.Task{
+		ID: "1", UserID: "u1", Version: 3,
+		Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "Invoices"},
+	}
+	task := old
+	task.ChangedBy = "u2"
+	task.Attributes.Status = taskmodels.StatusInProgress
+	task.Category = taskmodels.CategoryDoing
+	task.Attributes.Title = "Ignored, not in fields"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM tasks WHERE id = \\$1 FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(addTaskRow(newTaskRows(), old))
+	mock.ExpectQuery("UPDATE tasks SET status = \\$1, statuscategory = \\$2, version = version \\+ 1 WHERE id = \\$3 "+
+		"RETURNING version").
+		WithArgs(taskmodels.StatusInProgress, taskmodels.CategoryDoing, "1").
+		WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(int64(4)))
+	mock.ExpectExec("INSERT INTO task_history \\(id, taskid, revision, actorid, action, changes, changedat\\)").
+		WithArgs(pgxmock.AnyArg(), "1", int64(4), "u2", taskmodels.HistoryUpdated,
+			changesArg(`[{"field":"status","before":"New","after":"In Progress"}]`), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	expectOutboxOrRollback(mock, nil, eventmodels.TaskUpdated, eventmodels.TaskStatusChanged)
+
+	err = ts.UpdateTaskFields(task, []taskmodels.TaskField{taskmodels.FieldStatus})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskStorage_GetTaskHistory(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	changedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	changes := []taskmodels.FieldChange{{
+		Field: taskmodels.FieldTitle, Before: json.RawMessage(`"a"`), After: json.RawMessage(`"b"`),
+	}}
+
+	mock.ExpectQuery("SELECT id, taskid, revision, actorid, action, changes, changedat FROM task_history " +
+		"WHERE taskid = \\$1 ORDER BY revision").
+		WithArgs("1").
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "taskid", "revision", "actorid", "action", "changes", "changedat",
+		}).AddRow("h1", "1", int64(2), "u1", taskmodels.HistoryUpdated, changes, changedAt))
+
+	history, err := ts.GetTaskHistory("1")
+	require.NoError(t, err)
+	assert.Equal(t, []taskmodels.HistoryEntry{{
+		ID: "h1", TaskID: "1", Revision: 2, ActorID: "u1", Action: taskmodels.HistoryUpdated,
+		Changes: changes, ChangedAt: changedAt,
+	}}, history)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"toDoList/internal"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// headlineOptions - параметры ts_headline, maxWords 0 подсвечивает весь текст.
+func headlineOptions(maxWords int) string {
+	options := fmt.Sprintf(`StartSel="%s", StopSel="%s"`, taskmodels.HighlightStart, taskmodels.HighlightStop)
+	if maxWords == 0 {
+		return options + ", HighlightAll=true"
+	}
+	return options + fmt.Sprintf(", MaxWords=%d, MinWords=%d", maxWords, maxWords/2)
+}
+
+// SearchTasks - полнотекстовый поиск по колонке search с GIN-индексом. Ранг ts_rank учитывает
+// веса: заголовок - A, описание - B. Запрос разбирается конфигурацией query.Language.
+func (ts *taskStorage) SearchTasks(userID string, query taskmodels.SearchQuery) ([]taskmodels.SearchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := ts.db.Query(
+		ctx,
+		"SELECT "+taskColumns+", ts_rank(search, q) AS rank, "+
+			"ts_headline($2::regconfig, title, q, $5), ts_headline($2::regconfig, description, q, $6) "+
+			"FROM tasks, websearch_to_tsquery($2::regconfig, $3) q "+
+			"WHERE deleted = false AND "+visibleToUser(1)+" AND search @@ q ORDER BY rank DESC, id LIMIT $4",
+		userID,
+		string(query.Language),
+		query.Query,
+		query.Limit,
+		headlineOptions(0),
+		headlineOptions(taskmodels.SearchSnippetWords),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (taskmodels.SearchResult, error) {
+		var result taskmodels.SearchResult
+		task, errScan := scanTask(row, &result.Rank, &result.Title, &result.Snippet)
+		result.Task = task
+		return result, errScan
+	})
+}
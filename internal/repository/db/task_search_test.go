@@ -0,0 +1,42 @@
+package db
+
+import (
+	"testing"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskStorage_SearchTasks(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	task := taskmodels.Task{
+		ID: "t1", UserID: "u1", Version: 1,
+		Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "Invoices", Description: "Send invoices to accounting",
+		},
+	}
+
+	mock.ExpectQuery("SELECT .+, ts_rank\\(search, q\\) AS rank, ts_headline\\(\\$2::regconfig, title, q, \\$5\\), "+
+		"ts_headline\\(\\$2::regconfig, description, q, \\$6\\) "+
+		"FROM tasks, websearch_to_tsquery\\(\\$2::regconfig, \\$3\\) q "+
+		"WHERE deleted = false AND .+ AND search @@ q ORDER BY rank DESC, id LIMIT \\$4").
+		WithArgs("u1", "english", "invoice", 20,
+			`StartSel="<mark>", StopSel="</mark>", HighlightAll=true`,
+			`StartSel="<mark>", StopSel="</mark>", MaxWords=35, MinWords=17`).
+		WillReturnRows(addTaskRow(newTaskRows("rank", "title", "snippet"), task,
+			float64(0.6), "<mark>Invoices</mark>", "Send <mark>invoices</mark> to accounting"))
+
+	results, err := ts.SearchTasks("u1", taskmodels.SearchQuery{
+		Query: "invoice", Language: taskmodels.SearchEnglish, Limit: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []taskmodels.SearchResult{{
+		Task: task, Rank: 0.6, Title: "<mark>Invoices</mark>", Snippet: "Send <mark>invoices</mark> to accounting",
+	}}, results)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
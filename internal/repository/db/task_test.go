@@ -2,7 +2,10 @@ package db
 
 import (
 	"errors"
+	"strconv"
 	"testing"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/tag/tagmodels"
 	"toDoList/internal/domain/task/taskerrors"
 	"toDoList/internal/domain/task/taskmodels"
 
@@ -12,6 +15,53 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// newTaskRows - extra - колонки, которые в запросе идут после taskColumns.
+func newTaskRows(extra ...string) *pgxmock.Rows {
+	return pgxmock.NewRows(append([]string{
+		"id", "userid", "status", "statuscategory", "title", "description", "deleted", "projectid", "assigneeid",
+		"priority", "position", "parentid", "autocomplete", "checklist", "dueat", "rrule", "customfields", "estimate",
+		"seriesid", "occurrence", "version", "blocked_by", "blocking", "tags",
+	}, extra...))
+}
+
+func addTaskRow(rows *pgxmock.Rows, task taskmodels.Task, extra ...any) *pgxmock.Rows {
+	return rows.AddRow(append([]any{
+		task.ID,
+		task.UserID,
+		task.Attributes.Status,
+		task.Category,
+		task.Attributes.Title,
+		task.Attributes.Description,
+		task.Deleted,
+		task.Attributes.ProjectID,
+		task.Attributes.AssigneeID,
+		task.Attributes.Priority,
+		task.Position,
+		task.Attributes.ParentID,
+		task.Attributes.AutoComplete,
+		task.Checklist,
+		task.Attributes.DueDate,
+		task.Attributes.RRule,
+		task.Attributes.CustomFields,
+		task.Attributes.Estimate,
+		task.SeriesID,
+		task.Occurrence,
+		task.Version,
+		task.BlockedBy,
+		task.Blocking,
+		task.Tags,
+	}, extra...)...)
+}
+
+// deletedTaskRows - строки RETURNING для n изменённых задач.
+func deletedTaskRows(n int64) *pgxmock.Rows {
+	rows := newTaskRows()
+	for i := range n {
+		addTaskRow(rows, taskmodels.Task{ID: strconv.FormatInt(i+1, 10), UserID: "u1"})
+	}
+	return rows
+}
+
 func TestTaskStorage_AddTask(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -53,14 +103,22 @@ func TestTaskStorage_AddTask(t *testing.T) {
 			require.NoError(t, err)
 			ts := &taskStorage{db: mock}
 
+			mock.ExpectBegin()
 			exec := mock.ExpectExec("INSERT INTO tasks").
-				WithArgs(tt.task.ID, tt.task.UserID, tt.task.Attributes.Status, tt.task.Attributes.Title, tt.task.Attributes.Description)
+				WithArgs(tt.task.ID, tt.task.UserID, tt.task.Attributes.Status, tt.task.Attributes.Title,
+					tt.task.Attributes.Description, tt.task.Attributes.ProjectID, tt.task.Attributes.AssigneeID,
+					tt.task.Attributes.Priority, tt.task.Position, tt.task.Attributes.ParentID,
+					tt.task.Attributes.AutoComplete, tt.task.Attributes.DueDate, tt.task.Attributes.RRule,
+					tt.task.SeriesID, tt.task.Occurrence, []taskmodels.ChecklistItem{}, tt.task.Category,
+					map[string]any{}, 0)
 
 			if tt.shouldDuplicate {
 				exec.WillReturnError(&pgconn.PgError{Code: "23505"})
 			} else {
 				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				expectHistory(mock, 1)
 			}
+			expectOutboxOrRollback(mock, tt.wantErr, eventmodels.TaskCreated)
 
 			err = ts.AddTask(tt.task)
 			if tt.wantErr != nil {
@@ -75,29 +133,55 @@ func TestTaskStorage_AddTask(t *testing.T) {
 }
 
 func TestTaskStorage_UpdateTaskAttributes(t *testing.T) {
+	task := taskmodels.Task{
+		ID: "1",
+		Attributes: taskmodels.TaskAttributes{
+			Status:      taskmodels.StatusNew,
+			Title:       "t1",
+			Description: "d1",
+		},
+	}
+
 	tests := []struct {
-		name         string
-		task         taskmodels.Task
-		rowsAffected int64
-		wantErr      error
+		name      string
+		task      taskmodels.Task
+		oldStatus taskmodels.TaskStatus
+		events    []eventmodels.EventType
+		wantErr   error
 	}{
 		{
 			"success",
-			taskmodels.Task{
-				ID: "1",
-				Attributes: taskmodels.TaskAttributes{
-					Status:      taskmodels.StatusNew,
-					Title:       "t1",
-					Description: "d1",
-				},
-			},
-			1,
+			task,
+			taskmodels.StatusNew,
+			[]eventmodels.EventType{eventmodels.TaskUpdated},
 			nil,
 		},
+		{
+			"status changed",
+			task,
+			taskmodels.StatusInProgress,
+			[]eventmodels.EventType{eventmodels.TaskUpdated, eventmodels.TaskStatusChanged},
+			nil,
+		},
+		{
+			"expected version",
+			taskmodels.Task{ID: "1", Version: 3, Attributes: task.Attributes},
+			taskmodels.StatusNew,
+			[]eventmodels.EventType{eventmodels.TaskUpdated},
+			nil,
+		},
+		{
+			"stale version",
+			taskmodels.Task{ID: "1", Version: 2, Attributes: task.Attributes},
+			taskmodels.StatusNew,
+			nil,
+			taskerrors.ErrVersionConflict,
+		},
 		{
 			"not found",
 			taskmodels.Task{ID: "404"},
-			0,
+			"",
+			nil,
 			taskerrors.ErrFoundNothing,
 		},
 	}
@@ -108,9 +192,28 @@ func TestTaskStorage_UpdateTaskAttributes(t *testing.T) {
 			require.NoError(t, err)
 			ts := &taskStorage{db: mock}
 
-			mock.ExpectExec("UPDATE tasks").
-				WithArgs(tt.task.Attributes.Status, tt.task.Attributes.Title, tt.task.Attributes.Description, tt.task.ID).
-				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rowsAffected))
+			mock.ExpectBegin()
+			query := mock.ExpectQuery("SELECT .+ FROM tasks WHERE id = \\$1 FOR UPDATE").
+				WithArgs(tt.task.ID)
+			if errors.Is(tt.wantErr, taskerrors.ErrFoundNothing) {
+				query.WillReturnError(pgx.ErrNoRows)
+			} else {
+				old := taskmodels.Task{ID: tt.task.ID, Version: 3, Attributes: task.Attributes}
+				old.Attributes.Status = tt.oldStatus
+				query.WillReturnRows(addTaskRow(newTaskRows(), old))
+			}
+			if tt.wantErr == nil {
+				mock.ExpectQuery("UPDATE tasks .+ version = version \\+ 1 WHERE id = \\$16 RETURNING version").
+					WithArgs(tt.task.Attributes.Status, tt.task.Category, tt.task.Attributes.Title,
+						tt.task.Attributes.Description, tt.task.Attributes.ProjectID, tt.task.Attributes.AssigneeID,
+						tt.task.Attributes.Priority,
+						tt.task.Attributes.ParentID, tt.task.Attributes.AutoComplete, tt.task.Attributes.DueDate,
+						tt.task.Attributes.RRule, tt.task.SeriesID, tt.task.Occurrence, map[string]any{}, 0,
+						tt.task.ID).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(int64(4)))
+				expectHistory(mock, 1)
+			}
+			expectOutboxOrRollback(mock, tt.wantErr, tt.events...)
 
 			err = ts.UpdateTaskAttributes(tt.task)
 			if tt.wantErr != nil {
@@ -124,6 +227,34 @@ func TestTaskStorage_UpdateTaskAttributes(t *testing.T) {
 	}
 }
 
+func TestTaskStorage_UpdateTaskFields(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	task := taskmodels.Task{
+		ID: "1", SeriesID: "1", Occurrence: 1, Version: 3, Category: taskmodels.CategoryDone,
+		Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusCompleted, RRule: "FREQ=DAILY"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM tasks WHERE id = \\$1 FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(addTaskRow(newTaskRows(), taskmodels.Task{
+			ID: "1", Version: 3, Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew},
+		}))
+	mock.ExpectQuery("^UPDATE tasks SET status = \\$1, statuscategory = \\$2, seriesid = \\$3, occurrence = \\$4, "+
+		"version = version \\+ 1 WHERE id = \\$5 RETURNING version$").
+		WithArgs(taskmodels.StatusCompleted, taskmodels.CategoryDone, "1", 1, "1").
+		WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(int64(4)))
+	expectHistory(mock, 1)
+	expectOutboxOrRollback(mock, nil, eventmodels.TaskUpdated, eventmodels.TaskStatusChanged)
+
+	err = ts.UpdateTaskFields(task, []taskmodels.TaskField{taskmodels.FieldStatus, taskmodels.FieldSeries})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTaskStorage_DeleteTask(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -142,9 +273,12 @@ func TestTaskStorage_DeleteTask(t *testing.T) {
 			require.NoError(t, err)
 			ts := &taskStorage{db: mock}
 
-			mock.ExpectExec("DELETE FROM tasks").
+			mock.ExpectBegin()
+			mock.ExpectQuery("DELETE FROM tasks WHERE id = \\$1 AND userid = \\$2 RETURNING").
 				WithArgs(tt.taskID, tt.userID).
-				WillReturnResult(pgxmock.NewResult("DELETE", tt.rowsAffected))
+				WillReturnRows(deletedTaskRows(tt.rowsAffected))
+			expectHistory(mock, int(tt.rowsAffected))
+			expectOutboxOrRollback(mock, tt.wantErr, eventmodels.TaskDeleted)
 
 			err = ts.DeleteTask(tt.taskID, tt.userID)
 			if tt.wantErr != nil {
@@ -158,6 +292,56 @@ func TestTaskStorage_DeleteTask(t *testing.T) {
 	}
 }
 
+func TestTaskStorage_UpdateTaskPosition(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{"success", 1, nil},
+		{"not found", 0, taskerrors.ErrFoundNothing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ts := &taskStorage{db: mock}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("UPDATE tasks SET position = \\$1, version = version \\+ 1 "+
+				"WHERE id = \\$2 AND userid = \\$3 RETURNING").
+				WithArgs("ai", "1", "u1").
+				WillReturnRows(deletedTaskRows(tt.rowsAffected))
+			expectOutboxOrRollback(mock, tt.wantErr, eventmodels.TaskUpdated)
+
+			err = ts.UpdateTaskPosition("1", "u1", "ai")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTaskStorage_GetLastTaskPosition(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(position COLLATE \"C\"\\), ''\\) FROM tasks WHERE userid = \\$1").
+		WithArgs("u1").
+		WillReturnRows(pgxmock.NewRows([]string{"position"}).AddRow("az"))
+
+	position, err := ts.GetLastTaskPosition("u1")
+	require.NoError(t, err)
+	require.Equal(t, "az", position)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTaskStorage_MarkTaskToDelete(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -167,6 +351,7 @@ func TestTaskStorage_MarkTaskToDelete(t *testing.T) {
 		wantErr      error
 	}{
 		{"success", "1", "u1", 1, nil},
+		{"with subtasks", "1", "u1", 3, nil},
 		{"not found", "404", "u2", 0, taskerrors.ErrFoundNothing},
 	}
 
@@ -176,9 +361,26 @@ func TestTaskStorage_MarkTaskToDelete(t *testing.T) {
 			require.NoError(t, err)
 			ts := &taskStorage{db: mock}
 
-			mock.ExpectExec("UPDATE tasks SET deleted = true").
+			events := make([]eventmodels.EventType, tt.rowsAffected)
+			ids := make([]string, tt.rowsAffected)
+			for i := range events {
+				events[i] = eventmodels.TaskDeleted
+				ids[i] = strconv.Itoa(i + 1)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("^WITH RECURSIVE subtree AS \\(SELECT id FROM tasks WHERE id = \\$1 AND userid = \\$2 "+
+				".+\\) UPDATE tasks SET deleted = true, version = version \\+ 1 "+
+				"WHERE id IN \\(SELECT id FROM subtree\\) RETURNING ").
 				WithArgs(tt.taskID, tt.userID).
-				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rowsAffected))
+				WillReturnRows(deletedTaskRows(tt.rowsAffected))
+			if tt.rowsAffected > 0 {
+				mock.ExpectExec("^UPDATE comments SET deleted = true WHERE taskid = ANY\\(\\$1\\)$").
+					WithArgs(ids).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			}
+			expectHistory(mock, int(tt.rowsAffected))
+			expectOutboxOrRollback(mock, tt.wantErr, events...)
 
 			err = ts.MarkTaskToDelete(tt.taskID, tt.userID)
 			if tt.wantErr != nil {
@@ -192,6 +394,82 @@ func TestTaskStorage_MarkTaskToDelete(t *testing.T) {
 	}
 }
 
+func TestTaskStorage_GetSubtasks(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	child := taskmodels.Task{ID: "2", UserID: "u1", Attributes: taskmodels.TaskAttributes{ParentID: "1"}}
+	grandchild := taskmodels.Task{ID: "3", UserID: "u1", Attributes: taskmodels.TaskAttributes{ParentID: "2"}}
+
+	mock.ExpectQuery("^WITH RECURSIVE subtree AS \\(SELECT id FROM tasks WHERE parentid = \\$1 .+ " +
+		"WHERE id IN \\(SELECT id FROM subtree\\) AND deleted = false ORDER BY position").
+		WithArgs("1").
+		WillReturnRows(addTaskRow(addTaskRow(newTaskRows(), child), grandchild))
+
+	tasks, err := ts.GetSubtasks("1")
+	require.NoError(t, err)
+	require.Equal(t, []taskmodels.Task{child, grandchild}, tasks)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskStorage_GetSeriesTasks(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	first := taskmodels.Task{ID: "1", UserID: "u1", SeriesID: "1", Occurrence: 1}
+	second := taskmodels.Task{ID: "2", UserID: "u1", SeriesID: "1", Occurrence: 2}
+
+	mock.ExpectQuery("SELECT .+ FROM tasks WHERE seriesid = \\$1 AND deleted = false ORDER BY occurrence$").
+		WithArgs("1").
+		WillReturnRows(addTaskRow(addTaskRow(newTaskRows(), first), second))
+
+	tasks, err := ts.GetSeriesTasks("1")
+	require.NoError(t, err)
+	require.Equal(t, []taskmodels.Task{first, second}, tasks)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskStorage_CountTasksInStatus(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM tasks WHERE projectid = \\$1 AND status = \\$2 AND deleted = false").
+		WithArgs("p1", taskmodels.StatusInProgress).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := ts.CountTasksInStatus("p1", taskmodels.StatusInProgress)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskStorage_UpdateTaskChecklist(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	checklist := []taskmodels.ChecklistItem{{ID: "i1", Title: "step", Done: true}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET checklist = \\$1, version = version \\+ 1 WHERE id = \\$2 RETURNING").
+		WithArgs(checklist, "1").
+		WillReturnRows(addTaskRow(newTaskRows(), taskmodels.Task{ID: "1", UserID: "u1", Checklist: checklist}))
+	expectOutbox(mock, eventmodels.TaskUpdated)
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET checklist").
+		WithArgs([]taskmodels.ChecklistItem{}, "404").
+		WillReturnRows(newTaskRows())
+	mock.ExpectRollback()
+
+	require.NoError(t, ts.UpdateTaskChecklist("1", checklist))
+	require.ErrorIs(t, ts.UpdateTaskChecklist("404", nil), taskerrors.ErrFoundNothing)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTaskStorage_DeleteMarkedTasks(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -297,15 +575,15 @@ func TestTaskStorage_GetAllTasks(t *testing.T) {
 			ts := &taskStorage{db: mock}
 
 			if tt.mockErr != nil {
-				mock.ExpectQuery("SELECT id, userid, status, title, description, deleted FROM tasks where userid = \\$1").
+				mock.ExpectQuery("SELECT .+ FROM tasks where userid = \\$1").
 					WithArgs(tt.userID).
 					WillReturnError(tt.mockErr)
 			} else {
-				rows := pgxmock.NewRows([]string{"id", "userid", "status", "title", "description", "deleted"})
+				rows := newTaskRows()
 				for _, task := range tt.mockData {
-					rows.AddRow(task.ID, task.UserID, task.Attributes.Status, task.Attributes.Title, task.Attributes.Description, task.Deleted)
+					addTaskRow(rows, task)
 				}
-				mock.ExpectQuery("SELECT id, userid, status, title, description, deleted FROM tasks where userid = \\$1").
+				mock.ExpectQuery("SELECT .+ FROM tasks where userid = \\$1").
 					WithArgs(tt.userID).
 					WillReturnRows(rows)
 			}
@@ -364,15 +642,13 @@ func TestTaskStorage_GetTaskByID(t *testing.T) {
 			ts := &taskStorage{db: mock}
 
 			if tt.mockErr != nil {
-				mock.ExpectQuery("SELECT id, userid, status, title, description, deleted FROM tasks WHERE id = \\$1 AND userid = \\$2").
+				mock.ExpectQuery("SELECT .+ FROM tasks WHERE id = \\$1 AND \\(userid = \\$2").
 					WithArgs(tt.taskID, tt.userID).
 					WillReturnError(tt.mockErr)
 			} else {
-				rows := pgxmock.NewRows([]string{"id", "userid", "status", "title", "description", "deleted"}).
-					AddRow(tt.mockData.ID, tt.mockData.UserID, tt.mockData.Attributes.Status,
-						tt.mockData.Attributes.Title, tt.mockData.Attributes.Description, tt.mockData.Deleted)
+				rows := addTaskRow(newTaskRows(), tt.mockData)
 
-				mock.ExpectQuery("SELECT id, userid, status, title, description, deleted FROM tasks WHERE id = \\$1 AND userid = \\$2").
+				mock.ExpectQuery("SELECT .+ FROM tasks WHERE id = \\$1 AND \\(userid = \\$2").
 					WithArgs(tt.taskID, tt.userID).
 					WillReturnRows(rows)
 			}
@@ -390,3 +666,104 @@ func TestTaskStorage_GetTaskByID(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskStorage_FindTasks(t *testing.T) {
+	task := taskmodels.Task{
+		ID:     "1",
+		UserID: "owner",
+		Attributes: taskmodels.TaskAttributes{
+			Status:      taskmodels.StatusNew,
+			Title:       "t1",
+			Description: "d1",
+			ProjectID:   "p1",
+			AssigneeID:  "u1",
+		},
+		Tags: []tagmodels.Tag{{ID: "tag1", UserID: "u1", Name: "urgent", Color: "#ff0000"}},
+	}
+
+	tests := []struct {
+		name     string
+		filter   taskmodels.TaskFilter
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "assignee",
+			filter:   taskmodels.TaskFilter{AssigneeID: "u1"},
+			wantSQL:  "SELECT .+ FROM tasks WHERE \\(userid = \\$1 .+ AND assigneeid = \\$2 ORDER BY position",
+			wantArgs: []any{"u1", "u1"},
+		},
+		{
+			name:     "project",
+			filter:   taskmodels.TaskFilter{ProjectID: "p1", AssigneeID: "u1"},
+			wantSQL:  "WHERE \\(userid = \\$1 .+ AND projectid = \\$2 AND assigneeid = \\$3 ORDER BY position",
+			wantArgs: []any{"u1", "p1", "u1"},
+		},
+		{
+			name:     "any tag",
+			filter:   taskmodels.TaskFilter{Tags: []string{"urgent", "bug"}, TagMode: taskmodels.TagModeAny},
+			wantSQL:  "AND id IN \\(SELECT tt.taskid .+ t.name = ANY\\(\\$2\\)\\) ORDER BY position",
+			wantArgs: []any{"u1", []string{"urgent", "bug"}},
+		},
+		{
+			name: "all tags with assignee",
+			filter: taskmodels.TaskFilter{
+				AssigneeID: "u1", Tags: []string{"urgent", "bug"}, TagMode: taskmodels.TagModeAll,
+			},
+			wantSQL: "AND assigneeid = \\$2 AND .+ ANY\\(\\$3\\) " +
+				"GROUP BY tt.taskid HAVING COUNT\\(DISTINCT t.name\\) = \\$4\\) ORDER BY position",
+			wantArgs: []any{"u1", "u1", []string{"urgent", "bug"}, 2},
+		},
+		{
+			name: "custom fields sorted desc",
+			filter: taskmodels.TaskFilter{
+				ProjectID: "p1", CustomFields: map[string]any{"f1": []any{"a"}}, SortField: "f2", SortDesc: true,
+			},
+			wantSQL: "AND projectid = \\$2 AND customfields @> \\$3::jsonb " +
+				"ORDER BY customfields -> \\$4::text DESC NULLS LAST, position",
+			wantArgs: []any{"u1", "p1", map[string]any{"f1": []any{"a"}}, "f2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ts := &taskStorage{db: mock}
+
+			mock.ExpectQuery(tt.wantSQL).
+				WithArgs(tt.wantArgs...).
+				WillReturnRows(addTaskRow(newTaskRows(), task))
+
+			got, err := ts.FindTasks("u1", tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, []taskmodels.Task{task}, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTaskStorage_TaskWatchers(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &taskStorage{db: mock}
+
+	mock.ExpectExec("INSERT INTO task_watchers").
+		WithArgs("1", "u2").
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+	require.ErrorIs(t, ts.AddTaskWatcher("1", "u2"), taskerrors.ErrWatcherIsExist)
+
+	mock.ExpectExec("DELETE FROM task_watchers").
+		WithArgs("1", "u3").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	require.ErrorIs(t, ts.RemoveTaskWatcher("1", "u3"), taskerrors.ErrWatcherNotFound)
+
+	mock.ExpectQuery("SELECT userid FROM task_watchers WHERE taskid = \\$1").
+		WithArgs("1").
+		WillReturnRows(pgxmock.NewRows([]string{"userid"}).AddRow("u1").AddRow("u2"))
+	watchers, err := ts.GetTaskWatchers("1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1", "u2"}, watchers)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
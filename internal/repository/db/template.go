@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"toDoList/internal"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type templateStorage struct {
+	db PgxIface
+}
+
+const templateColumns = "id, userid, projectid, name, task, variables, createdat"
+
+func scanTemplate(row pgx.Row) (templatemodels.Template, error) {
+	var template templatemodels.Template
+	err := row.Scan(
+		&template.ID,
+		&template.UserID,
+		&template.ProjectID,
+		&template.Name,
+		&template.Task,
+		&template.Variables,
+		&template.CreatedAt,
+	)
+	return template, err
+}
+
+func (ts *templateStorage) AddTemplate(template templatemodels.Template) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := ts.db.Exec(
+		ctx,
+		"INSERT INTO templates ("+templateColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		template.ID,
+		template.UserID,
+		template.ProjectID,
+		template.Name,
+		template.Task,
+		template.Variables,
+		template.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return templateerrors.ErrTemplateIsAlreadyExist
+		}
+		return err
+	}
+	return nil
+}
+
+// GetTemplatesByUser - личные шаблоны пользователя и шаблоны его проектов.
+func (ts *templateStorage) GetTemplatesByUser(userID string) ([]templatemodels.Template, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := ts.db.Query(
+		ctx,
+		"SELECT "+templateColumns+" FROM templates WHERE "+visibleToUser(1)+" ORDER BY name, id",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []templatemodels.Template
+	for rows.Next() {
+		template, errScan := scanTemplate(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		templates = append(templates, template)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetTemplateByID - шаблон, видимый пользователю: его собственный или шаблон его проекта.
+func (ts *templateStorage) GetTemplateByID(templateID string, userID string) (templatemodels.Template, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	template, err := scanTemplate(ts.db.QueryRow(
+		ctx,
+		"SELECT "+templateColumns+" FROM templates WHERE id = $2 AND "+visibleToUser(1),
+		userID,
+		templateID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return templatemodels.Template{}, templateerrors.ErrTemplateNotFound
+		}
+		return templatemodels.Template{}, err
+	}
+	return template, nil
+}
+
+func (ts *templateStorage) DeleteTemplate(templateID string, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := ts.db.Exec(ctx, "DELETE FROM templates WHERE id = $1 AND userid = $2", templateID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return templateerrors.ErrTemplateNotFound
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateStorage_AddTemplate(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	template := templatemodels.Template{
+		ID: "tpl1", UserID: "u1", Name: "Release", Task: templatemodels.TemplateTask{Title: "T", Description: "D"},
+		Variables: []string{}, CreatedAt: now,
+	}
+
+	tests := []struct {
+		name    string
+		execErr error
+		wantErr error
+	}{
+		{name: "added"},
+		{
+			name:    "duplicate name",
+			execErr: &pgconn.PgError{Code: "23505"},
+			wantErr: templateerrors.ErrTemplateIsAlreadyExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ts := &templateStorage{db: mock}
+
+			exec := mock.ExpectExec("INSERT INTO templates").
+				WithArgs("tpl1", "u1", "", "Release", template.Task, []string{}, now)
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			require.ErrorIs(t, ts.AddTemplate(template), tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTemplateStorage_GetTemplateByID(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "userid", "projectid", "name", "task", "variables", "createdat"}
+	task := templatemodels.TemplateTask{Title: "Release {{version}}", Description: "D"}
+
+	tests := []struct {
+		name    string
+		rows    *pgxmock.Rows
+		want    templatemodels.Template
+		wantErr error
+	}{
+		{
+			name: "shared",
+			rows: pgxmock.NewRows(columns).AddRow("tpl1", "u2", "p1", "Release", task, []string{"version"}, now),
+			want: templatemodels.Template{
+				ID: "tpl1", UserID: "u2", ProjectID: "p1", Name: "Release", Task: task,
+				Variables: []string{"version"}, CreatedAt: now,
+			},
+		},
+		{name: "not visible", rows: pgxmock.NewRows(columns), wantErr: templateerrors.ErrTemplateNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ts := &templateStorage{db: mock}
+
+			mock.ExpectQuery("SELECT .+ FROM templates WHERE id = \\$2 AND \\(userid = \\$1 OR").
+				WithArgs("u1", "tpl1").
+				WillReturnRows(tt.rows)
+
+			template, err := ts.GetTemplateByID("tpl1", "u1")
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, template)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTemplateStorage_DeleteTemplate(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ts := &templateStorage{db: mock}
+
+	mock.ExpectExec("DELETE FROM templates WHERE id = \\$1 AND userid = \\$2").
+		WithArgs("tpl1", "u2").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	require.ErrorIs(t, ts.DeleteTemplate("tpl1", "u2"), templateerrors.ErrTemplateNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,240 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/timeentry/timeentryerrors"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type timeEntryStorage struct {
+	db PgxIface
+}
+
+const timeEntryColumns = "id, taskid, userid, startedat, endedat, note"
+
+func scanTimeEntry(row pgx.Row) (timeentrymodels.TimeEntry, error) {
+	var entry timeentrymodels.TimeEntry
+	err := row.Scan(&entry.ID, &entry.TaskID, &entry.UserID, &entry.StartedAt, &entry.EndedAt, &entry.Note)
+	return entry, err
+}
+
+func collectTimeEntries(rows pgx.Rows) ([]timeentrymodels.TimeEntry, error) {
+	defer rows.Close()
+
+	var entries []timeentrymodels.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// AddTimeEntry - запись без EndedAt запускает таймер: второй запущенный таймер пользователя
+// не пропустит уникальный индекс time_entries_running_idx.
+func (es *timeEntryStorage) AddTimeEntry(entry timeentrymodels.TimeEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := es.db.Exec(
+		ctx,
+		"INSERT INTO time_entries ("+timeEntryColumns+") VALUES ($1, $2, $3, $4, $5, $6)",
+		entry.ID,
+		entry.TaskID,
+		entry.UserID,
+		entry.StartedAt,
+		entry.EndedAt,
+		entry.Note,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return timeentryerrors.ErrTimerAlreadyRunning
+			case "23503":
+				return taskerrors.ErrFoundNothing
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (es *timeEntryStorage) GetRunningTimer(userID string) (timeentrymodels.TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	entry, err := scanTimeEntry(es.db.QueryRow(
+		ctx,
+		"SELECT "+timeEntryColumns+" FROM time_entries WHERE userid = $1 AND endedat IS NULL",
+		userID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return timeentrymodels.TimeEntry{}, timeentryerrors.ErrTimerNotRunning
+		}
+		return timeentrymodels.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// StopTimer - останавливает запущенный таймер пользователя на задаче и возвращает запись.
+func (es *timeEntryStorage) StopTimer(userID string, taskID string, endedAt time.Time) (
+	timeentrymodels.TimeEntry, error,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	entry, err := scanTimeEntry(es.db.QueryRow(
+		ctx,
+		"UPDATE time_entries SET endedat = GREATEST($3, startedat) "+
+			"WHERE userid = $1 AND taskid = $2 AND endedat IS NULL RETURNING "+timeEntryColumns,
+		userID,
+		taskID,
+		endedAt,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return timeentrymodels.TimeEntry{}, timeentryerrors.ErrTimerNotRunning
+		}
+		return timeentrymodels.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (es *timeEntryStorage) GetTaskTimeEntries(taskID string) ([]timeentrymodels.TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := es.db.Query(
+		ctx,
+		"SELECT "+timeEntryColumns+" FROM time_entries WHERE taskid = $1 ORDER BY startedat, id",
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectTimeEntries(rows)
+}
+
+func (es *timeEntryStorage) GetTimeEntryByID(entryID string, taskID string) (timeentrymodels.TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	entry, err := scanTimeEntry(es.db.QueryRow(
+		ctx,
+		"SELECT "+timeEntryColumns+" FROM time_entries WHERE id = $1 AND taskid = $2",
+		entryID,
+		taskID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return timeentrymodels.TimeEntry{}, timeentryerrors.ErrTimeEntryNotFound
+		}
+		return timeentrymodels.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// UpdateTimeEntry - меняются только остановленные записи, таймер сначала нужно остановить.
+func (es *timeEntryStorage) UpdateTimeEntry(entry timeentrymodels.TimeEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := es.db.Exec(
+		ctx,
+		"UPDATE time_entries SET startedat = $3, endedat = $4, note = $5 "+
+			"WHERE id = $1 AND taskid = $2 AND endedat IS NOT NULL",
+		entry.ID,
+		entry.TaskID,
+		entry.StartedAt,
+		entry.EndedAt,
+		entry.Note,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return timeentryerrors.ErrTimeEntryNotFound
+	}
+	return nil
+}
+
+func (es *timeEntryStorage) DeleteTimeEntry(entryID string, taskID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := es.db.Exec(ctx, "DELETE FROM time_entries WHERE id = $1 AND taskid = $2", entryID, taskID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return timeentryerrors.ErrTimeEntryNotFound
+	}
+	return nil
+}
+
+// GetReportEntries - записи, начатые в [from, to), по задачам, видимым пользователю. Записи задач,
+// помеченных на удаление, в отчёт не попадают.
+func (es *timeEntryStorage) GetReportEntries(userID string, from time.Time, to time.Time) (
+	[]timeentrymodels.ReportEntry, error,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := es.db.Query(
+		ctx,
+		"SELECT e.id, e.taskid, e.userid, e.startedat, e.endedat, e.note, t.projectid, t.title, t.estimate "+
+			"FROM time_entries e JOIN (SELECT id, projectid, title, estimate FROM tasks "+
+			"WHERE deleted = false AND "+visibleToUser(1)+") t ON t.id = e.taskid "+
+			"WHERE e.startedat >= $2 AND e.startedat < $3 ORDER BY e.startedat, e.id",
+		userID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []timeentrymodels.ReportEntry
+	for rows.Next() {
+		var entry timeentrymodels.ReportEntry
+		err = rows.Scan(
+			&entry.ID,
+			&entry.TaskID,
+			&entry.UserID,
+			&entry.StartedAt,
+			&entry.EndedAt,
+			&entry.Note,
+			&entry.ProjectID,
+			&entry.TaskTitle,
+			&entry.TaskEstimate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
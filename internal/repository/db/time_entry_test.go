@@ -0,0 +1,174 @@
+package db
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/timeentry/timeentryerrors"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeEntryStorage_AddTimeEntry(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	entry := timeentrymodels.TimeEntry{ID: "e1", TaskID: "t1", UserID: "u1", StartedAt: now, Note: "review"}
+
+	tests := []struct {
+		name    string
+		execErr error
+		wantErr error
+	}{
+		{name: "added"},
+		{
+			name:    "timer already running",
+			execErr: &pgconn.PgError{Code: "23505"},
+			wantErr: timeentryerrors.ErrTimerAlreadyRunning,
+		},
+		{name: "task not found", execErr: &pgconn.PgError{Code: "23503"}, wantErr: taskerrors.ErrFoundNothing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			es := &timeEntryStorage{db: mock}
+
+			exec := mock.ExpectExec("INSERT INTO time_entries").
+				WithArgs("e1", "t1", "u1", now, (*time.Time)(nil), "review")
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			require.ErrorIs(t, es.AddTimeEntry(entry), tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntryStorage_StopTimer(t *testing.T) {
+	started := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	ended := started.Add(time.Hour)
+	columns := []string{"id", "taskid", "userid", "startedat", "endedat", "note"}
+
+	tests := []struct {
+		name    string
+		rows    *pgxmock.Rows
+		want    timeentrymodels.TimeEntry
+		wantErr error
+	}{
+		{
+			name: "stopped",
+			rows: pgxmock.NewRows(columns).AddRow("e1", "t1", "u1", started, &ended, ""),
+			want: timeentrymodels.TimeEntry{ID: "e1", TaskID: "t1", UserID: "u1", StartedAt: started, EndedAt: &ended},
+		},
+		{name: "not running", rows: pgxmock.NewRows(columns), wantErr: timeentryerrors.ErrTimerNotRunning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			es := &timeEntryStorage{db: mock}
+
+			mock.ExpectQuery("UPDATE time_entries SET endedat = GREATEST\\(\\$3, startedat\\) "+
+				"WHERE userid = \\$1 AND taskid = \\$2 AND endedat IS NULL RETURNING").
+				WithArgs("u1", "t1", ended).
+				WillReturnRows(tt.rows)
+
+			entry, err := es.StopTimer("u1", "t1", ended)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, entry)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntryStorage_UpdateTimeEntry(t *testing.T) {
+	started := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	ended := started.Add(time.Hour)
+	entry := timeentrymodels.TimeEntry{ID: "e1", TaskID: "t1", UserID: "u1", StartedAt: started, EndedAt: &ended}
+
+	for _, tt := range []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "updated", rows: 1},
+		{name: "running or missing", rows: 0, wantErr: timeentryerrors.ErrTimeEntryNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			es := &timeEntryStorage{db: mock}
+
+			mock.ExpectExec("UPDATE time_entries .+ WHERE id = \\$1 AND taskid = \\$2 AND endedat IS NOT NULL").
+				WithArgs("e1", "t1", started, &ended, "").
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rows))
+
+			require.ErrorIs(t, es.UpdateTimeEntry(entry), tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntryStorage_DeleteTimeEntry(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	es := &timeEntryStorage{db: mock}
+
+	mock.ExpectExec("DELETE FROM time_entries WHERE id = \\$1 AND taskid = \\$2").
+		WithArgs("e1", "t1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	require.ErrorIs(t, es.DeleteTimeEntry("e1", "t1"), timeentryerrors.ErrTimeEntryNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntryStorage_GetRunningTimer(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	es := &timeEntryStorage{db: mock}
+
+	mock.ExpectQuery("SELECT .+ FROM time_entries WHERE userid = \\$1 AND endedat IS NULL").
+		WithArgs("u1").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = es.GetRunningTimer("u1")
+	require.ErrorIs(t, err, timeentryerrors.ErrTimerNotRunning)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntryStorage_GetReportEntries(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	es := &timeEntryStorage{db: mock}
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	started := from.Add(9 * time.Hour)
+
+	mock.ExpectQuery("FROM time_entries e JOIN \\(SELECT id, projectid, title, estimate FROM tasks "+
+		"WHERE deleted = false AND .+\\) t ON t.id = e.taskid "+
+		"WHERE e.startedat >= \\$2 AND e.startedat < \\$3 ORDER BY e.startedat, e.id").
+		WithArgs("u1", from, to).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "taskid", "userid", "startedat", "endedat", "note", "projectid", "title", "estimate",
+		}).AddRow("e1", "t1", "u2", started, (*time.Time)(nil), "", "p1", "Deploy", 90))
+
+	entries, err := es.GetReportEntries("u1", from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []timeentrymodels.ReportEntry{{
+		TimeEntry:    timeentrymodels.TimeEntry{ID: "e1", TaskID: "t1", UserID: "u2", StartedAt: started},
+		ProjectID:    "p1",
+		TaskTitle:    "Deploy",
+		TaskEstimate: 90,
+	}}, entries)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"toDoList/internal"
+	"toDoList/internal/domain/event/eventmodels"
 	"toDoList/internal/domain/user/usererrors"
 	"toDoList/internal/domain/user/usermodels"
 
@@ -74,54 +75,67 @@ func (us *userStorage) GetUserByEmail(email string) (usermodels.User, error) {
 }
 
 func (us *userStorage) SaveUser(user usermodels.User) (usermodels.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
-	defer cancel()
-
-	_, err := us.db.Exec(ctx, "INSERT INTO users (uuid, name, email, password) VALUES ($1, $2, $3, $4)",
-		user.UUID, user.Name, user.Email, user.Password)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == "23505" {
-				return usermodels.User{}, usererrors.ErrUserIsAlreadyExist
+	err := inTx(us.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		_, err := tx.Exec(ctx, "INSERT INTO users (uuid, name, email, password) VALUES ($1, $2, $3, $4)",
+			user.UUID, user.Name, user.Email, user.Password)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				if pgErr.Code == "23505" {
+					return nil, usererrors.ErrUserIsAlreadyExist
+				}
 			}
+			return nil, err
 		}
+		return userEvent(eventmodels.UserCreated, user)
+	})
+	if err != nil {
 		return usermodels.User{}, err
 	}
 	return user, nil
 }
 
 func (us *userStorage) UpdateUser(user usermodels.User) (usermodels.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
-	defer cancel()
+	err := inTx(us.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		cmd, err := tx.Exec(ctx, "UPDATE users SET name = $1, email = $2, password = $3 WHERE uuid = $4",
+			user.Name, user.Email, user.Password, user.UUID)
 
-	cmd, err := us.db.Exec(ctx, "UPDATE users SET name = $1, email = $2, password = $3 WHERE uuid = $4",
-		user.Name, user.Email, user.Password, user.UUID)
+		if err != nil {
+			return nil, err
+		}
 
+		if cmd.RowsAffected() == 0 {
+			return nil, usererrors.ErrUserNotFound
+		}
+
+		return userEvent(eventmodels.UserUpdated, user)
+	})
 	if err != nil {
 		return usermodels.User{}, err
 	}
-
-	if cmd.RowsAffected() == 0 {
-		return usermodels.User{}, usererrors.ErrUserNotFound
-	}
-
 	return user, nil
 }
 
 func (us *userStorage) DeleteUser(userID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
-	defer cancel()
+	return inTx(us.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		cmd, err := tx.Exec(ctx, "DELETE FROM users WHERE uuid = $1", userID)
 
-	cmd, err := us.db.Exec(ctx, "DELETE FROM users WHERE uuid = $1", userID)
+		if err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return err
-	}
+		if cmd.RowsAffected() == 0 {
+			return nil, usererrors.ErrUserNotFound
+		}
 
-	if cmd.RowsAffected() == 0 {
-		return usererrors.ErrUserNotFound
-	}
+		return userEvent(eventmodels.UserDeleted, usermodels.User{UUID: userID})
+	})
+}
 
-	return nil
+func userEvent(eventType eventmodels.EventType, user usermodels.User) ([]eventmodels.Event, error) {
+	event, err := eventmodels.NewUserEvent(eventType, user)
+	if err != nil {
+		return nil, err
+	}
+	return []eventmodels.Event{event}, nil
 }
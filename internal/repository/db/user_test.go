@@ -2,8 +2,8 @@ package db
 
 import (
 	"context"
-	"errors"
 	"testing"
+	"toDoList/internal/domain/event/eventmodels"
 	"toDoList/internal/domain/user/usererrors"
 	"toDoList/internal/domain/user/usermodels"
 
@@ -13,52 +13,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// mockRow - мок для QueryRow.
-type mockRow struct {
-	err error
-}
-
-//nolint:revive // Это мок
-func (r *mockRow) Scan(dest ...any) error {
-	return r.err
-}
-
-// mockDB - мок для PgxIface.
-type mockDB struct {
-	shouldDuplicate bool
-}
-
-//nolint:revive // Это мок
-func (m *mockDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
-	if m.shouldDuplicate {
-		return pgconn.NewCommandTag("INSERT"), &pgconn.PgError{
-			Code:    "23505",
-			Message: "duplicate key value violates unique constraint",
-		}
-	}
-	return pgconn.NewCommandTag("INSERT"), nil
-}
-
-//nolint:revive // Это мок
-func (m *mockDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
-	return nil, errors.New("Query not implemented in mock")
-}
-
-//nolint:revive // Это мок
-func (m *mockDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
-	return &mockRow{err: errors.New("QueryRow not implemented in mock")}
-}
-
-//nolint:revive // Это мок
-func (m *mockDB) Close(ctx context.Context) error {
-	return nil
-}
-
-//nolint:revive // Это мок
-func (m *mockDB) Begin(ctx context.Context) (pgx.Tx, error) {
-	return nil, nil //nolint:nilnil // Это мок
-}
-
 func TestUserStorage_GetAllUsers(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -243,14 +197,30 @@ func TestUserStorage_SaveUser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			us := &userStorage{db: &mockDB{shouldDuplicate: tt.shouldDuplicate}}
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			us := &userStorage{db: mock}
+
+			mock.ExpectBegin()
+			exec := mock.ExpectExec("INSERT INTO users").
+				WithArgs(tt.user.UUID, tt.user.Name, tt.user.Email, tt.user.Password)
+			if tt.shouldDuplicate {
+				exec.WillReturnError(&pgconn.PgError{Code: "23505"})
+				mock.ExpectRollback()
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				expectOutbox(mock, eventmodels.UserCreated)
+				mock.ExpectCommit()
+			}
 
-			_, err := us.SaveUser(tt.user)
+			_, err = us.SaveUser(tt.user)
 			if tt.wantErr != nil {
 				require.EqualError(t, err, tt.wantErr.Error())
 			} else {
 				require.NoError(t, err)
 			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -291,9 +261,11 @@ func TestUserStorage_UpdateUser(t *testing.T) {
 			require.NoError(t, err)
 			us := &userStorage{db: mock}
 
+			mock.ExpectBegin()
 			mock.ExpectExec("UPDATE users").
 				WithArgs(tt.user.Name, tt.user.Email, tt.user.Password, tt.user.UUID).
 				WillReturnResult(pgxmock.NewResult("UPDATE", int64(tt.rowsAffected)))
+			expectOutboxOrRollback(mock, tt.wantErr, eventmodels.UserUpdated)
 
 			_, err = us.UpdateUser(tt.user)
 			if tt.wantErr != nil {
@@ -333,9 +305,11 @@ func TestUserStorage_DeleteUser(t *testing.T) {
 			require.NoError(t, err)
 			us := &userStorage{db: mock}
 
+			mock.ExpectBegin()
 			mock.ExpectExec("DELETE FROM users").
 				WithArgs(tt.userID).
 				WillReturnResult(pgxmock.NewResult("DELETE", int64(tt.rowsAffected)))
+			expectOutboxOrRollback(mock, tt.wantErr, eventmodels.UserDeleted)
 
 			err = us.DeleteUser(tt.userID)
 			if tt.wantErr != nil {
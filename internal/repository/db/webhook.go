@@ -0,0 +1,286 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/webhook/webhookerrors"
+	"toDoList/internal/domain/webhook/webhookmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+type webhookStorage struct {
+	db PgxIface
+}
+
+const deliveryColumns = "d.id, d.webhookid, d.eventid, d.eventtype, d.payload, d.status, d.attempts, " +
+	"d.nextattemptat, d.lasterror, d.responsecode, d.createdat, d.deliveredat"
+
+func (whs *webhookStorage) AddWebhook(webhook webhookmodels.Webhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	_, err := whs.db.Exec(
+		ctx,
+		"INSERT INTO webhooks (id, userid, url, secret, events, createdat) VALUES ($1, $2, $3, $4, $5, $6)",
+		webhook.ID,
+		webhook.UserID,
+		webhook.URL,
+		webhook.Secret,
+		eventsToStrings(webhook.Events),
+		webhook.CreatedAt,
+	)
+	return err
+}
+
+func (whs *webhookStorage) GetWebhooksByUser(userID string) ([]webhookmodels.Webhook, error) {
+	return whs.queryWebhooks(
+		"SELECT id, userid, url, secret, events, createdat FROM webhooks WHERE userid = $1 ORDER BY createdat, id",
+		userID,
+	)
+}
+
+// GetWebhooksForEvent - вебхуки пользователя, подписанные на событие.
+func (whs *webhookStorage) GetWebhooksForEvent(userID string, eventType webhookmodels.EventType) (
+	[]webhookmodels.Webhook, error,
+) {
+	return whs.queryWebhooks(
+		"SELECT id, userid, url, secret, events, createdat FROM webhooks WHERE userid = $1 AND $2 = ANY(events) "+
+			"ORDER BY createdat, id",
+		userID,
+		string(eventType),
+	)
+}
+
+func (whs *webhookStorage) queryWebhooks(query string, args ...any) ([]webhookmodels.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := whs.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []webhookmodels.Webhook
+
+	for rows.Next() {
+		var webhook webhookmodels.Webhook
+		var events []string
+		if err = rows.Scan(
+			&webhook.ID,
+			&webhook.UserID,
+			&webhook.URL,
+			&webhook.Secret,
+			&events,
+			&webhook.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		webhook.Events = stringsToEvents(events)
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (whs *webhookStorage) GetWebhookByID(webhookID string, userID string) (webhookmodels.Webhook, error) {
+	webhooks, err := whs.queryWebhooks(
+		"SELECT id, userid, url, secret, events, createdat FROM webhooks WHERE id = $1 AND userid = $2",
+		webhookID,
+		userID,
+	)
+	if err != nil {
+		return webhookmodels.Webhook{}, err
+	}
+
+	if len(webhooks) == 0 {
+		return webhookmodels.Webhook{}, webhookerrors.ErrWebhookNotFound
+	}
+	return webhooks[0], nil
+}
+
+func (whs *webhookStorage) DeleteWebhook(webhookID string, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := whs.db.Exec(ctx, "DELETE FROM webhooks WHERE id = $1 AND userid = $2", webhookID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return webhookerrors.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (whs *webhookStorage) AddWebhookDeliveries(deliveries []webhookmodels.Delivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	tx, err := whs.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err = tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error().Err(err).Msg("Transaction rollback failed")
+		}
+	}(tx, ctx)
+
+	for _, delivery := range deliveries {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO webhook_deliveries (id, webhookid, eventid, eventtype, payload, status, nextattemptat, "+
+				"createdat) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (webhookid, eventid) DO NOTHING",
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.EventID,
+			delivery.EventType,
+			delivery.Payload,
+			delivery.Status,
+			delivery.NextAttemptAt,
+			delivery.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ClaimWebhookDeliveries - забирает до limit доставок, время попытки которых наступило, и переносит
+// их следующую попытку на leaseUntil. Пока идёт отправка, другие экземпляры сервера эти доставки
+// не увидят, а если экземпляр упадёт, доставка вернётся в работу после leaseUntil.
+func (whs *webhookStorage) ClaimWebhookDeliveries(now time.Time, leaseUntil time.Time, limit int) (
+	[]webhookmodels.Delivery, error,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := whs.db.Query(
+		ctx,
+		"UPDATE webhook_deliveries d SET nextattemptat = $2 FROM webhooks w "+
+			"WHERE w.id = d.webhookid AND d.id IN (SELECT id FROM webhook_deliveries "+
+			"WHERE status = $1 AND nextattemptat <= $3 ORDER BY nextattemptat, createdat LIMIT $4 "+
+			"FOR UPDATE SKIP LOCKED) RETURNING "+deliveryColumns+", w.url, w.secret",
+		webhookmodels.DeliveryPending,
+		leaseUntil,
+		now,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (webhookmodels.Delivery, error) {
+		return scanDelivery(row, true)
+	})
+}
+
+func (whs *webhookStorage) UpdateWebhookDelivery(delivery webhookmodels.Delivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	cmd, err := whs.db.Exec(
+		ctx,
+		"UPDATE webhook_deliveries SET status = $2, attempts = $3, nextattemptat = $4, lasterror = $5, "+
+			"responsecode = $6, deliveredat = $7 WHERE id = $1",
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastError,
+		delivery.ResponseCode,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return webhookerrors.ErrDeliveryNotFound
+	}
+	return nil
+}
+
+// GetWebhookDeliveries - журнал доставок вебхука, последние limit записей, новые первыми.
+func (whs *webhookStorage) GetWebhookDeliveries(webhookID string, limit int) ([]webhookmodels.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	rows, err := whs.db.Query(
+		ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries d WHERE d.webhookid = $1 "+
+			"ORDER BY d.createdat DESC, d.id LIMIT $2",
+		webhookID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (webhookmodels.Delivery, error) {
+		return scanDelivery(row, false)
+	})
+}
+
+func (whs *webhookStorage) GetWebhookDelivery(deliveryID string, webhookID string) (webhookmodels.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	row := whs.db.QueryRow(
+		ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries d WHERE d.id = $1 AND d.webhookid = $2",
+		deliveryID,
+		webhookID,
+	)
+
+	delivery, err := scanDelivery(row, false)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return webhookmodels.Delivery{}, webhookerrors.ErrDeliveryNotFound
+	}
+	return delivery, err
+}
+
+// scanDelivery - withTarget означает, что за колонками доставки идут url и secret вебхука.
+func scanDelivery(row pgx.Row, withTarget bool) (webhookmodels.Delivery, error) {
+	var d webhookmodels.Delivery
+
+	dest := []any{
+		&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastError, &d.ResponseCode, &d.CreatedAt, &d.DeliveredAt,
+	}
+	if withTarget {
+		dest = append(dest, &d.URL, &d.Secret)
+	}
+
+	err := row.Scan(dest...)
+	return d, err
+}
+
+func eventsToStrings(events []webhookmodels.EventType) []string {
+	result := make([]string, 0, len(events))
+	for _, event := range events {
+		result = append(result, string(event))
+	}
+	return result
+}
+
+func stringsToEvents(events []string) []webhookmodels.EventType {
+	result := make([]webhookmodels.EventType, 0, len(events))
+	for _, event := range events {
+		result = append(result, webhookmodels.EventType(event))
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	"toDoList/internal/domain/webhook/webhookerrors"
+	"toDoList/internal/domain/webhook/webhookmodels"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookStorage_ClaimWebhookDeliveries(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	whs := &webhookStorage{db: mock}
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	lease := now.Add(time.Minute)
+	payload := json.RawMessage(`{"type":"task.created"}`)
+
+	mock.ExpectQuery("UPDATE webhook_deliveries d SET nextattemptat = \\$2 .+ FOR UPDATE SKIP LOCKED\\) RETURNING").
+		WithArgs(webhookmodels.DeliveryPending, lease, now, 50).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "webhookid", "eventid", "eventtype", "payload", "status", "attempts", "nextattemptat",
+			"lasterror", "responsecode", "createdat", "deliveredat", "url", "secret",
+		}).AddRow(
+			"d1", "w1", "e1", webhookmodels.EventTaskCreated, payload, webhookmodels.DeliveryPending, 1, lease,
+			"timeout", 0, now, (*time.Time)(nil), "https://ci.example.com/hook", "secret",
+		))
+
+	deliveries, err := whs.ClaimWebhookDeliveries(now, lease, 50)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "https://ci.example.com/hook", deliveries[0].URL)
+	assert.Equal(t, payload, deliveries[0].Payload)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookStorage_DeleteWebhook(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	whs := &webhookStorage{db: mock}
+
+	mock.ExpectExec("DELETE FROM webhooks WHERE id = \\$1 AND userid = \\$2").
+		WithArgs("w1", "u1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	require.ErrorIs(t, whs.DeleteWebhook("w1", "u1"), webhookerrors.ErrWebhookNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
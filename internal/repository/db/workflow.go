@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"toDoList/internal"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/workflow/workflowerrors"
+	"toDoList/internal/domain/workflow/workflowmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type workflowStorage struct {
+	db PgxIface
+}
+
+// GetWorkflow - ErrWorkflowNotFound, если workflow проекта не настраивали.
+func (ws *workflowStorage) GetWorkflow(projectID string) (workflowmodels.Workflow, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	workflow := workflowmodels.Workflow{ProjectID: projectID}
+	err := ws.db.QueryRow(
+		ctx,
+		"SELECT statuses, transitions, updatedat FROM workflows WHERE projectid = $1",
+		projectID,
+	).Scan(&workflow.Statuses, &workflow.Transitions, &workflow.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return workflowmodels.Workflow{}, workflowerrors.ErrWorkflowNotFound
+		}
+		return workflowmodels.Workflow{}, err
+	}
+	return workflow, nil
+}
+
+// SaveWorkflow - статусы, в которых есть задачи проекта, удалить нельзя, помеченные на удаление
+// задачи не считаются. Задачи проекта в той же транзакции получают категории своих статусов из нового workflow.
+func (ws *workflowStorage) SaveWorkflow(workflow workflowmodels.Workflow) error {
+	return inTx(ws.db, func(ctx context.Context, tx pgx.Tx) ([]eventmodels.Event, error) {
+		statuses := make([]string, len(workflow.Statuses))
+		categories := make([]string, len(workflow.Statuses))
+		for i, status := range workflow.Statuses {
+			statuses[i] = string(status.Name)
+			categories[i] = string(status.Category)
+		}
+
+		rows, err := tx.Query(
+			ctx,
+			"SELECT DISTINCT status FROM tasks WHERE projectid = $1 AND deleted = false AND status <> ALL($2) "+
+				"ORDER BY status",
+			workflow.ProjectID,
+			statuses,
+		)
+		if err != nil {
+			return nil, err
+		}
+		inUse, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return nil, err
+		}
+		if len(inUse) != 0 {
+			return nil, fmt.Errorf("%w: %s", workflowerrors.ErrStatusInUse, strings.Join(inUse, ", "))
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO workflows (projectid, statuses, transitions, updatedat) VALUES ($1, $2, $3, $4) "+
+				"ON CONFLICT (projectid) DO UPDATE SET statuses = EXCLUDED.statuses, "+
+				"transitions = EXCLUDED.transitions, updatedat = EXCLUDED.updatedat",
+			workflow.ProjectID,
+			workflow.Statuses,
+			workflow.Transitions,
+			workflow.UpdatedAt,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return nil, projecterrors.ErrProjectNotFound
+			}
+			return nil, err
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE tasks t SET statuscategory = s.category, version = t.version + 1 "+
+				"FROM unnest($2::text[], $3::text[]) AS s(status, category) "+
+				"WHERE t.projectid = $1 AND t.status = s.status AND t.statuscategory <> s.category",
+			workflow.ProjectID,
+			statuses,
+			categories,
+		)
+		return nil, err
+	})
+}
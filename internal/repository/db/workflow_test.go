@@ -0,0 +1,110 @@
+package db
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowerrors"
+	"toDoList/internal/domain/workflow/workflowmodels"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowStorage_GetWorkflow(t *testing.T) {
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	ws := &workflowStorage{db: mock}
+
+	updatedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	statuses := []workflowmodels.Status{{Name: "Open", Category: taskmodels.CategoryTodo}}
+	transitions := []workflowmodels.Transition{}
+
+	mock.ExpectQuery("SELECT statuses, transitions, updatedat FROM workflows").
+		WithArgs("p1").
+		WillReturnRows(pgxmock.NewRows([]string{"statuses", "transitions", "updatedat"}).
+			AddRow(statuses, transitions, &updatedAt))
+	mock.ExpectQuery("SELECT statuses, transitions, updatedat FROM workflows").
+		WithArgs("p2").
+		WillReturnError(pgx.ErrNoRows)
+
+	workflow, err := ws.GetWorkflow("p1")
+	require.NoError(t, err)
+	assert.Equal(t, workflowmodels.Workflow{
+		ProjectID: "p1", Statuses: statuses, Transitions: transitions, UpdatedAt: &updatedAt,
+	}, workflow)
+
+	_, err = ws.GetWorkflow("p2")
+	assert.ErrorIs(t, err, workflowerrors.ErrWorkflowNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWorkflowStorage_SaveWorkflow(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	workflow := workflowmodels.Workflow{
+		ProjectID: "p1",
+		Statuses: []workflowmodels.Status{
+			{Name: "Open", Category: taskmodels.CategoryTodo},
+			{Name: "Closed", Category: taskmodels.CategoryDone},
+		},
+		Transitions: []workflowmodels.Transition{{From: "Open", To: "Closed"}},
+		UpdatedAt:   &updatedAt,
+	}
+
+	tests := []struct {
+		name      string
+		inUse     []string
+		insertErr error
+		wantErr   error
+	}{
+		{name: "success"},
+		{name: "status in use", inUse: []string{"Done", "New"}, wantErr: workflowerrors.ErrStatusInUse},
+		{
+			name:      "project not found",
+			insertErr: &pgconn.PgError{Code: "23503"},
+			wantErr:   projecterrors.ErrProjectNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			ws := &workflowStorage{db: mock}
+
+			rows := pgxmock.NewRows([]string{"status"})
+			for _, status := range tt.inUse {
+				rows.AddRow(status)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT DISTINCT status FROM tasks").
+				WithArgs("p1", []string{"Open", "Closed"}).
+				WillReturnRows(rows)
+			if tt.inUse == nil {
+				insert := mock.ExpectExec("INSERT INTO workflows .+ ON CONFLICT \\(projectid\\) DO UPDATE").
+					WithArgs("p1", workflow.Statuses, workflow.Transitions, workflow.UpdatedAt)
+				if tt.insertErr != nil {
+					insert.WillReturnError(tt.insertErr)
+				} else {
+					insert.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+					mock.ExpectExec("UPDATE tasks t SET statuscategory = s.category, version = t.version \\+ 1").
+						WithArgs("p1", []string{"Open", "Closed"}, []string{"todo", "done"}).
+						WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+				}
+			}
+			expectOutboxOrRollback(mock, tt.wantErr)
+
+			err = ws.SaveWorkflow(workflow)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.inUse != nil {
+				assert.ErrorContains(t, err, "Done, New")
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package inmemory
+
+import (
+	"slices"
+	"strings"
+	"toDoList/internal/domain/attachment/attachmenterrors"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/task/taskerrors"
+)
+
+func (storage *Storage) AddAttachment(attachment attachmentmodels.Attachment) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.tasks[attachment.TaskID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+
+	storage.attachments[attachment.ID] = attachment
+	return nil
+}
+
+func (storage *Storage) GetTaskAttachments(taskID string) ([]attachmentmodels.Attachment, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var attachments []attachmentmodels.Attachment
+	for _, attachment := range storage.attachments {
+		if attachment.TaskID == taskID {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	slices.SortFunc(attachments, func(a, b attachmentmodels.Attachment) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return attachments, nil
+}
+
+func (storage *Storage) GetAttachmentByID(attachmentID string, taskID string) (attachmentmodels.Attachment, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	attachment, ok := storage.attachments[attachmentID]
+	if !ok || attachment.TaskID != taskID {
+		return attachmentmodels.Attachment{}, attachmenterrors.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (storage *Storage) DeleteAttachment(attachmentID string, taskID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	attachment, ok := storage.attachments[attachmentID]
+	if !ok || attachment.TaskID != taskID {
+		return attachmenterrors.ErrAttachmentNotFound
+	}
+
+	storage.removeAttachment(attachment)
+	return nil
+}
+
+// removeAttachment - ключ содержимого передаётся сборщику, вызывается под tasksMu.
+func (storage *Storage) removeAttachment(attachment attachmentmodels.Attachment) {
+	delete(storage.attachments, attachment.ID)
+	if !slices.Contains(storage.orphanedBlobs, attachment.BlobKey) {
+		storage.orphanedBlobs = append(storage.orphanedBlobs, attachment.BlobKey)
+	}
+}
+
+func (storage *Storage) GetOrphanedBlobKeys(limit int) ([]string, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	return slices.Clone(storage.orphanedBlobs[:min(limit, len(storage.orphanedBlobs))]), nil
+}
+
+func (storage *Storage) DeleteOrphanedBlobKey(key string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	storage.orphanedBlobs = slices.DeleteFunc(storage.orphanedBlobs, func(k string) bool { return k == key })
+	return nil
+}
@@ -0,0 +1,72 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/attachment/attachmenterrors"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_Attachments(t *testing.T) {
+	storage := NewInMemoryStorage()
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID: "task1", UserID: "user1", Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "a"},
+	}))
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID: "task2", UserID: "user1",
+		Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "b", ParentID: "task1"},
+	}))
+
+	for _, attachment := range []attachmentmodels.Attachment{
+		{ID: "a2", TaskID: "task1", BlobKey: "tasks/task1/a2", CreatedAt: now.Add(time.Minute)},
+		{ID: "a1", TaskID: "task1", BlobKey: "tasks/task1/a1", CreatedAt: now},
+		{ID: "a3", TaskID: "task2", BlobKey: "tasks/task2/a3", CreatedAt: now},
+	} {
+		require.NoError(t, storage.AddAttachment(attachment))
+	}
+	assert.ErrorIs(t, storage.AddAttachment(attachmentmodels.Attachment{ID: "a4", TaskID: "nope"}),
+		taskerrors.ErrFoundNothing)
+
+	attachments, err := storage.GetTaskAttachments("task1")
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "a1", attachments[0].ID)
+	assert.Equal(t, "a2", attachments[1].ID)
+
+	_, err = storage.GetAttachmentByID("a3", "task1")
+	assert.ErrorIs(t, err, attachmenterrors.ErrAttachmentNotFound)
+
+	require.NoError(t, storage.DeleteAttachment("a2", "task1"))
+	assert.ErrorIs(t, storage.DeleteAttachment("a2", "task1"), attachmenterrors.ErrAttachmentNotFound)
+
+	keys, err := storage.GetOrphanedBlobKeys(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tasks/task1/a2"}, keys)
+
+	// Окончательное удаление задачи с подзадачей передаёт сборщику ключи всех их вложений.
+	require.NoError(t, storage.MarkTaskToDelete("task1", "user1"))
+	require.NoError(t, storage.DeleteMarkedTasks())
+
+	_, err = storage.GetAttachmentByID("a1", "task1")
+	assert.ErrorIs(t, err, attachmenterrors.ErrAttachmentNotFound)
+
+	keys, err = storage.GetOrphanedBlobKeys(10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tasks/task1/a2", "tasks/task1/a1", "tasks/task2/a3"}, keys)
+
+	keys, err = storage.GetOrphanedBlobKeys(1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tasks/task1/a2"}, keys)
+
+	require.NoError(t, storage.DeleteOrphanedBlobKey("tasks/task1/a2"))
+	keys, err = storage.GetOrphanedBlobKeys(10)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
@@ -0,0 +1,129 @@
+package inmemory
+
+import (
+	"slices"
+	"strings"
+	"toDoList/internal/domain/comment/commenterrors"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskerrors"
+)
+
+func mentionEvents(comment commentmodels.Comment, userIDs []string) ([]eventmodels.Event, error) {
+	events := make([]eventmodels.Event, 0, len(userIDs))
+	for _, userID := range userIDs {
+		event, err := eventmodels.NewMentionEvent(comment, userID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (storage *Storage) AddComment(comment commentmodels.Comment) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.tasks[comment.TaskID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+
+	events, err := mentionEvents(comment, comment.Mentions)
+	if err != nil {
+		return err
+	}
+
+	comment.Mentions = slices.Clone(comment.Mentions)
+	storage.comments[comment.ID] = comment
+	storage.recordEvents(events...)
+	return nil
+}
+
+func (storage *Storage) GetTaskComments(taskID string) ([]commentmodels.Comment, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var comments []commentmodels.Comment
+	for _, comment := range storage.comments {
+		if comment.TaskID == taskID {
+			comment.Mentions = slices.Clone(comment.Mentions)
+			comments = append(comments, comment)
+		}
+	}
+
+	slices.SortFunc(comments, func(a, b commentmodels.Comment) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return comments, nil
+}
+
+func (storage *Storage) GetCommentByID(commentID string, taskID string) (commentmodels.Comment, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	comment, ok := storage.comments[commentID]
+	if !ok || comment.TaskID != taskID {
+		return commentmodels.Comment{}, commenterrors.ErrCommentNotFound
+	}
+
+	comment.Mentions = slices.Clone(comment.Mentions)
+	return comment, nil
+}
+
+// UpdateComment - события получают только новые упомянутые.
+func (storage *Storage) UpdateComment(comment commentmodels.Comment) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	stored, ok := storage.comments[comment.ID]
+	if !ok || stored.TaskID != comment.TaskID || stored.Deleted {
+		return commenterrors.ErrCommentNotFound
+	}
+
+	var added []string
+	for _, userID := range comment.Mentions {
+		if !slices.Contains(stored.Mentions, userID) {
+			added = append(added, userID)
+		}
+	}
+
+	events, err := mentionEvents(comment, added)
+	if err != nil {
+		return err
+	}
+
+	stored.Body = comment.Body
+	stored.Mentions = slices.Clone(comment.Mentions)
+	stored.EditedAt = comment.EditedAt
+	storage.comments[comment.ID] = stored
+	storage.recordEvents(events...)
+	return nil
+}
+
+func (storage *Storage) DeleteComment(commentID string, taskID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	comment, ok := storage.comments[commentID]
+	if !ok || comment.TaskID != taskID || comment.Deleted {
+		return commenterrors.ErrCommentNotFound
+	}
+
+	comment.Deleted = true
+	storage.comments[commentID] = comment
+	return nil
+}
+
+// markCommentsDeleted - помечает комментарии задачи вместе с ней, вызывается под tasksMu.
+func (storage *Storage) markCommentsDeleted(taskID string) {
+	for id, comment := range storage.comments {
+		if comment.TaskID == taskID {
+			comment.Deleted = true
+			storage.comments[id] = comment
+		}
+	}
+}
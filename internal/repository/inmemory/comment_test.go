@@ -0,0 +1,79 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/comment/commenterrors"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_Comments(t *testing.T) {
+	storage := NewInMemoryStorage()
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID: "task1", UserID: "user1", Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "a"},
+	}))
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID: "task2", UserID: "user1",
+		Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "b", ParentID: "task1"},
+	}))
+
+	require.NoError(t, storage.AddComment(commentmodels.Comment{
+		ID: "c2", TaskID: "task1", AuthorID: "user1", Body: "second", CreatedAt: now.Add(time.Minute),
+	}))
+	require.NoError(t, storage.AddComment(commentmodels.Comment{
+		ID: "c1", TaskID: "task1", AuthorID: "user1", Body: "@bob", Mentions: []string{"user2"}, CreatedAt: now,
+	}))
+	require.NoError(t, storage.AddComment(commentmodels.Comment{
+		ID: "c3", TaskID: "task2", AuthorID: "user1", Body: "sub", CreatedAt: now,
+	}))
+	assert.ErrorIs(t, storage.AddComment(commentmodels.Comment{ID: "c4", TaskID: "nope"}), taskerrors.ErrFoundNothing)
+
+	comments, err := storage.GetTaskComments("task1")
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "c1", comments[0].ID)
+	assert.Equal(t, "c2", comments[1].ID)
+
+	// Правка уведомляет только новых упомянутых.
+	editedAt := now.Add(time.Hour)
+	require.NoError(t, storage.UpdateComment(commentmodels.Comment{
+		ID: "c1", TaskID: "task1", Body: "@bob @carol", Mentions: []string{"user2", "user3"}, EditedAt: &editedAt,
+	}))
+
+	var mentioned []string
+	for _, entry := range storage.outbox {
+		if entry.event.Type == eventmodels.CommentMentioned {
+			mentioned = append(mentioned, entry.event.UserID)
+		}
+	}
+	assert.Equal(t, []string{"user2", "user3"}, mentioned)
+
+	comment, err := storage.GetCommentByID("c1", "task1")
+	require.NoError(t, err)
+	assert.Equal(t, "@bob @carol", comment.Body)
+	assert.Equal(t, &editedAt, comment.EditedAt)
+
+	_, err = storage.GetCommentByID("c1", "task2")
+	assert.ErrorIs(t, err, commenterrors.ErrCommentNotFound)
+
+	require.NoError(t, storage.DeleteComment("c2", "task1"))
+	assert.ErrorIs(t, storage.DeleteComment("c2", "task1"), commenterrors.ErrCommentNotFound)
+
+	// Комментарии помечаются вместе с задачей и подзадачами, а удаляются вместе с ними.
+	require.NoError(t, storage.MarkTaskToDelete("task1", "user1"))
+	for _, id := range []string{"c1", "c3"} {
+		comment = storage.comments[id]
+		assert.True(t, comment.Deleted, id)
+	}
+
+	require.NoError(t, storage.DeleteMarkedTasks())
+	assert.Empty(t, storage.comments)
+}
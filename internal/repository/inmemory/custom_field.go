@@ -0,0 +1,141 @@
+package inmemory
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+func (storage *Storage) AddCustomField(field customfieldmodels.CustomField) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.projects[field.ProjectID]; !ok {
+		return projecterrors.ErrProjectNotFound
+	}
+	for _, f := range storage.customFields {
+		if f.ProjectID == field.ProjectID && f.Name == field.Name {
+			return customfielderrors.ErrCustomFieldIsAlreadyExist
+		}
+	}
+
+	storage.customFields[field.ID] = field
+	return nil
+}
+
+func (storage *Storage) GetCustomFields(projectID string) ([]customfieldmodels.CustomField, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var fields []customfieldmodels.CustomField
+	for _, field := range storage.customFields {
+		if field.ProjectID == projectID {
+			fields = append(fields, field)
+		}
+	}
+
+	slices.SortFunc(fields, func(a, b customfieldmodels.CustomField) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return fields, nil
+}
+
+func (storage *Storage) DeleteCustomField(fieldID string, projectID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	field, ok := storage.customFields[fieldID]
+	if !ok || field.ProjectID != projectID {
+		return customfielderrors.ErrCustomFieldNotFound
+	}
+	delete(storage.customFields, fieldID)
+
+	for id, task := range storage.tasks {
+		if task.Attributes.ProjectID != projectID {
+			continue
+		}
+		if _, ok = task.Attributes.CustomFields[fieldID]; ok {
+			// Копия, а не удаление на месте: map задачи разделяют снимок транзакции и прочитанные копии.
+			task.Attributes.CustomFields = maps.Clone(task.Attributes.CustomFields)
+			delete(task.Attributes.CustomFields, fieldID)
+			task.Version++
+			storage.tasks[id] = task
+		}
+	}
+	return nil
+}
+
+// containsCustomFields - то же, что customfields @> filter в БД: значения совпадают, а список
+// содержит все элементы списка из фильтра.
+func containsCustomFields(values map[string]any, filter map[string]any) bool {
+	for id, want := range filter {
+		got, ok := values[id]
+		if !ok {
+			return false
+		}
+
+		wantList, isList := want.([]any)
+		gotList, gotIsList := got.([]any)
+		switch {
+		case isList != gotIsList:
+			return false
+		case isList:
+			for _, item := range wantList {
+				if !slices.Contains(gotList, item) {
+					return false
+				}
+			}
+		case got != want:
+			return false
+		}
+	}
+	return true
+}
+
+// sortByCustomField - устойчивая сортировка по значению поля поверх порядка по рангу, задачи без
+// значения идут последними в обоих направлениях, как NULLS LAST в БД.
+func sortByCustomField(tasks []taskmodels.Task, fieldID string, desc bool) {
+	slices.SortStableFunc(tasks, func(a, b taskmodels.Task) int {
+		av, aok := a.Attributes.CustomFields[fieldID]
+		bv, bok := b.Attributes.CustomFields[fieldID]
+		switch {
+		case !aok || !bok:
+			return compareBool(bok, aok)
+		case desc:
+			return compareCustomValues(bv, av)
+		default:
+			return compareCustomValues(av, bv)
+		}
+	})
+}
+
+// compareCustomValues - числа сравниваются как числа, остальные значения - как строки.
+func compareCustomValues(a any, b any) int {
+	af, aIsNumber := a.(float64)
+	bf, bIsNumber := b.(float64)
+	if aIsNumber && bIsNumber {
+		return cmp.Compare(af, bf)
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// compareBool - false раньше true.
+func compareBool(a bool, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,116 @@
+package inmemory
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_CustomFields(t *testing.T) {
+	storage := NewInMemoryStorage()
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend"}))
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	size := customfieldmodels.CustomField{
+		ID: "f2", ProjectID: "p1", Name: "Size", Type: customfieldmodels.TypeSelect, Options: []string{"S"},
+		CreatedAt: now.Add(time.Minute),
+	}
+	estimate := customfieldmodels.CustomField{
+		ID: "f1", ProjectID: "p1", Name: "Estimate", Type: customfieldmodels.TypeNumber, CreatedAt: now,
+	}
+	require.NoError(t, storage.AddCustomField(size))
+	require.NoError(t, storage.AddCustomField(estimate))
+
+	estimate.ID = "f3"
+	assert.ErrorIs(t, storage.AddCustomField(estimate), customfielderrors.ErrCustomFieldIsAlreadyExist)
+	estimate.ProjectID = "p2"
+	assert.ErrorIs(t, storage.AddCustomField(estimate), projecterrors.ErrProjectNotFound)
+
+	fields, err := storage.GetCustomFields("p1")
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "f1", fields[0].ID)
+	assert.Equal(t, size, fields[1])
+
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID:     "t1",
+		UserID: "u1",
+		Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "T", Description: "D", ProjectID: "p1",
+			CustomFields: map[string]any{"f1": 3.0, "f2": "S"},
+		},
+	}))
+
+	// Откат транзакции возвращает и определение, и значения поля у задач.
+	errAbort := errors.New("abort")
+	err = storage.InTx(func(tx *Storage) error {
+		require.NoError(t, tx.DeleteCustomField("f2", "p1"))
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	task, err := storage.GetTaskByID("t1", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"f1": 3.0, "f2": "S"}, task.Attributes.CustomFields)
+
+	require.NoError(t, storage.DeleteCustomField("f2", "p1"))
+	assert.ErrorIs(t, storage.DeleteCustomField("f2", "p1"), customfielderrors.ErrCustomFieldNotFound)
+
+	version := task.Version
+	task, err = storage.GetTaskByID("t1", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"f1": 3.0}, task.Attributes.CustomFields)
+	assert.Equal(t, version+1, task.Version)
+}
+
+func TestStorage_FindTasksByCustomFields(t *testing.T) {
+	storage := NewInMemoryStorage()
+	for _, task := range []struct {
+		id       string
+		position string
+		values   map[string]any
+	}{
+		{"t1", "a", map[string]any{"estimate": 5.0, "labels": []any{"bug", "ui"}}},
+		{"t2", "b", map[string]any{"estimate": 10.0, "labels": []any{"bug"}}},
+		{"t3", "c", nil},
+		{"t4", "d", map[string]any{"estimate": 1.0, "labels": []any{"ui"}}},
+	} {
+		require.NoError(t, storage.AddTask(taskmodels.Task{
+			ID:       task.id,
+			UserID:   "u1",
+			Position: task.position,
+			Attributes: taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: task.id, Description: "D", CustomFields: task.values,
+			},
+		}))
+	}
+
+	ids := func(filter taskmodels.TaskFilter) []string {
+		tasks, err := storage.FindTasks("u1", filter)
+		require.NoError(t, err)
+		got := []string{}
+		for _, task := range tasks {
+			got = append(got, task.ID)
+		}
+		return got
+	}
+
+	assert.Equal(t, []string{"t1", "t2"}, ids(taskmodels.TaskFilter{
+		CustomFields: map[string]any{"labels": []any{"bug"}},
+	}))
+	assert.Equal(t, []string{"t2"}, ids(taskmodels.TaskFilter{
+		CustomFields: map[string]any{"labels": []any{"bug"}, "estimate": 10.0},
+	}))
+	assert.Empty(t, ids(taskmodels.TaskFilter{CustomFields: map[string]any{"labels": "bug"}}))
+
+	assert.Equal(t, []string{"t4", "t1", "t2", "t3"}, ids(taskmodels.TaskFilter{SortField: "estimate"}))
+	assert.Equal(t, []string{"t2", "t1", "t4", "t3"}, ids(taskmodels.TaskFilter{SortField: "estimate", SortDesc: true}))
+}
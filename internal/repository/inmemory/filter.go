@@ -0,0 +1,195 @@
+package inmemory
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/tag/tagmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/pkg/fulltext"
+)
+
+func (storage *Storage) AddFilter(filter filtermodels.Filter) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if storage.hasFilterName(filter) {
+		return filtererrors.ErrFilterIsAlreadyExist
+	}
+
+	storage.filters[filter.ID] = filter
+	return nil
+}
+
+func (storage *Storage) GetFiltersByUser(userID string) ([]filtermodels.Filter, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var filters []filtermodels.Filter
+
+	for _, filter := range storage.filters {
+		if filter.UserID == userID {
+			filters = append(filters, filter)
+		}
+	}
+
+	sort.Slice(filters, func(i, j int) bool {
+		return filters[i].Name < filters[j].Name
+	})
+
+	return filters, nil
+}
+
+func (storage *Storage) GetFilterByID(filterID string, userID string) (filtermodels.Filter, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	return storage.filterByID(filterID, userID)
+}
+
+// filterByID - вызывается под tasksMu.
+func (storage *Storage) filterByID(filterID string, userID string) (filtermodels.Filter, error) {
+	filter, ok := storage.filters[filterID]
+	if !ok || filter.UserID != userID {
+		return filtermodels.Filter{}, filtererrors.ErrFilterNotFound
+	}
+
+	return filter, nil
+}
+
+func (storage *Storage) UpdateFilter(filter filtermodels.Filter) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	saved, err := storage.filterByID(filter.ID, filter.UserID)
+	if err != nil {
+		return err
+	}
+
+	if storage.hasFilterName(filter) {
+		return filtererrors.ErrFilterIsAlreadyExist
+	}
+
+	filter.CreatedAt = saved.CreatedAt
+	storage.filters[filter.ID] = filter
+	return nil
+}
+
+func (storage *Storage) DeleteFilter(filterID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, err := storage.filterByID(filterID, userID); err != nil {
+		return err
+	}
+
+	delete(storage.filters, filterID)
+	return nil
+}
+
+// hasFilterName - у пользователя уже есть другой фильтр с таким именем.
+func (storage *Storage) hasFilterName(filter filtermodels.Filter) bool {
+	for _, f := range storage.filters {
+		if f.ID != filter.ID && f.UserID == filter.UserID && f.Name == filter.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// FindTasksByFilter - видимые пользователю неудалённые задачи, подходящие под выражение.
+func (storage *Storage) FindTasksByFilter(userID string, expr filtermodels.Expr) ([]taskmodels.Task, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var tasks []taskmodels.Task
+
+	for _, task := range storage.tasks {
+		if task.Deleted || !storage.isTaskVisible(task, userID) {
+			continue
+		}
+		task = storage.withRelations(task)
+		matched, err := matchFilter(task, userID, expr)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			tasks = append(tasks, task)
+		}
+	}
+
+	sortByPosition(tasks)
+	return tasks, nil
+}
+
+// matchFilter - то же, что условие filterCondition в БД: теги учитываются только пользовательские,
+// а сравнения due не проходят задачи без срока.
+func matchFilter(task taskmodels.Task, userID string, expr filtermodels.Expr) (bool, error) {
+	switch e := expr.(type) {
+	case filtermodels.And:
+		left, err := matchFilter(task, userID, e.Left)
+		if err != nil || !left {
+			return false, err
+		}
+		return matchFilter(task, userID, e.Right)
+	case filtermodels.Or:
+		left, err := matchFilter(task, userID, e.Left)
+		if err != nil || left {
+			return left, err
+		}
+		return matchFilter(task, userID, e.Right)
+	case filtermodels.Not:
+		matched, err := matchFilter(task, userID, e.Expr)
+		return !matched, err
+	case filtermodels.Condition:
+		return matchCondition(task, userID, e)
+	default:
+		return false, fmt.Errorf("%w: unsupported expression %T", filtererrors.ErrWrongFilterQuery, expr)
+	}
+}
+
+func matchCondition(task taskmodels.Task, userID string, cond filtermodels.Condition) (bool, error) {
+	attrs := task.Attributes
+
+	switch cond.Field {
+	case filtermodels.FieldStatus:
+		return string(attrs.Status) == cond.Value, nil
+	case filtermodels.FieldPriority:
+		return string(attrs.Priority) == cond.Value, nil
+	case filtermodels.FieldProject:
+		return attrs.ProjectID == cond.Value, nil
+	case filtermodels.FieldAssignee:
+		return attrs.AssigneeID == cond.Value, nil
+	case filtermodels.FieldTag:
+		return slices.ContainsFunc(task.Tags, func(tag tagmodels.Tag) bool {
+			return tag.UserID == userID && tag.Name == cond.Value
+		}), nil
+	case filtermodels.FieldText:
+		return fulltext.ParseQuery(cond.Value, string(cond.Language)).Matches(attrs.Title, attrs.Description), nil
+	case filtermodels.FieldDue:
+		if cond.Op == filtermodels.OpEq {
+			return attrs.DueDate == nil, nil
+		}
+		return attrs.DueDate != nil && compareTime(*attrs.DueDate, cond.Op, cond.Time), nil
+	default:
+		return false, fmt.Errorf("%w: unknown field %q", filtererrors.ErrWrongFilterQuery, cond.Field)
+	}
+}
+
+func compareTime(t time.Time, op filtermodels.Operator, bound time.Time) bool {
+	switch op {
+	case filtermodels.OpLess:
+		return t.Before(bound)
+	case filtermodels.OpLessEq:
+		return !t.After(bound)
+	case filtermodels.OpGreater:
+		return t.After(bound)
+	case filtermodels.OpGreaterEq:
+		return !t.Before(bound)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,105 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/tag/tagmodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_Filters(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	urgent := filtermodels.Filter{ID: "f1", UserID: "user1", Name: "Urgent", Query: "tag:urgent"}
+	overdue := filtermodels.Filter{ID: "f2", UserID: "user1", Name: "Overdue", Query: "due<now"}
+	require.NoError(t, storage.AddFilter(urgent))
+	require.NoError(t, storage.AddFilter(overdue))
+
+	err := storage.AddFilter(filtermodels.Filter{ID: "f3", UserID: "user1", Name: "Urgent"})
+	assert.ErrorIs(t, err, filtererrors.ErrFilterIsAlreadyExist)
+	assert.NoError(t, storage.AddFilter(filtermodels.Filter{ID: "f4", UserID: "user2", Name: "Urgent"}))
+
+	filters, err := storage.GetFiltersByUser("user1")
+	require.NoError(t, err)
+	assert.Equal(t, []filtermodels.Filter{overdue, urgent}, filters)
+
+	overdue.Name = "Urgent"
+	assert.ErrorIs(t, storage.UpdateFilter(overdue), filtererrors.ErrFilterIsAlreadyExist)
+	overdue.Name = "Late"
+	assert.NoError(t, storage.UpdateFilter(overdue))
+
+	_, err = storage.GetFilterByID("f1", "user2")
+	assert.ErrorIs(t, err, filtererrors.ErrFilterNotFound)
+	assert.ErrorIs(t, storage.DeleteFilter("f1", "user2"), filtererrors.ErrFilterNotFound)
+	assert.NoError(t, storage.DeleteFilter("f1", "user1"))
+	_, err = storage.GetFilterByID("f1", "user1")
+	assert.ErrorIs(t, err, filtererrors.ErrFilterNotFound)
+}
+
+func TestStorage_FindTasksByFilter(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	tasks := []taskmodels.Task{
+		{ID: "t1", UserID: "user1", Position: "a", Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusInProgress, Title: "Оплатить счета", Description: "За март", DueDate: &yesterday,
+		}},
+		{ID: "t2", UserID: "user1", Position: "b", Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "Call accounting", Description: "Invoices", DueDate: &nextWeek,
+		}},
+		{ID: "t3", UserID: "user1", Position: "c", Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusInProgress, Title: "Refactor", Description: "Storage",
+		}},
+		{ID: "t4", UserID: "user2", Position: "d", Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusInProgress, Title: "Foreign", Description: "Task",
+		}},
+	}
+	for _, task := range tasks {
+		require.NoError(t, storage.AddTask(task))
+	}
+	require.NoError(t, storage.AddTag(tagmodels.Tag{ID: "tag1", UserID: "user1", Name: "urgent"}))
+	require.NoError(t, storage.SetTaskTags("t2", "user1", []string{"tag1"}))
+
+	inProgress := filtermodels.Condition{
+		Field: filtermodels.FieldStatus, Op: filtermodels.OpEq, Value: string(taskmodels.StatusInProgress),
+	}
+	dueSoon := filtermodels.Condition{Field: filtermodels.FieldDue, Op: filtermodels.OpLess, Time: now}
+
+	tests := []struct {
+		name string
+		expr filtermodels.Expr
+		want []string
+	}{
+		{"status", inProgress, []string{"t1", "t3"}},
+		{"status and due", filtermodels.And{Left: inProgress, Right: dueSoon}, []string{"t1"}},
+		{"not due keeps tasks without due", filtermodels.Not{Expr: dueSoon}, []string{"t2", "t3"}},
+		{"no due", filtermodels.Condition{Field: filtermodels.FieldDue, Op: filtermodels.OpEq}, []string{"t3"}},
+		{"tag or text", filtermodels.Or{
+			Left: filtermodels.Condition{Field: filtermodels.FieldTag, Op: filtermodels.OpEq, Value: "urgent"},
+			Right: filtermodels.Condition{
+				Field: filtermodels.FieldText, Op: filtermodels.OpEq, Value: "счёт", Language: taskmodels.SearchRussian,
+			},
+		}, []string{"t1", "t2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := storage.FindTasksByFilter("user1", tt.expr)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, task := range found {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package inmemory
+
+import (
+	"maps"
+	"slices"
+	"time"
+	"toDoList/internal/domain/idempotency/idempotencyerrors"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+)
+
+type idempotencyKey struct {
+	userID string
+	key    string
+}
+
+func (storage *Storage) ReserveIdempotencyKey(record idempotencymodels.Record) (
+	idempotencymodels.Record, bool, error,
+) {
+	storage.idempotencyMu.Lock()
+	defer storage.idempotencyMu.Unlock()
+
+	id := idempotencyKey{userID: record.UserID, key: record.Key}
+	if saved, ok := storage.idempotency[id]; ok && saved.ExpiresAt.After(record.CreatedAt) {
+		return cloneRecord(saved), false, nil
+	}
+
+	record.StatusCode = 0
+	record.Header = nil
+	record.Body = nil
+	storage.idempotency[id] = record
+	return record, true, nil
+}
+
+func (storage *Storage) CompleteIdempotencyKey(record idempotencymodels.Record) error {
+	storage.idempotencyMu.Lock()
+	defer storage.idempotencyMu.Unlock()
+
+	id := idempotencyKey{userID: record.UserID, key: record.Key}
+	saved, ok := storage.idempotency[id]
+	if !ok || saved.Completed() || saved.Fingerprint != record.Fingerprint {
+		return idempotencyerrors.ErrKeyNotFound
+	}
+
+	saved.StatusCode = record.StatusCode
+	saved.Header = maps.Clone(record.Header)
+	saved.Body = slices.Clone(record.Body)
+	storage.idempotency[id] = saved
+	return nil
+}
+
+func (storage *Storage) ReleaseIdempotencyKey(userID string, key string) error {
+	storage.idempotencyMu.Lock()
+	defer storage.idempotencyMu.Unlock()
+
+	id := idempotencyKey{userID: userID, key: key}
+	saved, ok := storage.idempotency[id]
+	if !ok || saved.Completed() {
+		return idempotencyerrors.ErrKeyNotFound
+	}
+
+	delete(storage.idempotency, id)
+	return nil
+}
+
+func (storage *Storage) DeleteExpiredIdempotencyKeys(now time.Time) (int64, error) {
+	storage.idempotencyMu.Lock()
+	defer storage.idempotencyMu.Unlock()
+
+	var deleted int64
+	for id, record := range storage.idempotency {
+		if !record.ExpiresAt.After(now) {
+			delete(storage.idempotency, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func cloneRecord(record idempotencymodels.Record) idempotencymodels.Record {
+	record.Header = maps.Clone(record.Header)
+	record.Body = slices.Clone(record.Body)
+	return record
+}
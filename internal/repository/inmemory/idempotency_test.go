@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/idempotency/idempotencyerrors"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_IdempotencyKeys(t *testing.T) {
+	storage := NewInMemoryStorage()
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	record := idempotencymodels.Record{
+		UserID: "u1", Key: "k1", Fingerprint: "f1", CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	}
+	_, reserved, err := storage.ReserveIdempotencyKey(record)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	record.StatusCode = 201
+	record.Body = []byte(`{"id":"t1"}`)
+	require.NoError(t, storage.CompleteIdempotencyKey(record))
+	assert.ErrorIs(t, storage.CompleteIdempotencyKey(record), idempotencyerrors.ErrKeyNotFound)
+	assert.ErrorIs(t, storage.ReleaseIdempotencyKey("u1", "k1"), idempotencyerrors.ErrKeyNotFound)
+
+	retry := record
+	retry.StatusCode = 0
+	retry.Body = nil
+	retry.CreatedAt = now.Add(time.Minute)
+	saved, reserved, err := storage.ReserveIdempotencyKey(retry)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, record.Body, saved.Body)
+
+	// Истёкший ключ резервируется заново.
+	retry.CreatedAt = now.Add(2 * time.Hour)
+	retry.ExpiresAt = now.Add(3 * time.Hour)
+	saved, reserved, err = storage.ReserveIdempotencyKey(retry)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.False(t, saved.Completed())
+
+	deleted, err := storage.DeleteExpiredIdempotencyKeys(now.Add(3 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
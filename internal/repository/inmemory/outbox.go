@@ -0,0 +1,166 @@
+package inmemory
+
+import (
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/user/usermodels"
+
+	"github.com/rs/zerolog/log"
+)
+
+type outboxEntry struct {
+	event         eventmodels.Event
+	publishedAt   time.Time
+	attempts      int
+	lastError     string
+	nextAttemptAt time.Time
+	deadAt        time.Time
+}
+
+// pending - событие ещё ждёт публикации: не опубликовано и не получило отметку dead.
+func (entry outboxEntry) pending() bool {
+	return entry.publishedAt.IsZero() && entry.deadAt.IsZero()
+}
+
+// recordEvents - добавляет события в outbox, Seq назначается по порядку записи.
+func (storage *Storage) recordEvents(events ...eventmodels.Event) {
+	storage.outboxMu.Lock()
+	defer storage.outboxMu.Unlock()
+
+	for _, event := range events {
+		storage.outboxSeq++
+		event.Seq = storage.outboxSeq
+		storage.outbox = append(storage.outbox, outboxEntry{event: event})
+	}
+}
+
+// taskEvents - события задачи. Их собирают до изменения, чтобы ошибка сериализации
+// не оставила изменение без события.
+func taskEvents(task taskmodels.Task, eventTypes ...eventmodels.EventType) ([]eventmodels.Event, error) {
+	events := make([]eventmodels.Event, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		event, err := eventmodels.NewTaskEvent(eventType, task)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func userEvent(eventType eventmodels.EventType, user usermodels.User) ([]eventmodels.Event, error) {
+	event, err := eventmodels.NewUserEvent(eventType, user)
+	if err != nil {
+		return nil, err
+	}
+	return []eventmodels.Event{event}, nil
+}
+
+// RelayOutboxEvents - публикует до limit неопубликованных событий по порядку Seq. Как и в БД,
+// после неудачной публикации остальные события агрегата ждут повторной попытки, а после
+// MaxPublishAttempts неудач событие получает отметку dead и больше их не задерживает.
+func (storage *Storage) RelayOutboxEvents(limit int, publish func(event eventmodels.Event) error) (int, error) {
+	if !storage.relayMu.TryLock() {
+		return 0, nil
+	}
+	defer storage.relayMu.Unlock()
+
+	now := time.Now().UTC()
+
+	storage.outboxMu.Lock()
+	var events []eventmodels.Event
+	delayed := make(map[string]bool)
+	for _, entry := range storage.outbox {
+		if len(events) == limit {
+			break
+		}
+		aggregate := entry.event.AggregateType + ":" + entry.event.AggregateID
+		if !entry.pending() || delayed[aggregate] {
+			continue
+		}
+		if entry.nextAttemptAt.After(now) {
+			delayed[aggregate] = true
+			continue
+		}
+		events = append(events, entry.event)
+	}
+	storage.outboxMu.Unlock()
+
+	published := 0
+	failed := make(map[string]bool)
+
+	for _, event := range events {
+		aggregate := event.AggregateType + ":" + event.AggregateID
+		if failed[aggregate] {
+			continue
+		}
+
+		if err := publish(event); err != nil {
+			failed[aggregate] = true
+			storage.markFailed(event.Seq, err, now)
+			continue
+		}
+
+		published++
+		storage.markPublished(event.Seq, now)
+	}
+
+	return published, nil
+}
+
+func (storage *Storage) markPublished(seq int64, at time.Time) {
+	storage.outboxMu.Lock()
+	defer storage.outboxMu.Unlock()
+
+	if entry := storage.outboxEntry(seq); entry != nil {
+		entry.publishedAt = at
+	}
+}
+
+// markFailed - откладывает следующую попытку публикации события или помечает его dead.
+func (storage *Storage) markFailed(seq int64, err error, at time.Time) {
+	storage.outboxMu.Lock()
+	defer storage.outboxMu.Unlock()
+
+	entry := storage.outboxEntry(seq)
+	if entry == nil {
+		return
+	}
+
+	entry.attempts++
+	entry.lastError = err.Error()
+	entry.nextAttemptAt = at.Add(eventmodels.PublishBackoff(entry.attempts))
+	if entry.attempts >= eventmodels.MaxPublishAttempts {
+		entry.deadAt = at
+		log.Error().Err(err).Int64("seq", seq).Msg("outbox event is dead")
+		return
+	}
+	log.Error().Err(err).Int64("seq", seq).Msg("failed to publish outbox event")
+}
+
+// outboxEntry - запись outbox по Seq, вызывается под outboxMu.
+func (storage *Storage) outboxEntry(seq int64) *outboxEntry {
+	for i := range storage.outbox {
+		if storage.outbox[i].event.Seq == seq {
+			return &storage.outbox[i]
+		}
+	}
+	return nil
+}
+
+func (storage *Storage) DeletePublishedOutboxEvents(before time.Time) (int64, error) {
+	storage.outboxMu.Lock()
+	defer storage.outboxMu.Unlock()
+
+	kept := storage.outbox[:0]
+	for _, entry := range storage.outbox {
+		if entry.publishedAt.IsZero() || !entry.publishedAt.Before(before) {
+			kept = append(kept, entry)
+		}
+	}
+
+	deleted := int64(len(storage.outbox) - len(kept))
+	storage.outbox = kept
+	return deleted, nil
+}
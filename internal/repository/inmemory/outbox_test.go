@@ -0,0 +1,115 @@
+package inmemory
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/user/usermodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_Outbox(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	task := taskmodels.Task{
+		ID: "task1", UserID: "user1", Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "a"},
+	}
+	require.NoError(t, storage.AddTask(task))
+	_, err := storage.SaveUser(usermodels.User{UUID: "user1", Name: "Alice", Email: "a@test.com"})
+	require.NoError(t, err)
+
+	task.Attributes.Status = taskmodels.StatusCompleted
+	require.NoError(t, storage.UpdateTaskAttributes(task))
+	require.NoError(t, storage.MarkTaskToDelete("task1", "user1"))
+	// Неудачная операция не пишет событий.
+	require.Error(t, storage.DeleteTask("404", "user1"))
+
+	var published []eventmodels.EventType
+	failTask := true
+	publish := func(event eventmodels.Event) error {
+		if event.AggregateType == eventmodels.AggregateTask && failTask {
+			failTask = false
+			return errors.New("broker is down")
+		}
+		published = append(published, event.Type)
+		return nil
+	}
+
+	// Первое событие задачи не опубликовано, остальные события задачи ждут, события пользователя - нет.
+	count, err := storage.RelayOutboxEvents(10, publish)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []eventmodels.EventType{eventmodels.UserCreated}, published)
+
+	// До повторной попытки события задачи не публикуются.
+	count, err = storage.RelayOutboxEvents(10, publish)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	retryNow(storage)
+	count, err = storage.RelayOutboxEvents(10, publish)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.Equal(t, []eventmodels.EventType{
+		eventmodels.UserCreated,
+		eventmodels.TaskCreated,
+		eventmodels.TaskUpdated,
+		eventmodels.TaskStatusChanged,
+		eventmodels.TaskDeleted,
+	}, published)
+
+	count, err = storage.RelayOutboxEvents(10, publish)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	deleted, err := storage.DeletePublishedOutboxEvents(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+}
+
+func TestStorage_OutboxDeadEvent(t *testing.T) {
+	storage := NewInMemoryStorage()
+	require.NoError(t, storage.AddTask(taskmodels.Task{ID: "task1", UserID: "user1"}))
+	require.NoError(t, storage.MarkTaskToDelete("task1", "user1"))
+
+	var published []eventmodels.EventType
+	publish := func(event eventmodels.Event) error {
+		if event.Type == eventmodels.TaskCreated {
+			return errors.New("payload is rejected")
+		}
+		published = append(published, event.Type)
+		return nil
+	}
+
+	for range eventmodels.MaxPublishAttempts {
+		assert.Empty(t, published)
+		_, err := storage.RelayOutboxEvents(10, publish)
+		require.NoError(t, err)
+		retryNow(storage)
+	}
+
+	// Событие, которое так и не удалось опубликовать, остаётся в outbox и больше не держит очередь задачи.
+	count, err := storage.RelayOutboxEvents(10, publish)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []eventmodels.EventType{eventmodels.TaskDeleted}, published)
+
+	require.Len(t, storage.outbox, 2)
+	assert.Equal(t, eventmodels.MaxPublishAttempts, storage.outbox[0].attempts)
+	assert.Equal(t, "payload is rejected", storage.outbox[0].lastError)
+	assert.False(t, storage.outbox[0].deadAt.IsZero())
+}
+
+// retryNow - переносит отложенные попытки публикации на текущий момент.
+func retryNow(storage *Storage) {
+	storage.outboxMu.Lock()
+	defer storage.outboxMu.Unlock()
+
+	for i := range storage.outbox {
+		storage.outbox[i].nextAttemptAt = time.Time{}
+	}
+}
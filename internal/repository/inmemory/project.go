@@ -0,0 +1,105 @@
+package inmemory
+
+import (
+	"slices"
+	"sort"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+)
+
+func (storage *Storage) AddProject(project projectmodels.Project) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.projects[project.ID]; ok {
+		return projecterrors.ErrProjectIsAlreadyExist
+	}
+
+	project.Members = []string{project.OwnerID}
+	storage.projects[project.ID] = project
+	return nil
+}
+
+func (storage *Storage) GetProjectsByUser(userID string) ([]projectmodels.Project, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var projects []projectmodels.Project
+
+	for _, project := range storage.projects {
+		if slices.Contains(project.Members, userID) {
+			project.Members = nil
+			projects = append(projects, project)
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	return projects, nil
+}
+
+func (storage *Storage) GetProjectByID(projectID string) (projectmodels.Project, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	project, ok := storage.projects[projectID]
+	if !ok {
+		return projectmodels.Project{}, projecterrors.ErrProjectNotFound
+	}
+
+	project.Members = slices.Clone(project.Members)
+	return project, nil
+}
+
+func (storage *Storage) AddProjectMember(projectID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	project, ok := storage.projects[projectID]
+	if !ok {
+		return projecterrors.ErrProjectNotFound
+	}
+
+	if slices.Contains(project.Members, userID) {
+		return projecterrors.ErrMemberIsAlreadyExist
+	}
+
+	// Срез участников общий с копией транзакции, поэтому он не меняется на месте.
+	project.Members = append(slices.Clone(project.Members), userID)
+	slices.Sort(project.Members)
+	storage.projects[projectID] = project
+	return nil
+}
+
+func (storage *Storage) RemoveProjectMember(projectID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	project, ok := storage.projects[projectID]
+	if !ok {
+		return projecterrors.ErrProjectNotFound
+	}
+
+	idx := slices.Index(project.Members, userID)
+	if idx == -1 {
+		return projecterrors.ErrNotProjectMember
+	}
+
+	project.Members = slices.Delete(slices.Clone(project.Members), idx, idx+1)
+	storage.projects[projectID] = project
+	return nil
+}
+
+func (storage *Storage) IsProjectMember(projectID string, userID string) (bool, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	return storage.isProjectMember(projectID, userID), nil
+}
+
+// isProjectMember - вызывается под tasksMu.
+func (storage *Storage) isProjectMember(projectID string, userID string) bool {
+	return slices.Contains(storage.projects[projectID].Members, userID)
+}
@@ -0,0 +1,170 @@
+package inmemory
+
+import (
+	"testing"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_ProjectsAndAssignment(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	project := projectmodels.Project{ID: "p1", OwnerID: "owner", Name: "Backend"}
+	sharedTask := taskmodels.Task{
+		ID:     "task1",
+		UserID: "owner",
+		Attributes: taskmodels.TaskAttributes{
+			Status:      taskmodels.StatusNew,
+			Title:       "Shared",
+			Description: "Shared task",
+			ProjectID:   "p1",
+			AssigneeID:  "member",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		action      func() error
+		check       func(t *testing.T)
+		expectError error
+	}{
+		{
+			name: "AddProject_success",
+			action: func() error {
+				return storage.AddProject(project)
+			},
+			check: func(t *testing.T) {
+				isMember, _ := storage.IsProjectMember("p1", "owner")
+				assert.True(t, isMember)
+			},
+		},
+		{
+			name: "AddProject_duplicate",
+			action: func() error {
+				return storage.AddProject(project)
+			},
+			check:       func(_ *testing.T) {},
+			expectError: projecterrors.ErrProjectIsAlreadyExist,
+		},
+		{
+			name: "AddProjectMember_success",
+			action: func() error {
+				return storage.AddProjectMember("p1", "member")
+			},
+			check: func(t *testing.T) {
+				got, _ := storage.GetProjectByID("p1")
+				assert.Equal(t, []string{"member", "owner"}, got.Members)
+			},
+		},
+		{
+			name: "AddProjectMember_duplicate",
+			action: func() error {
+				return storage.AddProjectMember("p1", "member")
+			},
+			check:       func(_ *testing.T) {},
+			expectError: projecterrors.ErrMemberIsAlreadyExist,
+		},
+		{
+			name: "Shared_task_visible_to_member",
+			action: func() error {
+				return storage.AddTask(sharedTask)
+			},
+			check: func(t *testing.T) {
+				task, err := storage.GetTaskByID("task1", "member")
+				assert.NoError(t, err)
+				assert.Equal(t, "member", task.Attributes.AssigneeID)
+
+				_, err = storage.GetTaskByID("task1", "stranger")
+				assert.ErrorIs(t, err, taskerrors.ErrFoundNothing)
+			},
+		},
+		{
+			name: "FindTasks_by_assignee",
+			action: func() error {
+				_, err := storage.FindTasks("member", taskmodels.TaskFilter{AssigneeID: "member"})
+				return err
+			},
+			check: func(t *testing.T) {
+				tasks, _ := storage.FindTasks("member", taskmodels.TaskFilter{AssigneeID: "member"})
+				assert.Len(t, tasks, 1)
+
+				tasks, _ = storage.FindTasks("stranger", taskmodels.TaskFilter{AssigneeID: "member"})
+				assert.Empty(t, tasks)
+			},
+		},
+		{
+			name: "AddTaskWatcher_success",
+			action: func() error {
+				return storage.AddTaskWatcher("task1", "member")
+			},
+			check: func(t *testing.T) {
+				watchers, _ := storage.GetTaskWatchers("task1")
+				assert.Equal(t, []string{"member"}, watchers)
+			},
+		},
+		{
+			name: "AddTaskWatcher_duplicate",
+			action: func() error {
+				return storage.AddTaskWatcher("task1", "member")
+			},
+			check:       func(_ *testing.T) {},
+			expectError: taskerrors.ErrWatcherIsExist,
+		},
+		{
+			name: "RemoveTaskWatcher_success",
+			action: func() error {
+				return storage.RemoveTaskWatcher("task1", "member")
+			},
+			check: func(t *testing.T) {
+				watchers, _ := storage.GetTaskWatchers("task1")
+				assert.Empty(t, watchers)
+			},
+		},
+		{
+			name: "RemoveTaskWatcher_not_found",
+			action: func() error {
+				return storage.RemoveTaskWatcher("task1", "member")
+			},
+			check:       func(_ *testing.T) {},
+			expectError: taskerrors.ErrWatcherNotFound,
+		},
+		{
+			name: "RemoveProjectMember_hides_task",
+			action: func() error {
+				return storage.RemoveProjectMember("p1", "member")
+			},
+			check: func(t *testing.T) {
+				_, err := storage.GetTaskByID("task1", "member")
+				assert.ErrorIs(t, err, taskerrors.ErrFoundNothing)
+			},
+		},
+		{
+			name: "GetProjectsByUser",
+			action: func() error {
+				_, err := storage.GetProjectsByUser("owner")
+				return err
+			},
+			check: func(t *testing.T) {
+				projects, _ := storage.GetProjectsByUser("owner")
+				assert.Len(t, projects, 1)
+				assert.Equal(t, "Backend", projects[0].Name)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.action()
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			tc.check(t)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package inmemory
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/reminder/remindererrors"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+func (storage *Storage) AddReminder(reminder remindermodels.Reminder) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+	storage.remindersMu.Lock()
+	defer storage.remindersMu.Unlock()
+
+	if _, ok := storage.tasks[reminder.TaskID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+
+	storage.reminders[reminder.ID] = reminder
+	return nil
+}
+
+func (storage *Storage) GetTaskReminders(taskID string) ([]remindermodels.Reminder, error) {
+	storage.remindersMu.Lock()
+	defer storage.remindersMu.Unlock()
+
+	var reminders []remindermodels.Reminder
+	for _, reminder := range storage.reminders {
+		if reminder.TaskID == taskID {
+			reminders = append(reminders, reminder)
+		}
+	}
+
+	slices.SortFunc(reminders, func(a, b remindermodels.Reminder) int { return strings.Compare(a.ID, b.ID) })
+	return reminders, nil
+}
+
+func (storage *Storage) DeleteReminder(reminderID string, taskID string) error {
+	storage.remindersMu.Lock()
+	defer storage.remindersMu.Unlock()
+
+	reminder, ok := storage.reminders[reminderID]
+	if !ok || reminder.TaskID != taskID {
+		return remindererrors.ErrReminderNotFound
+	}
+
+	delete(storage.reminders, reminderID)
+	return nil
+}
+
+func (storage *Storage) CancelStaleReminders() (int64, error) {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+	storage.remindersMu.Lock()
+	defer storage.remindersMu.Unlock()
+
+	var cancelled int64
+	for id, reminder := range storage.reminders {
+		if reminder.Status != remindermodels.StatusPending {
+			continue
+		}
+
+		task, ok := storage.tasks[reminder.TaskID]
+		if ok && !task.Deleted && task.Category != taskmodels.CategoryDone &&
+			(reminder.At != nil || task.Attributes.DueDate != nil) {
+			continue
+		}
+
+		reminder.Status = remindermodels.StatusCancelled
+		storage.reminders[id] = reminder
+		cancelled++
+	}
+
+	return cancelled, nil
+}
+
+// FireDueReminders - отправка наступивших напоминаний, мьютекс заменяет блокировку строк. tasksMu
+// берётся, как и везде, раньше remindersMu и держится только пока выбираются напоминания: отправка
+// не должна останавливать работу с задачами.
+func (storage *Storage) FireDueReminders(
+	now time.Time,
+	limit int,
+	fire func(notification remindermodels.Notification) error,
+) (int, error) {
+	storage.tasksMu.Lock()
+	storage.remindersMu.Lock()
+	defer storage.remindersMu.Unlock()
+
+	due := storage.dueReminders(now, limit)
+	storage.tasksMu.Unlock()
+
+	fired := 0
+	for _, notification := range due {
+		reminder := storage.reminders[notification.ReminderID]
+		reminder.Attempts++
+
+		if err := fire(notification); err != nil {
+			if reminder.Attempts >= remindermodels.MaxAttempts {
+				reminder.Status = remindermodels.StatusFailed
+			}
+		} else {
+			fired++
+			sentAt := now
+			reminder.Status = remindermodels.StatusSent
+			reminder.SentAt = &sentAt
+		}
+
+		storage.reminders[notification.ReminderID] = reminder
+	}
+
+	return fired, nil
+}
+
+// dueReminders - до limit наступивших напоминаний по времени отправки. Вызывается под tasksMu
+// и remindersMu.
+func (storage *Storage) dueReminders(now time.Time, limit int) []remindermodels.Notification {
+	var due []remindermodels.Notification
+	for _, reminder := range storage.reminders {
+		task, ok := storage.tasks[reminder.TaskID]
+		if !ok || reminder.Status != remindermodels.StatusPending || task.Deleted ||
+			task.Category == taskmodels.CategoryDone {
+			continue
+		}
+
+		var fireAt time.Time
+		switch {
+		case reminder.At != nil:
+			fireAt = *reminder.At
+		case task.Attributes.DueDate != nil && reminder.BeforeMinutes != nil:
+			fireAt = task.Attributes.DueDate.Add(-time.Duration(*reminder.BeforeMinutes) * time.Minute)
+		default:
+			continue
+		}
+
+		if fireAt.After(now) {
+			continue
+		}
+
+		due = append(due, remindermodels.Notification{
+			ReminderID: reminder.ID,
+			TaskID:     task.ID,
+			UserID:     reminder.UserID,
+			TaskTitle:  task.Attributes.Title,
+			DueDate:    task.Attributes.DueDate,
+			FireAt:     fireAt,
+		})
+	}
+
+	slices.SortFunc(due, func(a, b remindermodels.Notification) int { return a.FireAt.Compare(b.FireAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due
+}
@@ -0,0 +1,162 @@
+package inmemory
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	"toDoList/internal/domain/reminder/remindererrors"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_Reminders(t *testing.T) {
+	storage := NewInMemoryStorage()
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	due := now.Add(time.Hour)
+
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID: "task1", UserID: "user1",
+		Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "a", DueDate: &due},
+	}))
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID: "task2", UserID: "user1", Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "b"},
+	}))
+
+	past := now.Add(-time.Minute)
+	hour := 60
+	day := 24 * 60
+	require.NoError(t, storage.AddReminder(remindermodels.Reminder{
+		ID: "r1", TaskID: "task1", UserID: "user1", BeforeMinutes: &day, Status: remindermodels.StatusPending,
+	}))
+	require.NoError(t, storage.AddReminder(remindermodels.Reminder{
+		ID: "r2", TaskID: "task1", UserID: "user1", BeforeMinutes: &hour, Status: remindermodels.StatusPending,
+	}))
+	require.NoError(t, storage.AddReminder(remindermodels.Reminder{
+		ID: "r3", TaskID: "task2", UserID: "user1", At: &past, Status: remindermodels.StatusPending,
+	}))
+
+	var fired []string
+	fire := func(n remindermodels.Notification) error {
+		fired = append(fired, n.ReminderID)
+		return nil
+	}
+
+	count, err := storage.FireDueReminders(now, 10, fire)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	// Порядок - по моменту срабатывания.
+	assert.Equal(t, []string{"r1", "r3", "r2"}, fired)
+
+	// Повторный проход ничего не отправляет.
+	fired = nil
+	count, err = storage.FireDueReminders(now.Add(time.Hour), 10, fire)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, fired)
+
+	reminders, err := storage.GetTaskReminders("task1")
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	assert.Equal(t, remindermodels.StatusSent, reminders[0].Status)
+
+	assert.ErrorIs(t, storage.DeleteReminder("r1", "task2"), remindererrors.ErrReminderNotFound)
+	assert.NoError(t, storage.DeleteReminder("r1", "task1"))
+}
+
+func TestStorage_FireDueRemindersRetries(t *testing.T) {
+	storage := NewInMemoryStorage()
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID: "task1", UserID: "user1", Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "a"},
+	}))
+	require.NoError(t, storage.AddReminder(remindermodels.Reminder{
+		ID: "r1", TaskID: "task1", UserID: "user1", At: &now, Status: remindermodels.StatusPending,
+	}))
+
+	failing := func(remindermodels.Notification) error { return errors.New("smtp is down") }
+	for range remindermodels.MaxAttempts {
+		count, err := storage.FireDueReminders(now, 10, failing)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	}
+
+	reminders, err := storage.GetTaskReminders("task1")
+	require.NoError(t, err)
+	assert.Equal(t, remindermodels.StatusFailed, reminders[0].Status)
+	assert.Equal(t, remindermodels.MaxAttempts, reminders[0].Attempts)
+}
+
+func TestStorage_CancelStaleReminders(t *testing.T) {
+	storage := NewInMemoryStorage()
+	due := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	minutes := 10
+
+	attrs := taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "a", DueDate: &due}
+	for _, id := range []string{"done", "deleted", "undated", "active"} {
+		require.NoError(t, storage.AddTask(taskmodels.Task{ID: id, UserID: "user1", Attributes: attrs}))
+		require.NoError(t, storage.AddReminder(remindermodels.Reminder{
+			ID: id, TaskID: id, UserID: "user1", BeforeMinutes: &minutes, Status: remindermodels.StatusPending,
+		}))
+	}
+
+	done := taskmodels.Task{ID: "done", UserID: "user1", Attributes: attrs}
+	done.Attributes.Status = taskmodels.StatusCompleted
+	done.Category = taskmodels.CategoryDone
+	require.NoError(t, storage.UpdateTaskAttributes(done))
+
+	undated := taskmodels.Task{ID: "undated", UserID: "user1", Attributes: attrs}
+	undated.Attributes.DueDate = nil
+	require.NoError(t, storage.UpdateTaskAttributes(undated))
+
+	require.NoError(t, storage.MarkTaskToDelete("deleted", "user1"))
+
+	cancelled, err := storage.CancelStaleReminders()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cancelled)
+
+	reminders, err := storage.GetTaskReminders("active")
+	require.NoError(t, err)
+	assert.Equal(t, remindermodels.StatusPending, reminders[0].Status)
+}
+
+func TestStorage_FireDueRemindersConcurrentWithAddTask(t *testing.T) {
+	storage := NewInMemoryStorage()
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+
+	require.NoError(t, storage.AddTask(taskmodels.Task{
+		ID: "task", UserID: "user1", Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "a"},
+	}))
+	require.NoError(t, storage.AddReminder(remindermodels.Reminder{
+		ID: "r1", TaskID: "task", UserID: "user1", At: &past, Status: remindermodels.StatusPending,
+	}))
+
+	// Гонку между чтением задач при выборе напоминаний и их добавлением ловит go test -race.
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := range 100 {
+			assert.NoError(t, storage.AddTask(taskmodels.Task{
+				ID: "task" + strconv.Itoa(i), UserID: "user1",
+				Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "b"},
+			}))
+		}
+	})
+	wg.Go(func() {
+		for range 100 {
+			_, err := storage.FireDueReminders(now, 10, func(remindermodels.Notification) error { return nil })
+			assert.NoError(t, err)
+		}
+	})
+	wg.Wait()
+
+	reminders, err := storage.GetTaskReminders("task")
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, remindermodels.StatusSent, reminders[0].Status)
+}
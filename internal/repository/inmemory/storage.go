@@ -1,18 +1,98 @@
 package inmemory
 
 import (
+	"sync"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/domain/tag/tagmodels"
 	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templatemodels"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
 	"toDoList/internal/domain/user/usermodels"
+	"toDoList/internal/domain/webhook/webhookmodels"
+	"toDoList/internal/domain/workflow/workflowmodels"
+	"toDoList/pkg/fulltext"
 )
 
 type Storage struct {
 	users map[string]usermodels.User
 	tasks map[string]taskmodels.Task
+	// tasksMu - проверка версии и запись задачи должны быть атомарными, а чтения не должны видеть
+	// задачу посреди записи. Под ним же меняются users.
+	tasksMu sync.RWMutex
+	// search - поисковый индекс по заголовкам и описаниям задач, меняется под tasksMu.
+	search *fulltext.Index
+	// history - записи истории по ID задачи, дописываются под tasksMu.
+	history map[string][]taskmodels.HistoryEntry
+	// comments - комментарии к задачам, меняются под tasksMu вместе с задачами.
+	comments map[string]commentmodels.Comment
+	// attachments - вложения задач, а orphanedBlobs - ключи содержимого удалённых вложений
+	// для сборщика. Меняются под tasksMu, потому что вложения удаляются вместе с задачами.
+	attachments   map[string]attachmentmodels.Attachment
+	orphanedBlobs []string
+	// customFields - определения пользовательских полей проектов, меняются под tasksMu: при удалении
+	// определения его значения удаляются из задач.
+	customFields map[string]customfieldmodels.CustomField
+	// timeEntries - записи времени и запущенные таймеры, меняются под tasksMu, потому что удаляются
+	// вместе с задачами.
+	timeEntries map[string]timeentrymodels.TimeEntry
+	// workflows - workflow проектов, меняются под tasksMu вместе с категориями статусов задач.
+	workflows map[string]workflowmodels.Workflow
+	// projects, watchers, assignments, tags, taskTags, blockers, filters и templates - меняются под
+	// tasksMu, чтобы InTx мог подменить их разом вместе с задачами.
+	projects    map[string]projectmodels.Project
+	watchers    map[string][]string
+	assignments map[string][]taskmodels.Assignment
+	tags        map[string]tagmodels.Tag
+	taskTags    map[string][]string
+	blockers    map[string][]string
+	filters     map[string]filtermodels.Filter
+	templates   map[string]templatemodels.Template
+	reminders   map[string]remindermodels.Reminder
+	// remindersMu - напоминания отправляет фоновый воркер параллельно с обработчиками.
+	remindersMu sync.Mutex
+	webhooks    map[string]webhookmodels.Webhook
+	deliveries  map[string]webhookmodels.Delivery
+	// webhooksMu - доставки вебхуков тоже обрабатывает фоновый воркер.
+	webhooksMu sync.Mutex
+	outbox     []outboxEntry
+	outboxSeq  int64
+	// outboxMu - события пишут обработчики, а публикует фоновый релей.
+	outboxMu sync.Mutex
+	// relayMu - события публикует только один релей за раз, иначе нарушится порядок.
+	relayMu     sync.Mutex
+	idempotency map[idempotencyKey]idempotencymodels.Record
+	// idempotencyMu - ключи пишет middleware параллельно для разных запросов.
+	idempotencyMu sync.Mutex
 }
 
 func NewInMemoryStorage() *Storage {
 	return &Storage{
-		users: make(map[string]usermodels.User),
-		tasks: make(map[string]taskmodels.Task),
+		users:        make(map[string]usermodels.User),
+		tasks:        make(map[string]taskmodels.Task),
+		search:       fulltext.NewIndex(),
+		history:      make(map[string][]taskmodels.HistoryEntry),
+		comments:     make(map[string]commentmodels.Comment),
+		attachments:  make(map[string]attachmentmodels.Attachment),
+		workflows:    make(map[string]workflowmodels.Workflow),
+		customFields: make(map[string]customfieldmodels.CustomField),
+		timeEntries:  make(map[string]timeentrymodels.TimeEntry),
+		projects:     make(map[string]projectmodels.Project),
+		watchers:     make(map[string][]string),
+		assignments:  make(map[string][]taskmodels.Assignment),
+		tags:         make(map[string]tagmodels.Tag),
+		taskTags:     make(map[string][]string),
+		blockers:     make(map[string][]string),
+		filters:      make(map[string]filtermodels.Filter),
+		templates:    make(map[string]templatemodels.Template),
+		reminders:    make(map[string]remindermodels.Reminder),
+		webhooks:     make(map[string]webhookmodels.Webhook),
+		deliveries:   make(map[string]webhookmodels.Delivery),
+		idempotency:  make(map[idempotencyKey]idempotencymodels.Record),
 	}
 }
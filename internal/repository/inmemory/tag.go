@@ -0,0 +1,148 @@
+package inmemory
+
+import (
+	"slices"
+	"sort"
+	"toDoList/internal/domain/tag/tagerrors"
+	"toDoList/internal/domain/tag/tagmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+func (storage *Storage) AddTag(tag tagmodels.Tag) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if storage.hasTagName(tag) {
+		return tagerrors.ErrTagIsAlreadyExist
+	}
+
+	storage.tags[tag.ID] = tag
+	for taskID, tagIDs := range storage.taskTags {
+		if slices.Contains(tagIDs, tag.ID) {
+			storage.bumpVersion(taskID)
+		}
+	}
+	return nil
+}
+
+func (storage *Storage) GetTagsByUser(userID string) ([]tagmodels.Tag, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var tags []tagmodels.Tag
+
+	for _, tag := range storage.tags {
+		if tag.UserID == userID {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	return tags, nil
+}
+
+func (storage *Storage) GetTagByID(tagID string, userID string) (tagmodels.Tag, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	return storage.tagByID(tagID, userID)
+}
+
+// tagByID - вызывается под tasksMu.
+func (storage *Storage) tagByID(tagID string, userID string) (tagmodels.Tag, error) {
+	tag, ok := storage.tags[tagID]
+	if !ok || tag.UserID != userID {
+		return tagmodels.Tag{}, tagerrors.ErrTagNotFound
+	}
+
+	return tag, nil
+}
+
+// UpdateTag - задачи хранят только ID тегов, поэтому новое имя сразу видно во всех задачах.
+func (storage *Storage) UpdateTag(tag tagmodels.Tag) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, err := storage.tagByID(tag.ID, tag.UserID); err != nil {
+		return err
+	}
+
+	if storage.hasTagName(tag) {
+		return tagerrors.ErrTagIsAlreadyExist
+	}
+
+	storage.tags[tag.ID] = tag
+	for taskID, tagIDs := range storage.taskTags {
+		if slices.Contains(tagIDs, tag.ID) {
+			storage.bumpVersion(taskID)
+		}
+	}
+	return nil
+}
+
+func (storage *Storage) DeleteTag(tagID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, err := storage.tagByID(tagID, userID); err != nil {
+		return err
+	}
+
+	delete(storage.tags, tagID)
+	for taskID, tagIDs := range storage.taskTags {
+		if !slices.Contains(tagIDs, tagID) {
+			continue
+		}
+		storage.taskTags[taskID] = slices.DeleteFunc(tagIDs, func(id string) bool {
+			return id == tagID
+		})
+		storage.bumpVersion(taskID)
+	}
+	return nil
+}
+
+// SetTaskTags - заменяет теги пользователя на задаче, чужие теги не трогает.
+func (storage *Storage) SetTaskTags(taskID string, userID string, tagIDs []string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.tasks[taskID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+
+	kept := slices.DeleteFunc(slices.Clone(storage.taskTags[taskID]), func(id string) bool {
+		return storage.tags[id].UserID == userID
+	})
+
+	storage.taskTags[taskID] = append(kept, tagIDs...)
+	storage.bumpVersion(taskID)
+	return nil
+}
+
+func (storage *Storage) withTags(task taskmodels.Task) taskmodels.Task {
+	task.Tags = nil
+	for _, tagID := range storage.taskTags[task.ID] {
+		if tag, ok := storage.tags[tagID]; ok {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	sort.Slice(task.Tags, func(i, j int) bool {
+		return task.Tags[i].Name < task.Tags[j].Name
+	})
+
+	return task
+}
+
+func (storage *Storage) hasTagName(tag tagmodels.Tag) bool {
+	for _, existing := range storage.tags {
+		if existing.ID != tag.ID && existing.UserID == tag.UserID && existing.Name == tag.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package inmemory
+
+import (
+	"testing"
+	"toDoList/internal/domain/tag/tagerrors"
+	"toDoList/internal/domain/tag/tagmodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_Tags(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	work := tagmodels.Tag{ID: "tag1", UserID: "user1", Name: "work", Color: "#0000ff"}
+	urgent := tagmodels.Tag{ID: "tag2", UserID: "user1", Name: "urgent", Color: "#ff0000"}
+
+	for _, id := range []string{"task1", "task2"} {
+		assert.NoError(t, storage.AddTask(taskmodels.Task{
+			ID:         id,
+			UserID:     "user1",
+			Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: id, Description: id},
+		}))
+	}
+
+	// versions - версии задач до действия: правка тега меняет представление задач с ним.
+	versions := make(map[string]int64)
+	saveVersions := func() {
+		for _, id := range []string{"task1", "task2"} {
+			task, _ := storage.GetTaskByID(id, "user1")
+			versions[id] = task.Version
+		}
+	}
+
+	tests := []struct {
+		name        string
+		action      func() error
+		check       func(t *testing.T)
+		expectError error
+	}{
+		{
+			name: "AddTag_success",
+			action: func() error {
+				if err := storage.AddTag(work); err != nil {
+					return err
+				}
+				return storage.AddTag(urgent)
+			},
+			check: func(t *testing.T) {
+				tags, _ := storage.GetTagsByUser("user1")
+				assert.Equal(t, []tagmodels.Tag{urgent, work}, tags)
+			},
+		},
+		{
+			name: "AddTag_duplicate_name",
+			action: func() error {
+				return storage.AddTag(tagmodels.Tag{ID: "tag3", UserID: "user1", Name: "work"})
+			},
+			check:       func(_ *testing.T) {},
+			expectError: tagerrors.ErrTagIsAlreadyExist,
+		},
+		{
+			name: "SetTaskTags",
+			action: func() error {
+				if err := storage.SetTaskTags("task1", "user1", []string{"tag1", "tag2"}); err != nil {
+					return err
+				}
+				return storage.SetTaskTags("task2", "user1", []string{"tag1"})
+			},
+			check: func(t *testing.T) {
+				task, _ := storage.GetTaskByID("task1", "user1")
+				assert.Equal(t, []tagmodels.Tag{urgent, work}, task.Tags)
+			},
+		},
+		{
+			name: "FindTasks_any_and_all",
+			action: func() error {
+				_, err := storage.FindTasks("user1", taskmodels.TaskFilter{Tags: []string{"work"}})
+				return err
+			},
+			check: func(t *testing.T) {
+				anyTasks, _ := storage.FindTasks("user1", taskmodels.TaskFilter{
+					Tags: []string{"work", "urgent"}, TagMode: taskmodels.TagModeAny,
+				})
+				assert.Len(t, anyTasks, 2)
+
+				allTasks, _ := storage.FindTasks("user1", taskmodels.TaskFilter{
+					Tags: []string{"work", "urgent"}, TagMode: taskmodels.TagModeAll,
+				})
+				assert.Len(t, allTasks, 1)
+				assert.Equal(t, "task1", allTasks[0].ID)
+			},
+		},
+		{
+			name: "UpdateTag_renames_everywhere",
+			action: func() error {
+				saveVersions()
+				work.Name = "job"
+				return storage.UpdateTag(work)
+			},
+			check: func(t *testing.T) {
+				for _, id := range []string{"task1", "task2"} {
+					task, _ := storage.GetTaskByID(id, "user1")
+					assert.Contains(t, task.Tags, work)
+					assert.Equal(t, versions[id]+1, task.Version)
+				}
+			},
+		},
+		{
+			name: "DeleteTag_detaches_from_tasks",
+			action: func() error {
+				saveVersions()
+				return storage.DeleteTag("tag2", "user1")
+			},
+			check: func(t *testing.T) {
+				task, _ := storage.GetTaskByID("task1", "user1")
+				assert.Equal(t, []tagmodels.Tag{work}, task.Tags)
+				assert.Equal(t, versions["task1"]+1, task.Version)
+
+				// Тега tag2 на task2 не было.
+				task, _ = storage.GetTaskByID("task2", "user1")
+				assert.Equal(t, versions["task2"], task.Version)
+			},
+		},
+		{
+			name: "DeleteTag_foreign",
+			action: func() error {
+				return storage.DeleteTag("tag1", "user2")
+			},
+			check:       func(_ *testing.T) {},
+			expectError: tagerrors.ErrTagNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.action()
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			tc.check(t)
+		})
+	}
+}
@@ -1,11 +1,19 @@
 package inmemory
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/tag/tagmodels"
 	"toDoList/internal/domain/task/taskerrors"
 	"toDoList/internal/domain/task/taskmodels"
 )
 
 func (storage *Storage) GetAllTasks(userID string) ([]taskmodels.Task, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
 	if len(storage.tasks) == 0 {
 		return []taskmodels.Task{}, taskerrors.ErrFoundNothing
 	}
@@ -14,7 +22,7 @@ func (storage *Storage) GetAllTasks(userID string) ([]taskmodels.Task, error) {
 
 	for _, userTasks := range storage.tasks {
 		if userTasks.UserID == userID {
-			tasks = append(tasks, userTasks)
+			tasks = append(tasks, storage.withRelations(userTasks))
 		}
 	}
 
@@ -22,52 +30,266 @@ func (storage *Storage) GetAllTasks(userID string) ([]taskmodels.Task, error) {
 		return []taskmodels.Task{}, taskerrors.ErrFoundNothing
 	}
 
+	sortByPosition(tasks)
 	return tasks, nil
 }
 func (storage *Storage) GetTaskByID(taskID string, userID string) (taskmodels.Task, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
 	if len(storage.tasks) == 0 {
 		return taskmodels.Task{}, taskerrors.ErrFoundNothing
 	}
 
-	var task taskmodels.Task
+	task, ok := storage.tasks[taskID]
+	if !ok || !storage.isTaskVisible(task, userID) {
+		return taskmodels.Task{}, taskerrors.ErrFoundNothing
+	}
 
-	for _, userTasks := range storage.tasks {
-		if userTasks.UserID == userID {
-			if userTasks.ID == taskID {
-				task = userTasks
-				return task, nil
-			}
+	return storage.withRelations(task), nil
+}
+
+// FindTasks - задачи, видимые пользователю и подходящие под фильтр.
+func (storage *Storage) FindTasks(userID string, filter taskmodels.TaskFilter) ([]taskmodels.Task, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var tasks []taskmodels.Task
+
+	for _, task := range storage.tasks {
+		if !storage.isTaskVisible(task, userID) {
+			continue
+		}
+		if filter.ProjectID != "" && task.Attributes.ProjectID != filter.ProjectID {
+			continue
+		}
+		if filter.AssigneeID != "" && task.Attributes.AssigneeID != filter.AssigneeID {
+			continue
+		}
+		task = storage.withRelations(task)
+		if len(filter.Tags) != 0 && !matchTags(task, userID, filter) {
+			continue
+		}
+		if !containsCustomFields(task.Attributes.CustomFields, filter.CustomFields) {
+			continue
 		}
+		tasks = append(tasks, task)
 	}
 
-	return taskmodels.Task{}, taskerrors.ErrFoundNothing
+	sortByPosition(tasks)
+	if filter.SortField != "" {
+		sortByCustomField(tasks, filter.SortField, filter.SortDesc)
+	}
+	return tasks, nil
+}
+
+// sortByPosition - тот же порядок, что и в БД: по рангу, затем по ID.
+func sortByPosition(tasks []taskmodels.Task) {
+	slices.SortFunc(tasks, func(a, b taskmodels.Task) int {
+		if c := strings.Compare(a.Position, b.Position); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// matchTags - проверка тегов задачи, учитываются только теги пользователя.
+func matchTags(task taskmodels.Task, userID string, filter taskmodels.TaskFilter) bool {
+	matched := 0
+	for _, name := range filter.Tags {
+		if slices.ContainsFunc(task.Tags, func(tag tagmodels.Tag) bool {
+			return tag.UserID == userID && tag.Name == name
+		}) {
+			matched++
+		}
+	}
+
+	if filter.TagMode == taskmodels.TagModeAll {
+		return matched == len(filter.Tags)
+	}
+	return matched > 0
+}
+
+// isTaskVisible - задача видна владельцу и участникам её проекта.
+func (storage *Storage) isTaskVisible(task taskmodels.Task, userID string) bool {
+	if task.UserID == userID {
+		return true
+	}
+	if task.Attributes.ProjectID == "" {
+		return false
+	}
+	return storage.isProjectMember(task.Attributes.ProjectID, userID)
 }
 
 func (storage *Storage) AddTask(newTask taskmodels.Task) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
 	for _, t := range storage.tasks {
 		if t.ID == newTask.ID {
 			return taskerrors.ErrTaskIsAlreadyExist
 		}
 	}
 
+	newTask.Version = 1
+	events, err := taskEvents(newTask, eventmodels.TaskCreated)
+	if err != nil {
+		return err
+	}
+
+	err = storage.recordHistory(taskmodels.HistoryCreated, actorOf(newTask), taskmodels.Task{}, newTask,
+		taskmodels.AllTaskFields)
+	if err != nil {
+		return err
+	}
+
+	newTask.ChangedBy = ""
 	storage.tasks[newTask.ID] = newTask
+	indexTask(storage.search, newTask)
+	storage.recordEvents(events...)
 	return nil
 }
 
+// UpdateTaskAttributes - ненулевая task.Version должна совпадать с текущей версией задачи.
 func (storage *Storage) UpdateTaskAttributes(task taskmodels.Task) error {
+	return storage.UpdateTaskFields(task, taskmodels.AllTaskFields)
+}
+
+func (storage *Storage) UpdateTaskFields(task taskmodels.Task, fields []taskmodels.TaskField) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
 	for _, t := range storage.tasks {
 		if t.ID == task.ID {
-			t.Attributes = task.Attributes //nolint:govet // Нам не важно, копия это или нет
-			storage.tasks[task.ID] = task
+			if task.Version != 0 && task.Version != t.Version {
+				return taskerrors.ErrVersionConflict
+			}
+
+			old := t
+			for _, field := range fields {
+				if err := copyTaskField(&t, task, field); err != nil {
+					return err
+				}
+			}
+			t.Version++
+
+			eventTypes := []eventmodels.EventType{eventmodels.TaskUpdated}
+			if t.Attributes.Status != old.Attributes.Status {
+				eventTypes = append(eventTypes, eventmodels.TaskStatusChanged)
+			}
+
+			events, err := taskEvents(t, eventTypes...)
+			if err != nil {
+				return err
+			}
+
+			err = storage.recordHistory(taskmodels.HistoryUpdated, actorOf(task), old, t, fields)
+			if err != nil {
+				return err
+			}
+
+			storage.tasks[task.ID] = t
+			indexTask(storage.search, t)
+			storage.recordEvents(events...)
 			return nil
 		}
 	}
 	return taskerrors.ErrFoundNothing
 }
+
+// copyTaskField - перенос одного поля из src в dst, аналог SET колонки в БД.
+func copyTaskField(dst *taskmodels.Task, src taskmodels.Task, field taskmodels.TaskField) error {
+	switch field {
+	case taskmodels.FieldStatus:
+		dst.Attributes.Status = src.Attributes.Status
+		dst.Category = src.Category
+	case taskmodels.FieldTitle:
+		dst.Attributes.Title = src.Attributes.Title
+	case taskmodels.FieldDescription:
+		dst.Attributes.Description = src.Attributes.Description
+	case taskmodels.FieldProjectID:
+		dst.Attributes.ProjectID = src.Attributes.ProjectID
+	case taskmodels.FieldAssigneeID:
+		dst.Attributes.AssigneeID = src.Attributes.AssigneeID
+	case taskmodels.FieldPriority:
+		dst.Attributes.Priority = src.Attributes.Priority
+	case taskmodels.FieldParentID:
+		dst.Attributes.ParentID = src.Attributes.ParentID
+	case taskmodels.FieldAutoComplete:
+		dst.Attributes.AutoComplete = src.Attributes.AutoComplete
+	case taskmodels.FieldDueDate:
+		dst.Attributes.DueDate = src.Attributes.DueDate
+	case taskmodels.FieldRRule:
+		dst.Attributes.RRule = src.Attributes.RRule
+	case taskmodels.FieldSeries:
+		dst.SeriesID = src.SeriesID
+		dst.Occurrence = src.Occurrence
+	case taskmodels.FieldCustomFields:
+		dst.Attributes.CustomFields = src.Attributes.CustomFields
+	case taskmodels.FieldEstimate:
+		dst.Attributes.Estimate = src.Attributes.Estimate
+	default:
+		return fmt.Errorf("unknown task field %q", field)
+	}
+	return nil
+}
+
+// GetLastTaskPosition - наибольший ранг среди задач пользователя.
+func (storage *Storage) GetLastTaskPosition(userID string) (string, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var last string
+	for _, t := range storage.tasks {
+		if t.UserID == userID && t.Position > last {
+			last = t.Position
+		}
+	}
+	return last, nil
+}
+
+func (storage *Storage) UpdateTaskPosition(taskID string, userID string, position string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	t, ok := storage.tasks[taskID]
+	if !ok || t.UserID != userID {
+		return taskerrors.ErrFoundNothing
+	}
+
+	t.Position = position
+	t.Version++
+
+	events, err := taskEvents(t, eventmodels.TaskUpdated)
+	if err != nil {
+		return err
+	}
+
+	storage.tasks[taskID] = t
+	storage.recordEvents(events...)
+	return nil
+}
+
 func (storage *Storage) DeleteTask(taskID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
 	for _, t := range storage.tasks {
 		if t.ID == taskID && t.UserID == userID {
-			delete(storage.tasks, t.ID)
+			events, err := taskEvents(t, eventmodels.TaskDeleted)
+			if err != nil {
+				return err
+			}
+
+			deleted := t
+			deleted.Version++
+			err = storage.recordHistory(taskmodels.HistoryDeleted, userID, t, deleted, nil)
+			if err != nil {
+				return err
+			}
+
+			storage.removeTask(t.ID)
+			storage.recordEvents(events...)
 			return nil
 		}
 	}
@@ -75,11 +297,139 @@ func (storage *Storage) DeleteTask(taskID string, userID string) error {
 	return taskerrors.ErrFoundNothing
 }
 
-//nolint:revive // Возможна будущая реализация, но нужно объявление для соблюдения интерфейса
+// MarkTaskToDelete - помечает задачу и все её подзадачи вместе с их комментариями.
 func (storage *Storage) MarkTaskToDelete(taskID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	task, ok := storage.tasks[taskID]
+	if !ok || task.UserID != userID {
+		return taskerrors.ErrFoundNothing
+	}
+
+	ids := append(storage.subtreeIDs(taskID), taskID)
+
+	var events []eventmodels.Event
+	for _, id := range ids {
+		t := storage.tasks[id]
+		t.Deleted = true
+		t.Version++
+
+		taskDeleted, err := taskEvents(t, eventmodels.TaskDeleted)
+		if err != nil {
+			return err
+		}
+		events = append(events, taskDeleted...)
+	}
+
+	for _, id := range ids {
+		t := storage.tasks[id]
+		deleted := t
+		deleted.Deleted = true
+		deleted.Version++
+		if err := storage.recordHistory(taskmodels.HistoryDeleted, userID, t, deleted, nil); err != nil {
+			return err
+		}
+		storage.tasks[id] = deleted
+		storage.markCommentsDeleted(id)
+	}
+	storage.recordEvents(events...)
 	return nil
 }
 
 func (storage *Storage) DeleteMarkedTasks() error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	for id, t := range storage.tasks {
+		if t.Deleted {
+			storage.removeTask(id)
+		}
+	}
+	return nil
+}
+
+// GetSubtasks - все неудалённые потомки задачи плоским списком.
+func (storage *Storage) GetSubtasks(taskID string) ([]taskmodels.Task, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var tasks []taskmodels.Task
+	for _, id := range storage.subtreeIDs(taskID) {
+		if t := storage.tasks[id]; !t.Deleted {
+			tasks = append(tasks, storage.withRelations(t))
+		}
+	}
+
+	sortByPosition(tasks)
+	return tasks, nil
+}
+
+// subtreeIDs - ID всех потомков задачи, без неё самой.
+func (storage *Storage) subtreeIDs(taskID string) []string {
+	var ids []string
+	parents := []string{taskID}
+	for len(parents) != 0 {
+		var next []string
+		for _, t := range storage.tasks {
+			if t.Attributes.ParentID != "" && slices.Contains(parents, t.Attributes.ParentID) {
+				ids = append(ids, t.ID)
+				next = append(next, t.ID)
+			}
+		}
+		parents = next
+	}
+	return ids
+}
+
+// GetSeriesTasks - неудалённые повторения серии по порядку.
+func (storage *Storage) GetSeriesTasks(seriesID string) ([]taskmodels.Task, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var tasks []taskmodels.Task
+	for _, t := range storage.tasks {
+		if t.SeriesID == seriesID && !t.Deleted {
+			tasks = append(tasks, storage.withRelations(t))
+		}
+	}
+
+	slices.SortFunc(tasks, func(a, b taskmodels.Task) int { return a.Occurrence - b.Occurrence })
+	return tasks, nil
+}
+
+// CountTasksInStatus - сколько неудалённых задач проекта в статусе, для WIP-лимитов.
+func (storage *Storage) CountTasksInStatus(projectID string, status taskmodels.TaskStatus) (int, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	count := 0
+	for _, t := range storage.tasks {
+		if t.Attributes.ProjectID == projectID && t.Attributes.Status == status && !t.Deleted {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (storage *Storage) UpdateTaskChecklist(taskID string, checklist []taskmodels.ChecklistItem) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	t, ok := storage.tasks[taskID]
+	if !ok {
+		return taskerrors.ErrFoundNothing
+	}
+
+	t.Checklist = slices.Clone(checklist)
+	t.Version++
+
+	events, err := taskEvents(t, eventmodels.TaskUpdated)
+	if err != nil {
+		return err
+	}
+
+	storage.tasks[taskID] = t
+	storage.recordEvents(events...)
 	return nil
 }
@@ -0,0 +1,63 @@
+package inmemory
+
+import (
+	"slices"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+func (storage *Storage) AddTaskAssignment(assignment taskmodels.Assignment) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.tasks[assignment.TaskID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+
+	storage.assignments[assignment.TaskID] = append(storage.assignments[assignment.TaskID], assignment)
+	return nil
+}
+
+func (storage *Storage) GetTaskAssignments(taskID string) ([]taskmodels.Assignment, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	return slices.Clone(storage.assignments[taskID]), nil
+}
+
+func (storage *Storage) AddTaskWatcher(taskID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.tasks[taskID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+
+	if slices.Contains(storage.watchers[taskID], userID) {
+		return taskerrors.ErrWatcherIsExist
+	}
+
+	storage.watchers[taskID] = append(storage.watchers[taskID], userID)
+	slices.Sort(storage.watchers[taskID])
+	return nil
+}
+
+func (storage *Storage) RemoveTaskWatcher(taskID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	idx := slices.Index(storage.watchers[taskID], userID)
+	if idx == -1 {
+		return taskerrors.ErrWatcherNotFound
+	}
+
+	storage.watchers[taskID] = slices.Delete(storage.watchers[taskID], idx, idx+1)
+	return nil
+}
+
+func (storage *Storage) GetTaskWatchers(taskID string) ([]string, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	return slices.Clone(storage.watchers[taskID]), nil
+}
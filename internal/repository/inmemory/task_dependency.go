@@ -0,0 +1,146 @@
+package inmemory
+
+import (
+	"slices"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+func (storage *Storage) AddTaskDependency(taskID string, blockerID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.tasks[taskID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+	if _, ok := storage.tasks[blockerID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+
+	if slices.Contains(storage.blockers[taskID], blockerID) {
+		return taskerrors.ErrDependencyIsExist
+	}
+
+	storage.blockers[taskID] = append(storage.blockers[taskID], blockerID)
+	storage.bumpVersion(taskID, blockerID)
+	return nil
+}
+
+func (storage *Storage) RemoveTaskDependency(taskID string, blockerID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	i := slices.Index(storage.blockers[taskID], blockerID)
+	if i == -1 {
+		return taskerrors.ErrDependencyNotFound
+	}
+
+	storage.blockers[taskID] = slices.Delete(storage.blockers[taskID], i, i+1)
+	storage.bumpVersion(taskID, blockerID)
+	return nil
+}
+
+// bumpVersion - связи входят в представление задачи, поэтому их изменение меняет её версию.
+func (storage *Storage) bumpVersion(taskIDs ...string) {
+	for _, taskID := range taskIDs {
+		if t, ok := storage.tasks[taskID]; ok {
+			t.Version++
+			storage.tasks[taskID] = t
+		}
+	}
+}
+
+// HasDependencyPath - зависит ли задача fromID от toID напрямую или через цепочку блокеров.
+func (storage *Storage) HasDependencyPath(fromID string, toID string) (bool, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	visited := map[string]bool{fromID: true}
+	queue := []string{fromID}
+
+	for len(queue) != 0 {
+		taskID := queue[0]
+		queue = queue[1:]
+
+		for _, blockerID := range storage.blockers[taskID] {
+			if blockerID == toID {
+				return true, nil
+			}
+			if !visited[blockerID] {
+				visited[blockerID] = true
+				queue = append(queue, blockerID)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (storage *Storage) GetUnfinishedBlockers(taskID string) ([]string, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var unfinished []string
+	for _, blockerID := range storage.blockers[taskID] {
+		blocker, ok := storage.tasks[blockerID]
+		if ok && !blocker.Deleted && blocker.Category != taskmodels.CategoryDone {
+			unfinished = append(unfinished, blockerID)
+		}
+	}
+
+	slices.Sort(unfinished)
+	return unfinished, nil
+}
+
+// withRelations - задача вместе с тегами и зависимостями, как её отдаёт БД.
+func (storage *Storage) withRelations(task taskmodels.Task) taskmodels.Task {
+	task = storage.withTags(task)
+
+	task.BlockedBy = slices.Sorted(slices.Values(storage.blockers[task.ID]))
+	task.Blocking = nil
+	for taskID, blockers := range storage.blockers {
+		if slices.Contains(blockers, task.ID) {
+			task.Blocking = append(task.Blocking, taskID)
+		}
+	}
+	slices.Sort(task.Blocking)
+
+	return task
+}
+
+// removeTask - удаление задачи вместе с зависимостями, комментариями, вложениями, записями времени
+// и напоминаниями, аналог ON DELETE CASCADE.
+func (storage *Storage) removeTask(taskID string) {
+	delete(storage.tasks, taskID)
+	storage.search.Remove(taskID)
+	delete(storage.blockers, taskID)
+	for id, blockers := range storage.blockers {
+		storage.blockers[id] = slices.DeleteFunc(blockers, func(blockerID string) bool {
+			return blockerID == taskID
+		})
+	}
+
+	for id, comment := range storage.comments {
+		if comment.TaskID == taskID {
+			delete(storage.comments, id)
+		}
+	}
+	for _, attachment := range storage.attachments {
+		if attachment.TaskID == taskID {
+			storage.removeAttachment(attachment)
+		}
+	}
+	for id, entry := range storage.timeEntries {
+		if entry.TaskID == taskID {
+			delete(storage.timeEntries, id)
+		}
+	}
+
+	storage.remindersMu.Lock()
+	defer storage.remindersMu.Unlock()
+	for id, reminder := range storage.reminders {
+		if reminder.TaskID == taskID {
+			delete(storage.reminders, id)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package inmemory
+
+import (
+	"slices"
+	"time"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/google/uuid"
+)
+
+// actorOf - кто меняет задачу: task.ChangedBy, а если он не задан - владелец.
+func actorOf(task taskmodels.Task) string {
+	if task.ChangedBy != "" {
+		return task.ChangedBy
+	}
+	return task.UserID
+}
+
+// recordHistory - вызывается под tasksMu вместе с изменением задачи.
+func (storage *Storage) recordHistory(
+	action taskmodels.HistoryAction,
+	actorID string,
+	old taskmodels.Task,
+	updated taskmodels.Task,
+	fields []taskmodels.TaskField,
+) error {
+	entry, err := taskmodels.NewHistoryEntry(action, actorID, old, updated, fields, time.Now())
+	if err != nil {
+		return err
+	}
+
+	entry.ID = uuid.New().String()
+	storage.history[entry.TaskID] = append(storage.history[entry.TaskID], entry)
+	return nil
+}
+
+// GetTaskHistory - история задачи по возрастанию ревизий.
+func (storage *Storage) GetTaskHistory(taskID string) ([]taskmodels.HistoryEntry, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	return slices.Clone(storage.history[taskID]), nil
+}
@@ -0,0 +1,51 @@
+package inmemory
+
+import (
+	"encoding/json"
+	"testing"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_TaskHistory(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	task := taskmodels.Task{
+		ID: "task1", UserID: "user1",
+		Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "Invoices", Description: "March"},
+	}
+	require.NoError(t, storage.AddTask(task))
+
+	task.Version = 1
+	task.ChangedBy = "user2"
+	task.Attributes.Status = taskmodels.StatusInProgress
+	require.NoError(t, storage.UpdateTaskAttributes(task))
+	require.NoError(t, storage.MarkTaskToDelete("task1", "user1"))
+
+	history, err := storage.GetTaskHistory("task1")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, taskmodels.HistoryCreated, history[0].Action)
+	assert.Equal(t, int64(1), history[0].Revision)
+	assert.Equal(t, "user1", history[0].ActorID)
+
+	assert.Equal(t, taskmodels.HistoryUpdated, history[1].Action)
+	assert.Equal(t, int64(2), history[1].Revision)
+	assert.Equal(t, "user2", history[1].ActorID)
+	assert.Equal(t, []taskmodels.FieldChange{{
+		Field:  taskmodels.FieldStatus,
+		Before: json.RawMessage(`"New"`),
+		After:  json.RawMessage(`"In Progress"`),
+	}}, history[1].Changes)
+
+	assert.Equal(t, taskmodels.HistoryDeleted, history[2].Action)
+	assert.Equal(t, int64(3), history[2].Revision)
+	assert.Empty(t, history[2].Changes)
+
+	stored, err := storage.GetTaskByID("task1", "user1")
+	require.NoError(t, err)
+	assert.Empty(t, stored.ChangedBy)
+}
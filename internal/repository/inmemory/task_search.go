@@ -0,0 +1,63 @@
+package inmemory
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/pkg/fulltext"
+)
+
+// Веса совпадений в заголовке и описании, как у весов A и B в ts_rank.
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+)
+
+// indexTask - обновляет термы задачи в поисковом индексе, вызывается под tasksMu.
+func indexTask(index *fulltext.Index, task taskmodels.Task) {
+	index.Add(
+		task.ID,
+		fulltext.Field{Text: task.Attributes.Title, Weight: titleWeight},
+		fulltext.Field{Text: task.Attributes.Description, Weight: descriptionWeight},
+	)
+}
+
+// SearchTasks - поиск по инвертированному индексу, когда Postgres недоступен. Ранги отличаются
+// от ts_rank по величине, но порядок результатов тот же: заголовок важнее описания.
+func (storage *Storage) SearchTasks(userID string, query taskmodels.SearchQuery) ([]taskmodels.SearchResult, error) {
+	parsed := fulltext.ParseQuery(query.Query, string(query.Language))
+
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	found := storage.search.Search(parsed)
+	results := make([]taskmodels.SearchResult, 0, len(found))
+	for taskID := range found {
+		task := storage.tasks[taskID]
+		if task.Deleted || !storage.isTaskVisible(task, userID) {
+			continue
+		}
+
+		results = append(results, taskmodels.SearchResult{
+			Task: storage.withRelations(task),
+			Rank: found[task.ID],
+			Title: fulltext.Highlight(task.Attributes.Title, parsed, 0,
+				taskmodels.HighlightStart, taskmodels.HighlightStop),
+			Snippet: fulltext.Highlight(task.Attributes.Description, parsed, taskmodels.SearchSnippetWords,
+				taskmodels.HighlightStart, taskmodels.HighlightStop),
+		})
+	}
+
+	slices.SortFunc(results, func(a, b taskmodels.SearchResult) int {
+		if c := cmp.Compare(b.Rank, a.Rank); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Task.ID, b.Task.ID)
+	})
+
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
@@ -0,0 +1,76 @@
+package inmemory
+
+import (
+	"errors"
+	"testing"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_SearchTasks(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	add := func(id string, title string, description string) {
+		require.NoError(t, storage.AddTask(taskmodels.Task{
+			ID: id, UserID: "u1",
+			Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: title, Description: description},
+		}))
+	}
+	add("t1", "Call accounting", "Ask about the March invoice")
+	add("t2", "Pay invoices", "Before Friday")
+	add("t3", "Оплатить счета", "Счёт от поставщика за март")
+	add("t4", "Invoices", "Someone else's task")
+
+	task4, err := storage.GetTaskByID("t4", "u1")
+	require.NoError(t, err)
+	task4.UserID = "u2"
+	storage.tasks["t4"] = task4
+
+	search := func(query string, language taskmodels.SearchLanguage) []string {
+		results, errSearch := storage.SearchTasks("u1", taskmodels.SearchQuery{Query: query, Language: language})
+		require.NoError(t, errSearch)
+		var ids []string
+		for _, result := range results {
+			ids = append(ids, result.Task.ID)
+		}
+		return ids
+	}
+
+	// Совпадение в заголовке ранжируется выше совпадения в описании.
+	assert.Equal(t, []string{"t2", "t1"}, search("invoice", taskmodels.SearchEnglish))
+	assert.Equal(t, []string{"t3"}, search("счёт", taskmodels.SearchRussian))
+	assert.Equal(t, []string{"t1", "t3"}, search("march or март", taskmodels.SearchRussian))
+
+	results, err := storage.SearchTasks("u1", taskmodels.SearchQuery{
+		Query: "invoices", Language: taskmodels.SearchEnglish, Limit: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Pay <mark>invoices</mark>", results[0].Title)
+	assert.Equal(t, "Before Friday", results[0].Snippet)
+
+	// Изменения и удаление задачи попадают в индекс, откат транзакции восстанавливает его.
+	task, err := storage.GetTaskByID("t2", "u1")
+	require.NoError(t, err)
+	task.Attributes.Title = "Pay rent"
+	require.NoError(t, storage.UpdateTaskFields(task, []taskmodels.TaskField{taskmodels.FieldTitle}))
+	assert.Equal(t, []string{"t1"}, search("invoice", taskmodels.SearchEnglish))
+
+	errRollback := errors.New("rollback")
+	err = storage.InTx(func(tx *Storage) error {
+		require.NoError(t, tx.DeleteTask("t1", "u1"))
+		results, errSearch := tx.SearchTasks("u1", taskmodels.SearchQuery{
+			Query: "invoice", Language: taskmodels.SearchEnglish,
+		})
+		require.NoError(t, errSearch)
+		assert.Empty(t, results)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	assert.Equal(t, []string{"t1"}, search("invoice", taskmodels.SearchEnglish))
+
+	require.NoError(t, storage.MarkTaskToDelete("t1", "u1"))
+	assert.Empty(t, search("invoice", taskmodels.SearchEnglish))
+}
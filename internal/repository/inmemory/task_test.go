@@ -1,6 +1,8 @@
 package inmemory
 
 import (
+	"strconv"
+	"sync"
 	"testing"
 	"toDoList/internal/domain/task/taskerrors"
 	"toDoList/internal/domain/task/taskmodels"
@@ -129,12 +131,12 @@ func TestStorage_Tasks(t *testing.T) {
 			expectError: taskerrors.ErrFoundNothing,
 		},
 		{
-			name: "MarkTaskToDelete",
+			name: "MarkTaskToDelete_not_found",
 			action: func() error {
 				return storage.MarkTaskToDelete("task2", "user2")
 			},
 			check:       func(_ *testing.T) {},
-			expectError: nil,
+			expectError: taskerrors.ErrFoundNothing,
 		},
 		{
 			name: "DeleteMarkedTasks",
@@ -158,3 +160,184 @@ func TestStorage_Tasks(t *testing.T) {
 		})
 	}
 }
+
+func TestStorage_TaskPositions(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	for id, position := range map[string]string{"t1": "b", "t2": "a", "t3": "c", "t4": "c"} {
+		assert.NoError(t, storage.AddTask(taskmodels.Task{ID: id, UserID: "user1", Position: position}))
+	}
+
+	last, err := storage.GetLastTaskPosition("user1")
+	assert.NoError(t, err)
+	assert.Equal(t, "c", last)
+
+	assert.NoError(t, storage.UpdateTaskPosition("t3", "user1", "0i"))
+	assert.ErrorIs(t, storage.UpdateTaskPosition("t3", "user2", "d"), taskerrors.ErrFoundNothing)
+
+	// Порядок стабилен между вызовами: по рангу, при равных рангах - по ID.
+	for range 5 {
+		tasks, errGet := storage.GetAllTasks("user1")
+		assert.NoError(t, errGet)
+
+		ids := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		assert.Equal(t, []string{"t3", "t2", "t1", "t4"}, ids)
+	}
+}
+
+func TestStorage_TaskVersions(t *testing.T) {
+	storage := NewInMemoryStorage()
+	assert.NoError(t, storage.AddTask(taskmodels.Task{ID: "t1", UserID: "user1"}))
+	assert.NoError(t, storage.AddTask(taskmodels.Task{ID: "t2", UserID: "user1"}))
+
+	task, err := storage.GetTaskByID("t1", "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), task.Version)
+
+	task.Attributes.Title = "first"
+	assert.NoError(t, storage.UpdateTaskAttributes(task))
+
+	// Второй клиент прочитал задачу до первого изменения.
+	task.Attributes.Title = "second"
+	assert.ErrorIs(t, storage.UpdateTaskAttributes(task), taskerrors.ErrVersionConflict)
+
+	assert.NoError(t, storage.AddTaskDependency("t1", "t2"))
+	assert.NoError(t, storage.UpdateTaskPosition("t1", "user1", "b"))
+
+	task, err = storage.GetTaskByID("t1", "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", task.Attributes.Title)
+	assert.Equal(t, int64(4), task.Version)
+
+	blocker, err := storage.GetTaskByID("t2", "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), blocker.Version)
+}
+
+func TestStorage_Subtasks(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	tasks := []taskmodels.Task{
+		{ID: "root", UserID: "user1", Position: "a"},
+		{ID: "child1", UserID: "user1", Position: "b", Attributes: taskmodels.TaskAttributes{ParentID: "root"}},
+		{ID: "child2", UserID: "user1", Position: "c", Attributes: taskmodels.TaskAttributes{ParentID: "root"}},
+		{ID: "grandchild", UserID: "user1", Position: "d", Attributes: taskmodels.TaskAttributes{ParentID: "child1"}},
+		{ID: "other", UserID: "user1", Position: "e"},
+	}
+	for _, task := range tasks {
+		assert.NoError(t, storage.AddTask(task))
+	}
+
+	subtasks, err := storage.GetSubtasks("root")
+	assert.NoError(t, err)
+	assert.Len(t, subtasks, 3)
+	assert.Equal(t, "child1", subtasks[0].ID)
+
+	assert.NoError(t, storage.UpdateTaskChecklist("child2", []taskmodels.ChecklistItem{{ID: "i1", Title: "step"}}))
+	task, _ := storage.GetTaskByID("child2", "user1")
+	assert.Len(t, task.Checklist, 1)
+
+	assert.ErrorIs(t, storage.MarkTaskToDelete("root", "user2"), taskerrors.ErrFoundNothing)
+	assert.NoError(t, storage.MarkTaskToDelete("child1", "user1"))
+
+	subtasks, _ = storage.GetSubtasks("root")
+	assert.Len(t, subtasks, 1)
+	assert.Equal(t, "child2", subtasks[0].ID)
+
+	assert.NoError(t, storage.DeleteMarkedTasks())
+	remaining, _ := storage.GetAllTasks("user1")
+	assert.Len(t, remaining, 3)
+}
+
+func TestStorage_TaskDependencies(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	for _, id := range []string{"a", "b", "c"} {
+		assert.NoError(t, storage.AddTask(taskmodels.Task{
+			ID: id, UserID: "user1", Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew},
+		}))
+	}
+
+	assert.NoError(t, storage.AddTaskDependency("a", "b"))
+	assert.NoError(t, storage.AddTaskDependency("b", "c"))
+	assert.ErrorIs(t, storage.AddTaskDependency("a", "b"), taskerrors.ErrDependencyIsExist)
+	assert.ErrorIs(t, storage.AddTaskDependency("a", "404"), taskerrors.ErrFoundNothing)
+
+	hasPath, _ := storage.HasDependencyPath("a", "c")
+	assert.True(t, hasPath)
+	hasPath, _ = storage.HasDependencyPath("c", "a")
+	assert.False(t, hasPath)
+
+	task, _ := storage.GetTaskByID("b", "user1")
+	assert.Equal(t, []string{"c"}, task.BlockedBy)
+	assert.Equal(t, []string{"a"}, task.Blocking)
+
+	unfinished, _ := storage.GetUnfinishedBlockers("a")
+	assert.Equal(t, []string{"b"}, unfinished)
+
+	assert.NoError(t, storage.DeleteTask("b", "user1"))
+	task, _ = storage.GetTaskByID("a", "user1")
+	assert.Empty(t, task.BlockedBy)
+	assert.ErrorIs(t, storage.RemoveTaskDependency("a", "b"), taskerrors.ErrDependencyNotFound)
+}
+
+func TestStorage_GetSeriesTasks(t *testing.T) {
+	storage := NewInMemoryStorage()
+
+	assert.NoError(t, storage.AddTask(taskmodels.Task{ID: "t2", UserID: "user1", SeriesID: "t1", Occurrence: 2}))
+	assert.NoError(t, storage.AddTask(taskmodels.Task{ID: "t1", UserID: "user1", SeriesID: "t1", Occurrence: 1}))
+	assert.NoError(t, storage.AddTask(taskmodels.Task{ID: "t3", UserID: "user1", SeriesID: "t1", Occurrence: 3}))
+	assert.NoError(t, storage.AddTask(taskmodels.Task{ID: "other", UserID: "user1"}))
+	assert.NoError(t, storage.MarkTaskToDelete("t3", "user1"))
+
+	series, err := storage.GetSeriesTasks("t1")
+	assert.NoError(t, err)
+	assert.Len(t, series, 2)
+	assert.Equal(t, "t1", series[0].ID)
+	assert.Equal(t, "t2", series[1].ID)
+}
+
+func TestStorage_ReadTasksConcurrentWithWrites(t *testing.T) {
+	storage := NewInMemoryStorage()
+	task := taskmodels.Task{
+		ID: "task", UserID: "user1", Attributes: taskmodels.TaskAttributes{Title: "a", Status: taskmodels.StatusNew},
+	}
+	assert.NoError(t, storage.AddTask(task))
+
+	// Чтения идут параллельно с записями, гонки ловит go test -race.
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := range 100 {
+			assert.NoError(t, storage.AddTask(taskmodels.Task{
+				ID: "task" + strconv.Itoa(i), UserID: "user1", Attributes: task.Attributes,
+			}))
+			updated := task
+			updated.Attributes.Title = strconv.Itoa(i)
+			assert.NoError(t, storage.UpdateTaskAttributes(updated))
+		}
+	})
+	wg.Go(func() {
+		for range 100 {
+			_, err := storage.GetAllTasks("user1")
+			assert.NoError(t, err)
+			_, err = storage.GetTaskByID("task", "user1")
+			assert.NoError(t, err)
+			_, err = storage.FindTasks("user1", taskmodels.TaskFilter{})
+			assert.NoError(t, err)
+			_, err = storage.GetLastTaskPosition("user1")
+			assert.NoError(t, err)
+			_, err = storage.GetSubtasks("task")
+			assert.NoError(t, err)
+			_, err = storage.GetSeriesTasks("series")
+			assert.NoError(t, err)
+		}
+	})
+	wg.Wait()
+
+	tasks, err := storage.GetAllTasks("user1")
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 101)
+}
@@ -0,0 +1,77 @@
+package inmemory
+
+import (
+	"slices"
+	"strings"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+)
+
+func (storage *Storage) AddTemplate(template templatemodels.Template) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	for _, t := range storage.templates {
+		if t.UserID == template.UserID && t.Name == template.Name {
+			return templateerrors.ErrTemplateIsAlreadyExist
+		}
+	}
+
+	storage.templates[template.ID] = template
+	return nil
+}
+
+// GetTemplatesByUser - личные шаблоны пользователя и шаблоны его проектов.
+func (storage *Storage) GetTemplatesByUser(userID string) ([]templatemodels.Template, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var templates []templatemodels.Template
+	for _, template := range storage.templates {
+		if storage.isTemplateVisible(template, userID) {
+			templates = append(templates, template)
+		}
+	}
+
+	slices.SortFunc(templates, func(a, b templatemodels.Template) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return templates, nil
+}
+
+func (storage *Storage) GetTemplateByID(templateID string, userID string) (templatemodels.Template, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	template, ok := storage.templates[templateID]
+	if !ok || !storage.isTemplateVisible(template, userID) {
+		return templatemodels.Template{}, templateerrors.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (storage *Storage) DeleteTemplate(templateID string, userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	template, ok := storage.templates[templateID]
+	if !ok || template.UserID != userID {
+		return templateerrors.ErrTemplateNotFound
+	}
+
+	delete(storage.templates, templateID)
+	return nil
+}
+
+func (storage *Storage) isTemplateVisible(template templatemodels.Template, userID string) bool {
+	if template.UserID == userID {
+		return true
+	}
+	if template.ProjectID == "" {
+		return false
+	}
+	return storage.isProjectMember(template.ProjectID, userID)
+}
@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"testing"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_Templates(t *testing.T) {
+	storage := NewInMemoryStorage()
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend"}))
+	require.NoError(t, storage.AddProjectMember("p1", "u2"))
+
+	for _, template := range []templatemodels.Template{
+		{ID: "tpl1", UserID: "u1", Name: "Release", ProjectID: "p1"},
+		{ID: "tpl2", UserID: "u1", Name: "Personal"},
+		{ID: "tpl3", UserID: "u2", Name: "Release"},
+	} {
+		require.NoError(t, storage.AddTemplate(template))
+	}
+	err := storage.AddTemplate(templatemodels.Template{ID: "tpl4", UserID: "u1", Name: "Release"})
+	assert.ErrorIs(t, err, templateerrors.ErrTemplateIsAlreadyExist)
+
+	templateIDs := func(userID string) []string {
+		templates, errGet := storage.GetTemplatesByUser(userID)
+		require.NoError(t, errGet)
+		var ids []string
+		for _, template := range templates {
+			ids = append(ids, template.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"tpl2", "tpl1"}, templateIDs("u1"))
+	assert.Equal(t, []string{"tpl1", "tpl3"}, templateIDs("u2"))
+	assert.Nil(t, templateIDs("u3"))
+
+	_, err = storage.GetTemplateByID("tpl1", "u2")
+	require.NoError(t, err)
+	_, err = storage.GetTemplateByID("tpl2", "u2")
+	assert.ErrorIs(t, err, templateerrors.ErrTemplateNotFound)
+
+	// Участник проекта видит шаблон, но удалить его может только автор.
+	assert.ErrorIs(t, storage.DeleteTemplate("tpl1", "u2"), templateerrors.ErrTemplateNotFound)
+	require.NoError(t, storage.DeleteTemplate("tpl1", "u1"))
+	assert.Equal(t, []string{"tpl3"}, templateIDs("u2"))
+}
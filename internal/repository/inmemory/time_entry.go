@@ -0,0 +1,158 @@
+package inmemory
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/timeentry/timeentryerrors"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
+)
+
+func (storage *Storage) AddTimeEntry(entry timeentrymodels.TimeEntry) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.tasks[entry.TaskID]; !ok {
+		return taskerrors.ErrFoundNothing
+	}
+	if entry.IsRunning() {
+		if _, ok := storage.runningTimer(entry.UserID); ok {
+			return timeentryerrors.ErrTimerAlreadyRunning
+		}
+	}
+
+	storage.timeEntries[entry.ID] = entry
+	return nil
+}
+
+// runningTimer - вызывается под tasksMu.
+func (storage *Storage) runningTimer(userID string) (timeentrymodels.TimeEntry, bool) {
+	for _, entry := range storage.timeEntries {
+		if entry.UserID == userID && entry.IsRunning() {
+			return entry, true
+		}
+	}
+	return timeentrymodels.TimeEntry{}, false
+}
+
+func (storage *Storage) GetRunningTimer(userID string) (timeentrymodels.TimeEntry, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	entry, ok := storage.runningTimer(userID)
+	if !ok {
+		return timeentrymodels.TimeEntry{}, timeentryerrors.ErrTimerNotRunning
+	}
+	return entry, nil
+}
+
+func (storage *Storage) StopTimer(userID string, taskID string, endedAt time.Time) (
+	timeentrymodels.TimeEntry, error,
+) {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	entry, ok := storage.runningTimer(userID)
+	if !ok || entry.TaskID != taskID {
+		return timeentrymodels.TimeEntry{}, timeentryerrors.ErrTimerNotRunning
+	}
+
+	if endedAt.Before(entry.StartedAt) {
+		endedAt = entry.StartedAt
+	}
+	entry.EndedAt = &endedAt
+	storage.timeEntries[entry.ID] = entry
+	return entry, nil
+}
+
+func (storage *Storage) GetTaskTimeEntries(taskID string) ([]timeentrymodels.TimeEntry, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var entries []timeentrymodels.TimeEntry
+	for _, entry := range storage.timeEntries {
+		if entry.TaskID == taskID {
+			entries = append(entries, entry)
+		}
+	}
+
+	sortTimeEntries(entries, func(e timeentrymodels.TimeEntry) timeentrymodels.TimeEntry { return e })
+	return entries, nil
+}
+
+func (storage *Storage) GetTimeEntryByID(entryID string, taskID string) (timeentrymodels.TimeEntry, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	entry, ok := storage.timeEntries[entryID]
+	if !ok || entry.TaskID != taskID {
+		return timeentrymodels.TimeEntry{}, timeentryerrors.ErrTimeEntryNotFound
+	}
+	return entry, nil
+}
+
+func (storage *Storage) UpdateTimeEntry(entry timeentrymodels.TimeEntry) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	stored, ok := storage.timeEntries[entry.ID]
+	if !ok || stored.TaskID != entry.TaskID || stored.IsRunning() {
+		return timeentryerrors.ErrTimeEntryNotFound
+	}
+
+	stored.StartedAt, stored.EndedAt, stored.Note = entry.StartedAt, entry.EndedAt, entry.Note
+	storage.timeEntries[entry.ID] = stored
+	return nil
+}
+
+func (storage *Storage) DeleteTimeEntry(entryID string, taskID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	entry, ok := storage.timeEntries[entryID]
+	if !ok || entry.TaskID != taskID {
+		return timeentryerrors.ErrTimeEntryNotFound
+	}
+
+	delete(storage.timeEntries, entryID)
+	return nil
+}
+
+func (storage *Storage) GetReportEntries(userID string, from time.Time, to time.Time) (
+	[]timeentrymodels.ReportEntry, error,
+) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	var entries []timeentrymodels.ReportEntry
+	for _, entry := range storage.timeEntries {
+		if entry.StartedAt.Before(from) || !entry.StartedAt.Before(to) {
+			continue
+		}
+		task, ok := storage.tasks[entry.TaskID]
+		if !ok || task.Deleted || !storage.isTaskVisible(task, userID) {
+			continue
+		}
+		entries = append(entries, timeentrymodels.ReportEntry{
+			TimeEntry:    entry,
+			ProjectID:    task.Attributes.ProjectID,
+			TaskTitle:    task.Attributes.Title,
+			TaskEstimate: task.Attributes.Estimate,
+		})
+	}
+
+	sortTimeEntries(entries, func(e timeentrymodels.ReportEntry) timeentrymodels.TimeEntry { return e.TimeEntry })
+	return entries, nil
+}
+
+// sortTimeEntries - тот же порядок, что и в БД: по началу, затем по ID.
+func sortTimeEntries[E any](entries []E, entry func(E) timeentrymodels.TimeEntry) {
+	slices.SortFunc(entries, func(a, b E) int {
+		ea, eb := entry(a), entry(b)
+		if c := ea.StartedAt.Compare(eb.StartedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(ea.ID, eb.ID)
+	})
+}
@@ -0,0 +1,103 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/timeentry/timeentryerrors"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_TimeEntries(t *testing.T) {
+	storage := NewInMemoryStorage()
+	for _, id := range []string{"t1", "t2"} {
+		require.NoError(t, storage.AddTask(taskmodels.Task{
+			ID:         id,
+			UserID:     "u1",
+			Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: id, Description: "D"},
+		}))
+	}
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, storage.AddTimeEntry(timeentrymodels.TimeEntry{
+		ID: "e1", TaskID: "t1", UserID: "u1", StartedAt: now,
+	}))
+
+	// Второй таймер того же пользователя не запускается, даже на другой задаче.
+	err := storage.AddTimeEntry(timeentrymodels.TimeEntry{ID: "e2", TaskID: "t2", UserID: "u1", StartedAt: now})
+	assert.ErrorIs(t, err, timeentryerrors.ErrTimerAlreadyRunning)
+	err = storage.AddTimeEntry(timeentrymodels.TimeEntry{ID: "e2", TaskID: "t3", UserID: "u2", StartedAt: now})
+	assert.ErrorIs(t, err, taskerrors.ErrFoundNothing)
+
+	ended := now.Add(-time.Hour)
+	require.NoError(t, storage.AddTimeEntry(timeentrymodels.TimeEntry{
+		ID: "e0", TaskID: "t1", UserID: "u1", StartedAt: now.Add(-2 * time.Hour), EndedAt: &ended,
+	}))
+
+	running, err := storage.GetRunningTimer("u1")
+	require.NoError(t, err)
+	assert.Equal(t, "e1", running.ID)
+
+	// Запись, остановленная раньше начала, получает нулевую длительность.
+	_, err = storage.StopTimer("u1", "t2", now)
+	assert.ErrorIs(t, err, timeentryerrors.ErrTimerNotRunning)
+	stopped, err := storage.StopTimer("u1", "t1", now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, now, *stopped.EndedAt)
+
+	_, err = storage.GetRunningTimer("u1")
+	assert.ErrorIs(t, err, timeentryerrors.ErrTimerNotRunning)
+
+	entries, err := storage.GetTaskTimeEntries("t1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "e0", entries[0].ID)
+	assert.Equal(t, "e1", entries[1].ID)
+
+	_, err = storage.GetTimeEntryByID("e1", "t2")
+	assert.ErrorIs(t, err, timeentryerrors.ErrTimeEntryNotFound)
+
+	require.NoError(t, storage.DeleteTask("t1", "u1"))
+	entries, err = storage.GetTaskTimeEntries("t1")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestStorage_GetReportEntries(t *testing.T) {
+	storage := NewInMemoryStorage()
+	for _, task := range []taskmodels.Task{
+		{ID: "t1", UserID: "u1", Attributes: taskmodels.TaskAttributes{Title: "Mine", Estimate: 60}},
+		{ID: "t2", UserID: "u2", Attributes: taskmodels.TaskAttributes{Title: "Foreign"}},
+		{ID: "t3", UserID: "u1", Attributes: taskmodels.TaskAttributes{Title: "Deleted"}},
+	} {
+		task.Attributes.Status, task.Attributes.Description = taskmodels.StatusNew, "D"
+		require.NoError(t, storage.AddTask(task))
+	}
+	require.NoError(t, storage.MarkTaskToDelete("t3", "u1"))
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	for _, entry := range []timeentrymodels.TimeEntry{
+		{ID: "e1", TaskID: "t1", UserID: "u1", StartedAt: from.Add(time.Hour)},
+		{ID: "e2", TaskID: "t1", UserID: "u2", StartedAt: from},
+		{ID: "e3", TaskID: "t1", UserID: "u3", StartedAt: to},
+		{ID: "e4", TaskID: "t2", UserID: "u2", StartedAt: from},
+		{ID: "e5", TaskID: "t3", UserID: "u1", StartedAt: from},
+	} {
+		ended := entry.StartedAt.Add(time.Minute)
+		entry.EndedAt = &ended
+		require.NoError(t, storage.AddTimeEntry(entry))
+	}
+
+	entries, err := storage.GetReportEntries("u1", from, to)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "e2", entries[0].ID)
+	assert.Equal(t, "e1", entries[1].ID)
+	assert.Equal(t, "Mine", entries[1].TaskTitle)
+	assert.Equal(t, 60, entries[1].TaskEstimate)
+}
@@ -0,0 +1,96 @@
+package inmemory
+
+import (
+	"maps"
+	"slices"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+)
+
+// InTx - выполняет fn над копией данных хранилища и переносит изменения копии в хранилище, только
+// если fn завершилась без ошибки. Всё это время хранилище заблокировано: другие запросы ждут конца
+// транзакции, поэтому откат не затрагивает их изменения, а релей не видит событий до коммита.
+// Ключей идемпотентности в копии нет: их пишет middleware вне транзакций.
+func (storage *Storage) InTx(fn func(tx *Storage) error) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+	storage.remindersMu.Lock()
+	defer storage.remindersMu.Unlock()
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+	storage.outboxMu.Lock()
+	defer storage.outboxMu.Unlock()
+
+	tx := storage.stage()
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	storage.commit(tx)
+	return nil
+}
+
+// stage - копия данных для транзакции, вызывается под всеми мьютексами данных. Outbox копии
+// начинается пустым: при коммите её события дописываются в конец.
+func (storage *Storage) stage() *Storage {
+	return &Storage{
+		users:         maps.Clone(storage.users),
+		tasks:         maps.Clone(storage.tasks),
+		search:        storage.search.Clone(),
+		history:       cloneSlices(storage.history),
+		comments:      maps.Clone(storage.comments),
+		attachments:   maps.Clone(storage.attachments),
+		orphanedBlobs: slices.Clone(storage.orphanedBlobs),
+		customFields:  maps.Clone(storage.customFields),
+		timeEntries:   maps.Clone(storage.timeEntries),
+		workflows:     maps.Clone(storage.workflows),
+		projects:      maps.Clone(storage.projects),
+		watchers:      cloneSlices(storage.watchers),
+		assignments:   cloneSlices(storage.assignments),
+		tags:          maps.Clone(storage.tags),
+		taskTags:      cloneSlices(storage.taskTags),
+		blockers:      cloneSlices(storage.blockers),
+		filters:       maps.Clone(storage.filters),
+		templates:     maps.Clone(storage.templates),
+		reminders:     maps.Clone(storage.reminders),
+		webhooks:      maps.Clone(storage.webhooks),
+		deliveries:    maps.Clone(storage.deliveries),
+		outboxSeq:     storage.outboxSeq,
+		idempotency:   make(map[idempotencyKey]idempotencymodels.Record),
+	}
+}
+
+// commit - переносит данные копии в хранилище, вызывается под всеми мьютексами данных.
+func (storage *Storage) commit(tx *Storage) {
+	storage.users = tx.users
+	storage.tasks = tx.tasks
+	storage.search = tx.search
+	storage.history = tx.history
+	storage.comments = tx.comments
+	storage.attachments = tx.attachments
+	storage.orphanedBlobs = tx.orphanedBlobs
+	storage.customFields = tx.customFields
+	storage.timeEntries = tx.timeEntries
+	storage.workflows = tx.workflows
+	storage.projects = tx.projects
+	storage.watchers = tx.watchers
+	storage.assignments = tx.assignments
+	storage.tags = tx.tags
+	storage.taskTags = tx.taskTags
+	storage.blockers = tx.blockers
+	storage.filters = tx.filters
+	storage.templates = tx.templates
+	storage.reminders = tx.reminders
+	storage.webhooks = tx.webhooks
+	storage.deliveries = tx.deliveries
+	storage.outbox = append(storage.outbox, tx.outbox...)
+	storage.outboxSeq = tx.outboxSeq
+}
+
+// cloneSlices - копия map вместе со срезами, которые методы хранилища меняют на месте.
+func cloneSlices[V any](m map[string][]V) map[string][]V {
+	cloned := make(map[string][]V, len(m))
+	for key, values := range m {
+		cloned[key] = slices.Clone(values)
+	}
+	return cloned
+}
@@ -0,0 +1,74 @@
+package inmemory
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_InTx(t *testing.T) {
+	storage := NewInMemoryStorage()
+	require.NoError(t, storage.AddTask(taskmodels.Task{ID: "t1", UserID: "u1"}))
+	require.NoError(t, storage.AddTask(taskmodels.Task{ID: "t2", UserID: "u1"}))
+	require.NoError(t, storage.AddTaskDependency("t1", "t2"))
+
+	published := func() int {
+		n, err := storage.RelayOutboxEvents(100, func(eventmodels.Event) error { return nil })
+		require.NoError(t, err)
+		return n
+	}
+	require.Equal(t, 2, published())
+
+	var wg sync.WaitGroup
+	errBulk := errors.New("bulk failed")
+	err := storage.InTx(func(tx *Storage) error {
+		require.NoError(t, tx.AddTask(taskmodels.Task{ID: "t3", UserID: "u1"}))
+		require.NoError(t, tx.RemoveTaskDependency("t1", "t2"))
+		require.NoError(t, tx.MarkTaskToDelete("t1", "u1"))
+		require.NoError(t, tx.AddFilter(filtermodels.Filter{ID: "f1", UserID: "u1", Name: "f"}))
+		require.NoError(t, tx.AddTemplate(templatemodels.Template{ID: "tpl1", UserID: "u1", Name: "tpl"}))
+
+		// Запись другого запроса ждёт конца транзакции, и откат её не отменяет.
+		wg.Go(func() {
+			assert.NoError(t, storage.AddTask(taskmodels.Task{ID: "t4", UserID: "u2"}))
+		})
+		return errBulk
+	})
+	assert.ErrorIs(t, err, errBulk)
+	wg.Wait()
+
+	_, err = storage.GetTaskByID("t3", "u1")
+	assert.ErrorIs(t, err, taskerrors.ErrFoundNothing)
+	_, err = storage.GetFilterByID("f1", "u1")
+	assert.ErrorIs(t, err, filtererrors.ErrFilterNotFound)
+	_, err = storage.GetTemplateByID("tpl1", "u1")
+	assert.ErrorIs(t, err, templateerrors.ErrTemplateNotFound)
+
+	task, err := storage.GetTaskByID("t1", "u1")
+	require.NoError(t, err)
+	assert.False(t, task.Deleted)
+	assert.Equal(t, []string{"t2"}, task.BlockedBy)
+
+	_, err = storage.GetTaskByID("t4", "u2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published())
+
+	require.NoError(t, storage.InTx(func(tx *Storage) error {
+		require.NoError(t, tx.AddFilter(filtermodels.Filter{ID: "f1", UserID: "u1", Name: "f"}))
+		return tx.AddTask(taskmodels.Task{ID: "t3", UserID: "u1"})
+	}))
+	assert.Equal(t, 1, published())
+
+	_, err = storage.GetFilterByID("f1", "u1")
+	assert.NoError(t, err)
+}
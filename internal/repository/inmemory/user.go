@@ -1,6 +1,7 @@
 package inmemory
 
 import (
+	"toDoList/internal/domain/event/eventmodels"
 	"toDoList/internal/domain/user/usererrors"
 	"toDoList/internal/domain/user/usermodels"
 )
@@ -8,6 +9,9 @@ import (
 // TODO: протестить локальное хранилище
 
 func (storage *Storage) GetAllUsers() ([]usermodels.User, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
 	var users []usermodels.User
 
 	for _, user := range storage.users {
@@ -17,18 +21,30 @@ func (storage *Storage) GetAllUsers() ([]usermodels.User, error) {
 }
 
 func (storage *Storage) SaveUser(user usermodels.User) (usermodels.User, error) {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
 	for _, userInMemory := range storage.users {
 		if user.Email == userInMemory.Email {
 			return usermodels.User{}, usererrors.ErrUserIsAlreadyExist
 		}
 	}
 
+	events, err := userEvent(eventmodels.UserCreated, user)
+	if err != nil {
+		return usermodels.User{}, err
+	}
+
 	storage.users[user.UUID] = user
+	storage.recordEvents(events...)
 
 	return user, nil
 }
 
 func (storage *Storage) GetUserByID(userID string) (usermodels.User, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
 	user, ok := storage.users[userID]
 	if !ok {
 		return usermodels.User{}, usererrors.ErrUserNotExist
@@ -38,6 +54,9 @@ func (storage *Storage) GetUserByID(userID string) (usermodels.User, error) {
 }
 
 func (storage *Storage) GetUserByEmail(email string) (usermodels.User, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
 	var user usermodels.User
 	for _, userInMemory := range storage.users {
 		if userInMemory.Email == email {
@@ -49,13 +68,22 @@ func (storage *Storage) GetUserByEmail(email string) (usermodels.User, error) {
 }
 
 func (storage *Storage) UpdateUser(user usermodels.User) (usermodels.User, error) {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
 	for _, userInMemory := range storage.users {
 		if userInMemory.UUID == user.UUID {
 			userInMemory.Name = user.Name
 			userInMemory.Email = user.Email
 			userInMemory.Password = user.Password
 
+			events, err := userEvent(eventmodels.UserUpdated, userInMemory)
+			if err != nil {
+				return usermodels.User{}, err
+			}
+
 			storage.users[user.UUID] = userInMemory
+			storage.recordEvents(events...)
 			return user, nil
 		}
 	}
@@ -63,10 +91,20 @@ func (storage *Storage) UpdateUser(user usermodels.User) (usermodels.User, error
 }
 
 func (storage *Storage) DeleteUser(userID string) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
 	_, ok := storage.users[userID]
 	if !ok {
 		return usererrors.ErrUserNotExist
 	}
+
+	events, err := userEvent(eventmodels.UserDeleted, usermodels.User{UUID: userID})
+	if err != nil {
+		return err
+	}
+
 	delete(storage.users, userID)
+	storage.recordEvents(events...)
 	return nil
 }
@@ -0,0 +1,197 @@
+package inmemory
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/webhook/webhookerrors"
+	"toDoList/internal/domain/webhook/webhookmodels"
+)
+
+func (storage *Storage) AddWebhook(webhook webhookmodels.Webhook) error {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	webhook.Events = slices.Clone(webhook.Events)
+	storage.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (storage *Storage) GetWebhooksByUser(userID string) ([]webhookmodels.Webhook, error) {
+	return storage.findWebhooks(func(webhook webhookmodels.Webhook) bool {
+		return webhook.UserID == userID
+	}), nil
+}
+
+func (storage *Storage) GetWebhooksForEvent(userID string, eventType webhookmodels.EventType) (
+	[]webhookmodels.Webhook, error,
+) {
+	return storage.findWebhooks(func(webhook webhookmodels.Webhook) bool {
+		return webhook.UserID == userID && slices.Contains(webhook.Events, eventType)
+	}), nil
+}
+
+func (storage *Storage) findWebhooks(match func(webhook webhookmodels.Webhook) bool) []webhookmodels.Webhook {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	var webhooks []webhookmodels.Webhook
+	for _, webhook := range storage.webhooks {
+		if match(webhook) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	slices.SortFunc(webhooks, func(a, b webhookmodels.Webhook) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return webhooks
+}
+
+func (storage *Storage) GetWebhookByID(webhookID string, userID string) (webhookmodels.Webhook, error) {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	webhook, ok := storage.webhooks[webhookID]
+	if !ok || webhook.UserID != userID {
+		return webhookmodels.Webhook{}, webhookerrors.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (storage *Storage) DeleteWebhook(webhookID string, userID string) error {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	webhook, ok := storage.webhooks[webhookID]
+	if !ok || webhook.UserID != userID {
+		return webhookerrors.ErrWebhookNotFound
+	}
+
+	delete(storage.webhooks, webhookID)
+	for id, delivery := range storage.deliveries {
+		if delivery.WebhookID == webhookID {
+			delete(storage.deliveries, id)
+		}
+	}
+	return nil
+}
+
+// AddWebhookDeliveries - доставка события вебхуку, которая уже есть, повторно не добавляется: релей
+// публикует события не меньше одного раза.
+func (storage *Storage) AddWebhookDeliveries(deliveries []webhookmodels.Delivery) error {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	for _, delivery := range deliveries {
+		if _, ok := storage.webhooks[delivery.WebhookID]; !ok {
+			return webhookerrors.ErrWebhookNotFound
+		}
+	}
+
+	for _, delivery := range deliveries {
+		if storage.hasDelivery(delivery.WebhookID, delivery.EventID) {
+			continue
+		}
+		storage.deliveries[delivery.ID] = delivery
+	}
+	return nil
+}
+
+// hasDelivery - есть ли доставка события вебхуку, вызывается под webhooksMu.
+func (storage *Storage) hasDelivery(webhookID string, eventID string) bool {
+	for _, delivery := range storage.deliveries {
+		if delivery.WebhookID == webhookID && delivery.EventID == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+// ClaimWebhookDeliveries - аналог выборки с FOR UPDATE SKIP LOCKED: мьютекс заменяет блокировку строк.
+func (storage *Storage) ClaimWebhookDeliveries(now time.Time, leaseUntil time.Time, limit int) (
+	[]webhookmodels.Delivery, error,
+) {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	var due []webhookmodels.Delivery
+	for _, delivery := range storage.deliveries {
+		if delivery.Status == webhookmodels.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+
+	slices.SortFunc(due, func(a, b webhookmodels.Delivery) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	for i, delivery := range due {
+		delivery.NextAttemptAt = leaseUntil
+		storage.deliveries[delivery.ID] = delivery
+
+		webhook := storage.webhooks[delivery.WebhookID]
+		due[i].NextAttemptAt = leaseUntil
+		due[i].URL = webhook.URL
+		due[i].Secret = webhook.Secret
+	}
+
+	return due, nil
+}
+
+func (storage *Storage) UpdateWebhookDelivery(delivery webhookmodels.Delivery) error {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	if _, ok := storage.deliveries[delivery.ID]; !ok {
+		return webhookerrors.ErrDeliveryNotFound
+	}
+
+	delivery.URL = ""
+	delivery.Secret = ""
+	storage.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (storage *Storage) GetWebhookDeliveries(webhookID string, limit int) ([]webhookmodels.Delivery, error) {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	var deliveries []webhookmodels.Delivery
+	for _, delivery := range storage.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	slices.SortFunc(deliveries, func(a, b webhookmodels.Delivery) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (storage *Storage) GetWebhookDelivery(deliveryID string, webhookID string) (webhookmodels.Delivery, error) {
+	storage.webhooksMu.Lock()
+	defer storage.webhooksMu.Unlock()
+
+	delivery, ok := storage.deliveries[deliveryID]
+	if !ok || delivery.WebhookID != webhookID {
+		return webhookmodels.Delivery{}, webhookerrors.ErrDeliveryNotFound
+	}
+	return delivery, nil
+}
@@ -0,0 +1,58 @@
+package inmemory
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/workflow/workflowerrors"
+	"toDoList/internal/domain/workflow/workflowmodels"
+)
+
+func (storage *Storage) GetWorkflow(projectID string) (workflowmodels.Workflow, error) {
+	storage.tasksMu.RLock()
+	defer storage.tasksMu.RUnlock()
+
+	workflow, ok := storage.workflows[projectID]
+	if !ok {
+		return workflowmodels.Workflow{}, workflowerrors.ErrWorkflowNotFound
+	}
+	return workflow, nil
+}
+
+func (storage *Storage) SaveWorkflow(workflow workflowmodels.Workflow) error {
+	storage.tasksMu.Lock()
+	defer storage.tasksMu.Unlock()
+
+	if _, ok := storage.projects[workflow.ProjectID]; !ok {
+		return projecterrors.ErrProjectNotFound
+	}
+
+	var inUse []string
+	for _, task := range storage.tasks {
+		if task.Attributes.ProjectID != workflow.ProjectID || task.Deleted {
+			continue
+		}
+		if _, ok := workflow.Status(task.Attributes.Status); !ok {
+			inUse = append(inUse, string(task.Attributes.Status))
+		}
+	}
+	if len(inUse) != 0 {
+		slices.Sort(inUse)
+		return fmt.Errorf("%w: %s", workflowerrors.ErrStatusInUse, strings.Join(slices.Compact(inUse), ", "))
+	}
+
+	storage.workflows[workflow.ProjectID] = workflow
+
+	for id, task := range storage.tasks {
+		if task.Attributes.ProjectID != workflow.ProjectID {
+			continue
+		}
+		if status, ok := workflow.Status(task.Attributes.Status); ok && status.Category != task.Category {
+			task.Category = status.Category
+			task.Version++
+			storage.tasks[id] = task
+		}
+	}
+	return nil
+}
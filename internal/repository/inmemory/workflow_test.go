@@ -0,0 +1,66 @@
+package inmemory
+
+import (
+	"testing"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowerrors"
+	"toDoList/internal/domain/workflow/workflowmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_SaveWorkflow(t *testing.T) {
+	storage := NewInMemoryStorage()
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend"}))
+
+	task := func(id string, status taskmodels.TaskStatus, category taskmodels.StatusCategory) taskmodels.Task {
+		return taskmodels.Task{
+			ID:         id,
+			UserID:     "u1",
+			Attributes: taskmodels.TaskAttributes{Status: status, Title: id, Description: id, ProjectID: "p1"},
+			Category:   category,
+		}
+	}
+	require.NoError(t, storage.AddTask(task("t1", taskmodels.StatusNew, taskmodels.CategoryTodo)))
+	require.NoError(t, storage.AddTask(task("t2", taskmodels.StatusInProgress, taskmodels.CategoryDoing)))
+
+	_, err := storage.GetWorkflow("p1")
+	assert.ErrorIs(t, err, workflowerrors.ErrWorkflowNotFound)
+
+	workflow := workflowmodels.Workflow{
+		ProjectID: "p1",
+		Statuses: []workflowmodels.Status{
+			{Name: taskmodels.StatusNew, Category: taskmodels.CategoryTodo},
+			{Name: "Review", Category: taskmodels.CategoryDoing},
+		},
+	}
+	err = storage.SaveWorkflow(workflow)
+	assert.ErrorIs(t, err, workflowerrors.ErrStatusInUse)
+	assert.ErrorContains(t, err, string(taskmodels.StatusInProgress))
+
+	workflow.Statuses = append(workflow.Statuses, workflowmodels.Status{
+		Name: taskmodels.StatusInProgress, Category: taskmodels.CategoryDone,
+	})
+	before, err := storage.GetTaskByID("t2", "u1")
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveWorkflow(workflow))
+
+	saved, err := storage.GetWorkflow("p1")
+	require.NoError(t, err)
+	assert.Equal(t, workflow, saved)
+
+	t2, err := storage.GetTaskByID("t2", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, taskmodels.CategoryDone, t2.Category)
+	assert.Equal(t, before.Version+1, t2.Version)
+
+	count, err := storage.CountTasksInStatus("p1", taskmodels.StatusInProgress)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	workflow.ProjectID = "p2"
+	assert.ErrorIs(t, storage.SaveWorkflow(workflow), projecterrors.ErrProjectNotFound)
+}
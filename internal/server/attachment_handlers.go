@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"toDoList/internal/domain/attachment/attachmenterrors"
+	"toDoList/internal/service/attachmentservice"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// multipartOverhead - запас на границы и заголовки частей формы сверх размера файла.
+const multipartOverhead = 64 << 10
+
+// attachmentError - сбой хранилища файлов не выдаём клиенту, остальное отвечаем как для задач.
+func attachmentError(ctx *gin.Context, err error) {
+	if errors.Is(err, attachmenterrors.ErrBlobStorage) {
+		log.Error().Err(err).Msg("attachment blob storage failed")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+}
+
+func (srv *ToDoListAPI) getAttachments(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	attachmentService := attachmentservice.NewAttachmentService(srv.db, srv.blobs, srv.maxAttachmentSize)
+	attachments, err := attachmentService.GetAttachments(ctx.Param("id"), userID)
+	if err != nil {
+		attachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"attachments": attachments})
+}
+
+// uploadAttachment - multipart/form-data с файлом в поле file. Тело ограничено, чтобы не принимать
+// файл больше лимита целиком.
+func (srv *ToDoListAPI) uploadAttachment(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, srv.maxAttachmentSize+multipartOverhead)
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": attachmenterrors.ErrAttachmentTooLarge.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	attachmentService := attachmentservice.NewAttachmentService(srv.db, srv.blobs, srv.maxAttachmentSize)
+	attachment, err := attachmentService.Upload(
+		ctx.Request.Context(),
+		ctx.Param("id"),
+		userID,
+		header.Filename,
+		file,
+		header.Size,
+	)
+	if err != nil {
+		attachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attachment)
+}
+
+// downloadAttachment - http.ServeContent отвечает на Range и If-None-Match. Содержимое вложения
+// не меняется, поэтому ETag - его ID.
+func (srv *ToDoListAPI) downloadAttachment(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	attachmentService := attachmentservice.NewAttachmentService(srv.db, srv.blobs, srv.maxAttachmentSize)
+	attachment, object, err := attachmentService.Open(
+		ctx.Request.Context(),
+		ctx.Param("id"),
+		ctx.Param("attachment_id"),
+		userID,
+	)
+	if err != nil {
+		attachmentError(ctx, err)
+		return
+	}
+	defer object.Close()
+
+	ctx.Header("Content-Type", attachment.ContentType)
+	disposition := mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName})
+	ctx.Header("Content-Disposition", disposition)
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("ETag", `"`+attachment.ID+`"`)
+
+	http.ServeContent(ctx.Writer, ctx.Request, attachment.FileName, attachment.CreatedAt, object)
+}
+
+func (srv *ToDoListAPI) deleteAttachment(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	attachmentService := attachmentservice.NewAttachmentService(srv.db, srv.blobs, srv.maxAttachmentSize)
+	if err := attachmentService.DeleteAttachment(ctx.Param("id"), ctx.Param("attachment_id"), userID); err != nil {
+		attachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Attachment was deleted")
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/mocks"
+	"toDoList/pkg/blob"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentUploadAndDownload(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	blobs, err := blob.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	repo := mocks.NewStorage(t)
+	srv := ToDoListAPI{db: repo, blobs: blobs, maxAttachmentSize: 1 << 10}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.POST("/tasks/:id/attachments", srv.uploadAttachment)
+	r.GET("/tasks/:id/attachments/:attachment_id", srv.downloadAttachment)
+
+	var added attachmentmodels.Attachment
+	repo.On("GetTaskByID", "task1", "user1").Return(taskmodels.Task{ID: "task1", UserID: "user1"}, nil)
+	repo.On("AddAttachment", mock.Anything).
+		Run(func(args mock.Arguments) { added = args.Get(0).(attachmentmodels.Attachment) }).
+		Return(nil).Once()
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	content := []byte("0123456789 notes")
+	res, err := resty.New().R().
+		SetFileReader("file", "отчёт.txt", bytes.NewReader(content)).
+		Post(httpSrv.URL + "/tasks/task1/attachments")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode(), string(res.Body()))
+	assert.Contains(t, string(res.Body()), `"content_type":"text/plain; charset=utf-8"`)
+	assert.NotContains(t, string(res.Body()), added.BlobKey)
+
+	res, err = resty.New().R().
+		SetFileReader("file", "big.txt", bytes.NewReader(bytes.Repeat([]byte("a"), 2<<10))).
+		Post(httpSrv.URL + "/tasks/task1/attachments")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode(), string(res.Body()))
+
+	repo.On("GetAttachmentByID", added.ID, "task1").Return(added, nil)
+
+	res, err = resty.New().R().
+		SetHeader("Range", "bytes=11-").
+		Get(httpSrv.URL + "/tasks/task1/attachments/" + added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, res.StatusCode())
+	assert.Equal(t, "notes", string(res.Body()))
+	assert.Equal(t, "bytes 11-15/16", res.Header().Get("Content-Range"))
+	assert.Equal(t, "text/plain; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal(t, "inline; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.txt",
+		res.Header().Get("Content-Disposition"))
+	assert.Equal(t, "nosniff", res.Header().Get("X-Content-Type-Options"))
+
+	res, err = resty.New().R().
+		SetHeader("If-None-Match", `"`+added.ID+`"`).
+		Get(httpSrv.URL + "/tasks/task1/attachments/" + added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode())
+}
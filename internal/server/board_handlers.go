@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/service/taskservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getProjectBoard(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	board, err := taskService.GetBoard(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, board)
+}
+
+// moveOnBoard - статус и позиция задачи меняются вместе или не меняются вовсе.
+func (srv *ToDoListAPI) moveOnBoard(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req taskmodels.BoardMoveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	runInTx := func(fn func(tx taskservice.TaskStorage) error) error {
+		return srv.runInTx(func(tx Storage) error { return fn(tx) })
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	task, err := taskService.MoveOnBoard(userID, req, runInTx)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("ETag", taskETag(task))
+	ctx.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"net/http"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/service/commentservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getComments(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	commentService := commentservice.NewCommentService(srv.db)
+	comments, err := commentService.GetComments(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"comments": comments})
+}
+
+// addComment - тело {"body": "@anna, посмотри", "parent_id": "..."}, parent_id нужен только для ответа.
+func (srv *ToDoListAPI) addComment(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req commentmodels.CommentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	commentService := commentservice.NewCommentService(srv.db)
+	comment, err := commentService.AddComment(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+func (srv *ToDoListAPI) updateComment(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req commentmodels.CommentUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	commentService := commentservice.NewCommentService(srv.db)
+	comment, err := commentService.UpdateComment(ctx.Param("id"), ctx.Param("comment_id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+func (srv *ToDoListAPI) deleteComment(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	commentService := commentservice.NewCommentService(srv.db)
+	if err := commentService.DeleteComment(ctx.Param("id"), ctx.Param("comment_id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Comment was deleted")
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/service/customfieldservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getCustomFields(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	customFieldService := customfieldservice.NewCustomFieldService(srv.db)
+	fields, err := customFieldService.GetCustomFields(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"fields": fields})
+}
+
+func (srv *ToDoListAPI) createCustomField(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req customfieldmodels.CustomFieldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customFieldService := customfieldservice.NewCustomFieldService(srv.db)
+	field, err := customFieldService.CreateCustomField(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, field)
+}
+
+func (srv *ToDoListAPI) deleteCustomField(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	customFieldService := customfieldservice.NewCustomFieldService(srv.db)
+	if err := customFieldService.DeleteCustomField(ctx.Param("id"), ctx.Param("field_id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Custom field was deleted")
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/server/eventbus"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// resetEvent - клиент пропустил события, которых уже нет в буфере, и должен перечитать задачи.
+const resetEvent = "reset"
+
+// streamEvents - поток SSE с событиями задач пользователя и его упоминаниями в комментариях.
+// Заголовок Last-Event-ID возобновляет поток после переподключения, комментарии-heartbeat
+// не дают прокси закрыть соединение.
+func (srv *ToDoListAPI) streamEvents(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var lastID int64
+	if header := ctx.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || id < 0 {
+			// Непонятный id не найдётся в буфере: поток начнётся с reset.
+			id = -1
+		}
+		lastID = id
+	}
+
+	sub, replay, complete := srv.events.Subscribe(func(event eventmodels.Event) bool {
+		isStreamed := event.AggregateType == eventmodels.AggregateTask ||
+			event.AggregateType == eventmodels.AggregateComment
+		return isStreamed && event.UserID == userID
+	}, lastID)
+	defer sub.Close()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// Отключает буферизацию ответа в nginx.
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	if !complete {
+		fmt.Fprintf(ctx.Writer, "event: %s\ndata: {}\n\n", resetEvent)
+	}
+	for _, message := range replay {
+		if err := writeEvent(ctx.Writer, message); err != nil {
+			return
+		}
+	}
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(srv.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case message, open := <-sub.Messages():
+			if !open {
+				// Шина закрыта при остановке сервера или клиент не успевал читать.
+				return
+			}
+			if err := writeEvent(ctx.Writer, message); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(ctx.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, message eventbus.Message) error {
+	data, err := json.Marshal(message.Event)
+	if err != nil {
+		log.Error().Err(err).Str("event", message.Event.ID).Msg("failed to encode event")
+		return nil
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", message.ID, message.Event.Type, data)
+	return err
+}
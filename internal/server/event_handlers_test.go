@@ -0,0 +1,121 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/eventbus"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readEvent - следующий блок SSE до пустой строки.
+func readEvent(t *testing.T, reader *bufio.Reader) []string {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	bus := eventbus.NewBus(10)
+	srv := ToDoListAPI{events: bus, heartbeat: 50 * time.Millisecond}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.GET("/events", srv.streamEvents)
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	publish := func(eventType eventmodels.EventType, userID string) {
+		event, err := eventmodels.NewTaskEvent(eventType, taskmodels.Task{ID: "task1", UserID: userID})
+		require.NoError(t, err)
+		require.NoError(t, bus.Publish(context.Background(), event))
+	}
+
+	publish(eventmodels.TaskCreated, "user1")
+	publish(eventmodels.TaskCreated, "user2")
+
+	req, err := http.NewRequest(http.MethodGet, httpSrv.URL+"/events", nil)
+	require.NoError(t, err)
+
+	// Первое событие уже получено клиентом, поток продолжается со второго.
+	req.Header.Set("Last-Event-ID", "1")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(res.Body)
+
+	publish(eventmodels.TaskDeleted, "user1")
+	lines := readEvent(t, reader)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "id: 3", lines[0])
+	assert.Equal(t, "event: task.deleted", lines[1])
+	assert.Contains(t, lines[2], `"aggregate_id":"task1"`)
+
+	assert.Equal(t, []string{": heartbeat"}, readEvent(t, reader))
+
+	// Закрытие шины завершает поток.
+	bus.Close()
+	for {
+		if _, err = reader.ReadString('\n'); err != nil {
+			break
+		}
+	}
+}
+
+func TestStreamEventsReset(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	bus := eventbus.NewBus(10)
+	srv := ToDoListAPI{events: bus, heartbeat: time.Hour}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.GET("/events", srv.streamEvents)
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpSrv.URL+"/events", nil)
+	require.NoError(t, err)
+	// id из прошлого запуска сервера.
+	req.Header.Set("Last-Event-ID", "42")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, []string{"event: reset", "data: {}"}, readEvent(t, bufio.NewReader(res.Body)))
+}
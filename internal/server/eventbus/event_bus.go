@@ -0,0 +1,156 @@
+// Package eventbus - шина доменных событий внутри процесса. События в неё публикует релей outbox,
+// поэтому шину одинаково питают оба хранилища, а подписчики (например, потоки SSE) получают
+// их с собственными возрастающими номерами и могут догнать пропущенное из буфера.
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"toDoList/internal/domain/event/eventmodels"
+)
+
+// subscriberBuffer - сколько сообщений может ждать медленный подписчик, прежде чем его отключат.
+const subscriberBuffer = 64
+
+// Message - событие с номером в шине, номер служит id события SSE.
+type Message struct {
+	ID    int64
+	Event eventmodels.Event
+}
+
+type Bus struct {
+	mu     sync.Mutex
+	nextID int64
+	replay []Message
+	size   int
+	seen   map[string]struct{}
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewBus - replaySize задаёт, сколько последних сообщений хранится для возобновления подписки.
+func NewBus(replaySize int) *Bus {
+	return &Bus{
+		nextID: 1,
+		size:   replaySize,
+		seen:   make(map[string]struct{}),
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription - подписка на сообщения, прошедшие filter. Канал закрывается при Close,
+// при закрытии шины и когда подписчик не успевает читать.
+type Subscription struct {
+	bus      *Bus
+	filter   func(event eventmodels.Event) bool
+	messages chan Message
+}
+
+func (s *Subscription) Messages() <-chan Message {
+	return s.messages
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.unsubscribe(s)
+}
+
+// Publish - раздаёт событие подписчикам. Релей outbox может повторить событие,
+// повтор с тем же ID, пока оригинал ещё в буфере, отбрасывается.
+func (b *Bus) Publish(_ context.Context, event eventmodels.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	if _, ok := b.seen[event.ID]; ok {
+		return nil
+	}
+
+	message := Message{ID: b.nextID, Event: event}
+	b.nextID++
+
+	b.seen[event.ID] = struct{}{}
+	b.replay = append(b.replay, message)
+	if len(b.replay) > b.size {
+		delete(b.seen, b.replay[0].Event.ID)
+		b.replay = b.replay[1:]
+	}
+
+	for sub := range b.subs {
+		if !sub.filter(event) {
+			continue
+		}
+		select {
+		case sub.messages <- message:
+		default:
+			// Подписчик отстал: отключаем его, а пропущенное он получит из буфера при переподключении.
+			b.unsubscribe(sub)
+		}
+	}
+	return nil
+}
+
+// Subscribe - подписка на сообщения после lastID. replay - уже опубликованные сообщения после lastID,
+// новые придут в канал подписки без пропусков. complete = false, если часть сообщений после lastID
+// уже вытеснена из буфера или lastID из прошлого запуска сервера, тогда клиенту нужно перечитать данные.
+// lastID = 0 означает подписку только на новые сообщения.
+func (b *Bus) Subscribe(filter func(event eventmodels.Event) bool, lastID int64) (
+	sub *Subscription, replay []Message, complete bool,
+) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub = &Subscription{bus: b, filter: filter, messages: make(chan Message, subscriberBuffer)}
+	if b.closed {
+		close(sub.messages)
+		return sub, nil, false
+	}
+	b.subs[sub] = struct{}{}
+
+	if lastID == 0 {
+		return sub, nil, true
+	}
+
+	oldest := b.nextID
+	if len(b.replay) != 0 {
+		oldest = b.replay[0].ID
+	}
+	complete = lastID >= oldest-1 && lastID < b.nextID
+
+	for _, message := range b.replay {
+		if message.ID > lastID && filter(message.Event) {
+			replay = append(replay, message)
+		}
+	}
+	return sub, replay, complete
+}
+
+// Close - закрывает все подписки, новые подписки сразу получают закрытый канал.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subs {
+		b.unsubscribe(sub)
+	}
+}
+
+func (b *Bus) Closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.closed
+}
+
+func (b *Bus) unsubscribe(sub *Subscription) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	close(sub.messages)
+}
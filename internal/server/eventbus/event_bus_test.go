@@ -0,0 +1,107 @@
+package eventbus
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"toDoList/internal/domain/event/eventmodels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publish - события с ID вида "1a", первая цифра - номер пользователя.
+func publish(t *testing.T, bus *Bus, ids ...string) {
+	for _, id := range ids {
+		require.NoError(t, bus.Publish(context.Background(), eventmodels.Event{ID: id, UserID: "u" + id[:1]}))
+	}
+}
+
+func eventIDs(messages []Message) []string {
+	var ids []string
+	for _, message := range messages {
+		ids = append(ids, message.Event.ID)
+	}
+	return ids
+}
+
+func all(eventmodels.Event) bool { return true }
+
+func TestBus_Publish(t *testing.T) {
+	bus := NewBus(3)
+
+	sub, replay, complete := bus.Subscribe(func(event eventmodels.Event) bool { return event.UserID == "u1" }, 0)
+	assert.True(t, complete)
+	assert.Empty(t, replay)
+
+	// Повтор события с тем же ID отбрасывается.
+	publish(t, bus, "1a", "2a", "1b", "1a")
+	require.Len(t, sub.Messages(), 2)
+	assert.Equal(t, Message{ID: 1, Event: eventmodels.Event{ID: "1a", UserID: "u1"}}, <-sub.Messages())
+	assert.Equal(t, int64(3), (<-sub.Messages()).ID)
+
+	sub.Close()
+	_, open := <-sub.Messages()
+	assert.False(t, open)
+}
+
+func TestBus_Resume(t *testing.T) {
+	bus := NewBus(3)
+	publish(t, bus, "1a", "2a", "1b")
+
+	tests := []struct {
+		name         string
+		lastID       int64
+		wantReplay   []string
+		wantComplete bool
+	}{
+		{"up to date", 3, nil, true},
+		{"missed one", 2, []string{"1b"}, true},
+		{"whole buffer", 1, []string{"2a", "1b"}, true},
+		{"from previous run", 42, nil, false},
+		{"bad id", -1, []string{"1a", "2a", "1b"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, replay, complete := bus.Subscribe(all, tt.lastID)
+			defer sub.Close()
+
+			assert.Equal(t, tt.wantComplete, complete)
+			assert.Equal(t, tt.wantReplay, eventIDs(replay))
+		})
+	}
+
+	// Буфер на 3 сообщения: после двух новых первые два вытеснены.
+	publish(t, bus, "2b", "2c")
+	_, replay, complete := bus.Subscribe(all, 1)
+	assert.False(t, complete)
+	assert.Equal(t, []string{"1b", "2b", "2c"}, eventIDs(replay))
+}
+
+func TestBus_DisconnectsSlowSubscriber(t *testing.T) {
+	bus := NewBus(subscriberBuffer * 2)
+	slow, _, _ := bus.Subscribe(all, 0)
+	fast, _, _ := bus.Subscribe(all, 0)
+
+	for i := range subscriberBuffer + 1 {
+		require.NoError(t, bus.Publish(context.Background(), eventmodels.Event{ID: strconv.Itoa(i)}))
+		<-fast.Messages()
+	}
+
+	assert.Len(t, slow.Messages(), subscriberBuffer)
+	for range subscriberBuffer {
+		<-slow.Messages()
+	}
+	_, open := <-slow.Messages()
+	assert.False(t, open)
+
+	bus.Close()
+	_, open = <-fast.Messages()
+	assert.False(t, open)
+
+	closed, _, complete := bus.Subscribe(all, 0)
+	assert.False(t, complete)
+	_, open = <-closed.Messages()
+	assert.False(t, open)
+}
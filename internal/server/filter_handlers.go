@@ -0,0 +1,127 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/service/filterservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getFilters(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	filterService := filterservice.NewFilterService(srv.db)
+	filters, err := filterService.GetFilters(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"filters": filters})
+}
+
+func (srv *ToDoListAPI) getFilterByID(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	filterService := filterservice.NewFilterService(srv.db)
+	filter, err := filterService.GetFilter(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, filter)
+}
+
+func (srv *ToDoListAPI) createFilter(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req filtermodels.FilterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filterService := filterservice.NewFilterService(srv.db)
+	filter, err := filterService.CreateFilter(req, userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, filter)
+}
+
+func (srv *ToDoListAPI) updateFilter(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req filtermodels.FilterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filterService := filterservice.NewFilterService(srv.db)
+	filter, err := filterService.UpdateFilter(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, filter)
+}
+
+func (srv *ToDoListAPI) deleteFilter(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	filterService := filterservice.NewFilterService(srv.db)
+	if err := filterService.DeleteFilter(ctx.Param("id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Filter was deleted")
+}
+
+// getFilterTasks - фильтр применяется заново при каждом запросе, поэтому список всегда актуален.
+func (srv *ToDoListAPI) getFilterTasks(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	filterService := filterservice.NewFilterService(srv.db)
+	tasks, err := filterService.FilterTasks(ctx.Param("id"), userID)
+	if err != nil {
+		if errors.Is(err, filtererrors.ErrFilterNotFound) || errors.Is(err, filtererrors.ErrWrongFilterQuery) {
+			ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if tasks == nil {
+		tasks = []taskmodels.Task{}
+	}
+	ctx.JSON(http.StatusOK, tasks)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetFilterTasks(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.GET("/filters/:id/tasks", srv.getFilterTasks)
+
+	repo.On("GetFilterByID", "urgent", "user1").
+		Return(filtermodels.Filter{ID: "urgent", UserID: "user1", Query: "tag:urgent"}, nil)
+	repo.On("GetFilterByID", "empty", "user1").
+		Return(filtermodels.Filter{ID: "empty", UserID: "user1", Query: "status:New"}, nil)
+	repo.On("GetFilterByID", "broken", "user1").
+		Return(filtermodels.Filter{ID: "broken", UserID: "user1", Query: "status:New"}, nil)
+	repo.On("GetFilterByID", "missing", "user1").Return(filtermodels.Filter{}, filtererrors.ErrFilterNotFound)
+	repo.On("FindTasksByFilter", "user1", filtermodels.Condition{
+		Field: filtermodels.FieldTag, Op: filtermodels.OpEq, Value: "urgent",
+	}).Return([]taskmodels.Task{{ID: "task1"}}, nil)
+	repo.On("FindTasksByFilter", "user1", mock.Anything).Return(nil, nil).Once()
+	repo.On("FindTasksByFilter", "user1", mock.Anything).Return(nil, errors.New("db is down")).Once()
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	tests := []struct {
+		name       string
+		filterID   string
+		statusCode int
+		wantBody   string
+	}{
+		{"found", "urgent", http.StatusOK, `"id":"task1"`},
+		{"nothing found", "empty", http.StatusOK, `[]`},
+		{"storage error", "broken", http.StatusInternalServerError, `"error"`},
+		{"not found", "missing", http.StatusNotFound, `"error"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := resty.New().R().Get(httpSrv.URL + "/filters/" + tt.filterID + "/tasks")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.statusCode, res.StatusCode(), string(res.Body()))
+			assert.Contains(t, string(res.Body()), tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+	"toDoList/internal/domain/idempotency/idempotencyerrors"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type IdempotencyStorage interface {
+	ReserveIdempotencyKey(record idempotencymodels.Record) (idempotencymodels.Record, bool, error)
+	CompleteIdempotencyKey(record idempotencymodels.Record) error
+	ReleaseIdempotencyKey(userID string, key string) error
+}
+
+// replayedHeaders - заголовки ответа, которые сохраняются вместе с телом. Остальные, например
+// Content-Encoding, выставляют общие middleware при каждом ответе заново.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// IdempotencyMiddleware - повтор небезопасного запроса с тем же заголовком Idempotency-Key получает
+// сохранённый ответ первого запроса, а не выполняет его ещё раз. Ключи хранятся для каждого
+// пользователя ttl, поэтому middleware ставится после AuthMiddleware. Тот же ключ с другим запросом
+// отклоняется с 422, повтор запроса, который ещё выполняется, - с 409. Ответы 5xx не сохраняются,
+// и такой запрос можно повторить с тем же ключом.
+func IdempotencyMiddleware(storage IdempotencyStorage, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencymodels.KeyHeader)
+		if key == "" || isSafeMethod(ctx.Request.Method) {
+			ctx.Next()
+			return
+		}
+
+		if len(key) > idempotencymodels.MaxKeyLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": idempotencyerrors.ErrWrongKey.Error()})
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now().UTC()
+		record := idempotencymodels.Record{
+			UserID:      ctx.GetString("userID"),
+			Key:         key,
+			Fingerprint: requestFingerprint(ctx.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		saved, reserved, err := storage.ReserveIdempotencyKey(record)
+		switch {
+		case errors.Is(err, idempotencyerrors.ErrRequestInProgress):
+			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		case !reserved:
+			replay(ctx, record, saved)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+
+		defer func() {
+			// Паника дойдёт до Recovery, а ключ освобождается, чтобы запрос можно было повторить.
+			if recovered := recover(); recovered != nil {
+				release(storage, record)
+				panic(recovered)
+			}
+		}()
+
+		ctx.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			release(storage, record)
+			return
+		}
+
+		record.StatusCode = recorder.Status()
+		record.Header = make(map[string]string)
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				record.Header[name] = value
+			}
+		}
+		record.Body = recorder.body.Bytes()
+
+		if err = storage.CompleteIdempotencyKey(record); err != nil {
+			log.Error().Err(err).Str("key", record.Key).Msg("failed to save idempotent response")
+		}
+	}
+}
+
+func replay(ctx *gin.Context, record idempotencymodels.Record, saved idempotencymodels.Record) {
+	switch {
+	case saved.Fingerprint != record.Fingerprint:
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": idempotencyerrors.ErrKeyReused.Error()})
+	case !saved.Completed():
+		ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": idempotencyerrors.ErrRequestInProgress.Error()})
+	default:
+		for name, value := range saved.Header {
+			ctx.Header(name, value)
+		}
+		ctx.Header(idempotencymodels.ReplayedHeader, "true")
+		ctx.Status(saved.StatusCode)
+		if _, err := ctx.Writer.Write(saved.Body); err != nil {
+			log.Error().Err(err).Msg("failed to write idempotent response")
+		}
+		ctx.Abort()
+	}
+}
+
+func release(storage IdempotencyStorage, record idempotencymodels.Record) {
+	if err := storage.ReleaseIdempotencyKey(record.UserID, record.Key); err != nil {
+		log.Error().Err(err).Str("key", record.Key).Msg("failed to release idempotency key")
+	}
+}
+
+// requestFingerprint - отпечаток метода, пути с параметрами и тела запроса.
+func requestFingerprint(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// responseRecorder - копирует тело ответа, чтобы его можно было сохранить.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
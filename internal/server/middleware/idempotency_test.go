@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+	"toDoList/internal/repository/inmemory"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	storage := inmemory.NewInMemoryStorage()
+	calls := 0
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", c.GetHeader("X-User"))
+		c.Next()
+	})
+	r.Use(IdempotencyMiddleware(storage, time.Hour))
+	r.POST("/tasks", func(c *gin.Context) {
+		calls++
+		c.Header("Location", "/tasks/t1")
+		c.JSON(http.StatusCreated, gin.H{"id": "t1", "call": calls})
+	})
+	r.POST("/fail", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db is down"})
+	})
+	r.GET("/tasks", func(c *gin.Context) {
+		calls++
+		c.Status(http.StatusOK)
+	})
+
+	do := func(method string, path string, user string, key string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-User", user)
+		if key != "" {
+			req.Header.Set(idempotencymodels.KeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := do(http.MethodPost, "/tasks", "u1", "k1", `{"title":"A"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(idempotencymodels.ReplayedHeader))
+
+	retry := do(http.MethodPost, "/tasks", "u1", "k1", `{"title":"A"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "/tasks/t1", retry.Header().Get("Location"))
+	assert.Equal(t, "application/json; charset=utf-8", retry.Header().Get("Content-Type"))
+	assert.Equal(t, "true", retry.Header().Get(idempotencymodels.ReplayedHeader))
+	assert.Equal(t, 1, calls)
+
+	// Тот же ключ с другим телом или путём.
+	assert.Equal(t, http.StatusUnprocessableEntity, do(http.MethodPost, "/tasks", "u1", "k1", `{"title":"B"}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, do(http.MethodPost, "/tasks?x=1", "u1", "k1", `{"title":"A"}`).Code)
+	assert.Equal(t, 1, calls)
+
+	// Ключи у каждого пользователя свои, без ключа запрос выполняется каждый раз.
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, "/tasks", "u2", "k1", `{"title":"B"}`).Code)
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, "/tasks", "u1", "", `{"title":"A"}`).Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/tasks", "u1", "k1", "").Code)
+	assert.Equal(t, 4, calls)
+
+	// Ответ 5xx не сохраняется, повтор выполняется заново.
+	assert.Equal(t, http.StatusInternalServerError, do(http.MethodPost, "/fail", "u1", "k2", "").Code)
+	assert.Equal(t, http.StatusInternalServerError, do(http.MethodPost, "/fail", "u1", "k2", "").Code)
+	assert.Equal(t, 6, calls)
+
+	// Первый запрос с ключом ещё выполняется.
+	now := time.Now().UTC()
+	fingerprint := requestFingerprint(httptest.NewRequest(http.MethodPost, "/tasks", nil), nil)
+	_, reserved, err := storage.ReserveIdempotencyKey(idempotencymodels.Record{
+		UserID: "u1", Key: "k3", Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.True(t, reserved)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/tasks", "u1", "k3", "").Code)
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/tasks", "u1", strings.Repeat("k", 256), "").Code)
+	assert.Equal(t, 6, calls)
+}
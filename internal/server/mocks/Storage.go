@@ -3,11 +3,38 @@
 package mocks
 
 import (
+	attachmentmodels "toDoList/internal/domain/attachment/attachmentmodels"
+	commentmodels "toDoList/internal/domain/comment/commentmodels"
+
+	customfieldmodels "toDoList/internal/domain/customfield/customfieldmodels"
+
+	eventmodels "toDoList/internal/domain/event/eventmodels"
+
+	filtermodels "toDoList/internal/domain/filter/filtermodels"
+
+	idempotencymodels "toDoList/internal/domain/idempotency/idempotencymodels"
+
 	mock "github.com/stretchr/testify/mock"
 
-	task_models "toDoList/internal/domain/task/taskmodels"
+	projectmodels "toDoList/internal/domain/project/projectmodels"
+
+	remindermodels "toDoList/internal/domain/reminder/remindermodels"
+
+	tagmodels "toDoList/internal/domain/tag/tagmodels"
+
+	taskmodels "toDoList/internal/domain/task/taskmodels"
 
-	user_models "toDoList/internal/domain/user/usermodels"
+	templatemodels "toDoList/internal/domain/template/templatemodels"
+
+	time "time"
+
+	timeentrymodels "toDoList/internal/domain/timeentry/timeentrymodels"
+
+	usermodels "toDoList/internal/domain/user/usermodels"
+
+	webhookmodels "toDoList/internal/domain/webhook/webhookmodels"
+
+	workflowmodels "toDoList/internal/domain/workflow/workflowmodels"
 )
 
 // Storage is an autogenerated mock type for the Storage type
@@ -15,8 +42,152 @@ type Storage struct {
 	mock.Mock
 }
 
+// AddAttachment provides a mock function with given fields: attachment
+func (_m *Storage) AddAttachment(attachment attachmentmodels.Attachment) error {
+	ret := _m.Called(attachment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(attachmentmodels.Attachment) error); ok {
+		r0 = rf(attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddComment provides a mock function with given fields: comment
+func (_m *Storage) AddComment(comment commentmodels.Comment) error {
+	ret := _m.Called(comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(commentmodels.Comment) error); ok {
+		r0 = rf(comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddCustomField provides a mock function with given fields: field
+func (_m *Storage) AddCustomField(field customfieldmodels.CustomField) error {
+	ret := _m.Called(field)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCustomField")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(customfieldmodels.CustomField) error); ok {
+		r0 = rf(field)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddFilter provides a mock function with given fields: filter
+func (_m *Storage) AddFilter(filter filtermodels.Filter) error {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFilter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(filtermodels.Filter) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddProject provides a mock function with given fields: project
+func (_m *Storage) AddProject(project projectmodels.Project) error {
+	ret := _m.Called(project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(projectmodels.Project) error); ok {
+		r0 = rf(project)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddProjectMember provides a mock function with given fields: projectID, userID
+func (_m *Storage) AddProjectMember(projectID string, userID string) error {
+	ret := _m.Called(projectID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddProjectMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddReminder provides a mock function with given fields: reminder
+func (_m *Storage) AddReminder(reminder remindermodels.Reminder) error {
+	ret := _m.Called(reminder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReminder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(remindermodels.Reminder) error); ok {
+		r0 = rf(reminder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddTag provides a mock function with given fields: tag
+func (_m *Storage) AddTag(tag tagmodels.Tag) error {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(tagmodels.Tag) error); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddTask provides a mock function with given fields: newTask
-func (_m *Storage) AddTask(newTask task_models.Task) error {
+func (_m *Storage) AddTask(newTask taskmodels.Task) error {
 	ret := _m.Called(newTask)
 
 	if len(ret) == 0 {
@@ -24,7 +195,7 @@ func (_m *Storage) AddTask(newTask task_models.Task) error {
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(task_models.Task) error); ok {
+	if rf, ok := ret.Get(0).(func(taskmodels.Task) error); ok {
 		r0 = rf(newTask)
 	} else {
 		r0 = ret.Error(0)
@@ -33,17 +204,17 @@ func (_m *Storage) AddTask(newTask task_models.Task) error {
 	return r0
 }
 
-// DeleteMarkedTasks provides a mock function with no fields
-func (_m *Storage) DeleteMarkedTasks() error {
-	ret := _m.Called()
+// AddTaskAssignment provides a mock function with given fields: assignment
+func (_m *Storage) AddTaskAssignment(assignment taskmodels.Assignment) error {
+	ret := _m.Called(assignment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteMarkedTasks")
+		panic("no return value specified for AddTaskAssignment")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(taskmodels.Assignment) error); ok {
+		r0 = rf(assignment)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,12 +222,30 @@ func (_m *Storage) DeleteMarkedTasks() error {
 	return r0
 }
 
-// DeleteTask provides a mock function with given fields: taskID, userID
-func (_m *Storage) DeleteTask(taskID string, userID string) error {
+// AddTaskDependency provides a mock function with given fields: taskID, blockerID
+func (_m *Storage) AddTaskDependency(taskID string, blockerID string) error {
+	ret := _m.Called(taskID, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTaskDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(taskID, blockerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddTaskWatcher provides a mock function with given fields: taskID, userID
+func (_m *Storage) AddTaskWatcher(taskID string, userID string) error {
 	ret := _m.Called(taskID, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTask")
+		panic("no return value specified for AddTaskWatcher")
 	}
 
 	var r0 error
@@ -69,17 +258,17 @@ func (_m *Storage) DeleteTask(taskID string, userID string) error {
 	return r0
 }
 
-// DeleteUser provides a mock function with given fields: userID
-func (_m *Storage) DeleteUser(userID string) error {
-	ret := _m.Called(userID)
+// AddTemplate provides a mock function with given fields: template
+func (_m *Storage) AddTemplate(template templatemodels.Template) error {
+	ret := _m.Called(template)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUser")
+		panic("no return value specified for AddTemplate")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(templatemodels.Template) error); ok {
+		r0 = rf(template)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -87,55 +276,77 @@ func (_m *Storage) DeleteUser(userID string) error {
 	return r0
 }
 
-// GetAllTasks provides a mock function with given fields: userID
-func (_m *Storage) GetAllTasks(userID string) ([]task_models.Task, error) {
-	ret := _m.Called(userID)
+// AddTimeEntry provides a mock function with given fields: entry
+func (_m *Storage) AddTimeEntry(entry timeentrymodels.TimeEntry) error {
+	ret := _m.Called(entry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllTasks")
+		panic("no return value specified for AddTimeEntry")
 	}
 
-	var r0 []task_models.Task
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]task_models.Task, error)); ok {
-		return rf(userID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(timeentrymodels.TimeEntry) error); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(string) []task_models.Task); ok {
-		r0 = rf(userID)
+
+	return r0
+}
+
+// AddWebhook provides a mock function with given fields: webhook
+func (_m *Storage) AddWebhook(webhook webhookmodels.Webhook) error {
+	ret := _m.Called(webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(webhookmodels.Webhook) error); ok {
+		r0 = rf(webhook)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]task_models.Task)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	return r0
+}
+
+// AddWebhookDeliveries provides a mock function with given fields: deliveries
+func (_m *Storage) AddWebhookDeliveries(deliveries []webhookmodels.Delivery) error {
+	ret := _m.Called(deliveries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWebhookDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]webhookmodels.Delivery) error); ok {
+		r0 = rf(deliveries)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetAllUsers provides a mock function with no fields
-func (_m *Storage) GetAllUsers() ([]user_models.User, error) {
+// CancelStaleReminders provides a mock function with no fields
+func (_m *Storage) CancelStaleReminders() (int64, error) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllUsers")
+		panic("no return value specified for CancelStaleReminders")
 	}
 
-	var r0 []user_models.User
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]user_models.User, error)); ok {
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() []user_models.User); ok {
+	if rf, ok := ret.Get(0).(func() int64); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]user_models.User)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func() error); ok {
@@ -147,27 +358,29 @@ func (_m *Storage) GetAllUsers() ([]user_models.User, error) {
 	return r0, r1
 }
 
-// GetTaskByID provides a mock function with given fields: taskID, userID
-func (_m *Storage) GetTaskByID(taskID string, userID string) (task_models.Task, error) {
-	ret := _m.Called(taskID, userID)
+// ClaimWebhookDeliveries provides a mock function with given fields: now, leaseUntil, limit
+func (_m *Storage) ClaimWebhookDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]webhookmodels.Delivery, error) {
+	ret := _m.Called(now, leaseUntil, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTaskByID")
+		panic("no return value specified for ClaimWebhookDeliveries")
 	}
 
-	var r0 task_models.Task
+	var r0 []webhookmodels.Delivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (task_models.Task, error)); ok {
-		return rf(taskID, userID)
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int) ([]webhookmodels.Delivery, error)); ok {
+		return rf(now, leaseUntil, limit)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) task_models.Task); ok {
-		r0 = rf(taskID, userID)
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int) []webhookmodels.Delivery); ok {
+		r0 = rf(now, leaseUntil, limit)
 	} else {
-		r0 = ret.Get(0).(task_models.Task)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookmodels.Delivery)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(taskID, userID)
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time, int) error); ok {
+		r1 = rf(now, leaseUntil, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -175,55 +388,45 @@ func (_m *Storage) GetTaskByID(taskID string, userID string) (task_models.Task,
 	return r0, r1
 }
 
-// GetUserByEmail provides a mock function with given fields: email
-func (_m *Storage) GetUserByEmail(email string) (user_models.User, error) {
-	ret := _m.Called(email)
+// CompleteIdempotencyKey provides a mock function with given fields: record
+func (_m *Storage) CompleteIdempotencyKey(record idempotencymodels.Record) error {
+	ret := _m.Called(record)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByEmail")
-	}
-
-	var r0 user_models.User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (user_models.User, error)); ok {
-		return rf(email)
-	}
-	if rf, ok := ret.Get(0).(func(string) user_models.User); ok {
-		r0 = rf(email)
-	} else {
-		r0 = ret.Get(0).(user_models.User)
+		panic("no return value specified for CompleteIdempotencyKey")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(idempotencymodels.Record) error); ok {
+		r0 = rf(record)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetUserByID provides a mock function with given fields: userID
-func (_m *Storage) GetUserByID(userID string) (user_models.User, error) {
-	ret := _m.Called(userID)
+// CountTasksInStatus provides a mock function with given fields: projectID, status
+func (_m *Storage) CountTasksInStatus(projectID string, status taskmodels.TaskStatus) (int, error) {
+	ret := _m.Called(projectID, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByID")
+		panic("no return value specified for CountTasksInStatus")
 	}
 
-	var r0 user_models.User
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (user_models.User, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(string, taskmodels.TaskStatus) (int, error)); ok {
+		return rf(projectID, status)
 	}
-	if rf, ok := ret.Get(0).(func(string) user_models.User); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(string, taskmodels.TaskStatus) int); ok {
+		r0 = rf(projectID, status)
 	} else {
-		r0 = ret.Get(0).(user_models.User)
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(string, taskmodels.TaskStatus) error); ok {
+		r1 = rf(projectID, status)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -231,17 +434,17 @@ func (_m *Storage) GetUserByID(userID string) (user_models.User, error) {
 	return r0, r1
 }
 
-// MarkTaskToDelete provides a mock function with given fields: taskID, userID
-func (_m *Storage) MarkTaskToDelete(taskID string, userID string) error {
-	ret := _m.Called(taskID, userID)
+// DeleteAttachment provides a mock function with given fields: attachmentID, taskID
+func (_m *Storage) DeleteAttachment(attachmentID string, taskID string) error {
+	ret := _m.Called(attachmentID, taskID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MarkTaskToDelete")
+		panic("no return value specified for DeleteAttachment")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(taskID, userID)
+		r0 = rf(attachmentID, taskID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -249,45 +452,35 @@ func (_m *Storage) MarkTaskToDelete(taskID string, userID string) error {
 	return r0
 }
 
-// SaveUser provides a mock function with given fields: user
-func (_m *Storage) SaveUser(user user_models.User) (user_models.User, error) {
-	ret := _m.Called(user)
+// DeleteComment provides a mock function with given fields: commentID, taskID
+func (_m *Storage) DeleteComment(commentID string, taskID string) error {
+	ret := _m.Called(commentID, taskID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveUser")
-	}
-
-	var r0 user_models.User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(user_models.User) (user_models.User, error)); ok {
-		return rf(user)
-	}
-	if rf, ok := ret.Get(0).(func(user_models.User) user_models.User); ok {
-		r0 = rf(user)
-	} else {
-		r0 = ret.Get(0).(user_models.User)
+		panic("no return value specified for DeleteComment")
 	}
 
-	if rf, ok := ret.Get(1).(func(user_models.User) error); ok {
-		r1 = rf(user)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(commentID, taskID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// UpdateTaskAttributes provides a mock function with given fields: task
-func (_m *Storage) UpdateTaskAttributes(task task_models.Task) error {
-	ret := _m.Called(task)
+// DeleteCustomField provides a mock function with given fields: fieldID, projectID
+func (_m *Storage) DeleteCustomField(fieldID string, projectID string) error {
+	ret := _m.Called(fieldID, projectID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTaskAttributes")
+		panic("no return value specified for DeleteCustomField")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(task_models.Task) error); ok {
-		r0 = rf(task)
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(fieldID, projectID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -295,27 +488,27 @@ func (_m *Storage) UpdateTaskAttributes(task task_models.Task) error {
 	return r0
 }
 
-// UpdateUser provides a mock function with given fields: user
-func (_m *Storage) UpdateUser(user user_models.User) (user_models.User, error) {
-	ret := _m.Called(user)
+// DeleteExpiredIdempotencyKeys provides a mock function with given fields: now
+func (_m *Storage) DeleteExpiredIdempotencyKeys(now time.Time) (int64, error) {
+	ret := _m.Called(now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for DeleteExpiredIdempotencyKeys")
 	}
 
-	var r0 user_models.User
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(user_models.User) (user_models.User, error)); ok {
-		return rf(user)
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(now)
 	}
-	if rf, ok := ret.Get(0).(func(user_models.User) user_models.User); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(now)
 	} else {
-		r0 = ret.Get(0).(user_models.User)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(user_models.User) error); ok {
-		r1 = rf(user)
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -323,6 +516,1903 @@ func (_m *Storage) UpdateUser(user user_models.User) (user_models.User, error) {
 	return r0, r1
 }
 
+// DeleteFilter provides a mock function with given fields: filterID, userID
+func (_m *Storage) DeleteFilter(filterID string, userID string) error {
+	ret := _m.Called(filterID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFilter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(filterID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMarkedTasks provides a mock function with no fields
+func (_m *Storage) DeleteMarkedTasks() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMarkedTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOrphanedBlobKey provides a mock function with given fields: key
+func (_m *Storage) DeleteOrphanedBlobKey(key string) error {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOrphanedBlobKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePublishedOutboxEvents provides a mock function with given fields: before
+func (_m *Storage) DeletePublishedOutboxEvents(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePublishedOutboxEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteReminder provides a mock function with given fields: reminderID, taskID
+func (_m *Storage) DeleteReminder(reminderID string, taskID string) error {
+	ret := _m.Called(reminderID, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReminder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(reminderID, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTag provides a mock function with given fields: tagID, userID
+func (_m *Storage) DeleteTag(tagID string, userID string) error {
+	ret := _m.Called(tagID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(tagID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTask provides a mock function with given fields: taskID, userID
+func (_m *Storage) DeleteTask(taskID string, userID string) error {
+	ret := _m.Called(taskID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(taskID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTemplate provides a mock function with given fields: templateID, userID
+func (_m *Storage) DeleteTemplate(templateID string, userID string) error {
+	ret := _m.Called(templateID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(templateID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTimeEntry provides a mock function with given fields: entryID, taskID
+func (_m *Storage) DeleteTimeEntry(entryID string, taskID string) error {
+	ret := _m.Called(entryID, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTimeEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(entryID, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUser provides a mock function with given fields: userID
+func (_m *Storage) DeleteUser(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWebhook provides a mock function with given fields: webhookID, userID
+func (_m *Storage) DeleteWebhook(webhookID string, userID string) error {
+	ret := _m.Called(webhookID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(webhookID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindTasks provides a mock function with given fields: userID, filter
+func (_m *Storage) FindTasks(userID string, filter taskmodels.TaskFilter) ([]taskmodels.Task, error) {
+	ret := _m.Called(userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTasks")
+	}
+
+	var r0 []taskmodels.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, taskmodels.TaskFilter) ([]taskmodels.Task, error)); ok {
+		return rf(userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(string, taskmodels.TaskFilter) []taskmodels.Task); ok {
+		r0 = rf(userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]taskmodels.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, taskmodels.TaskFilter) error); ok {
+		r1 = rf(userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTasksByFilter provides a mock function with given fields: userID, expr
+func (_m *Storage) FindTasksByFilter(userID string, expr filtermodels.Expr) ([]taskmodels.Task, error) {
+	ret := _m.Called(userID, expr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTasksByFilter")
+	}
+
+	var r0 []taskmodels.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, filtermodels.Expr) ([]taskmodels.Task, error)); ok {
+		return rf(userID, expr)
+	}
+	if rf, ok := ret.Get(0).(func(string, filtermodels.Expr) []taskmodels.Task); ok {
+		r0 = rf(userID, expr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]taskmodels.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, filtermodels.Expr) error); ok {
+		r1 = rf(userID, expr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FireDueReminders provides a mock function with given fields: now, limit, fire
+func (_m *Storage) FireDueReminders(now time.Time, limit int, fire func(remindermodels.Notification) error) (int, error) {
+	ret := _m.Called(now, limit, fire)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FireDueReminders")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, int, func(remindermodels.Notification) error) (int, error)); ok {
+		return rf(now, limit, fire)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, int, func(remindermodels.Notification) error) int); ok {
+		r0 = rf(now, limit, fire)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, int, func(remindermodels.Notification) error) error); ok {
+		r1 = rf(now, limit, fire)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllTasks provides a mock function with given fields: userID
+func (_m *Storage) GetAllTasks(userID string) ([]taskmodels.Task, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTasks")
+	}
+
+	var r0 []taskmodels.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]taskmodels.Task, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []taskmodels.Task); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]taskmodels.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllUsers provides a mock function with no fields
+func (_m *Storage) GetAllUsers() ([]usermodels.User, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllUsers")
+	}
+
+	var r0 []usermodels.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]usermodels.User, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []usermodels.User); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usermodels.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAttachmentByID provides a mock function with given fields: attachmentID, taskID
+func (_m *Storage) GetAttachmentByID(attachmentID string, taskID string) (attachmentmodels.Attachment, error) {
+	ret := _m.Called(attachmentID, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachmentByID")
+	}
+
+	var r0 attachmentmodels.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (attachmentmodels.Attachment, error)); ok {
+		return rf(attachmentID, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) attachmentmodels.Attachment); ok {
+		r0 = rf(attachmentID, taskID)
+	} else {
+		r0 = ret.Get(0).(attachmentmodels.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(attachmentID, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommentByID provides a mock function with given fields: commentID, taskID
+func (_m *Storage) GetCommentByID(commentID string, taskID string) (commentmodels.Comment, error) {
+	ret := _m.Called(commentID, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentByID")
+	}
+
+	var r0 commentmodels.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (commentmodels.Comment, error)); ok {
+		return rf(commentID, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) commentmodels.Comment); ok {
+		r0 = rf(commentID, taskID)
+	} else {
+		r0 = ret.Get(0).(commentmodels.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(commentID, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCustomFields provides a mock function with given fields: projectID
+func (_m *Storage) GetCustomFields(projectID string) ([]customfieldmodels.CustomField, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomFields")
+	}
+
+	var r0 []customfieldmodels.CustomField
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]customfieldmodels.CustomField, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []customfieldmodels.CustomField); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]customfieldmodels.CustomField)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilterByID provides a mock function with given fields: filterID, userID
+func (_m *Storage) GetFilterByID(filterID string, userID string) (filtermodels.Filter, error) {
+	ret := _m.Called(filterID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilterByID")
+	}
+
+	var r0 filtermodels.Filter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (filtermodels.Filter, error)); ok {
+		return rf(filterID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) filtermodels.Filter); ok {
+		r0 = rf(filterID, userID)
+	} else {
+		r0 = ret.Get(0).(filtermodels.Filter)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(filterID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFiltersByUser provides a mock function with given fields: userID
+func (_m *Storage) GetFiltersByUser(userID string) ([]filtermodels.Filter, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFiltersByUser")
+	}
+
+	var r0 []filtermodels.Filter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]filtermodels.Filter, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []filtermodels.Filter); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]filtermodels.Filter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastTaskPosition provides a mock function with given fields: userID
+func (_m *Storage) GetLastTaskPosition(userID string) (string, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastTaskPosition")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrphanedBlobKeys provides a mock function with given fields: limit
+func (_m *Storage) GetOrphanedBlobKeys(limit int) ([]string, error) {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrphanedBlobKeys")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]string, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []string); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectByID provides a mock function with given fields: projectID
+func (_m *Storage) GetProjectByID(projectID string) (projectmodels.Project, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectByID")
+	}
+
+	var r0 projectmodels.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (projectmodels.Project, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(string) projectmodels.Project); ok {
+		r0 = rf(projectID)
+	} else {
+		r0 = ret.Get(0).(projectmodels.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectsByUser provides a mock function with given fields: userID
+func (_m *Storage) GetProjectsByUser(userID string) ([]projectmodels.Project, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectsByUser")
+	}
+
+	var r0 []projectmodels.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]projectmodels.Project, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []projectmodels.Project); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]projectmodels.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReportEntries provides a mock function with given fields: userID, from, to
+func (_m *Storage) GetReportEntries(userID string, from time.Time, to time.Time) ([]timeentrymodels.ReportEntry, error) {
+	ret := _m.Called(userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReportEntries")
+	}
+
+	var r0 []timeentrymodels.ReportEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) ([]timeentrymodels.ReportEntry, error)); ok {
+		return rf(userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) []timeentrymodels.ReportEntry); ok {
+		r0 = rf(userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]timeentrymodels.ReportEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRunningTimer provides a mock function with given fields: userID
+func (_m *Storage) GetRunningTimer(userID string) (timeentrymodels.TimeEntry, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRunningTimer")
+	}
+
+	var r0 timeentrymodels.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (timeentrymodels.TimeEntry, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) timeentrymodels.TimeEntry); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(timeentrymodels.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSeriesTasks provides a mock function with given fields: seriesID
+func (_m *Storage) GetSeriesTasks(seriesID string) ([]taskmodels.Task, error) {
+	ret := _m.Called(seriesID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeriesTasks")
+	}
+
+	var r0 []taskmodels.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]taskmodels.Task, error)); ok {
+		return rf(seriesID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []taskmodels.Task); ok {
+		r0 = rf(seriesID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]taskmodels.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(seriesID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubtasks provides a mock function with given fields: taskID
+func (_m *Storage) GetSubtasks(taskID string) ([]taskmodels.Task, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubtasks")
+	}
+
+	var r0 []taskmodels.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]taskmodels.Task, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []taskmodels.Task); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]taskmodels.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTagByID provides a mock function with given fields: tagID, userID
+func (_m *Storage) GetTagByID(tagID string, userID string) (tagmodels.Tag, error) {
+	ret := _m.Called(tagID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagByID")
+	}
+
+	var r0 tagmodels.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (tagmodels.Tag, error)); ok {
+		return rf(tagID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) tagmodels.Tag); ok {
+		r0 = rf(tagID, userID)
+	} else {
+		r0 = ret.Get(0).(tagmodels.Tag)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tagID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTagsByUser provides a mock function with given fields: userID
+func (_m *Storage) GetTagsByUser(userID string) ([]tagmodels.Tag, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagsByUser")
+	}
+
+	var r0 []tagmodels.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]tagmodels.Tag, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []tagmodels.Tag); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tagmodels.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskAssignments provides a mock function with given fields: taskID
+func (_m *Storage) GetTaskAssignments(taskID string) ([]taskmodels.Assignment, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskAssignments")
+	}
+
+	var r0 []taskmodels.Assignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]taskmodels.Assignment, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []taskmodels.Assignment); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]taskmodels.Assignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskAttachments provides a mock function with given fields: taskID
+func (_m *Storage) GetTaskAttachments(taskID string) ([]attachmentmodels.Attachment, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskAttachments")
+	}
+
+	var r0 []attachmentmodels.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]attachmentmodels.Attachment, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []attachmentmodels.Attachment); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]attachmentmodels.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskByID provides a mock function with given fields: taskID, userID
+func (_m *Storage) GetTaskByID(taskID string, userID string) (taskmodels.Task, error) {
+	ret := _m.Called(taskID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskByID")
+	}
+
+	var r0 taskmodels.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (taskmodels.Task, error)); ok {
+		return rf(taskID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) taskmodels.Task); ok {
+		r0 = rf(taskID, userID)
+	} else {
+		r0 = ret.Get(0).(taskmodels.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(taskID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskComments provides a mock function with given fields: taskID
+func (_m *Storage) GetTaskComments(taskID string) ([]commentmodels.Comment, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskComments")
+	}
+
+	var r0 []commentmodels.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]commentmodels.Comment, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []commentmodels.Comment); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]commentmodels.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskHistory provides a mock function with given fields: taskID
+func (_m *Storage) GetTaskHistory(taskID string) ([]taskmodels.HistoryEntry, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskHistory")
+	}
+
+	var r0 []taskmodels.HistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]taskmodels.HistoryEntry, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []taskmodels.HistoryEntry); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]taskmodels.HistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskReminders provides a mock function with given fields: taskID
+func (_m *Storage) GetTaskReminders(taskID string) ([]remindermodels.Reminder, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskReminders")
+	}
+
+	var r0 []remindermodels.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]remindermodels.Reminder, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []remindermodels.Reminder); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]remindermodels.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskTimeEntries provides a mock function with given fields: taskID
+func (_m *Storage) GetTaskTimeEntries(taskID string) ([]timeentrymodels.TimeEntry, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskTimeEntries")
+	}
+
+	var r0 []timeentrymodels.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]timeentrymodels.TimeEntry, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []timeentrymodels.TimeEntry); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]timeentrymodels.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskWatchers provides a mock function with given fields: taskID
+func (_m *Storage) GetTaskWatchers(taskID string) ([]string, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskWatchers")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemplateByID provides a mock function with given fields: templateID, userID
+func (_m *Storage) GetTemplateByID(templateID string, userID string) (templatemodels.Template, error) {
+	ret := _m.Called(templateID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateByID")
+	}
+
+	var r0 templatemodels.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (templatemodels.Template, error)); ok {
+		return rf(templateID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) templatemodels.Template); ok {
+		r0 = rf(templateID, userID)
+	} else {
+		r0 = ret.Get(0).(templatemodels.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(templateID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemplatesByUser provides a mock function with given fields: userID
+func (_m *Storage) GetTemplatesByUser(userID string) ([]templatemodels.Template, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplatesByUser")
+	}
+
+	var r0 []templatemodels.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]templatemodels.Template, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []templatemodels.Template); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]templatemodels.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTimeEntryByID provides a mock function with given fields: entryID, taskID
+func (_m *Storage) GetTimeEntryByID(entryID string, taskID string) (timeentrymodels.TimeEntry, error) {
+	ret := _m.Called(entryID, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeEntryByID")
+	}
+
+	var r0 timeentrymodels.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (timeentrymodels.TimeEntry, error)); ok {
+		return rf(entryID, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) timeentrymodels.TimeEntry); ok {
+		r0 = rf(entryID, taskID)
+	} else {
+		r0 = ret.Get(0).(timeentrymodels.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(entryID, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnfinishedBlockers provides a mock function with given fields: taskID
+func (_m *Storage) GetUnfinishedBlockers(taskID string) ([]string, error) {
+	ret := _m.Called(taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnfinishedBlockers")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(taskID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: email
+func (_m *Storage) GetUserByEmail(email string) (usermodels.User, error) {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 usermodels.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (usermodels.User, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) usermodels.User); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Get(0).(usermodels.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: userID
+func (_m *Storage) GetUserByID(userID string) (usermodels.User, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 usermodels.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (usermodels.User, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) usermodels.User); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(usermodels.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookByID provides a mock function with given fields: webhookID, userID
+func (_m *Storage) GetWebhookByID(webhookID string, userID string) (webhookmodels.Webhook, error) {
+	ret := _m.Called(webhookID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookByID")
+	}
+
+	var r0 webhookmodels.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (webhookmodels.Webhook, error)); ok {
+		return rf(webhookID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) webhookmodels.Webhook); ok {
+		r0 = rf(webhookID, userID)
+	} else {
+		r0 = ret.Get(0).(webhookmodels.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(webhookID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDeliveries provides a mock function with given fields: webhookID, limit
+func (_m *Storage) GetWebhookDeliveries(webhookID string, limit int) ([]webhookmodels.Delivery, error) {
+	ret := _m.Called(webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDeliveries")
+	}
+
+	var r0 []webhookmodels.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]webhookmodels.Delivery, error)); ok {
+		return rf(webhookID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []webhookmodels.Delivery); ok {
+		r0 = rf(webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookmodels.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDelivery provides a mock function with given fields: deliveryID, webhookID
+func (_m *Storage) GetWebhookDelivery(deliveryID string, webhookID string) (webhookmodels.Delivery, error) {
+	ret := _m.Called(deliveryID, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 webhookmodels.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (webhookmodels.Delivery, error)); ok {
+		return rf(deliveryID, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) webhookmodels.Delivery); ok {
+		r0 = rf(deliveryID, webhookID)
+	} else {
+		r0 = ret.Get(0).(webhookmodels.Delivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(deliveryID, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooksByUser provides a mock function with given fields: userID
+func (_m *Storage) GetWebhooksByUser(userID string) ([]webhookmodels.Webhook, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooksByUser")
+	}
+
+	var r0 []webhookmodels.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]webhookmodels.Webhook, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []webhookmodels.Webhook); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookmodels.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooksForEvent provides a mock function with given fields: userID, eventType
+func (_m *Storage) GetWebhooksForEvent(userID string, eventType webhookmodels.EventType) ([]webhookmodels.Webhook, error) {
+	ret := _m.Called(userID, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhooksForEvent")
+	}
+
+	var r0 []webhookmodels.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, webhookmodels.EventType) ([]webhookmodels.Webhook, error)); ok {
+		return rf(userID, eventType)
+	}
+	if rf, ok := ret.Get(0).(func(string, webhookmodels.EventType) []webhookmodels.Webhook); ok {
+		r0 = rf(userID, eventType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookmodels.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, webhookmodels.EventType) error); ok {
+		r1 = rf(userID, eventType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkflow provides a mock function with given fields: projectID
+func (_m *Storage) GetWorkflow(projectID string) (workflowmodels.Workflow, error) {
+	ret := _m.Called(projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflow")
+	}
+
+	var r0 workflowmodels.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (workflowmodels.Workflow, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(string) workflowmodels.Workflow); ok {
+		r0 = rf(projectID)
+	} else {
+		r0 = ret.Get(0).(workflowmodels.Workflow)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasDependencyPath provides a mock function with given fields: fromID, toID
+func (_m *Storage) HasDependencyPath(fromID string, toID string) (bool, error) {
+	ret := _m.Called(fromID, toID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasDependencyPath")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(fromID, toID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(fromID, toID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(fromID, toID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsProjectMember provides a mock function with given fields: projectID, userID
+func (_m *Storage) IsProjectMember(projectID string, userID string) (bool, error) {
+	ret := _m.Called(projectID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsProjectMember")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(projectID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(projectID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkTaskToDelete provides a mock function with given fields: taskID, userID
+func (_m *Storage) MarkTaskToDelete(taskID string, userID string) error {
+	ret := _m.Called(taskID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkTaskToDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(taskID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RelayOutboxEvents provides a mock function with given fields: limit, publish
+func (_m *Storage) RelayOutboxEvents(limit int, publish func(eventmodels.Event) error) (int, error) {
+	ret := _m.Called(limit, publish)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RelayOutboxEvents")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, func(eventmodels.Event) error) (int, error)); ok {
+		return rf(limit, publish)
+	}
+	if rf, ok := ret.Get(0).(func(int, func(eventmodels.Event) error) int); ok {
+		r0 = rf(limit, publish)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, func(eventmodels.Event) error) error); ok {
+		r1 = rf(limit, publish)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseIdempotencyKey provides a mock function with given fields: userID, key
+func (_m *Storage) ReleaseIdempotencyKey(userID string, key string) error {
+	ret := _m.Called(userID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveProjectMember provides a mock function with given fields: projectID, userID
+func (_m *Storage) RemoveProjectMember(projectID string, userID string) error {
+	ret := _m.Called(projectID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveProjectMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTaskDependency provides a mock function with given fields: taskID, blockerID
+func (_m *Storage) RemoveTaskDependency(taskID string, blockerID string) error {
+	ret := _m.Called(taskID, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTaskDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(taskID, blockerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTaskWatcher provides a mock function with given fields: taskID, userID
+func (_m *Storage) RemoveTaskWatcher(taskID string, userID string) error {
+	ret := _m.Called(taskID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTaskWatcher")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(taskID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveIdempotencyKey provides a mock function with given fields: record
+func (_m *Storage) ReserveIdempotencyKey(record idempotencymodels.Record) (idempotencymodels.Record, bool, error) {
+	ret := _m.Called(record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveIdempotencyKey")
+	}
+
+	var r0 idempotencymodels.Record
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(idempotencymodels.Record) (idempotencymodels.Record, bool, error)); ok {
+		return rf(record)
+	}
+	if rf, ok := ret.Get(0).(func(idempotencymodels.Record) idempotencymodels.Record); ok {
+		r0 = rf(record)
+	} else {
+		r0 = ret.Get(0).(idempotencymodels.Record)
+	}
+
+	if rf, ok := ret.Get(1).(func(idempotencymodels.Record) bool); ok {
+		r1 = rf(record)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(idempotencymodels.Record) error); ok {
+		r2 = rf(record)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SaveUser provides a mock function with given fields: user
+func (_m *Storage) SaveUser(user usermodels.User) (usermodels.User, error) {
+	ret := _m.Called(user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUser")
+	}
+
+	var r0 usermodels.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(usermodels.User) (usermodels.User, error)); ok {
+		return rf(user)
+	}
+	if rf, ok := ret.Get(0).(func(usermodels.User) usermodels.User); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Get(0).(usermodels.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(usermodels.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveWorkflow provides a mock function with given fields: workflow
+func (_m *Storage) SaveWorkflow(workflow workflowmodels.Workflow) error {
+	ret := _m.Called(workflow)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWorkflow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(workflowmodels.Workflow) error); ok {
+		r0 = rf(workflow)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchTasks provides a mock function with given fields: userID, query
+func (_m *Storage) SearchTasks(userID string, query taskmodels.SearchQuery) ([]taskmodels.SearchResult, error) {
+	ret := _m.Called(userID, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTasks")
+	}
+
+	var r0 []taskmodels.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, taskmodels.SearchQuery) ([]taskmodels.SearchResult, error)); ok {
+		return rf(userID, query)
+	}
+	if rf, ok := ret.Get(0).(func(string, taskmodels.SearchQuery) []taskmodels.SearchResult); ok {
+		r0 = rf(userID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]taskmodels.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, taskmodels.SearchQuery) error); ok {
+		r1 = rf(userID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTaskTags provides a mock function with given fields: taskID, userID, tagIDs
+func (_m *Storage) SetTaskTags(taskID string, userID string, tagIDs []string) error {
+	ret := _m.Called(taskID, userID, tagIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTaskTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
+		r0 = rf(taskID, userID, tagIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StopTimer provides a mock function with given fields: userID, taskID, endedAt
+func (_m *Storage) StopTimer(userID string, taskID string, endedAt time.Time) (timeentrymodels.TimeEntry, error) {
+	ret := _m.Called(userID, taskID, endedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopTimer")
+	}
+
+	var r0 timeentrymodels.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) (timeentrymodels.TimeEntry, error)); ok {
+		return rf(userID, taskID, endedAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) timeentrymodels.TimeEntry); ok {
+		r0 = rf(userID, taskID, endedAt)
+	} else {
+		r0 = ret.Get(0).(timeentrymodels.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) error); ok {
+		r1 = rf(userID, taskID, endedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateComment provides a mock function with given fields: comment
+func (_m *Storage) UpdateComment(comment commentmodels.Comment) error {
+	ret := _m.Called(comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(commentmodels.Comment) error); ok {
+		r0 = rf(comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateFilter provides a mock function with given fields: filter
+func (_m *Storage) UpdateFilter(filter filtermodels.Filter) error {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFilter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(filtermodels.Filter) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTag provides a mock function with given fields: tag
+func (_m *Storage) UpdateTag(tag tagmodels.Tag) error {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(tagmodels.Tag) error); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTaskAttributes provides a mock function with given fields: task
+func (_m *Storage) UpdateTaskAttributes(task taskmodels.Task) error {
+	ret := _m.Called(task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTaskAttributes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(taskmodels.Task) error); ok {
+		r0 = rf(task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTaskChecklist provides a mock function with given fields: taskID, checklist
+func (_m *Storage) UpdateTaskChecklist(taskID string, checklist []taskmodels.ChecklistItem) error {
+	ret := _m.Called(taskID, checklist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTaskChecklist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []taskmodels.ChecklistItem) error); ok {
+		r0 = rf(taskID, checklist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTaskFields provides a mock function with given fields: task, fields
+func (_m *Storage) UpdateTaskFields(task taskmodels.Task, fields []taskmodels.TaskField) error {
+	ret := _m.Called(task, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTaskFields")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(taskmodels.Task, []taskmodels.TaskField) error); ok {
+		r0 = rf(task, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTaskPosition provides a mock function with given fields: taskID, userID, position
+func (_m *Storage) UpdateTaskPosition(taskID string, userID string, position string) error {
+	ret := _m.Called(taskID, userID, position)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTaskPosition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(taskID, userID, position)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTimeEntry provides a mock function with given fields: entry
+func (_m *Storage) UpdateTimeEntry(entry timeentrymodels.TimeEntry) error {
+	ret := _m.Called(entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTimeEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(timeentrymodels.TimeEntry) error); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: user
+func (_m *Storage) UpdateUser(user usermodels.User) (usermodels.User, error) {
+	ret := _m.Called(user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 usermodels.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(usermodels.User) (usermodels.User, error)); ok {
+		return rf(user)
+	}
+	if rf, ok := ret.Get(0).(func(usermodels.User) usermodels.User); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Get(0).(usermodels.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(usermodels.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWebhookDelivery provides a mock function with given fields: delivery
+func (_m *Storage) UpdateWebhookDelivery(delivery webhookmodels.Delivery) error {
+	ret := _m.Called(delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(webhookmodels.Delivery) error); ok {
+		r0 = rf(delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStorage(t interface {
@@ -0,0 +1,382 @@
+// Package presence - комнаты задач поверх WebSocket: кто открыл задачу, кто печатает или редактирует,
+// плюс изменения задачи из шины событий для участников её комнаты.
+package presence
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/presence/presenceerrors"
+	"toDoList/internal/domain/presence/presencemodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/eventbus"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// sendBuffer - сколько сообщений может ждать отправки, прежде чем медленного клиента отключат.
+	sendBuffer     = 32
+	writeWait      = internal.SecTen
+	pongWait       = internal.MinOne
+	pingPeriod     = pongWait * 9 / 10
+	maxMessageSize = 4096
+)
+
+// Hub - комнаты задач и их участники. Одно соединение может быть в нескольких комнатах.
+type Hub struct {
+	mu      sync.Mutex
+	rooms   map[string]map[*client]presencemodels.Activity
+	clients map[*client]struct{}
+	closed  bool
+	serving sync.WaitGroup
+	events  *eventbus.Bus
+	ctx     context.Context
+	log     zerolog.Logger
+}
+
+type client struct {
+	userID string
+	conn   *websocket.Conn
+	send   chan presencemodels.ServerMessage
+	// rooms - ID задач, в комнатах которых состоит клиент, защищены Hub.mu.
+	rooms map[string]struct{}
+}
+
+func NewHub(ctx context.Context, events *eventbus.Bus, log zerolog.Logger) *Hub {
+	return &Hub{
+		rooms:   make(map[string]map[*client]presencemodels.Activity),
+		clients: make(map[*client]struct{}),
+		events:  events,
+		ctx:     ctx,
+		log:     log,
+	}
+}
+
+// Start - рассылает изменения задач участникам их комнат, пока не закроется шина событий.
+func (h *Hub) Start() {
+	filter := func(event eventmodels.Event) bool { return event.AggregateType == eventmodels.AggregateTask }
+	var lastID int64
+
+	for {
+		sub, replay, _ := h.events.Subscribe(filter, lastID)
+		for _, message := range replay {
+			h.broadcastTask(message.Event)
+			lastID = message.ID
+		}
+
+		if !h.consume(sub, &lastID) {
+			h.log.Info().Msg("presence Hub stopped")
+			return
+		}
+		// Шина отключила отставшую подписку: переподписываемся и догоняем пропущенное из её буфера.
+		h.log.Warn().Msg("presence Hub fell behind event bus, resubscribing")
+	}
+}
+
+// consume - читает подписку до её закрытия, false означает остановку хаба.
+func (h *Hub) consume(sub *eventbus.Subscription, lastID *int64) bool {
+	defer sub.Close()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return false
+		case message, open := <-sub.Messages():
+			if !open {
+				return !h.events.Closed() && !h.isClosed() && h.ctx.Err() == nil
+			}
+			h.broadcastTask(message.Event)
+			*lastID = message.ID
+		}
+	}
+}
+
+func (h *Hub) broadcastTask(event eventmodels.Event) {
+	var task taskmodels.Task
+	if err := json.Unmarshal(event.Payload, &task); err != nil {
+		h.log.Error().Err(err).Str("event", event.ID).Msg("failed to decode task event")
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for c := range h.rooms[task.ID] {
+		h.enqueue(c, presencemodels.ServerMessage{
+			Type:   presencemodels.MessageType(event.Type),
+			TaskID: task.ID,
+			Task:   &task,
+		})
+	}
+}
+
+// Serve - обслуживает соединение до его закрытия. authorize проверяет, может ли пользователь
+// войти в комнату задачи.
+func (h *Hub) Serve(conn *websocket.Conn, userID string, authorize func(taskID string) error) {
+	c := &client{
+		userID: userID,
+		conn:   conn,
+		send:   make(chan presencemodels.ServerMessage, sendBuffer),
+		rooms:  make(map[string]struct{}),
+	}
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		closeConn(conn)
+		return
+	}
+	h.clients[c] = struct{}{}
+	h.serving.Add(1)
+	h.mu.Unlock()
+
+	defer h.serving.Done()
+
+	go c.writePump()
+	h.readPump(c, authorize)
+
+	h.mu.Lock()
+	h.disconnect(c, true)
+	h.mu.Unlock()
+}
+
+func (h *Hub) readPump(c *client, authorize func(taskID string) error) {
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				h.log.Debug().Err(err).Str("user", c.userID).Msg("presence connection closed")
+			}
+			return
+		}
+
+		var message presencemodels.ClientMessage
+		if err = json.Unmarshal(data, &message); err == nil {
+			err = h.handle(c, message, authorize)
+		}
+		if err != nil {
+			h.mu.Lock()
+			h.enqueue(c, presencemodels.ServerMessage{
+				Type:   presencemodels.MessageError,
+				TaskID: message.TaskID,
+				Error:  err.Error(),
+			})
+			h.mu.Unlock()
+		}
+	}
+}
+
+func (h *Hub) handle(c *client, message presencemodels.ClientMessage, authorize func(taskID string) error) error {
+	switch message.Type {
+	case presencemodels.MessageJoin:
+		if err := authorize(message.TaskID); err != nil {
+			return err
+		}
+		h.join(c, message.TaskID)
+		return nil
+	case presencemodels.MessageLeave:
+		return h.leave(c, message.TaskID)
+	case presencemodels.MessageActivity:
+		if !message.Activity.IsValid() {
+			return presenceerrors.ErrWrongActivity
+		}
+		return h.setActivity(c, message.TaskID, message.Activity)
+	default:
+		return presenceerrors.ErrUnknownMessage
+	}
+}
+
+// join - новичок получает список участников, остальные - сообщение joined.
+func (h *Hub) join(c *client, taskID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, ok := h.rooms[taskID]
+	if !ok {
+		room = make(map[*client]presencemodels.Activity)
+		h.rooms[taskID] = room
+	}
+	if _, joined := room[c]; !joined {
+		room[c] = presencemodels.ActivityViewing
+		c.rooms[taskID] = struct{}{}
+		h.broadcast(taskID, c, presencemodels.ServerMessage{
+			Type:     presencemodels.MessageJoined,
+			TaskID:   taskID,
+			UserID:   c.userID,
+			Activity: presencemodels.ActivityViewing,
+		})
+	}
+
+	members := make([]presencemodels.Member, 0, len(room))
+	for member, activity := range room {
+		members = append(members, presencemodels.Member{UserID: member.userID, Activity: activity})
+	}
+	slices.SortFunc(members, func(a, b presencemodels.Member) int { return strings.Compare(a.UserID, b.UserID) })
+
+	h.enqueue(c, presencemodels.ServerMessage{Type: presencemodels.MessagePresence, TaskID: taskID, Members: members})
+}
+
+func (h *Hub) leave(c *client, taskID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := c.rooms[taskID]; !ok {
+		return presenceerrors.ErrNotInRoom
+	}
+	h.leaveRoom(c, taskID, true)
+	return nil
+}
+
+func (h *Hub) setActivity(c *client, taskID string, activity presencemodels.Activity) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, ok := h.rooms[taskID]
+	if _, joined := room[c]; !ok || !joined {
+		return presenceerrors.ErrNotInRoom
+	}
+	if room[c] == activity {
+		return nil
+	}
+
+	room[c] = activity
+	h.broadcast(taskID, c, presencemodels.ServerMessage{
+		Type:     presencemodels.MessageActivity,
+		TaskID:   taskID,
+		UserID:   c.userID,
+		Activity: activity,
+	})
+	return nil
+}
+
+// leaveRoom - вызывается под h.mu.
+func (h *Hub) leaveRoom(c *client, taskID string, notify bool) {
+	room := h.rooms[taskID]
+	delete(room, c)
+	delete(c.rooms, taskID)
+	if len(room) == 0 {
+		delete(h.rooms, taskID)
+	}
+
+	if notify {
+		h.broadcast(taskID, c, presencemodels.ServerMessage{
+			Type:   presencemodels.MessageLeft,
+			TaskID: taskID,
+			UserID: c.userID,
+		})
+	}
+}
+
+// broadcast - сообщение всем участникам комнаты, кроме except. Вызывается под h.mu.
+func (h *Hub) broadcast(taskID string, except *client, message presencemodels.ServerMessage) {
+	for c := range h.rooms[taskID] {
+		if c != except {
+			h.enqueue(c, message)
+		}
+	}
+}
+
+// enqueue - не блокируется: клиент, который не успевает читать, отключается. Вызывается под h.mu.
+func (h *Hub) enqueue(c *client, message presencemodels.ServerMessage) {
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+
+	select {
+	case c.send <- message:
+	default:
+		h.log.Warn().Str("user", c.userID).Msg("presence client is too slow, disconnecting")
+		h.disconnect(c, true)
+	}
+}
+
+// disconnect - выводит клиента из всех комнат и закрывает его очередь, после чего writePump
+// закрывает соединение. Вызывается под h.mu.
+func (h *Hub) disconnect(c *client, notify bool) {
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+	delete(h.clients, c)
+
+	for taskID := range c.rooms {
+		h.leaveRoom(c, taskID, notify)
+	}
+	close(c.send)
+}
+
+func (h *Hub) isClosed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.closed
+}
+
+// Close - закрывает все соединения и ждёт завершения их обработчиков, но не дольше ctx.
+func (h *Hub) Close(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	for c := range h.clients {
+		h.disconnect(c, false)
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.serving.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			if !ok {
+				closeConn(c.conn)
+				return
+			}
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeConn - вежливо закрывает соединение кадром close.
+func closeConn(conn *websocket.Conn) {
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "")
+	_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait))
+	_ = conn.Close()
+}
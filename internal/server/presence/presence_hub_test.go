@@ -0,0 +1,175 @@
+package presence
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/presence/presenceerrors"
+	"toDoList/internal/domain/presence/presencemodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/eventbus"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var upgrader = websocket.Upgrader{}
+
+// newTestHub - пользователь берётся из query, в комнату "secret" пускают только u1.
+func newTestHub(t *testing.T) (*Hub, *eventbus.Bus, string) {
+	bus := eventbus.NewBus(10)
+	hub := NewHub(context.Background(), bus, zerolog.Nop())
+	go hub.Start()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		userID := r.URL.Query().Get("user")
+		hub.Serve(conn, userID, func(taskID string) error {
+			if taskID == "secret" && userID != "u1" {
+				return taskerrors.ErrFoundNothing
+			}
+			return nil
+		})
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(bus.Close)
+
+	return hub, bus, "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func dial(t *testing.T, url string, userID string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?user="+userID, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, message presencemodels.ClientMessage) {
+	require.NoError(t, conn.WriteJSON(message))
+}
+
+func receive(t *testing.T, conn *websocket.Conn) presencemodels.ServerMessage {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	var message presencemodels.ServerMessage
+	require.NoError(t, conn.ReadJSON(&message))
+	return message
+}
+
+func TestHub_Rooms(t *testing.T) {
+	_, bus, url := newTestHub(t)
+	alice := dial(t, url, "u1")
+	bob := dial(t, url, "u2")
+
+	send(t, alice, presencemodels.ClientMessage{Type: presencemodels.MessageJoin, TaskID: "t1"})
+	assert.Equal(t, presencemodels.ServerMessage{
+		Type: presencemodels.MessagePresence, TaskID: "t1",
+		Members: []presencemodels.Member{{UserID: "u1", Activity: presencemodels.ActivityViewing}},
+	}, receive(t, alice))
+
+	send(t, bob, presencemodels.ClientMessage{Type: presencemodels.MessageJoin, TaskID: "t1"})
+	assert.Len(t, receive(t, bob).Members, 2)
+	assert.Equal(t, presencemodels.ServerMessage{
+		Type: presencemodels.MessageJoined, TaskID: "t1", UserID: "u2", Activity: presencemodels.ActivityViewing,
+	}, receive(t, alice))
+
+	send(t, bob, presencemodels.ClientMessage{
+		Type: presencemodels.MessageActivity, TaskID: "t1", Activity: presencemodels.ActivityTyping,
+	})
+	assert.Equal(t, presencemodels.ServerMessage{
+		Type: presencemodels.MessageActivity, TaskID: "t1", UserID: "u2", Activity: presencemodels.ActivityTyping,
+	}, receive(t, alice))
+
+	// Изменение задачи приходит всем участникам комнаты в представлении REST API.
+	task := taskmodels.Task{ID: "t1", UserID: "u1", Attributes: taskmodels.TaskAttributes{Title: "Report"}}
+	event, err := eventmodels.NewTaskEvent(eventmodels.TaskUpdated, task)
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	for _, conn := range []*websocket.Conn{alice, bob} {
+		message := receive(t, conn)
+		assert.Equal(t, presencemodels.MessageType("task.updated"), message.Type)
+		require.NotNil(t, message.Task)
+		assert.Equal(t, task, *message.Task)
+	}
+
+	// Разрыв соединения - то же, что выход из всех комнат.
+	require.NoError(t, bob.Close())
+	assert.Equal(t, presencemodels.ServerMessage{
+		Type: presencemodels.MessageLeft, TaskID: "t1", UserID: "u2",
+	}, receive(t, alice))
+}
+
+func TestHub_Errors(t *testing.T) {
+	_, _, url := newTestHub(t)
+	bob := dial(t, url, "u2")
+
+	tests := []struct {
+		name    string
+		message presencemodels.ClientMessage
+		wantErr error
+	}{
+		{
+			"no access",
+			presencemodels.ClientMessage{Type: presencemodels.MessageJoin, TaskID: "secret"},
+			taskerrors.ErrFoundNothing,
+		},
+		{
+			"not in room",
+			presencemodels.ClientMessage{
+				Type: presencemodels.MessageActivity, TaskID: "t1", Activity: presencemodels.ActivityEditing,
+			},
+			presenceerrors.ErrNotInRoom,
+		},
+		{
+			"wrong activity",
+			presencemodels.ClientMessage{Type: presencemodels.MessageActivity, TaskID: "t1", Activity: "sleeping"},
+			presenceerrors.ErrWrongActivity,
+		},
+		{
+			"leave without join",
+			presencemodels.ClientMessage{Type: presencemodels.MessageLeave, TaskID: "t1"},
+			presenceerrors.ErrNotInRoom,
+		},
+		{"unknown", presencemodels.ClientMessage{Type: "dance"}, presenceerrors.ErrUnknownMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			send(t, bob, tt.message)
+			message := receive(t, bob)
+			assert.Equal(t, presencemodels.MessageError, message.Type)
+			assert.Equal(t, tt.wantErr.Error(), message.Error)
+		})
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	hub, _, url := newTestHub(t)
+	alice := dial(t, url, "u1")
+
+	send(t, alice, presencemodels.ClientMessage{Type: presencemodels.MessageJoin, TaskID: "t1"})
+	receive(t, alice)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, hub.Close(ctx))
+
+	require.NoError(t, alice.SetReadDeadline(time.Now().Add(time.Second)))
+	_, _, err := alice.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+
+	// После закрытия новые соединения сразу закрываются.
+	late := dial(t, url, "u2")
+	require.NoError(t, late.SetReadDeadline(time.Now().Add(time.Second)))
+	_, _, err = late.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+}
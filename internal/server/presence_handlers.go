@@ -0,0 +1,32 @@
+package server
+
+import (
+	"toDoList/internal/service/taskservice"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// upgrader - проверка Origin по умолчанию оставлена: авторизация идёт по cookie,
+// и чужой сайт не должен открывать соединение от имени пользователя.
+var upgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024}
+
+// taskPresence - WebSocket присутствия в комнатах задач: сообщения join, leave и activity.
+func (srv *ToDoListAPI) taskPresence(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// Upgrade уже ответил клиенту ошибкой.
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	srv.presence.Serve(conn, userID, func(taskID string) error {
+		_, errGet := taskService.GetTaskByID(taskID, userID)
+		return errGet
+	})
+}
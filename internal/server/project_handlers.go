@@ -0,0 +1,139 @@
+package server
+
+import (
+	"net/http"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/workflow/workflowmodels"
+	"toDoList/internal/service/projectservice"
+	"toDoList/internal/service/workflowservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getProjects(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	projectService := projectservice.NewProjectService(srv.db)
+	projects, err := projectService.GetProjects(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"projects": projects})
+}
+
+func (srv *ToDoListAPI) getProjectByID(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	projectService := projectservice.NewProjectService(srv.db)
+	project, err := projectService.GetProjectByID(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+func (srv *ToDoListAPI) createProject(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req projectmodels.ProjectRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	projectService := projectservice.NewProjectService(srv.db)
+	project, err := projectService.CreateProject(req, userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+func (srv *ToDoListAPI) addProjectMember(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req projectmodels.ProjectMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	projectService := projectservice.NewProjectService(srv.db)
+	if err := projectService.AddMember(ctx.Param("id"), userID, req); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Member was added")
+}
+
+func (srv *ToDoListAPI) removeProjectMember(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	projectService := projectservice.NewProjectService(srv.db)
+	if err := projectService.RemoveMember(ctx.Param("id"), userID, ctx.Param("user_id")); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Member was removed")
+}
+
+func (srv *ToDoListAPI) getProjectWorkflow(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	workflowService := workflowservice.NewWorkflowService(srv.db)
+	workflow, err := workflowService.GetWorkflow(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workflow)
+}
+
+func (srv *ToDoListAPI) updateProjectWorkflow(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req workflowmodels.WorkflowRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workflowService := workflowservice.NewWorkflowService(srv.db)
+	workflow, err := workflowService.UpdateWorkflow(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workflow)
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"net/http"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/service/reminderservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getReminders(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	reminderService := reminderservice.NewReminderService(srv.db)
+	reminders, err := reminderService.GetReminders(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"reminders": reminders})
+}
+
+// addReminder - тело {"at": "2026-01-02T09:00:00Z"} или {"before_minutes": 30}.
+func (srv *ToDoListAPI) addReminder(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req remindermodels.ReminderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminderService := reminderservice.NewReminderService(srv.db)
+	reminder, err := reminderService.AddReminder(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reminder)
+}
+
+func (srv *ToDoListAPI) deleteReminder(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	reminderService := reminderservice.NewReminderService(srv.db)
+	if err := reminderService.DeleteReminder(ctx.Param("id"), ctx.Param("reminder_id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Reminder was deleted")
+}
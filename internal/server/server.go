@@ -5,12 +5,29 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"time"
 	"toDoList/internal"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/idempotency/idempotencymodels"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/domain/tag/tagmodels"
 	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templatemodels"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
 	"toDoList/internal/domain/user/usermodels"
+	"toDoList/internal/domain/webhook/webhookmodels"
+	"toDoList/internal/domain/workflow/workflowmodels"
 	auth "toDoList/internal/server/auth/user_auth"
+	"toDoList/internal/server/eventbus"
 	"toDoList/internal/server/middleware"
+	"toDoList/internal/server/presence"
 	"toDoList/internal/server/workers"
+	"toDoList/pkg/blob"
 
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
@@ -32,16 +49,167 @@ type TaskStorage interface {
 	GetTaskByID(taskID string, userID string) (taskmodels.Task, error)
 	AddTask(newTask taskmodels.Task) error
 	UpdateTaskAttributes(task taskmodels.Task) error
+	UpdateTaskFields(task taskmodels.Task, fields []taskmodels.TaskField) error
 	DeleteTask(taskID string, userID string) error
 	MarkTaskToDelete(taskID string, userID string) error
 	DeleteMarkedTasks() error
+	FindTasks(userID string, filter taskmodels.TaskFilter) ([]taskmodels.Task, error)
+	SearchTasks(userID string, query taskmodels.SearchQuery) ([]taskmodels.SearchResult, error)
+	AddTaskAssignment(assignment taskmodels.Assignment) error
+	GetTaskAssignments(taskID string) ([]taskmodels.Assignment, error)
+	AddTaskWatcher(taskID string, userID string) error
+	RemoveTaskWatcher(taskID string, userID string) error
+	GetTaskWatchers(taskID string) ([]string, error)
+	GetLastTaskPosition(userID string) (string, error)
+	UpdateTaskPosition(taskID string, userID string, position string) error
+	GetSubtasks(taskID string) ([]taskmodels.Task, error)
+	UpdateTaskChecklist(taskID string, checklist []taskmodels.ChecklistItem) error
+	AddTaskDependency(taskID string, blockerID string) error
+	RemoveTaskDependency(taskID string, blockerID string) error
+	HasDependencyPath(fromID string, toID string) (bool, error)
+	GetUnfinishedBlockers(taskID string) ([]string, error)
+	GetSeriesTasks(seriesID string) ([]taskmodels.Task, error)
+	GetTaskHistory(taskID string) ([]taskmodels.HistoryEntry, error)
+	CountTasksInStatus(projectID string, status taskmodels.TaskStatus) (int, error)
+}
+
+type ProjectStorage interface {
+	AddProject(project projectmodels.Project) error
+	GetProjectsByUser(userID string) ([]projectmodels.Project, error)
+	GetProjectByID(projectID string) (projectmodels.Project, error)
+	AddProjectMember(projectID string, userID string) error
+	RemoveProjectMember(projectID string, userID string) error
+	IsProjectMember(projectID string, userID string) (bool, error)
+}
+
+type WorkflowStorage interface {
+	GetWorkflow(projectID string) (workflowmodels.Workflow, error)
+	SaveWorkflow(workflow workflowmodels.Workflow) error
+}
+
+type CustomFieldStorage interface {
+	AddCustomField(field customfieldmodels.CustomField) error
+	GetCustomFields(projectID string) ([]customfieldmodels.CustomField, error)
+	DeleteCustomField(fieldID string, projectID string) error
+}
+
+type TagStorage interface {
+	AddTag(tag tagmodels.Tag) error
+	GetTagsByUser(userID string) ([]tagmodels.Tag, error)
+	GetTagByID(tagID string, userID string) (tagmodels.Tag, error)
+	UpdateTag(tag tagmodels.Tag) error
+	DeleteTag(tagID string, userID string) error
+	SetTaskTags(taskID string, userID string, tagIDs []string) error
+}
+
+type FilterStorage interface {
+	AddFilter(filter filtermodels.Filter) error
+	GetFiltersByUser(userID string) ([]filtermodels.Filter, error)
+	GetFilterByID(filterID string, userID string) (filtermodels.Filter, error)
+	UpdateFilter(filter filtermodels.Filter) error
+	DeleteFilter(filterID string, userID string) error
+	FindTasksByFilter(userID string, expr filtermodels.Expr) ([]taskmodels.Task, error)
+}
+
+type CommentStorage interface {
+	AddComment(comment commentmodels.Comment) error
+	GetTaskComments(taskID string) ([]commentmodels.Comment, error)
+	GetCommentByID(commentID string, taskID string) (commentmodels.Comment, error)
+	UpdateComment(comment commentmodels.Comment) error
+	DeleteComment(commentID string, taskID string) error
+}
+
+type AttachmentStorage interface {
+	AddAttachment(attachment attachmentmodels.Attachment) error
+	GetTaskAttachments(taskID string) ([]attachmentmodels.Attachment, error)
+	GetAttachmentByID(attachmentID string, taskID string) (attachmentmodels.Attachment, error)
+	DeleteAttachment(attachmentID string, taskID string) error
+	GetOrphanedBlobKeys(limit int) ([]string, error)
+	DeleteOrphanedBlobKey(key string) error
+}
+
+type TimeEntryStorage interface {
+	AddTimeEntry(entry timeentrymodels.TimeEntry) error
+	GetRunningTimer(userID string) (timeentrymodels.TimeEntry, error)
+	StopTimer(userID string, taskID string, endedAt time.Time) (timeentrymodels.TimeEntry, error)
+	GetTaskTimeEntries(taskID string) ([]timeentrymodels.TimeEntry, error)
+	GetTimeEntryByID(entryID string, taskID string) (timeentrymodels.TimeEntry, error)
+	UpdateTimeEntry(entry timeentrymodels.TimeEntry) error
+	DeleteTimeEntry(entryID string, taskID string) error
+	GetReportEntries(userID string, from time.Time, to time.Time) ([]timeentrymodels.ReportEntry, error)
+}
+
+type TemplateStorage interface {
+	AddTemplate(template templatemodels.Template) error
+	GetTemplatesByUser(userID string) ([]templatemodels.Template, error)
+	GetTemplateByID(templateID string, userID string) (templatemodels.Template, error)
+	DeleteTemplate(templateID string, userID string) error
+}
+
+type ReminderStorage interface {
+	AddReminder(reminder remindermodels.Reminder) error
+	GetTaskReminders(taskID string) ([]remindermodels.Reminder, error)
+	DeleteReminder(reminderID string, taskID string) error
+	CancelStaleReminders() (int64, error)
+	FireDueReminders(
+		now time.Time,
+		limit int,
+		fire func(notification remindermodels.Notification) error,
+	) (int, error)
+}
+
+type WebhookStorage interface {
+	AddWebhook(webhook webhookmodels.Webhook) error
+	GetWebhooksByUser(userID string) ([]webhookmodels.Webhook, error)
+	GetWebhookByID(webhookID string, userID string) (webhookmodels.Webhook, error)
+	DeleteWebhook(webhookID string, userID string) error
+	GetWebhooksForEvent(userID string, eventType webhookmodels.EventType) ([]webhookmodels.Webhook, error)
+	AddWebhookDeliveries(deliveries []webhookmodels.Delivery) error
+	ClaimWebhookDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]webhookmodels.Delivery, error)
+	UpdateWebhookDelivery(delivery webhookmodels.Delivery) error
+	GetWebhookDeliveries(webhookID string, limit int) ([]webhookmodels.Delivery, error)
+	GetWebhookDelivery(deliveryID string, webhookID string) (webhookmodels.Delivery, error)
+}
+
+type OutboxStorage interface {
+	RelayOutboxEvents(limit int, publish func(event eventmodels.Event) error) (int, error)
+	DeletePublishedOutboxEvents(before time.Time) (int64, error)
+}
+
+type IdempotencyStorage interface {
+	ReserveIdempotencyKey(record idempotencymodels.Record) (idempotencymodels.Record, bool, error)
+	CompleteIdempotencyKey(record idempotencymodels.Record) error
+	ReleaseIdempotencyKey(userID string, key string) error
+	DeleteExpiredIdempotencyKeys(now time.Time) (int64, error)
 }
 
 type Storage interface {
 	UserStorage
 	TaskStorage
+	ProjectStorage
+	WorkflowStorage
+	CustomFieldStorage
+	TagStorage
+	FilterStorage
+	CommentStorage
+	AttachmentStorage
+	TimeEntryStorage
+	TemplateStorage
+	ReminderStorage
+	WebhookStorage
+	OutboxStorage
+	IdempotencyStorage
 }
 
+// idempotencyTTL - сколько хранятся ключи идемпотентности и сохранённые ответы.
+const idempotencyTTL = internal.DayOne
+
+// maxMultipartMemory - сколько формы с файлом держится в памяти.
+const maxMultipartMemory = 8 << 20
+
+// TxRunner - выполняет fn над хранилищем внутри одной транзакции, ошибка fn откатывает её целиком.
+type TxRunner func(fn func(tx Storage) error) error
+
 type TokenSigner interface {
 	NewAccessToken(userID string) (string, error)
 	NewRefreshToken(userID string) (string, error)
@@ -54,18 +222,31 @@ type TokenSigner interface {
 type ToDoListAPI struct {
 	srv         *http.Server
 	db          Storage
+	runInTx     TxRunner
 	tokenSigner TokenSigner
 	taskDeleter *workers.TaskBatchDeleter
-	secure      bool
-	certFile    string
-	keyFile     string
+	webhooks    *workers.WebhookDispatcher
+	events      *eventbus.Bus
+	presence    *presence.Hub
+	blobs       blob.Store
+	// maxAttachmentSize - максимальный размер вложения в байтах.
+	maxAttachmentSize int64
+	heartbeat         time.Duration
+	secure            bool
+	certFile          string
+	keyFile           string
 }
 
 func NewServer(
 	cfg internal.Config,
 	db Storage,
+	runInTx TxRunner,
 	tokenSigner TokenSigner,
 	taskDeleter *workers.TaskBatchDeleter,
+	webhooks *workers.WebhookDispatcher,
+	events *eventbus.Bus,
+	presenceHub *presence.Hub,
+	blobs blob.Store,
 ) *ToDoListAPI {
 	HTTPSrv := http.Server{ //nolint:gocritic // Линтеры противоречат друг другу, оставил так
 		Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
@@ -76,13 +257,20 @@ func NewServer(
 	}
 
 	api := ToDoListAPI{
-		srv:         &HTTPSrv,
-		db:          db,
-		tokenSigner: tokenSigner,
-		taskDeleter: taskDeleter,
-		secure:      cfg.SecureProtocol,
-		certFile:    cfg.CertCert,
-		keyFile:     cfg.KeyCert,
+		srv:               &HTTPSrv,
+		db:                db,
+		runInTx:           runInTx,
+		tokenSigner:       tokenSigner,
+		taskDeleter:       taskDeleter,
+		webhooks:          webhooks,
+		events:            events,
+		presence:          presenceHub,
+		blobs:             blobs,
+		maxAttachmentSize: cfg.MaxAttachmentSize,
+		heartbeat:         internal.SecTen,
+		secure:            cfg.SecureProtocol,
+		certFile:          cfg.CertCert,
+		keyFile:           cfg.KeyCert,
 	}
 
 	api.configRouter()
@@ -102,26 +290,215 @@ func (api *ToDoListAPI) Run() error {
 	return api.srv.ListenAndServe()
 }
 
+// ShutDown - потоки SSE и соединения WebSocket не завершаются сами, поэтому перед остановкой
+// закрываются шина событий и хаб присутствия.
 func (api *ToDoListAPI) ShutDown(ctx context.Context) error {
+	api.events.Close()
+	if err := api.presence.Close(ctx); err != nil {
+		return err
+	}
 	return api.srv.Shutdown(ctx)
 }
 
 func (api *ToDoListAPI) configRouter() {
 	router := gin.Default()
+	// Файлы вложений больше этого размера при разборе формы пишутся во временные файлы.
+	router.MaxMultipartMemory = maxMultipartMemory
 
 	router.Use(middleware.GzipDecompressMiddleware())
 
 	router.Use(gzip.Gzip(gzip.DefaultCompression,
 		gzip.WithExcludedExtensions([]string{".png", ".jpg", ".gif", ".mp4"}),
+		// Сжатие буферизует поток SSE.
+		gzip.WithExcludedPaths([]string{"/events"}),
+		// Сжатие ломает ответы на Range при скачивании вложений.
+		gzip.WithExcludedPathsRegexs([]string{`^/tasks/[^/]+/attachments/[^/]+$`}),
 	))
 
+	idempotent := middleware.IdempotencyMiddleware(api.db, idempotencyTTL)
+
+	router.GET("/events", middleware.AuthMiddleware(api.tokenSigner), api.streamEvents)
+	router.GET("/ws", middleware.AuthMiddleware(api.tokenSigner), api.taskPresence)
+
 	tasks := router.Group("/tasks")
 	{
 		tasks.GET("/", middleware.AuthMiddleware(api.tokenSigner), api.getTasks)
+		tasks.GET("/order", middleware.AuthMiddleware(api.tokenSigner), api.getTasksInDependencyOrder)
+		tasks.GET("/search", middleware.AuthMiddleware(api.tokenSigner), api.searchTasks)
 		tasks.GET("/:id", middleware.AuthMiddleware(api.tokenSigner), api.getTaskByID)
-		tasks.POST("/", middleware.AuthMiddleware(api.tokenSigner), api.createTask)
-		tasks.PUT("/:id", middleware.AuthMiddleware(api.tokenSigner), api.updateTask)
-		tasks.DELETE("/:id", middleware.AuthMiddleware(api.tokenSigner), api.deleteTask)
+		tasks.POST("/", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.createTask)
+		tasks.POST("/bulk", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.bulkTasks)
+		tasks.PUT("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.updateTask)
+		tasks.PATCH("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.patchTask)
+		tasks.DELETE("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.deleteTask)
+		tasks.GET("/:id/assignments", middleware.AuthMiddleware(api.tokenSigner), api.getTaskAssignments)
+		tasks.GET("/:id/history", middleware.AuthMiddleware(api.tokenSigner), api.getTaskHistory)
+		tasks.POST("/:id/revert", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.revertTask)
+		tasks.GET("/:id/watchers", middleware.AuthMiddleware(api.tokenSigner), api.getTaskWatchers)
+		tasks.POST("/:id/watchers", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.addTaskWatcher)
+		tasks.DELETE(
+			"/:id/watchers/:user_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.removeTaskWatcher,
+		)
+		tasks.PUT("/:id/tags", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.setTaskTags)
+		tasks.POST("/:id/move", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.moveTask)
+		tasks.GET("/:id/subtree", middleware.AuthMiddleware(api.tokenSigner), api.getTaskSubtree)
+		tasks.POST("/:id/checklist", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.addChecklistItem)
+		tasks.PUT(
+			"/:id/checklist/:item_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.updateChecklistItem,
+		)
+		tasks.DELETE(
+			"/:id/checklist/:item_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.deleteChecklistItem,
+		)
+		tasks.GET("/:id/comments", middleware.AuthMiddleware(api.tokenSigner), api.getComments)
+		tasks.POST("/:id/comments", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.addComment)
+		tasks.PUT(
+			"/:id/comments/:comment_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.updateComment,
+		)
+		tasks.DELETE(
+			"/:id/comments/:comment_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.deleteComment,
+		)
+		tasks.GET("/:id/attachments", middleware.AuthMiddleware(api.tokenSigner), api.getAttachments)
+		// Без idempotent: middleware читает тело в память целиком, а файл может быть большим.
+		tasks.POST("/:id/attachments", middleware.AuthMiddleware(api.tokenSigner), api.uploadAttachment)
+		tasks.GET(
+			"/:id/attachments/:attachment_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			api.downloadAttachment,
+		)
+		tasks.DELETE(
+			"/:id/attachments/:attachment_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.deleteAttachment,
+		)
+		tasks.GET("/:id/reminders", middleware.AuthMiddleware(api.tokenSigner), api.getReminders)
+		tasks.POST("/:id/reminders", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.addReminder)
+		tasks.DELETE(
+			"/:id/reminders/:reminder_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.deleteReminder,
+		)
+		tasks.POST("/:id/dependencies", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.addTaskDependency)
+		tasks.DELETE(
+			"/:id/dependencies/:blocker_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.removeTaskDependency,
+		)
+		tasks.POST("/:id/timer/start", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.startTimer)
+		tasks.POST("/:id/timer/stop", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.stopTimer)
+		tasks.GET("/:id/time", middleware.AuthMiddleware(api.tokenSigner), api.getTimeEntries)
+		tasks.POST("/:id/time", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.addTimeEntry)
+		tasks.PUT(
+			"/:id/time/:entry_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.updateTimeEntry,
+		)
+		tasks.DELETE(
+			"/:id/time/:entry_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.deleteTimeEntry,
+		)
+	}
+
+	router.GET("/timer", middleware.AuthMiddleware(api.tokenSigner), api.getRunningTimer)
+
+	reports := router.Group("/reports")
+	{
+		reports.GET("/time", middleware.AuthMiddleware(api.tokenSigner), api.getTimeReport)
+	}
+
+	tags := router.Group("/tags")
+	{
+		tags.GET("/", middleware.AuthMiddleware(api.tokenSigner), api.getTags)
+		tags.POST("/", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.createTag)
+		tags.PUT("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.updateTag)
+		tags.DELETE("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.deleteTag)
+	}
+
+	filters := router.Group("/filters")
+	{
+		filters.GET("/", middleware.AuthMiddleware(api.tokenSigner), api.getFilters)
+		filters.GET("/:id", middleware.AuthMiddleware(api.tokenSigner), api.getFilterByID)
+		filters.GET("/:id/tasks", middleware.AuthMiddleware(api.tokenSigner), api.getFilterTasks)
+		filters.POST("/", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.createFilter)
+		filters.PUT("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.updateFilter)
+		filters.DELETE("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.deleteFilter)
+	}
+
+	templates := router.Group("/templates")
+	{
+		templates.GET("/", middleware.AuthMiddleware(api.tokenSigner), api.getTemplates)
+		templates.GET("/:id", middleware.AuthMiddleware(api.tokenSigner), api.getTemplateByID)
+		templates.POST("/", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.createTemplate)
+		templates.POST(
+			"/:id/instantiate",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.instantiateTemplate,
+		)
+		templates.DELETE("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.deleteTemplate)
+	}
+
+	webhooks := router.Group("/webhooks")
+	{
+		webhooks.GET("/", middleware.AuthMiddleware(api.tokenSigner), api.getWebhooks)
+		webhooks.POST("/", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.createWebhook)
+		webhooks.DELETE("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.deleteWebhook)
+		webhooks.GET("/:id/deliveries", middleware.AuthMiddleware(api.tokenSigner), api.getWebhookDeliveries)
+		webhooks.POST(
+			"/:id/deliveries/:delivery_id/redeliver",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.redeliverWebhook,
+		)
+	}
+	projects := router.Group("/projects")
+	{
+		projects.GET("/", middleware.AuthMiddleware(api.tokenSigner), api.getProjects)
+		projects.GET("/:id", middleware.AuthMiddleware(api.tokenSigner), api.getProjectByID)
+		projects.POST("/", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.createProject)
+		projects.POST("/:id/members", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.addProjectMember)
+		projects.DELETE(
+			"/:id/members/:user_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.removeProjectMember,
+		)
+		projects.GET("/:id/workflow", middleware.AuthMiddleware(api.tokenSigner), api.getProjectWorkflow)
+		projects.PUT("/:id/workflow", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.updateProjectWorkflow)
+		projects.GET("/:id/board", middleware.AuthMiddleware(api.tokenSigner), api.getProjectBoard)
+		projects.GET("/:id/fields", middleware.AuthMiddleware(api.tokenSigner), api.getCustomFields)
+		projects.POST("/:id/fields", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.createCustomField)
+		projects.DELETE(
+			"/:id/fields/:field_id",
+			middleware.AuthMiddleware(api.tokenSigner),
+			idempotent,
+			api.deleteCustomField,
+		)
+	}
+
+	board := router.Group("/board")
+	{
+		board.POST("/move", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.moveOnBoard)
 	}
 
 	users := router.Group("/users")
@@ -131,8 +508,8 @@ func (api *ToDoListAPI) configRouter() {
 		users.POST("/register", api.register)
 		users.POST("/login", api.login)
 		users.POST("/admin-login", api.loginAdmin)
-		users.PUT("/:id", middleware.AuthMiddleware(api.tokenSigner), api.updateUser)
-		users.DELETE("/:id", middleware.AuthMiddleware(api.tokenSigner), api.deleteUser)
+		users.PUT("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.updateUser)
+		users.DELETE("/:id", middleware.AuthMiddleware(api.tokenSigner), idempotent, api.deleteUser)
 	}
 
 	api.srv.Handler = router
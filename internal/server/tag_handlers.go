@@ -0,0 +1,106 @@
+package server
+
+import (
+	"net/http"
+	"toDoList/internal/domain/tag/tagmodels"
+	"toDoList/internal/service/tagservice"
+	"toDoList/internal/service/taskservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getTags(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	tagService := tagservice.NewTagService(srv.db)
+	tags, err := tagService.GetTags(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+func (srv *ToDoListAPI) createTag(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req tagmodels.TagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tagService := tagservice.NewTagService(srv.db)
+	tag, err := tagService.CreateTag(req, userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+func (srv *ToDoListAPI) updateTag(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req tagmodels.TagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tagService := tagservice.NewTagService(srv.db)
+	tag, err := tagService.UpdateTag(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+func (srv *ToDoListAPI) deleteTag(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	tagService := tagservice.NewTagService(srv.db)
+	if err := tagService.DeleteTag(ctx.Param("id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Tag was deleted")
+}
+
+func (srv *ToDoListAPI) setTaskTags(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req tagmodels.TaskTagsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	if err := taskService.SetTaskTags(ctx.Param("id"), userID, req.TagIDs); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Task tags were updated")
+}
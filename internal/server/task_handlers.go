@@ -1,16 +1,189 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"toDoList/internal/domain/attachment/attachmenterrors"
+	"toDoList/internal/domain/comment/commenterrors"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/reminder/remindererrors"
+	"toDoList/internal/domain/tag/tagerrors"
+	"toDoList/internal/domain/task/taskerrors"
 	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/timeentry/timeentryerrors"
+	"toDoList/internal/domain/webhook/webhookerrors"
+	"toDoList/internal/domain/workflow/workflowerrors"
 	"toDoList/internal/service/taskservice"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 // обрабатываем для вывода, возвращаем респонсы с ошибками и проч.
 
+// userIDFromContext - достаёт ID пользователя, положенный AuthMiddleware, и сам отвечает клиенту при ошибке.
+func userIDFromContext(ctx *gin.Context) (string, bool) {
+	userIDFromCtx, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return "", false
+	}
+
+	userID, ok := userIDFromCtx.(string)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "userID has wrong type"})
+		return "", false
+	}
+
+	return userID, true
+}
+
+// taskErrorStatus - HTTP-статус для доменных ошибок задач и проектов. Ошибки, которых нет в списке,
+// считаются сбоем сервера.
+func taskErrorStatus(err error) int {
+	var validationErrs validator.ValidationErrors
+
+	switch {
+	case errors.Is(err, taskerrors.ErrFoundNothing),
+		errors.Is(err, taskerrors.ErrWatcherNotFound),
+		errors.Is(err, taskerrors.ErrChecklistNotFound),
+		errors.Is(err, taskerrors.ErrDependencyNotFound),
+		errors.Is(err, taskerrors.ErrRevisionNotFound),
+		errors.Is(err, remindererrors.ErrReminderNotFound),
+		errors.Is(err, commenterrors.ErrCommentNotFound),
+		errors.Is(err, attachmenterrors.ErrAttachmentNotFound),
+		errors.Is(err, projecterrors.ErrProjectNotFound),
+		errors.Is(err, workflowerrors.ErrWorkflowNotFound),
+		errors.Is(err, customfielderrors.ErrCustomFieldNotFound),
+		errors.Is(err, timeentryerrors.ErrTimeEntryNotFound),
+		errors.Is(err, timeentryerrors.ErrTimerNotRunning),
+		errors.Is(err, tagerrors.ErrTagNotFound),
+		errors.Is(err, filtererrors.ErrFilterNotFound),
+		errors.Is(err, templateerrors.ErrTemplateNotFound),
+		errors.Is(err, webhookerrors.ErrWebhookNotFound),
+		errors.Is(err, webhookerrors.ErrDeliveryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, projecterrors.ErrNotProjectMember),
+		errors.Is(err, projecterrors.ErrNotProjectOwner),
+		errors.Is(err, commenterrors.ErrNotCommentAuthor),
+		errors.Is(err, attachmenterrors.ErrNotAttachmentOwner),
+		errors.Is(err, timeentryerrors.ErrNotTimeEntryOwner),
+		errors.Is(err, templateerrors.ErrNotTemplateOwner):
+		return http.StatusForbidden
+	case errors.Is(err, taskerrors.ErrWatcherIsExist),
+		errors.Is(err, projecterrors.ErrMemberIsAlreadyExist),
+		errors.Is(err, tagerrors.ErrTagIsAlreadyExist),
+		errors.Is(err, filtererrors.ErrFilterIsAlreadyExist),
+		errors.Is(err, templateerrors.ErrTemplateIsAlreadyExist),
+		errors.Is(err, customfielderrors.ErrCustomFieldIsAlreadyExist),
+		errors.Is(err, taskerrors.ErrDependencyIsExist),
+		errors.Is(err, taskerrors.ErrTaskBlocked),
+		errors.Is(err, taskerrors.ErrTransitionDenied),
+		errors.Is(err, taskerrors.ErrWIPLimitReached),
+		errors.Is(err, workflowerrors.ErrStatusInUse),
+		errors.Is(err, timeentryerrors.ErrTimerAlreadyRunning),
+		errors.Is(err, timeentryerrors.ErrTimeEntryIsRunning),
+		errors.Is(err, taskerrors.ErrPatchTestFailed),
+		errors.Is(err, webhookerrors.ErrDeliveryNotDead):
+		return http.StatusConflict
+	case errors.Is(err, taskerrors.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, attachmenterrors.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, taskerrors.ErrWrongPatchType),
+		errors.Is(err, attachmenterrors.ErrAttachmentType):
+		return http.StatusUnsupportedMediaType
+	case errors.As(err, &validationErrs),
+		errors.Is(err, taskerrors.ErrEmptyString),
+		errors.Is(err, taskerrors.ErrWrongStatus),
+		errors.Is(err, taskerrors.ErrTaskIsAlreadyExist),
+		errors.Is(err, taskerrors.ErrAssigneeNotMember),
+		errors.Is(err, taskerrors.ErrWatcherNotMember),
+		errors.Is(err, taskerrors.ErrWrongPriority),
+		errors.Is(err, taskerrors.ErrWrongMove),
+		errors.Is(err, taskerrors.ErrWrongParent),
+		errors.Is(err, taskerrors.ErrSubtaskCycle),
+		errors.Is(err, taskerrors.ErrSubtaskTooDeep),
+		errors.Is(err, taskerrors.ErrDependencyCycle),
+		errors.Is(err, taskerrors.ErrWrongRRule),
+		errors.Is(err, taskerrors.ErrRRuleNeedsDueDate),
+		errors.Is(err, taskerrors.ErrWrongEditScope),
+		errors.Is(err, taskerrors.ErrWrongPatch),
+		errors.Is(err, taskerrors.ErrWrongBulkMode),
+		errors.Is(err, taskerrors.ErrWrongBulkSize),
+		errors.Is(err, taskerrors.ErrWrongBulkOperation),
+		errors.Is(err, taskerrors.ErrEmptySearchQuery),
+		errors.Is(err, taskerrors.ErrWrongSearchLang),
+		errors.Is(err, taskerrors.ErrWrongSearchLimit),
+		errors.Is(err, taskerrors.ErrWrongBoardMove),
+		errors.Is(err, attachmenterrors.ErrWrongAttachment),
+		errors.Is(err, commenterrors.ErrWrongComment),
+		errors.Is(err, customfielderrors.ErrWrongCustomField),
+		errors.Is(err, customfielderrors.ErrWrongCustomFieldValue),
+		errors.Is(err, customfielderrors.ErrWrongCustomFieldSort),
+		errors.Is(err, filtererrors.ErrWrongFilterQuery),
+		errors.Is(err, projecterrors.ErrProjectIsAlreadyExist),
+		errors.Is(err, projecterrors.ErrCantRemoveOwner),
+		errors.Is(err, remindererrors.ErrWrongReminder),
+		errors.Is(err, remindererrors.ErrReminderInPast),
+		errors.Is(err, remindererrors.ErrReminderNeedsDueDate),
+		errors.Is(err, tagerrors.ErrWrongTagMode),
+		errors.Is(err, templateerrors.ErrWrongTemplate),
+		errors.Is(err, templateerrors.ErrMissingTemplateVariable),
+		errors.Is(err, timeentryerrors.ErrWrongTimeEntry),
+		errors.Is(err, timeentryerrors.ErrWrongTimeReportQuery),
+		errors.Is(err, webhookerrors.ErrWrongEventType),
+		errors.Is(err, webhookerrors.ErrWrongWebhookURL),
+		errors.Is(err, webhookerrors.ErrWebhookAddressNotAllowed),
+		errors.Is(err, workflowerrors.ErrWrongWorkflow):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// taskETag - сильный ETag задачи, построенный из её версии.
+func taskETag(task taskmodels.Task) string {
+	return `"` + strconv.FormatInt(task.Version, 10) + `"`
+}
+
+// matchETag - есть ли etag в списке из If-None-Match, "*" подходит к любому.
+func matchETag(header string, etag string) bool {
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion - версия задачи из If-Match. 0 - заголовка нет или это "*",
+// тогда версия не проверяется. Тег, который не может совпасть с ETag задачи, - ErrVersionConflict.
+func ifMatchVersion(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, taskerrors.ErrVersionConflict
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, taskerrors.ErrVersionConflict
+	}
+	return version, nil
+}
+
 func (srv *ToDoListAPI) getTasks(ctx *gin.Context) {
 	userIDFromCtx, exists := ctx.Get("userID")
 	if !exists {
@@ -24,10 +197,42 @@ func (srv *ToDoListAPI) getTasks(ctx *gin.Context) {
 		return
 	}
 
+	filter := taskmodels.TaskFilter{
+		ProjectID:  ctx.Query("project"),
+		AssigneeID: ctx.Query("assignee"),
+		Tags:       ctx.QueryArray("tag"),
+		TagMode:    taskmodels.TagMode(ctx.Query("tag_mode")),
+		SortField:  ctx.Query("sort_field"),
+	}
+
+	// cf[<ID поля>]=<значение> - фильтр по пользовательскому полю проекта.
+	if values := ctx.QueryMap("cf"); len(values) != 0 {
+		filter.CustomFields = make(map[string]any, len(values))
+		for id, value := range values {
+			filter.CustomFields[id] = value
+		}
+	}
+
+	switch ctx.Query("order") {
+	case "", "asc":
+	case "desc":
+		filter.SortDesc = true
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": customfielderrors.ErrWrongCustomFieldSort.Error()})
+		return
+	}
+
 	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
-	tasks, err := taskService.GetAllTasks(userID)
+	tasks, err := taskService.GetTasks(userID, filter)
 	if err != nil {
+		if errors.Is(err, tagerrors.ErrWrongTagMode) ||
+			errors.Is(err, customfielderrors.ErrWrongCustomFieldValue) ||
+			errors.Is(err, customfielderrors.ErrWrongCustomFieldSort) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
 	}
 	if len(tasks) != 0 {
 		ctx.JSON(http.StatusOK, tasks)
@@ -36,6 +241,44 @@ func (srv *ToDoListAPI) getTasks(ctx *gin.Context) {
 	}
 }
 
+// searchTasks - GET /tasks/search?q=&lang=&limit=, результаты отсортированы по рангу.
+func (srv *ToDoListAPI) searchTasks(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	query := taskmodels.SearchQuery{
+		Query:    ctx.Query("q"),
+		Language: taskmodels.SearchLanguage(ctx.Query("lang")),
+	}
+	if limit := ctx.Query("limit"); limit != "" {
+		var err error
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": taskerrors.ErrWrongSearchLimit.Error()})
+			return
+		}
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	results, err := taskService.SearchTasks(userID, query)
+	if err != nil {
+		if errors.Is(err, taskerrors.ErrEmptySearchQuery) ||
+			errors.Is(err, taskerrors.ErrWrongSearchLang) ||
+			errors.Is(err, taskerrors.ErrWrongSearchLimit) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if results == nil {
+		results = []taskmodels.SearchResult{}
+	}
+	ctx.JSON(http.StatusOK, results)
+}
+
 func (srv *ToDoListAPI) getTaskByID(ctx *gin.Context) {
 	taskID := ctx.Param("id")
 
@@ -58,6 +301,13 @@ func (srv *ToDoListAPI) getTaskByID(ctx *gin.Context) {
 		return
 	}
 
+	etag := taskETag(foundedTask)
+	ctx.Header("ETag", etag)
+	if matchETag(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, foundedTask)
 }
 
@@ -83,7 +333,7 @@ func (srv *ToDoListAPI) createTask(ctx *gin.Context) {
 	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
 	taskID, err := taskService.CreateTask(newTaskAttributes, userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -111,16 +361,107 @@ func (srv *ToDoListAPI) updateTask(ctx *gin.Context) {
 		return
 	}
 
+	// force=true позволяет начать или завершить задачу, не дожидаясь блокирующих задач.
+	force, _ := strconv.ParseBool(ctx.Query("force"))
+
+	// scope=series применяет изменения ко всей серии повторяющейся задачи.
+	scope := taskmodels.EditScope(ctx.DefaultQuery("scope", string(taskmodels.EditScopeThis)))
+	if !scope.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": taskerrors.ErrWrongEditScope.Error()})
+		return
+	}
+
+	// If-Match с ETag из GET защищает от перезаписи чужих изменений.
+	version, err := ifMatchVersion(ctx.GetHeader("If-Match"))
+	if err != nil {
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		return
+	}
+
 	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
-	err := taskService.UpdateTask(taskID, userID, newAttributes)
+
+	if scope == taskmodels.EditScopeSeries {
+		runInTx := func(fn func(tx taskservice.TaskStorage) error) error {
+			return srv.runInTx(func(tx Storage) error { return fn(tx) })
+		}
+		err = taskService.UpdateTaskSeries(taskID, userID, newAttributes, version, force, runInTx)
+	} else {
+		err = taskService.UpdateTask(taskID, userID, newAttributes, version, force)
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, fmt.Sprintf("TaskID: %s was updated", taskID))
 }
 
+// patchTask - частичное изменение задачи. Формат патча задаётся Content-Type:
+// application/merge-patch+json или application/json-patch+json.
+func (srv *ToDoListAPI) patchTask(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	version, err := ifMatchVersion(ctx.GetHeader("If-Match"))
+	if err != nil {
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		return
+	}
+
+	force, _ := strconv.ParseBool(ctx.Query("force"))
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	task, err := taskService.PatchTask(ctx.Param("id"), userID, taskmodels.PatchType(ctx.ContentType()), patch,
+		version, force)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("ETag", taskETag(task))
+	ctx.JSON(http.StatusOK, task)
+}
+
+// bulkTasks - пакет операций над задачами. Если atomic откатился, отвечает статусом ошибки
+// первой неудачной операции, результаты по каждой операции есть в ответе в обоих случаях.
+func (srv *ToDoListAPI) bulkTasks(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req taskmodels.BulkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	runInTx := func(fn func(tx taskservice.TaskStorage) error) error {
+		return srv.runInTx(func(tx Storage) error { return fn(tx) })
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	results, err := taskService.BulkTasks(userID, req, runInTx)
+	if err != nil {
+		if results == nil {
+			ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error(), "results": results})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"results": results})
+}
+
 func (srv *ToDoListAPI) deleteTask(ctx *gin.Context) {
 	taskID := ctx.Param("id")
 	userIDFromCtx, exists := ctx.Get("userID")
@@ -142,3 +483,277 @@ func (srv *ToDoListAPI) deleteTask(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, "Task was deleted")
 }
+
+func (srv *ToDoListAPI) getTaskAssignments(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	assignments, err := taskService.GetTaskAssignments(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"assignments": assignments})
+}
+
+func (srv *ToDoListAPI) getTaskHistory(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	history, err := taskService.GetTaskHistory(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"history": history})
+}
+
+// revertTask - откат атрибутов задачи к ревизии из истории, If-Match и force - как в updateTask.
+func (srv *ToDoListAPI) revertTask(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req taskmodels.RevertRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	version, err := ifMatchVersion(ctx.GetHeader("If-Match"))
+	if err != nil {
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		return
+	}
+
+	force, _ := strconv.ParseBool(ctx.Query("force"))
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	task, err := taskService.RevertTask(ctx.Param("id"), userID, req.Revision, version, force)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("ETag", taskETag(task))
+	ctx.JSON(http.StatusOK, task)
+}
+
+func (srv *ToDoListAPI) getTaskWatchers(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	watchers, err := taskService.GetTaskWatchers(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"watchers": watchers})
+}
+
+// addTaskWatcher - без user_id в теле подписывает на задачу самого пользователя.
+func (srv *ToDoListAPI) addTaskWatcher(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req taskmodels.WatcherRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	if err := taskService.AddTaskWatcher(ctx.Param("id"), userID, req.UserID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Watcher was added")
+}
+
+func (srv *ToDoListAPI) removeTaskWatcher(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	if err := taskService.RemoveTaskWatcher(ctx.Param("id"), userID, ctx.Param("user_id")); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Watcher was removed")
+}
+
+// moveTask - ставит задачу между задачами after и before, достаточно указать одну из них.
+func (srv *ToDoListAPI) moveTask(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req taskmodels.MoveTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	position, err := taskService.MoveTask(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"position": position})
+}
+
+func (srv *ToDoListAPI) getTaskSubtree(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	task, err := taskService.GetTaskSubtree(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, task)
+}
+
+func (srv *ToDoListAPI) addChecklistItem(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req taskmodels.ChecklistItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	item, err := taskService.AddChecklistItem(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+func (srv *ToDoListAPI) updateChecklistItem(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req taskmodels.ChecklistItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	err := taskService.UpdateChecklistItem(ctx.Param("id"), ctx.Param("item_id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Checklist item was updated")
+}
+
+func (srv *ToDoListAPI) deleteChecklistItem(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	err := taskService.DeleteChecklistItem(ctx.Param("id"), ctx.Param("item_id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Checklist item was deleted")
+}
+
+func (srv *ToDoListAPI) addTaskDependency(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req taskmodels.DependencyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	if err := taskService.AddTaskDependency(ctx.Param("id"), req.BlockerID, userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Dependency was added")
+}
+
+func (srv *ToDoListAPI) removeTaskDependency(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	if err := taskService.RemoveTaskDependency(ctx.Param("id"), ctx.Param("blocker_id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Dependency was removed")
+}
+
+func (srv *ToDoListAPI) getTasksInDependencyOrder(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	tasks, err := taskService.GetTasksInDependencyOrder(userID)
+	if err != nil {
+		if errors.Is(err, taskerrors.ErrFoundNothing) {
+			ctx.JSON(http.StatusOK, "Task list is empty")
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tasks)
+}
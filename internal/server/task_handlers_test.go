@@ -3,14 +3,19 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/task/taskerrors"
 	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowmodels"
 	"toDoList/internal/server/mocks"
 	"toDoList/internal/server/workers"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 	"github.com/go-resty/resty/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -155,6 +160,29 @@ func TestCreateTask(t *testing.T) {
 				statusCode: http.StatusOK,
 			},
 		},
+		{
+			name:      "Storage_failure",
+			userIDCtx: "user123",
+			taskJSON: `{
+				"title":"New Task",
+				"description":"Task description",
+				"status":"New"
+			}`,
+			taskFromDB: taskmodels.Task{
+				Attributes: taskmodels.TaskAttributes{
+					Title:       "New Task",
+					Description: "Task description",
+					Status:      "New",
+				},
+				UserID: "user123",
+			},
+			mockFlag: true,
+			err:      errors.New("connection refused"),
+			want: want{
+				body:       "connection refused",
+				statusCode: http.StatusInternalServerError,
+			},
+		},
 		{
 			name:      "Bad_request_missing_status",
 			userIDCtx: "user1",
@@ -236,6 +264,7 @@ func TestCreateTask(t *testing.T) {
 			srv.taskDeleter = taskDeleter
 
 			if tc.mockFlag {
+				repo.On("GetLastTaskPosition", tc.taskFromDB.UserID).Return("", nil)
 				repo.On("AddTask", mock.MatchedBy(func(task taskmodels.Task) bool {
 					return task.Attributes.Title == tc.taskFromDB.Attributes.Title &&
 						task.Attributes.Description == tc.taskFromDB.Attributes.Description &&
@@ -260,6 +289,31 @@ func TestCreateTask(t *testing.T) {
 	}
 }
 
+func TestTaskErrorStatus(t *testing.T) {
+	validationErr := validator.New().Struct(struct {
+		Title string `validate:"required"`
+	}{})
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"validation", validationErr, http.StatusBadRequest},
+		{"wrapped domain error", fmt.Errorf("%w: FREQ=HOURLY", taskerrors.ErrWrongRRule), http.StatusBadRequest},
+		{"not found", taskerrors.ErrFoundNothing, http.StatusNotFound},
+		{"conflict", taskerrors.ErrWIPLimitReached, http.StatusConflict},
+		{"storage failure", errors.New("connection refused"), http.StatusInternalServerError},
+		{"db error", taskerrors.ErrDBOnUpdate, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, taskErrorStatus(tt.err))
+		})
+	}
+}
+
 func TestGetTaskByID(t *testing.T) {
 	var srv ToDoListAPI
 	gin.SetMode(gin.ReleaseMode)
@@ -384,6 +438,7 @@ func TestUpdateTask(t *testing.T) {
 		userIDCtx any
 		bodyJSON  string
 		mockFlag  bool
+		getFlag   bool
 		err       error
 		want      want
 	}
@@ -415,9 +470,10 @@ func TestUpdateTask(t *testing.T) {
 				"status": "Wrong"
 			}`,
 			mockFlag: false,
+			getFlag:  true,
 			want: want{
-				body:       "wrong status",
-				statusCode: http.StatusInternalServerError,
+				body:       `{"error":"wrong status`,
+				statusCode: http.StatusBadRequest,
 			},
 		},
 		{
@@ -485,7 +541,7 @@ func TestUpdateTask(t *testing.T) {
 			taskDeleter := workers.NewTaskBatchDeleter(context.Background(), repo, 10, zerolog.Nop())
 			srv.taskDeleter = taskDeleter
 
-			if tc.mockFlag {
+			if tc.mockFlag || tc.getFlag {
 				repo.On(
 					"GetTaskByID",
 					tc.taskID,
@@ -499,7 +555,9 @@ func TestUpdateTask(t *testing.T) {
 						Status:      "New",
 					},
 				}, nil)
+			}
 
+			if tc.mockFlag {
 				repo.On(
 					"UpdateTaskAttributes",
 					mock.MatchedBy(func(task taskmodels.Task) bool {
@@ -628,3 +686,407 @@ func TestDeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTasksByAssignee(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+	srv.taskDeleter = workers.NewTaskBatchDeleter(context.Background(), srv.db, 10, zerolog.Nop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.GET("/tasks", srv.getTasks)
+
+	repo.On("FindTasks", "user1", taskmodels.TaskFilter{AssigneeID: "user1"}).Return([]taskmodels.Task{
+		{ID: "task1", UserID: "owner", Attributes: taskmodels.TaskAttributes{Title: "Assigned", AssigneeID: "user1"}},
+	}, nil)
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	res, err := resty.New().R().SetQueryParam("assignee", "me").Get(httpSrv.URL + "/tasks")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+	assert.Contains(t, string(res.Body()), `"assignee_id":"user1"`)
+}
+
+func TestUpdateTaskBlocked(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+	srv.taskDeleter = workers.NewTaskBatchDeleter(context.Background(), srv.db, 10, zerolog.Nop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.PUT("/tasks/:id", srv.updateTask)
+
+	repo.On("GetTaskByID", "task1", "user1").Return(taskmodels.Task{
+		ID: "task1", UserID: "user1", BlockedBy: []string{"task0"},
+		Attributes: taskmodels.TaskAttributes{Title: "Old", Description: "Old", Status: taskmodels.StatusNew},
+	}, nil)
+	repo.On("GetUnfinishedBlockers", "task1").Return([]string{"task0"}, nil).Once()
+	repo.On("UpdateTaskAttributes", mock.Anything).Return(nil).Once()
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	body := `{"title": "Updated", "description": "Updated desc", "status": "In Progress"}`
+
+	res, err := resty.New().R().SetBody(body).Put(httpSrv.URL + "/tasks/task1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, res.StatusCode())
+	assert.Contains(t, string(res.Body()), "task0")
+
+	res, err = resty.New().R().SetBody(body).SetQueryParam("force", "true").Put(httpSrv.URL + "/tasks/task1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+}
+
+func TestUpdateTaskWorkflow(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+	srv.taskDeleter = workers.NewTaskBatchDeleter(context.Background(), srv.db, 10, zerolog.Nop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.PUT("/tasks/:id", srv.updateTask)
+
+	repo.On("GetTaskByID", "task1", "user1").Return(taskmodels.Task{
+		ID: "task1", UserID: "user1",
+		Attributes: taskmodels.TaskAttributes{
+			Title: "Old", Description: "Old", Status: taskmodels.StatusNew, ProjectID: "project1",
+		},
+	}, nil)
+	repo.On("GetWorkflow", "project1").Return(workflowmodels.Workflow{
+		ProjectID: "project1",
+		Statuses: []workflowmodels.Status{
+			{Name: taskmodels.StatusNew, Category: taskmodels.CategoryTodo},
+			{Name: "Review", Category: taskmodels.CategoryDoing, WIPLimit: 2},
+			{Name: taskmodels.StatusCompleted, Category: taskmodels.CategoryDone},
+		},
+		Transitions: []workflowmodels.Transition{{From: taskmodels.StatusNew, To: "Review"}},
+	}, nil)
+	repo.On("CountTasksInStatus", "project1", taskmodels.TaskStatus("Review")).Return(2, nil).Once()
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	tests := []struct {
+		name       string
+		status     string
+		statusCode int
+		body       string
+	}{
+		{
+			name:       "Transition_denied",
+			status:     string(taskmodels.StatusCompleted),
+			statusCode: http.StatusConflict,
+			body:       `{"error":"status transition is not allowed`,
+		},
+		{
+			name:       "Status_not_in_workflow",
+			status:     "Archived",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":"wrong status`,
+		},
+		{
+			name:       "WIP_limit_reached",
+			status:     "Review",
+			statusCode: http.StatusConflict,
+			body:       `{"error":"WIP limit of the status is reached`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"title": "Updated", "description": "Updated desc", "project_id": "project1", "status": "` +
+				tc.status + `"}`
+
+			res, err := resty.New().R().SetBody(body).Put(httpSrv.URL + "/tasks/task1")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode())
+			assert.Contains(t, string(res.Body()), tc.body)
+		})
+	}
+}
+
+func TestUpdateTaskCustomFields(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+	srv.taskDeleter = workers.NewTaskBatchDeleter(context.Background(), srv.db, 10, zerolog.Nop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.PUT("/tasks/:id", srv.updateTask)
+
+	repo.On("GetTaskByID", "task1", "user1").Return(taskmodels.Task{
+		ID: "task1", UserID: "user1",
+		Attributes: taskmodels.TaskAttributes{
+			Title: "Old", Description: "Old", Status: taskmodels.StatusNew, ProjectID: "project1",
+		},
+	}, nil)
+	repo.On("GetCustomFields", "project1").Return([]customfieldmodels.CustomField{
+		{ID: "field1", ProjectID: "project1", Name: "Points", Type: customfieldmodels.TypeNumber},
+	}, nil)
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	body := `{"title": "Updated", "description": "Updated desc", "project_id": "project1", "status": "New",
+		"custom_fields": {"field1": "five"}}`
+
+	res, err := resty.New().R().SetBody(body).Put(httpSrv.URL + "/tasks/task1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	assert.Contains(t, string(res.Body()), `{"error":"wrong custom field value`)
+}
+
+func TestTaskETag(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+	srv.taskDeleter = workers.NewTaskBatchDeleter(context.Background(), srv.db, 10, zerolog.Nop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.GET("/tasks/:id", srv.getTaskByID)
+	r.PUT("/tasks/:id", srv.updateTask)
+
+	repo.On("GetTaskByID", "task1", "user1").Return(taskmodels.Task{
+		ID: "task1", UserID: "user1", Version: 3,
+		Attributes: taskmodels.TaskAttributes{Title: "Old", Description: "Old", Status: taskmodels.StatusNew},
+	}, nil)
+	repo.On("UpdateTaskAttributes", mock.MatchedBy(func(task taskmodels.Task) bool {
+		return task.Version == 3
+	})).Return(nil).Once()
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	res, err := resty.New().R().Get(httpSrv.URL + "/tasks/task1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+	assert.Equal(t, `"3"`, res.Header().Get("ETag"))
+
+	res, err = resty.New().R().SetHeader("If-None-Match", `"3"`).Get(httpSrv.URL + "/tasks/task1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode())
+
+	res, err = resty.New().R().SetHeader("If-None-Match", `"2"`).Get(httpSrv.URL + "/tasks/task1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+
+	body := `{"title": "Updated", "description": "Updated desc", "status": "In Progress"}`
+
+	for _, etag := range []string{`"2"`, "garbage"} {
+		res, err = resty.New().R().SetHeader("If-Match", etag).SetBody(body).Put(httpSrv.URL + "/tasks/task1")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, res.StatusCode())
+	}
+
+	res, err = resty.New().R().SetHeader("If-Match", `"3"`).SetBody(body).Put(httpSrv.URL + "/tasks/task1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+}
+
+func TestPatchTask(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.PATCH("/tasks/:id", srv.patchTask)
+
+	task := taskmodels.Task{
+		ID: "task1", UserID: "user1", Version: 3,
+		Attributes: taskmodels.TaskAttributes{
+			Title: "Old", Description: "Old", Status: taskmodels.StatusNew, Priority: taskmodels.PriorityNone,
+		},
+	}
+	patched := task
+	patched.Version = 4
+	patched.Attributes.Status = taskmodels.StatusInProgress
+
+	repo.On("GetTaskByID", "task1", "user1").Return(task, nil).Once()
+	repo.On("UpdateTaskFields", mock.Anything, []taskmodels.TaskField{taskmodels.FieldStatus}).Return(nil).Once()
+	repo.On("GetTaskByID", "task1", "user1").Return(patched, nil).Once()
+	repo.On("GetTaskByID", "task1", "user1").Return(task, nil).Times(3)
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		statusCode  int
+	}{
+		{"merge patch", "application/merge-patch+json", `{"status": "In Progress"}`, http.StatusOK},
+		{"wrong content type", "application/json", `{"status": "In Progress"}`, http.StatusUnsupportedMediaType},
+		{"test failed", "application/json-patch+json", `[{"op": "test", "path": "/title", "value": "New"}]`,
+			http.StatusConflict},
+		{"invalid result", "application/merge-patch+json", `{"title": ""}`, http.StatusBadRequest},
+		{"broken patch", "application/json-patch+json", `{"op": "remove"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := resty.New().R().
+				SetHeader("Content-Type", tt.contentType).
+				SetHeader("If-Match", `"3"`).
+				SetBody(tt.body).
+				Patch(httpSrv.URL + "/tasks/task1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.statusCode, res.StatusCode(), string(res.Body()))
+
+			if tt.statusCode == http.StatusOK {
+				assert.Equal(t, `"4"`, res.Header().Get("ETag"))
+				assert.Contains(t, string(res.Body()), `"status":"In Progress"`)
+			}
+		})
+	}
+}
+
+func TestBulkTasks(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+	srv.runInTx = func(fn func(tx Storage) error) error { return fn(repo) }
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.POST("/tasks/bulk", srv.bulkTasks)
+
+	repo.On("MarkTaskToDelete", "task1", "user1").Return(nil)
+	repo.On("GetTaskByID", "task2", "user1").Return(taskmodels.Task{}, taskerrors.ErrFoundNothing)
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		wantBody   string
+	}{
+		{
+			name: "best effort",
+			body: `{"mode": "best_effort", "operations": [{"action": "delete", "task_id": "task1"},
+				{"action": "status", "task_id": "task2", "status": "Completed"}]}`,
+			statusCode: http.StatusOK,
+			wantBody:   `"status":"failed"`,
+		},
+		{
+			name: "atomic",
+			body: `{"operations": [{"action": "delete", "task_id": "task1"},
+				{"action": "status", "task_id": "task2", "status": "Completed"}]}`,
+			statusCode: http.StatusNotFound,
+			wantBody:   `"status":"rolled_back"`,
+		},
+		{
+			name:       "wrong mode",
+			body:       `{"mode": "all", "operations": [{"action": "delete", "task_id": "task1"}]}`,
+			statusCode: http.StatusBadRequest,
+			wantBody:   `"error"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := resty.New().R().
+				SetHeader("Content-Type", "application/json").
+				SetBody(tt.body).
+				Post(httpSrv.URL + "/tasks/bulk")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.statusCode, res.StatusCode(), string(res.Body()))
+			assert.Contains(t, string(res.Body()), tt.wantBody)
+		})
+	}
+}
+
+func TestSearchTasks(t *testing.T) {
+	var srv ToDoListAPI
+	gin.SetMode(gin.ReleaseMode)
+
+	repo := mocks.NewStorage(t)
+	srv.db = repo
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.GET("/tasks/search", srv.searchTasks)
+
+	repo.On("SearchTasks", "user1", taskmodels.SearchQuery{
+		Query: "invoices", Language: taskmodels.SearchEnglish, Limit: 5,
+	}).Return([]taskmodels.SearchResult{{
+		Task: taskmodels.Task{ID: "task1"}, Rank: 0.6, Title: "<mark>Invoices</mark>",
+	}}, nil)
+	repo.On("SearchTasks", "user1", taskmodels.SearchQuery{
+		Query: "счета", Language: taskmodels.SearchRussian, Limit: taskmodels.DefaultSearchLimit,
+	}).Return(nil, nil)
+
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+		wantBody   string
+	}{
+		{"found", "q=invoices&lang=english&limit=5", http.StatusOK, `"rank":0.6`},
+		{"nothing found", "q=счета", http.StatusOK, `[]`},
+		{"no query", "", http.StatusBadRequest, `"error"`},
+		{"wrong limit", "q=invoices&limit=many", http.StatusBadRequest, `"error"`},
+		{"wrong language", "q=invoices&lang=german", http.StatusBadRequest, `"error"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := resty.New().R().Get(httpSrv.URL + "/tasks/search?" + tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.statusCode, res.StatusCode(), string(res.Body()))
+			assert.Contains(t, string(res.Body()), tt.wantBody)
+		})
+	}
+}
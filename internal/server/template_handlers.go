@@ -0,0 +1,108 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"toDoList/internal/domain/template/templatemodels"
+	"toDoList/internal/service/taskservice"
+	"toDoList/internal/service/templateservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getTemplates(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	templateService := templateservice.NewTemplateService(srv.db)
+	templates, err := templateService.GetTemplates(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+func (srv *ToDoListAPI) getTemplateByID(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	templateService := templateservice.NewTemplateService(srv.db)
+	template, err := templateService.GetTemplate(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+func (srv *ToDoListAPI) createTemplate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req templatemodels.TemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	templateService := templateservice.NewTemplateService(srv.db)
+	template, err := templateService.CreateTemplate(userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+func (srv *ToDoListAPI) deleteTemplate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	templateService := templateservice.NewTemplateService(srv.db)
+	if err := templateService.DeleteTemplate(ctx.Param("id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Template was deleted")
+}
+
+// instantiateTemplate - тело необязательно, если в шаблоне нет переменных.
+func (srv *ToDoListAPI) instantiateTemplate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req templatemodels.InstantiateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	runInTx := func(fn func(tx taskservice.TaskStorage) error) error {
+		return srv.runInTx(func(tx Storage) error { return fn(tx) })
+	}
+
+	taskService := taskservice.NewTaskService(srv.db, srv.taskDeleter)
+	task, err := taskService.InstantiateTemplate(ctx.Param("id"), userID, req, runInTx)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, task)
+}
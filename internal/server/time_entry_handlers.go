@@ -0,0 +1,223 @@
+package server
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
+	"toDoList/internal/service/timeentryservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+// startTimer - тело с заметкой необязательно.
+func (srv *ToDoListAPI) startTimer(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req timeentrymodels.TimerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timeEntryService := timeentryservice.NewTimeEntryService(srv.db)
+	entry, err := timeEntryService.StartTimer(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+func (srv *ToDoListAPI) stopTimer(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	timeEntryService := timeentryservice.NewTimeEntryService(srv.db)
+	entry, err := timeEntryService.StopTimer(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// getRunningTimer - GET /timer, запущенный таймер пользователя.
+func (srv *ToDoListAPI) getRunningTimer(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	timeEntryService := timeentryservice.NewTimeEntryService(srv.db)
+	entry, err := timeEntryService.GetRunningTimer(userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+func (srv *ToDoListAPI) getTimeEntries(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	timeEntryService := timeentryservice.NewTimeEntryService(srv.db)
+	entries, err := timeEntryService.GetTimeEntries(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"time_entries": entries})
+}
+
+func (srv *ToDoListAPI) addTimeEntry(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req timeentrymodels.TimeEntryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timeEntryService := timeentryservice.NewTimeEntryService(srv.db)
+	entry, err := timeEntryService.AddTimeEntry(ctx.Param("id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+func (srv *ToDoListAPI) updateTimeEntry(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req timeentrymodels.TimeEntryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timeEntryService := timeentryservice.NewTimeEntryService(srv.db)
+	entry, err := timeEntryService.UpdateTimeEntry(ctx.Param("id"), ctx.Param("entry_id"), userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+func (srv *ToDoListAPI) deleteTimeEntry(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	timeEntryService := timeentryservice.NewTimeEntryService(srv.db)
+	if err := timeEntryService.DeleteTimeEntry(ctx.Param("id"), ctx.Param("entry_id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Time entry was deleted")
+}
+
+// getTimeReport - GET /reports/time?from=&to=&group_by=project|task|day, с format=csv отчёт
+// отдаётся файлом CSV.
+func (srv *ToDoListAPI) getTimeReport(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	query := timeentrymodels.ReportQuery{
+		From:    ctx.Query("from"),
+		To:      ctx.Query("to"),
+		GroupBy: timeentrymodels.GroupBy(ctx.Query("group_by")),
+	}
+
+	timeEntryService := timeentryservice.NewTimeEntryService(srv.db)
+	report, err := timeEntryService.GetReport(userID, query)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	switch ctx.Query("format") {
+	case "", "json":
+		ctx.JSON(http.StatusOK, report)
+	case "csv":
+		data, errCSV := timeReportCSV(report)
+		if errCSV != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		ctx.Header("Content-Disposition", `attachment; filename="time-report.csv"`)
+		ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "unknown format, expected json or csv"})
+	}
+}
+
+// timeReportCSV - строка на группу и итоговая строка total. Часы округлены до сотых, точное время -
+// в колонке seconds. При группировке по задачам добавляется оценка задачи в минутах.
+func timeReportCSV(report timeentrymodels.Report) ([]byte, error) {
+	withEstimate := report.GroupBy == timeentrymodels.GroupByTask
+
+	header := []string{string(report.GroupBy), "title", "hours", "seconds"}
+	if withEstimate {
+		header = append(header, "estimate_minutes")
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	record := func(key string, title string, seconds int64, estimate string) []string {
+		fields := []string{key, title, strconv.FormatFloat(float64(seconds)/3600, 'f', 2, 64),
+			strconv.FormatInt(seconds, 10)}
+		if withEstimate {
+			fields = append(fields, estimate)
+		}
+		return fields
+	}
+
+	for _, row := range report.Rows {
+		if err := w.Write(record(row.Key, row.Title, row.Seconds, strconv.Itoa(row.Estimate))); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Write(record("total", "", report.TotalSeconds, "")); err != nil {
+		return nil, err
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+	"toDoList/internal/domain/webhook/webhookmodels"
+	"toDoList/internal/service/webhookservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (srv *ToDoListAPI) getWebhooks(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	webhookService := webhookservice.NewWebhookService(srv.db, srv.webhooks)
+	webhooks, err := webhookService.GetWebhooks(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func (srv *ToDoListAPI) createWebhook(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req webhookmodels.WebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhookService := webhookservice.NewWebhookService(srv.db, srv.webhooks)
+	webhook, err := webhookService.CreateWebhook(userID, req)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (srv *ToDoListAPI) deleteWebhook(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	webhookService := webhookservice.NewWebhookService(srv.db, srv.webhooks)
+	if err := webhookService.DeleteWebhook(ctx.Param("id"), userID); err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "Webhook was deleted")
+}
+
+func (srv *ToDoListAPI) getWebhookDeliveries(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	webhookService := webhookservice.NewWebhookService(srv.db, srv.webhooks)
+	deliveries, err := webhookService.GetDeliveries(ctx.Param("id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+func (srv *ToDoListAPI) redeliverWebhook(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	webhookService := webhookservice.NewWebhookService(srv.db, srv.webhooks)
+	delivery, err := webhookService.Redeliver(ctx.Param("id"), ctx.Param("delivery_id"), userID)
+	if err != nil {
+		ctx.JSON(taskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
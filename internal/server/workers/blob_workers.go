@@ -0,0 +1,85 @@
+package workers
+
+import (
+	"context"
+	"time"
+	"toDoList/pkg/blob"
+
+	"github.com/rs/zerolog"
+)
+
+// blobBatchSize - столько объектов сборщик удаляет за один тик.
+const blobBatchSize = 100
+
+type BlobStorage interface {
+	GetOrphanedBlobKeys(limit int) ([]string, error)
+	DeleteOrphanedBlobKey(key string) error
+}
+
+// BlobCollector - периодически удаляет из хранилища файлов содержимое удалённых вложений,
+// в том числе вложений окончательно удалённых задач.
+type BlobCollector struct {
+	storage  BlobStorage
+	blobs    blob.Store
+	interval time.Duration
+	ctx      context.Context
+	log      zerolog.Logger
+}
+
+func NewBlobCollector(
+	ctx context.Context,
+	storage BlobStorage,
+	blobs blob.Store,
+	interval time.Duration,
+	log zerolog.Logger,
+) *BlobCollector {
+	return &BlobCollector{
+		storage:  storage,
+		blobs:    blobs,
+		interval: interval,
+		ctx:      ctx,
+		log:      log,
+	}
+}
+
+func (c *BlobCollector) Start() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			c.log.Info().Msg("BlobCollector stopped")
+			return
+		case <-ticker.C:
+		}
+
+		c.collect()
+	}
+}
+
+// collect - ключ удаляется только после объекта, поэтому при ошибке объект удалится на следующем тике.
+func (c *BlobCollector) collect() {
+	keys, err := c.storage.GetOrphanedBlobKeys(blobBatchSize)
+	if err != nil {
+		c.log.Error().Err(err).Msg("failed to get orphaned blob keys")
+		return
+	}
+
+	var deleted int
+	for _, key := range keys {
+		if err = c.blobs.Delete(c.ctx, key); err != nil {
+			c.log.Error().Err(err).Str("key", key).Msg("failed to delete orphaned blob")
+			continue
+		}
+		if err = c.storage.DeleteOrphanedBlobKey(key); err != nil {
+			c.log.Error().Err(err).Str("key", key).Msg("failed to delete orphaned blob key")
+			continue
+		}
+		deleted++
+	}
+
+	if deleted != 0 {
+		c.log.Debug().Int("count", deleted).Msg("orphaned blobs deleted")
+	}
+}
@@ -0,0 +1,62 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/repository/inmemory"
+	"toDoList/pkg/blob"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyStore - хранилище файлов, которое не может удалить failKey.
+type flakyStore struct {
+	blob.Store
+	failKey string
+}
+
+func (s flakyStore) Delete(ctx context.Context, key string) error {
+	if key == s.failKey {
+		return errors.New("storage is down")
+	}
+	return s.Store.Delete(ctx, key)
+}
+
+func TestBlobCollector(t *testing.T) {
+	ctx := context.Background()
+	storage := inmemory.NewInMemoryStorage()
+	blobs, err := blob.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, storage.AddTask(taskmodels.Task{ID: "t1", UserID: "u1"}))
+	for _, id := range []string{"a1", "a2"} {
+		key := "tasks/t1/" + id
+		require.NoError(t, blobs.Put(ctx, key, strings.NewReader("data"), 4, "text/plain"))
+		require.NoError(t, storage.AddAttachment(attachmentmodels.Attachment{ID: id, TaskID: "t1", BlobKey: key}))
+	}
+	require.NoError(t, storage.DeleteTask("t1", "u1"))
+
+	// Ключ, объект которого удалить не удалось, остаётся до следующего тика.
+	NewBlobCollector(ctx, storage, flakyStore{Store: blobs, failKey: "tasks/t1/a2"}, time.Hour, zerolog.Nop()).collect()
+
+	_, err = blobs.Open(ctx, "tasks/t1/a1")
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+	keys, err := storage.GetOrphanedBlobKeys(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tasks/t1/a2"}, keys)
+
+	NewBlobCollector(ctx, storage, blobs, time.Hour, zerolog.Nop()).collect()
+
+	_, err = blobs.Open(ctx, "tasks/t1/a2")
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+	keys, err = storage.GetOrphanedBlobKeys(10)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type IdempotencyStorage interface {
+	DeleteExpiredIdempotencyKeys(now time.Time) (int64, error)
+}
+
+// IdempotencyKeyCleaner - периодически удаляет истёкшие ключи идемпотентности вместе с сохранёнными ответами.
+type IdempotencyKeyCleaner struct {
+	storage  IdempotencyStorage
+	interval time.Duration
+	now      func() time.Time
+	ctx      context.Context
+	log      zerolog.Logger
+}
+
+func NewIdempotencyKeyCleaner(
+	ctx context.Context,
+	storage IdempotencyStorage,
+	interval time.Duration,
+	log zerolog.Logger,
+) *IdempotencyKeyCleaner {
+	return &IdempotencyKeyCleaner{
+		storage:  storage,
+		interval: interval,
+		now:      time.Now,
+		ctx:      ctx,
+		log:      log,
+	}
+}
+
+func (c *IdempotencyKeyCleaner) Start() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			c.log.Info().Msg("IdempotencyKeyCleaner stopped")
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := c.storage.DeleteExpiredIdempotencyKeys(c.now().UTC())
+		if err != nil {
+			c.log.Error().Err(err).Msg("failed to delete expired idempotency keys")
+		} else if deleted != 0 {
+			c.log.Debug().Int64("count", deleted).Msg("expired idempotency keys deleted")
+		}
+	}
+}
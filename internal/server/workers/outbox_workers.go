@@ -0,0 +1,156 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/event/eventmodels"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// outboxBatch - сколько событий публикуется за один проход.
+	outboxBatch = 100
+	// outboxRetention - сколько хранятся уже опубликованные события.
+	outboxRetention = internal.WeekOne
+)
+
+var ErrPublisherFull = errors.New("event channel is full")
+
+type OutboxStorage interface {
+	RelayOutboxEvents(limit int, publish func(event eventmodels.Event) error) (int, error)
+	DeletePublishedOutboxEvents(before time.Time) (int64, error)
+}
+
+// Publisher - получатель доменных событий. Ошибка означает, что событие будет опубликовано
+// повторно, поэтому Publish должен переносить повторы.
+type Publisher interface {
+	Publish(ctx context.Context, event eventmodels.Event) error
+}
+
+// LogPublisher - пишет события в лог.
+type LogPublisher struct {
+	Log zerolog.Logger
+}
+
+func (p LogPublisher) Publish(_ context.Context, event eventmodels.Event) error {
+	p.Log.Debug().
+		Int64("seq", event.Seq).
+		Str("type", string(event.Type)).
+		Str("aggregate", event.AggregateType).
+		Str("aggregate_id", event.AggregateID).
+		Msg("domain event")
+	return nil
+}
+
+// ChannelPublisher - отдаёт события подписчикам внутри процесса через буферизированный канал.
+// Если буфер заполнен, Publish не ждёт, а возвращает ErrPublisherFull, и релей повторит попытку позже.
+type ChannelPublisher struct {
+	events chan eventmodels.Event
+}
+
+func NewChannelPublisher(buffer int) *ChannelPublisher {
+	return &ChannelPublisher{events: make(chan eventmodels.Event, buffer)}
+}
+
+func (p *ChannelPublisher) Events() <-chan eventmodels.Event {
+	return p.events
+}
+
+func (p *ChannelPublisher) Publish(ctx context.Context, event eventmodels.Event) error {
+	// select выбирает случайную из готовых веток, поэтому отмену проверяем заранее.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case p.events <- event:
+		return nil
+	default:
+		return ErrPublisherFull
+	}
+}
+
+// MultiPublisher - публикует событие во все получатели по очереди. После ошибки одного из них
+// событие повторяется целиком, так что предыдущие получатели увидят его ещё раз.
+type MultiPublisher []Publisher
+
+func (p MultiPublisher) Publish(ctx context.Context, event eventmodels.Event) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OutboxRelay - периодически публикует события из outbox и удаляет старые опубликованные.
+// Порядок событий одного агрегата и единственность релея обеспечивает хранилище.
+type OutboxRelay struct {
+	storage   OutboxStorage
+	publisher Publisher
+	interval  time.Duration
+	now       func() time.Time
+	ctx       context.Context
+	log       zerolog.Logger
+}
+
+func NewOutboxRelay(
+	ctx context.Context,
+	storage OutboxStorage,
+	publisher Publisher,
+	interval time.Duration,
+	log zerolog.Logger,
+) *OutboxRelay {
+	return &OutboxRelay{
+		storage:   storage,
+		publisher: publisher,
+		interval:  interval,
+		now:       time.Now,
+		ctx:       ctx,
+		log:       log,
+	}
+}
+
+func (r *OutboxRelay) Start() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			r.log.Info().Msg("OutboxRelay stopped")
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.relay(); err != nil {
+			r.log.Error().Err(err).Msg("failed to relay outbox events")
+		}
+
+		deleted, err := r.storage.DeletePublishedOutboxEvents(r.now().Add(-outboxRetention))
+		if err != nil {
+			r.log.Error().Err(err).Msg("failed to delete published outbox events")
+		} else if deleted != 0 {
+			r.log.Debug().Int64("count", deleted).Msg("published outbox events deleted")
+		}
+	}
+}
+
+// relay - публикует события, пока хранилище отдаёт полные пачки.
+func (r *OutboxRelay) relay() error {
+	for {
+		published, err := r.storage.RelayOutboxEvents(outboxBatch, func(event eventmodels.Event) error {
+			return r.publisher.Publish(r.ctx, event)
+		})
+		if err != nil {
+			return err
+		}
+
+		if published < outboxBatch {
+			return nil
+		}
+	}
+}
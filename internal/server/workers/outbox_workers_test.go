@@ -0,0 +1,77 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/repository/inmemory"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingPublisher struct {
+	calls int
+}
+
+func (p *failingPublisher) Publish(context.Context, eventmodels.Event) error {
+	p.calls++
+	return errors.New("broker is down")
+}
+
+func TestChannelPublisher(t *testing.T) {
+	publisher := NewChannelPublisher(1)
+	event := eventmodels.Event{ID: "e1"}
+
+	require.NoError(t, publisher.Publish(context.Background(), event))
+	assert.ErrorIs(t, publisher.Publish(context.Background(), event), ErrPublisherFull)
+	assert.Equal(t, event, <-publisher.Events())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, publisher.Publish(ctx, event), context.Canceled)
+}
+
+func TestOutboxRelay(t *testing.T) {
+	storage := inmemory.NewInMemoryStorage()
+	task := taskmodels.Task{ID: "t1", UserID: "u1", Attributes: taskmodels.TaskAttributes{Title: "Report"}}
+	require.NoError(t, storage.AddTask(task))
+	task.Attributes.Status = taskmodels.StatusCompleted
+	require.NoError(t, storage.UpdateTaskAttributes(task))
+
+	channel := NewChannelPublisher(10)
+	failing := &failingPublisher{}
+
+	// Пока второй получатель недоступен, события остаются в outbox.
+	relay := NewOutboxRelay(context.Background(), storage, MultiPublisher{channel, failing}, time.Hour, zerolog.Nop())
+	require.NoError(t, relay.relay())
+	assert.Equal(t, 1, failing.calls)
+	require.Len(t, channel.Events(), 1)
+	<-channel.Events()
+
+	// До повторной попытки события задачи не публикуются.
+	require.NoError(t, relay.relay())
+	assert.Equal(t, 1, failing.calls)
+	assert.Empty(t, channel.Events())
+	time.Sleep(eventmodels.PublishBackoff(1))
+
+	relay = NewOutboxRelay(context.Background(), storage, MultiPublisher{LogPublisher{Log: zerolog.Nop()}, channel},
+		time.Hour, zerolog.Nop())
+	require.NoError(t, relay.relay())
+
+	require.Len(t, channel.Events(), 3)
+	for _, want := range []eventmodels.EventType{
+		eventmodels.TaskCreated, eventmodels.TaskUpdated, eventmodels.TaskStatusChanged,
+	} {
+		event := <-channel.Events()
+		assert.Equal(t, want, event.Type)
+		assert.Equal(t, "t1", event.AggregateID)
+	}
+
+	require.NoError(t, relay.relay())
+	assert.Empty(t, channel.Events())
+}
@@ -0,0 +1,111 @@
+package workers
+
+import (
+	"context"
+	"time"
+	"toDoList/internal/domain/reminder/remindermodels"
+
+	"github.com/rs/zerolog"
+)
+
+// reminderBatch - сколько напоминаний отправляется за один тик.
+const reminderBatch = 100
+
+type ReminderStorage interface {
+	CancelStaleReminders() (int64, error)
+	FireDueReminders(
+		now time.Time,
+		limit int,
+		fire func(notification remindermodels.Notification) error,
+	) (int, error)
+}
+
+// Notifier - канал доставки напоминаний: почта, мессенджер, push и т.п.
+type Notifier interface {
+	Notify(ctx context.Context, notification remindermodels.Notification) error
+}
+
+// LogNotifier - Notifier по умолчанию, только пишет напоминание в лог.
+type LogNotifier struct {
+	Log zerolog.Logger
+}
+
+func (n LogNotifier) Notify(_ context.Context, notification remindermodels.Notification) error {
+	n.Log.Info().
+		Str("user", notification.UserID).
+		Str("task", notification.TaskID).
+		Str("title", notification.TaskTitle).
+		Time("fire_at", notification.FireAt).
+		Msg("reminder")
+	return nil
+}
+
+// ReminderScheduler - периодически отменяет неактуальные напоминания и отправляет наступившие.
+// Защита от повторной отправки лежит на хранилище, поэтому воркеров может быть несколько.
+type ReminderScheduler struct {
+	storage  ReminderStorage
+	notifier Notifier
+	interval time.Duration
+	now      func() time.Time
+	ctx      context.Context
+	log      zerolog.Logger
+}
+
+func NewReminderScheduler(
+	ctx context.Context,
+	storage ReminderStorage,
+	notifier Notifier,
+	interval time.Duration,
+	log zerolog.Logger,
+) *ReminderScheduler {
+	return &ReminderScheduler{
+		storage:  storage,
+		notifier: notifier,
+		interval: interval,
+		now:      time.Now,
+		ctx:      ctx,
+		log:      log,
+	}
+}
+
+func (r *ReminderScheduler) Start() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			r.log.Info().Msg("ReminderScheduler stopped")
+			return
+		case <-ticker.C:
+			if err := r.tick(); err != nil {
+				r.log.Error().Err(err).Msg("failed to process reminders")
+			}
+		}
+	}
+}
+
+func (r *ReminderScheduler) tick() error {
+	cancelled, err := r.storage.CancelStaleReminders()
+	if err != nil {
+		return err
+	}
+	if cancelled != 0 {
+		r.log.Info().Int64("count", cancelled).Msg("reminders cancelled")
+	}
+
+	notify := func(notification remindermodels.Notification) error {
+		return r.notifier.Notify(r.ctx, notification)
+	}
+
+	for {
+		fired, errFire := r.storage.FireDueReminders(r.now().UTC(), reminderBatch, notify)
+		if errFire != nil {
+			return errFire
+		}
+		// Полная пачка - возможно, есть ещё наступившие напоминания.
+		if fired < reminderBatch {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/webhook/webhookerrors"
+	"toDoList/internal/domain/webhook/webhookmodels"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// webhookBatch - сколько доставок забирается из хранилища за раз.
+	webhookBatch = 50
+	// webhookLease - на это время забранная доставка скрыта от других экземпляров сервера.
+	webhookLease      = internal.MinOne
+	webhookTimeout    = internal.SecTen
+	webhookMaxBackoff = time.Hour
+)
+
+type WebhookStorage interface {
+	GetWebhooksForEvent(userID string, eventType webhookmodels.EventType) ([]webhookmodels.Webhook, error)
+	AddWebhookDeliveries(deliveries []webhookmodels.Delivery) error
+	ClaimWebhookDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]webhookmodels.Delivery, error)
+	UpdateWebhookDelivery(delivery webhookmodels.Delivery) error
+}
+
+// WebhookDispatcher - сохраняет доставки событий в хранилище и отправляет их в фоне.
+// Неудачная попытка повторяется с экспоненциальной задержкой, после MaxDeliveryAttempts
+// доставка получает статус dead и остаётся в журнале.
+type WebhookDispatcher struct {
+	storage     WebhookStorage
+	client      *http.Client
+	wake        chan struct{}
+	interval    time.Duration
+	baseBackoff time.Duration
+	now         func() time.Time
+	ctx         context.Context
+	log         zerolog.Logger
+}
+
+func NewWebhookDispatcher(
+	ctx context.Context,
+	storage WebhookStorage,
+	interval time.Duration,
+	log zerolog.Logger,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		storage:     storage,
+		client:      newWebhookClient(),
+		wake:        make(chan struct{}, 1),
+		interval:    interval,
+		baseBackoff: internal.SecFive,
+		now:         time.Now,
+		ctx:         ctx,
+		log:         log,
+	}
+}
+
+// newWebhookClient - клиент, который подключается только к публичным адресам. Адрес проверяется
+// после разрешения имени, поэтому ни смена ответа DNS, ни редирект не уводят запрос во внутреннюю сеть.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(_ string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !webhookmodels.PublicIP(ip) {
+				return fmt.Errorf("%w: %s", webhookerrors.ErrWebhookAddressNotAllowed, host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: webhookTimeout, Transport: transport}
+}
+
+// Publish - создаёт доставки события задачи для подписанных вебхуков её владельца,
+// остальные события вебхукам не отправляются. ID доставляемого события совпадает с ID события outbox.
+func (d *WebhookDispatcher) Publish(_ context.Context, event eventmodels.Event) error {
+	eventType := webhookmodels.EventType(event.Type)
+	if event.AggregateType != eventmodels.AggregateTask || !eventType.IsValid() {
+		return nil
+	}
+
+	webhooks, err := d.storage.GetWebhooksForEvent(event.UserID, eventType)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	var task taskmodels.Task
+	if err = json.Unmarshal(event.Payload, &task); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(webhookmodels.Event{
+		ID:         event.ID,
+		Type:       eventType,
+		UserID:     event.UserID,
+		OccurredAt: event.OccurredAt,
+		Task:       task,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := d.now().UTC()
+	deliveries := make([]webhookmodels.Delivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, webhookmodels.Delivery{
+			ID:            uuid.New().String(),
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     eventType,
+			Payload:       payload,
+			Status:        webhookmodels.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	if err = d.storage.AddWebhookDeliveries(deliveries); err != nil {
+		return err
+	}
+
+	d.Notify()
+	return nil
+}
+
+// Notify - будит воркер, не дожидаясь следующего тика.
+func (d *WebhookDispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *WebhookDispatcher) Start() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			d.log.Info().Msg("WebhookDispatcher stopped")
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+
+		if err := d.dispatch(); err != nil {
+			d.log.Error().Err(err).Msg("failed to dispatch webhooks")
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch() error {
+	for {
+		now := d.now().UTC()
+		deliveries, err := d.storage.ClaimWebhookDeliveries(now, now.Add(webhookLease), webhookBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			if err = d.storage.UpdateWebhookDelivery(d.deliver(delivery)); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < webhookBatch {
+			return nil
+		}
+	}
+}
+
+// deliver - одна попытка доставки, возвращает доставку с обновлённым состоянием.
+func (d *WebhookDispatcher) deliver(delivery webhookmodels.Delivery) webhookmodels.Delivery {
+	delivery.Attempts++
+
+	code, err := d.send(delivery)
+	now := d.now().UTC()
+	delivery.ResponseCode = code
+
+	if err == nil {
+		delivery.Status = webhookmodels.DeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		return delivery
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= webhookmodels.MaxDeliveryAttempts {
+		delivery.Status = webhookmodels.DeliveryDead
+		d.log.Warn().Str("delivery", delivery.ID).Str("webhook", delivery.WebhookID).Msg("webhook delivery is dead")
+		return delivery
+	}
+
+	delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	return delivery
+}
+
+// backoff - задержка после attempts неудачных попыток: baseBackoff, 2*baseBackoff, 4*baseBackoff...
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	delay := d.baseBackoff
+	for i := 1; i < attempts && delay < webhookMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookMaxBackoff)
+}
+
+func (d *WebhookDispatcher) send(delivery webhookmodels.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookmodels.EventHeader, string(delivery.EventType))
+	req.Header.Set(webhookmodels.DeliveryHeader, delivery.ID)
+	req.Header.Set(webhookmodels.SignatureHeader, webhookmodels.Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Тело ответа не нужно, но его дочитывание позволяет переиспользовать соединение.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,193 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/user/usermodels"
+	"toDoList/internal/domain/webhook/webhookerrors"
+	"toDoList/internal/domain/webhook/webhookmodels"
+	"toDoList/internal/repository/inmemory"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver - локальный получатель вебхуков, первые failures запросов отвечают 500.
+func newReceiver(t *testing.T, failures int32) (*httptest.Server, chan receivedRequest) {
+	received := make(chan receivedRequest, 100)
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received <- receivedRequest{header: r.Header.Clone(), body: body}
+
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, received
+}
+
+func newTestDispatcher(t *testing.T, url string, events ...webhookmodels.EventType) (
+	*WebhookDispatcher, *inmemory.Storage, *time.Time,
+) {
+	storage := inmemory.NewInMemoryStorage()
+	require.NoError(t, storage.AddWebhook(webhookmodels.Webhook{
+		ID: "w1", UserID: "u1", URL: url, Secret: "top-secret", Events: events,
+	}))
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	dispatcher := NewWebhookDispatcher(context.Background(), storage, time.Hour, zerolog.Nop())
+	dispatcher.now = func() time.Time { return now }
+	// Получатели в тестах слушают loopback, на который клиент диспетчера не подключается.
+	dispatcher.client = &http.Client{Timeout: webhookTimeout}
+
+	return dispatcher, storage, &now
+}
+
+func publish(t *testing.T, dispatcher *WebhookDispatcher, eventType eventmodels.EventType) {
+	event, err := eventmodels.NewTaskEvent(eventType,
+		taskmodels.Task{ID: "t1", UserID: "u1", Attributes: taskmodels.TaskAttributes{Title: "Report"}})
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Publish(context.Background(), event))
+}
+
+func TestWebhookDispatcher_DeliversSignedPayload(t *testing.T) {
+	receiver, received := newReceiver(t, 0)
+	dispatcher, storage, _ := newTestDispatcher(t, receiver.URL, webhookmodels.EventTaskCreated)
+
+	publish(t, dispatcher, eventmodels.TaskCreated)
+	// На это событие вебхук не подписан, а события пользователей вебхукам не отправляются.
+	publish(t, dispatcher, eventmodels.TaskDeleted)
+	userEvent, err := eventmodels.NewUserEvent(eventmodels.UserCreated, usermodels.User{UUID: "u1"})
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Publish(context.Background(), userEvent))
+	require.NoError(t, dispatcher.dispatch())
+
+	require.Len(t, received, 1)
+	req := <-received
+
+	assert.Equal(t, "task.created", req.header.Get(webhookmodels.EventHeader))
+	assert.Equal(t, webhookmodels.Sign("top-secret", req.body), req.header.Get(webhookmodels.SignatureHeader))
+
+	var event webhookmodels.Event
+	require.NoError(t, json.Unmarshal(req.body, &event))
+	assert.Equal(t, "t1", event.Task.ID)
+	assert.Equal(t, "Report", event.Task.Attributes.Title)
+
+	deliveries, err := storage.GetWebhookDeliveries("w1", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhookmodels.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].ResponseCode)
+	assert.Equal(t, req.header.Get(webhookmodels.DeliveryHeader), deliveries[0].ID)
+}
+
+func TestWebhookDispatcher_RetriesWithBackoff(t *testing.T) {
+	receiver, received := newReceiver(t, 2)
+	dispatcher, storage, now := newTestDispatcher(t, receiver.URL, webhookmodels.EventTaskUpdated)
+
+	publish(t, dispatcher, eventmodels.TaskUpdated)
+
+	start := *now
+	for _, wait := range []time.Duration{0, dispatcher.baseBackoff, 2 * dispatcher.baseBackoff} {
+		// Раньше срока повторная попытка не делается.
+		*now = now.Add(wait - time.Second)
+		require.NoError(t, dispatcher.dispatch())
+
+		*now = now.Add(time.Second)
+		require.NoError(t, dispatcher.dispatch())
+	}
+
+	assert.Len(t, received, 3)
+
+	deliveries, err := storage.GetWebhookDeliveries("w1", 10)
+	require.NoError(t, err)
+	assert.Equal(t, webhookmodels.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+	assert.Equal(t, start.Add(3*dispatcher.baseBackoff), *deliveries[0].DeliveredAt)
+
+	// Все попытки отправили одно и то же тело.
+	first := <-received
+	for range 2 {
+		assert.Equal(t, first.body, (<-received).body)
+	}
+}
+
+func TestWebhookDispatcher_DeadLetter(t *testing.T) {
+	receiver, received := newReceiver(t, webhookmodels.MaxDeliveryAttempts+1)
+	dispatcher, storage, now := newTestDispatcher(t, receiver.URL, webhookmodels.EventTaskStatusChanged)
+
+	publish(t, dispatcher, eventmodels.TaskStatusChanged)
+	for range webhookmodels.MaxDeliveryAttempts + 2 {
+		require.NoError(t, dispatcher.dispatch())
+		*now = now.Add(webhookMaxBackoff)
+	}
+
+	assert.Len(t, received, webhookmodels.MaxDeliveryAttempts)
+
+	deliveries, err := storage.GetWebhookDeliveries("w1", 10)
+	require.NoError(t, err)
+	assert.Equal(t, webhookmodels.DeliveryDead, deliveries[0].Status)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].ResponseCode)
+	assert.Contains(t, deliveries[0].LastError, "500")
+}
+
+func TestWebhookDispatcher_PublishIsIdempotent(t *testing.T) {
+	dispatcher, storage, _ := newTestDispatcher(t, "https://ci.example.com/hook", webhookmodels.EventTaskCreated)
+
+	event, err := eventmodels.NewTaskEvent(eventmodels.TaskCreated, taskmodels.Task{ID: "t1", UserID: "u1"})
+	require.NoError(t, err)
+	// Релей повторяет публикацию, если не успел отметить событие отправленным.
+	require.NoError(t, dispatcher.Publish(context.Background(), event))
+	require.NoError(t, dispatcher.Publish(context.Background(), event))
+
+	deliveries, err := storage.GetWebhookDeliveries("w1", 10)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+}
+
+func TestWebhookDispatcher_RefusesInternalAddress(t *testing.T) {
+	receiver, received := newReceiver(t, 0)
+	dispatcher, storage, _ := newTestDispatcher(t, receiver.URL, webhookmodels.EventTaskCreated)
+	dispatcher.client = newWebhookClient()
+
+	publish(t, dispatcher, eventmodels.TaskCreated)
+	require.NoError(t, dispatcher.dispatch())
+
+	assert.Empty(t, received)
+
+	deliveries, err := storage.GetWebhookDeliveries("w1", 10)
+	require.NoError(t, err)
+	assert.Equal(t, webhookmodels.DeliveryPending, deliveries[0].Status)
+	assert.Contains(t, deliveries[0].LastError, webhookerrors.ErrWebhookAddressNotAllowed.Error())
+}
+
+func TestWebhookDispatcher_Backoff(t *testing.T) {
+	dispatcher := NewWebhookDispatcher(context.Background(), nil, time.Hour, zerolog.Nop())
+	dispatcher.baseBackoff = time.Second
+
+	assert.Equal(t, time.Second, dispatcher.backoff(1))
+	assert.Equal(t, 2*time.Second, dispatcher.backoff(2))
+	assert.Equal(t, 8*time.Second, dispatcher.backoff(4))
+	assert.Equal(t, webhookMaxBackoff, dispatcher.backoff(100))
+}
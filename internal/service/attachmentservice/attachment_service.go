@@ -0,0 +1,179 @@
+package attachmentservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/attachment/attachmenterrors"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/pkg/blob"
+
+	"github.com/google/uuid"
+)
+
+// maxFileNameLength - длиннее имя файла обрезается.
+const maxFileNameLength = 255
+
+// sniffLength - столько первых байт нужно http.DetectContentType.
+const sniffLength = 512
+
+// AttachmentStorage - DeleteAttachment и окончательное удаление задач передают ключи
+// содержимого вложений сборщику, сами объекты удаляет workers.BlobCollector.
+type AttachmentStorage interface {
+	GetTaskByID(taskID string, userID string) (taskmodels.Task, error)
+	AddAttachment(attachment attachmentmodels.Attachment) error
+	GetTaskAttachments(taskID string) ([]attachmentmodels.Attachment, error)
+	GetAttachmentByID(attachmentID string, taskID string) (attachmentmodels.Attachment, error)
+	DeleteAttachment(attachmentID string, taskID string) error
+}
+
+type AttachmentService struct {
+	db      AttachmentStorage
+	blobs   blob.Store
+	maxSize int64
+	now     func() time.Time
+}
+
+func NewAttachmentService(db AttachmentStorage, blobs blob.Store, maxSize int64) *AttachmentService {
+	return &AttachmentService{db: db, blobs: blobs, maxSize: maxSize, now: time.Now}
+}
+
+// Upload - прикладывает к задаче, видимой пользователю, файл размером size. Тип содержимого
+// определяется по первым байтам файла и должен быть среди AllowedContentTypes.
+func (as *AttachmentService) Upload(
+	ctx context.Context,
+	taskID string,
+	userID string,
+	fileName string,
+	content io.ReadSeeker,
+	size int64,
+) (attachmentmodels.Attachment, error) {
+	if size <= 0 {
+		return attachmentmodels.Attachment{}, fmt.Errorf("%w: file is empty", attachmenterrors.ErrWrongAttachment)
+	}
+	if size > as.maxSize {
+		return attachmentmodels.Attachment{}, fmt.Errorf("%w: limit is %d bytes",
+			attachmenterrors.ErrAttachmentTooLarge, as.maxSize)
+	}
+
+	if _, err := as.db.GetTaskByID(taskID, userID); err != nil {
+		return attachmentmodels.Attachment{}, err
+	}
+
+	contentType, err := detectContentType(content)
+	if err != nil {
+		return attachmentmodels.Attachment{}, err
+	}
+
+	id := uuid.New().String()
+	attachment := attachmentmodels.Attachment{
+		ID:          id,
+		TaskID:      taskID,
+		UserID:      userID,
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		BlobKey:     "tasks/" + taskID + "/" + id,
+		CreatedAt:   as.now().UTC(),
+	}
+
+	if err = as.blobs.Put(ctx, attachment.BlobKey, content, size, contentType); err != nil {
+		return attachmentmodels.Attachment{}, fmt.Errorf("%w: %w", attachmenterrors.ErrBlobStorage, err)
+	}
+
+	if err = as.db.AddAttachment(attachment); err != nil {
+		// Без записи о вложении объект никто не найдёт, поэтому удаляем его сразу.
+		if errDelete := as.blobs.Delete(context.WithoutCancel(ctx), attachment.BlobKey); errDelete != nil {
+			err = errors.Join(err, fmt.Errorf("%w: %w", attachmenterrors.ErrBlobStorage, errDelete))
+		}
+		return attachmentmodels.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+func detectContentType(content io.ReadSeeker) (string, error) {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("%w: %w", attachmenterrors.ErrWrongAttachment, err)
+	}
+	if _, err = content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !slices.Contains(attachmentmodels.AllowedContentTypes, mediaType) {
+		return "", fmt.Errorf("%w: %s", attachmenterrors.ErrAttachmentType, contentType)
+	}
+	return contentType, nil
+}
+
+// cleanFileName - только имя без каталогов, как его прислал браузер на Windows или Unix.
+func cleanFileName(fileName string) string {
+	name := strings.TrimSpace(path.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = string(runes[:maxFileNameLength])
+	}
+	return name
+}
+
+func (as *AttachmentService) GetAttachments(taskID string, userID string) ([]attachmentmodels.Attachment, error) {
+	if _, err := as.db.GetTaskByID(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return as.db.GetTaskAttachments(taskID)
+}
+
+// Open - вложение и его содержимое, которое вызывающий код должен закрыть.
+func (as *AttachmentService) Open(ctx context.Context, taskID string, attachmentID string, userID string) (
+	attachmentmodels.Attachment, blob.Object, error,
+) {
+	if _, err := as.db.GetTaskByID(taskID, userID); err != nil {
+		return attachmentmodels.Attachment{}, nil, err
+	}
+
+	attachment, err := as.db.GetAttachmentByID(attachmentID, taskID)
+	if err != nil {
+		return attachmentmodels.Attachment{}, nil, err
+	}
+
+	object, err := as.blobs.Open(ctx, attachment.BlobKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return attachmentmodels.Attachment{}, nil, attachmenterrors.ErrAttachmentNotFound
+		}
+		return attachmentmodels.Attachment{}, nil, fmt.Errorf("%w: %w", attachmenterrors.ErrBlobStorage, err)
+	}
+	return attachment, object, nil
+}
+
+// DeleteAttachment - удалить вложение может загрузивший его или владелец задачи.
+func (as *AttachmentService) DeleteAttachment(taskID string, attachmentID string, userID string) error {
+	task, err := as.db.GetTaskByID(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := as.db.GetAttachmentByID(attachmentID, taskID)
+	if err != nil {
+		return err
+	}
+	if attachment.UserID != userID && task.UserID != userID {
+		return attachmenterrors.ErrNotAttachmentOwner
+	}
+
+	return as.db.DeleteAttachment(attachmentID, taskID)
+}
@@ -0,0 +1,177 @@
+package attachmentservice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"toDoList/internal/domain/attachment/attachmenterrors"
+	"toDoList/internal/domain/attachment/attachmentmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/mocks"
+	"toDoList/pkg/blob"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		fileName string
+		taskErr  error
+		addErr   error
+		wantType string
+		wantName string
+		wantErr  error
+	}{
+		{
+			name:     "png",
+			content:  pngHeader,
+			fileName: `C:\Users\anna\screenshot.png`,
+			wantType: "image/png",
+			wantName: "screenshot.png",
+		},
+		{
+			name:     "text",
+			content:  []byte("notes"),
+			fileName: "../notes.txt",
+			wantType: "text/plain; charset=utf-8",
+			wantName: "notes.txt",
+		},
+		{
+			name:     "executable is rejected",
+			content:  []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff"),
+			fileName: "setup.png",
+			wantErr:  attachmenterrors.ErrAttachmentType,
+		},
+		{
+			name:     "html is rejected",
+			content:  []byte("<html><script>alert(1)</script>"),
+			fileName: "page.txt",
+			wantErr:  attachmenterrors.ErrAttachmentType,
+		},
+		{
+			name:    "too large",
+			content: bytes.Repeat([]byte("a"), 65),
+			wantErr: attachmenterrors.ErrAttachmentTooLarge,
+		},
+		{
+			name:    "empty",
+			wantErr: attachmenterrors.ErrWrongAttachment,
+		},
+		{
+			name:    "invisible task",
+			content: []byte("notes"),
+			taskErr: taskerrors.ErrFoundNothing,
+			wantErr: taskerrors.ErrFoundNothing,
+		},
+		{
+			name:    "task deleted meanwhile",
+			content: []byte("notes"),
+			addErr:  taskerrors.ErrFoundNothing,
+			wantErr: taskerrors.ErrFoundNothing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			blobs, err := blob.NewLocal(t.TempDir())
+			require.NoError(t, err)
+
+			repo.On("GetTaskByID", "t1", "u1").Return(taskmodels.Task{ID: "t1", UserID: "u1"}, tt.taskErr).Maybe()
+			var added attachmentmodels.Attachment
+			repo.On("AddAttachment", mock.Anything).
+				Run(func(args mock.Arguments) { added = args.Get(0).(attachmentmodels.Attachment) }).
+				Return(tt.addErr).Maybe()
+
+			as := NewAttachmentService(repo, blobs, 64)
+			got, err := as.Upload(
+				context.Background(), "t1", "u1", tt.fileName, bytes.NewReader(tt.content), int64(len(tt.content)),
+			)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				if added.BlobKey != "" {
+					_, err = blobs.Open(context.Background(), added.BlobKey)
+					assert.ErrorIs(t, err, blob.ErrNotFound, "blob must be removed when insert fails")
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, added, got)
+			assert.Equal(t, tt.wantType, got.ContentType)
+			assert.Equal(t, tt.wantName, got.FileName)
+			assert.Equal(t, "tasks/t1/"+got.ID, got.BlobKey)
+
+			obj, err := blobs.Open(context.Background(), got.BlobKey)
+			require.NoError(t, err)
+			defer obj.Close()
+			stored, err := io.ReadAll(obj)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, stored)
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	blobs, err := blob.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, blobs.Put(context.Background(), "tasks/t1/a1", strings.NewReader("notes"), 5, "text/plain"))
+
+	repo := mocks.NewStorage(t)
+	repo.On("GetTaskByID", "t1", "u1").Return(taskmodels.Task{ID: "t1"}, nil)
+	repo.On("GetAttachmentByID", "a1", "t1").Return(attachmentmodels.Attachment{ID: "a1", BlobKey: "tasks/t1/a1"}, nil)
+	repo.On("GetAttachmentByID", "lost", "t1").
+		Return(attachmentmodels.Attachment{ID: "lost", BlobKey: "tasks/t1/lost"}, nil)
+	repo.On("GetAttachmentByID", "bad", "t1").Return(attachmentmodels.Attachment{ID: "bad", BlobKey: "../x"}, nil)
+
+	as := NewAttachmentService(repo, blobs, attachmentmodels.DefaultMaxSize)
+
+	attachment, obj, err := as.Open(context.Background(), "t1", "a1", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "a1", attachment.ID)
+	assert.Equal(t, int64(5), obj.Size())
+	require.NoError(t, obj.Close())
+
+	_, _, err = as.Open(context.Background(), "t1", "lost", "u1")
+	assert.ErrorIs(t, err, attachmenterrors.ErrAttachmentNotFound)
+
+	_, _, err = as.Open(context.Background(), "t1", "bad", "u1")
+	assert.ErrorIs(t, err, attachmenterrors.ErrBlobStorage)
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		{name: "uploader", userID: "u2"},
+		{name: "task owner", userID: "u1"},
+		{name: "another member", userID: "u3", wantErr: attachmenterrors.ErrNotAttachmentOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			repo.On("GetTaskByID", "t1", tt.userID).Return(taskmodels.Task{ID: "t1", UserID: "u1"}, nil)
+			repo.On("GetAttachmentByID", "a1", "t1").Return(attachmentmodels.Attachment{ID: "a1", UserID: "u2"}, nil)
+			if tt.wantErr == nil {
+				repo.On("DeleteAttachment", "a1", "t1").Return(nil)
+			}
+
+			as := NewAttachmentService(repo, nil, attachmentmodels.DefaultMaxSize)
+			err := as.DeleteAttachment("t1", "a1", tt.userID)
+			assert.True(t, errors.Is(err, tt.wantErr), err)
+		})
+	}
+}
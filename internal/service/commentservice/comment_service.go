@@ -0,0 +1,217 @@
+package commentservice
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/comment/commenterrors"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/user/usererrors"
+	"toDoList/internal/domain/user/usermodels"
+	"toDoList/pkg/mention"
+
+	"github.com/google/uuid"
+)
+
+// CommentStorage - AddComment и UpdateComment сами пишут события об упоминаниях:
+// первый - для всех Mentions, второй - только для новых.
+type CommentStorage interface {
+	GetTaskByID(taskID string, userID string) (taskmodels.Task, error)
+	GetProjectByID(projectID string) (projectmodels.Project, error)
+	GetUserByID(userID string) (usermodels.User, error)
+	AddComment(comment commentmodels.Comment) error
+	GetTaskComments(taskID string) ([]commentmodels.Comment, error)
+	GetCommentByID(commentID string, taskID string) (commentmodels.Comment, error)
+	UpdateComment(comment commentmodels.Comment) error
+	DeleteComment(commentID string, taskID string) error
+}
+
+type CommentService struct {
+	db  CommentStorage
+	now func() time.Time
+}
+
+func NewCommentService(db CommentStorage) *CommentService {
+	return &CommentService{db: db, now: time.Now}
+}
+
+// AddComment - комментарий или ответ на комментарий к задаче, видимой пользователю.
+func (cs *CommentService) AddComment(taskID string, userID string, req commentmodels.CommentRequest) (
+	commentmodels.Comment, error,
+) {
+	if err := validateBody(req.Body); err != nil {
+		return commentmodels.Comment{}, err
+	}
+
+	task, err := cs.db.GetTaskByID(taskID, userID)
+	if err != nil {
+		return commentmodels.Comment{}, err
+	}
+
+	if req.ParentID != "" {
+		parent, errParent := cs.db.GetCommentByID(req.ParentID, taskID)
+		if errParent != nil {
+			return commentmodels.Comment{}, errParent
+		}
+		if parent.Deleted {
+			return commentmodels.Comment{}, commenterrors.ErrCommentNotFound
+		}
+	}
+
+	mentions, err := cs.resolveMentions(task, userID, req.Body)
+	if err != nil {
+		return commentmodels.Comment{}, err
+	}
+
+	comment := commentmodels.Comment{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		ParentID:  req.ParentID,
+		AuthorID:  userID,
+		Body:      req.Body,
+		Mentions:  mentions,
+		CreatedAt: cs.now().UTC(),
+	}
+
+	if err = cs.db.AddComment(comment); err != nil {
+		return commentmodels.Comment{}, err
+	}
+	return comment, nil
+}
+
+// GetComments - комментарии задачи по времени создания. Тексты удалённых комментариев не отдаются.
+func (cs *CommentService) GetComments(taskID string, userID string) ([]commentmodels.Comment, error) {
+	if _, err := cs.db.GetTaskByID(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	comments, err := cs.db.GetTaskComments(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range comments {
+		if comments[i].Deleted {
+			comments[i].Body = ""
+			comments[i].Mentions = nil
+		}
+	}
+	return comments, nil
+}
+
+// UpdateComment - менять текст может только автор, пока задача ему видна.
+func (cs *CommentService) UpdateComment(
+	taskID string,
+	commentID string,
+	userID string,
+	req commentmodels.CommentUpdateRequest,
+) (commentmodels.Comment, error) {
+	if err := validateBody(req.Body); err != nil {
+		return commentmodels.Comment{}, err
+	}
+
+	task, comment, err := cs.getOwnComment(taskID, commentID, userID)
+	if err != nil {
+		return commentmodels.Comment{}, err
+	}
+
+	mentions, err := cs.resolveMentions(task, userID, req.Body)
+	if err != nil {
+		return commentmodels.Comment{}, err
+	}
+
+	editedAt := cs.now().UTC()
+	comment.Body = req.Body
+	comment.Mentions = mentions
+	comment.EditedAt = &editedAt
+
+	if err = cs.db.UpdateComment(comment); err != nil {
+		return commentmodels.Comment{}, err
+	}
+	return comment, nil
+}
+
+func (cs *CommentService) DeleteComment(taskID string, commentID string, userID string) error {
+	if _, _, err := cs.getOwnComment(taskID, commentID, userID); err != nil {
+		return err
+	}
+
+	return cs.db.DeleteComment(commentID, taskID)
+}
+
+func (cs *CommentService) getOwnComment(taskID string, commentID string, userID string) (
+	taskmodels.Task, commentmodels.Comment, error,
+) {
+	task, err := cs.db.GetTaskByID(taskID, userID)
+	if err != nil {
+		return taskmodels.Task{}, commentmodels.Comment{}, err
+	}
+
+	comment, err := cs.db.GetCommentByID(commentID, taskID)
+	if err != nil {
+		return taskmodels.Task{}, commentmodels.Comment{}, err
+	}
+	if comment.Deleted {
+		return taskmodels.Task{}, commentmodels.Comment{}, commenterrors.ErrCommentNotFound
+	}
+	if comment.AuthorID != userID {
+		return taskmodels.Task{}, commentmodels.Comment{}, commenterrors.ErrNotCommentAuthor
+	}
+	return task, comment, nil
+}
+
+func validateBody(body string) error {
+	if strings.TrimSpace(body) == "" || len([]rune(body)) > commentmodels.MaxBodyLength {
+		return fmt.Errorf("%w: body must be 1 to %d characters", commenterrors.ErrWrongComment,
+			commentmodels.MaxBodyLength)
+	}
+	return nil
+}
+
+// resolveMentions - ID участников проекта задачи, упомянутых в body по имени, кроме самого автора.
+// Упоминание подходит ко всем участникам с таким именем, остальные упоминания игнорируются.
+// У задачи без проекта упоминать некого: её видит только владелец.
+func (cs *CommentService) resolveMentions(task taskmodels.Task, authorID string, body string) ([]string, error) {
+	names := mention.Names(body)
+	if len(names) == 0 || task.Attributes.ProjectID == "" {
+		return nil, nil
+	}
+
+	project, err := cs.db.GetProjectByID(task.Attributes.ProjectID)
+	if err != nil {
+		if errors.Is(err, projecterrors.ErrProjectNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	membersByName := make(map[string][]string)
+	for _, memberID := range project.Members {
+		if memberID == authorID {
+			continue
+		}
+
+		member, errUser := cs.db.GetUserByID(memberID)
+		if errUser != nil {
+			if errors.Is(errUser, usererrors.ErrUserNotExist) {
+				continue
+			}
+			return nil, errUser
+		}
+
+		key := mention.Key(member.Name)
+		membersByName[key] = append(membersByName[key], memberID)
+	}
+
+	var mentions []string
+	for _, name := range names {
+		mentions = append(mentions, membersByName[name]...)
+	}
+	slices.Sort(mentions)
+	return slices.Compact(mentions), nil
+}
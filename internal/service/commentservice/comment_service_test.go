@@ -0,0 +1,172 @@
+package commentservice
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"toDoList/internal/domain/comment/commenterrors"
+	"toDoList/internal/domain/comment/commentmodels"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/user/usermodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddComment(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	projectTask := taskmodels.Task{ID: "t1", UserID: "u1", Attributes: taskmodels.TaskAttributes{ProjectID: "p1"}}
+	privateTask := taskmodels.Task{ID: "t1", UserID: "u1"}
+
+	tests := []struct {
+		name         string
+		req          commentmodels.CommentRequest
+		task         taskmodels.Task
+		taskErr      error
+		parent       *commentmodels.Comment
+		wantMentions []string
+		wantErr      error
+	}{
+		{
+			name:         "mentions project members",
+			req:          commentmodels.CommentRequest{Body: "@AnnaSmith, @bob and @carol, take a look. @Me"},
+			task:         projectTask,
+			wantMentions: []string{"u2", "u3"},
+		},
+		{
+			name: "mention in code is ignored",
+			req:  commentmodels.CommentRequest{Body: "run `notify @bob`"},
+			task: projectTask,
+		},
+		{
+			name: "no project no mentions",
+			req:  commentmodels.CommentRequest{Body: "@bob"},
+			task: privateTask,
+		},
+		{
+			name:   "reply",
+			req:    commentmodels.CommentRequest{Body: "agree", ParentID: "c1"},
+			task:   privateTask,
+			parent: &commentmodels.Comment{ID: "c1", TaskID: "t1"},
+		},
+		{
+			name:    "reply to deleted comment",
+			req:     commentmodels.CommentRequest{Body: "agree", ParentID: "c1"},
+			task:    privateTask,
+			parent:  &commentmodels.Comment{ID: "c1", TaskID: "t1", Deleted: true},
+			wantErr: commenterrors.ErrCommentNotFound,
+		},
+		{
+			name:    "empty body",
+			req:     commentmodels.CommentRequest{Body: " \n"},
+			wantErr: commenterrors.ErrWrongComment,
+		},
+		{
+			name:    "too long body",
+			req:     commentmodels.CommentRequest{Body: strings.Repeat("я", commentmodels.MaxBodyLength+1)},
+			wantErr: commenterrors.ErrWrongComment,
+		},
+		{
+			name:    "invisible task",
+			req:     commentmodels.CommentRequest{Body: "hi"},
+			taskErr: taskerrors.ErrFoundNothing,
+			wantErr: taskerrors.ErrFoundNothing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewCommentService(repo)
+			service.now = func() time.Time { return now }
+
+			if tt.task.ID != "" || tt.taskErr != nil {
+				repo.On("GetTaskByID", "t1", "me").Return(tt.task, tt.taskErr)
+			}
+			if tt.parent != nil {
+				repo.On("GetCommentByID", tt.parent.ID, "t1").Return(*tt.parent, nil)
+			}
+			if len(tt.wantMentions) != 0 {
+				repo.On("GetProjectByID", "p1").Return(projectmodels.Project{
+					ID: "p1", Members: []string{"me", "u1", "u2", "u3"},
+				}, nil)
+				repo.On("GetUserByID", "u1").Return(usermodels.User{UUID: "u1", Name: "Dave"}, nil)
+				repo.On("GetUserByID", "u2").Return(usermodels.User{UUID: "u2", Name: "Anna Smith"}, nil)
+				repo.On("GetUserByID", "u3").Return(usermodels.User{UUID: "u3", Name: "Bob"}, nil)
+			}
+			if tt.wantErr == nil {
+				repo.On("AddComment", mock.MatchedBy(func(c commentmodels.Comment) bool {
+					return c.ID != "" && c.TaskID == "t1" && c.AuthorID == "me" && c.CreatedAt.Equal(now)
+				})).Return(nil)
+			}
+
+			comment, err := service.AddComment("t1", "me", tt.req)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.req.ParentID, comment.ParentID)
+				assert.Equal(t, tt.wantMentions, comment.Mentions)
+			}
+		})
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	task := taskmodels.Task{ID: "t1", UserID: "u1"}
+
+	tests := []struct {
+		name    string
+		comment commentmodels.Comment
+		wantErr error
+	}{
+		{"author", commentmodels.Comment{ID: "c1", TaskID: "t1", AuthorID: "me", Body: "old"}, nil},
+		{"not author", commentmodels.Comment{ID: "c1", TaskID: "t1", AuthorID: "u1", Body: "old"},
+			commenterrors.ErrNotCommentAuthor},
+		{"deleted", commentmodels.Comment{ID: "c1", TaskID: "t1", AuthorID: "me", Deleted: true},
+			commenterrors.ErrCommentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewCommentService(repo)
+			service.now = func() time.Time { return now }
+
+			repo.On("GetTaskByID", "t1", "me").Return(task, nil)
+			repo.On("GetCommentByID", "c1", "t1").Return(tt.comment, nil)
+			if tt.wantErr == nil {
+				repo.On("UpdateComment", mock.MatchedBy(func(c commentmodels.Comment) bool {
+					return c.Body == "new" && c.EditedAt != nil && c.EditedAt.Equal(now)
+				})).Return(nil)
+			}
+
+			comment, err := service.UpdateComment("t1", "c1", "me", commentmodels.CommentUpdateRequest{Body: "new"})
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, "new", comment.Body)
+			}
+		})
+	}
+}
+
+func TestGetComments_HidesDeletedBodies(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewCommentService(repo)
+
+	repo.On("GetTaskByID", "t1", "me").Return(taskmodels.Task{ID: "t1"}, nil)
+	repo.On("GetTaskComments", "t1").Return([]commentmodels.Comment{
+		{ID: "c1", TaskID: "t1", Body: "secret @bob", Mentions: []string{"u2"}, Deleted: true},
+		{ID: "c2", TaskID: "t1", ParentID: "c1", Body: "reply"},
+	}, nil)
+
+	comments, err := service.GetComments("t1", "me")
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Empty(t, comments[0].Body)
+	assert.Empty(t, comments[0].Mentions)
+	assert.Equal(t, "reply", comments[1].Body)
+}
@@ -0,0 +1,167 @@
+package customfieldservice
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// CustomFieldStorage - DeleteCustomField удаляет и значения поля из задач проекта.
+type CustomFieldStorage interface {
+	GetProjectByID(projectID string) (projectmodels.Project, error)
+	AddCustomField(field customfieldmodels.CustomField) error
+	GetCustomFields(projectID string) ([]customfieldmodels.CustomField, error)
+	DeleteCustomField(fieldID string, projectID string) error
+}
+
+type CustomFieldService struct {
+	db    CustomFieldStorage
+	valid *validator.Validate
+	now   func() time.Time
+}
+
+func NewCustomFieldService(db CustomFieldStorage) *CustomFieldService {
+	return &CustomFieldService{db: db, valid: validator.New(), now: time.Now}
+}
+
+// GetCustomFields - поля доступны участникам проекта.
+func (cs *CustomFieldService) GetCustomFields(projectID string, userID string) (
+	[]customfieldmodels.CustomField, error,
+) {
+	if _, err := cs.project(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	fields, err := cs.db.GetCustomFields(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = []customfieldmodels.CustomField{}
+	}
+	return fields, nil
+}
+
+// CreateCustomField - добавлять поля может только владелец проекта.
+func (cs *CustomFieldService) CreateCustomField(
+	projectID string,
+	userID string,
+	req customfieldmodels.CustomFieldRequest,
+) (customfieldmodels.CustomField, error) {
+	if err := cs.valid.Struct(req); err != nil {
+		return customfieldmodels.CustomField{}, err
+	}
+
+	if err := cs.checkOwner(projectID, userID); err != nil {
+		return customfieldmodels.CustomField{}, err
+	}
+
+	field, err := newCustomField(req)
+	if err != nil {
+		return customfieldmodels.CustomField{}, err
+	}
+
+	fields, err := cs.db.GetCustomFields(projectID)
+	if err != nil {
+		return customfieldmodels.CustomField{}, err
+	}
+	if len(fields) >= customfieldmodels.MaxFieldsPerProject {
+		return customfieldmodels.CustomField{}, fmt.Errorf("%w: project already has %d fields",
+			customfielderrors.ErrWrongCustomField, customfieldmodels.MaxFieldsPerProject)
+	}
+
+	field.ID = uuid.New().String()
+	field.ProjectID = projectID
+	field.CreatedAt = cs.now().UTC()
+
+	if err = cs.db.AddCustomField(field); err != nil {
+		return customfieldmodels.CustomField{}, err
+	}
+	return field, nil
+}
+
+// DeleteCustomField - удалять поля может только владелец проекта, значения поля у задач пропадают.
+func (cs *CustomFieldService) DeleteCustomField(projectID string, fieldID string, userID string) error {
+	if err := cs.checkOwner(projectID, userID); err != nil {
+		return err
+	}
+	return cs.db.DeleteCustomField(fieldID, projectID)
+}
+
+func (cs *CustomFieldService) checkOwner(projectID string, userID string) error {
+	project, err := cs.project(projectID, userID)
+	if err != nil {
+		return err
+	}
+	if project.OwnerID != userID {
+		return projecterrors.ErrNotProjectOwner
+	}
+	return nil
+}
+
+func (cs *CustomFieldService) project(projectID string, userID string) (projectmodels.Project, error) {
+	project, err := cs.db.GetProjectByID(projectID)
+	if err != nil {
+		return projectmodels.Project{}, err
+	}
+
+	if !slices.Contains(project.Members, userID) {
+		return projectmodels.Project{}, projecterrors.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// newCustomField - проверяет имя, тип и варианты запроса. Варианты нужны только полям выбора,
+// повторяющиеся варианты схлопываются.
+func newCustomField(req customfieldmodels.CustomFieldRequest) (customfieldmodels.CustomField, error) {
+	field := customfieldmodels.CustomField{Name: strings.TrimSpace(req.Name), Type: req.Type}
+
+	switch {
+	case field.Name == "":
+		return customfieldmodels.CustomField{}, fmt.Errorf("%w: name is empty", customfielderrors.ErrWrongCustomField)
+	case utf8.RuneCountInString(field.Name) > customfieldmodels.MaxNameLength:
+		return customfieldmodels.CustomField{}, fmt.Errorf("%w: name is longer than %d characters",
+			customfielderrors.ErrWrongCustomField, customfieldmodels.MaxNameLength)
+	case !field.Type.IsValid():
+		return customfieldmodels.CustomField{}, fmt.Errorf("%w: unknown type %q",
+			customfielderrors.ErrWrongCustomField, field.Type)
+	case !field.Type.HasOptions() && len(req.Options) != 0:
+		return customfieldmodels.CustomField{}, fmt.Errorf("%w: %s field has no options",
+			customfielderrors.ErrWrongCustomField, field.Type)
+	case len(req.Options) > customfieldmodels.MaxOptions:
+		return customfieldmodels.CustomField{}, fmt.Errorf("%w: more than %d options",
+			customfielderrors.ErrWrongCustomField, customfieldmodels.MaxOptions)
+	}
+
+	for _, option := range req.Options {
+		option = strings.TrimSpace(option)
+
+		switch {
+		case option == "":
+			return customfieldmodels.CustomField{}, fmt.Errorf("%w: option is empty",
+				customfielderrors.ErrWrongCustomField)
+		case utf8.RuneCountInString(option) > customfieldmodels.MaxOptionLength:
+			return customfieldmodels.CustomField{}, fmt.Errorf("%w: option %q is longer than %d characters",
+				customfielderrors.ErrWrongCustomField, option, customfieldmodels.MaxOptionLength)
+		}
+
+		if !field.HasOption(option) {
+			field.Options = append(field.Options, option)
+		}
+	}
+
+	if field.Type.HasOptions() && len(field.Options) == 0 {
+		return customfieldmodels.CustomField{}, fmt.Errorf("%w: %s field needs options",
+			customfielderrors.ErrWrongCustomField, field.Type)
+	}
+	return field, nil
+}
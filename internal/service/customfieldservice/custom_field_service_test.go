@@ -0,0 +1,153 @@
+package customfieldservice
+
+import (
+	"strings"
+	"testing"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var project = projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend", Members: []string{"u1", "u2"}}
+
+func TestGetCustomFields(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewCustomFieldService(repo)
+
+	repo.On("GetProjectByID", "p1").Return(project, nil)
+	repo.On("GetCustomFields", "p1").Return(nil, nil).Once()
+
+	fields, err := service.GetCustomFields("p1", "u2")
+	assert.NoError(t, err)
+	assert.Equal(t, []customfieldmodels.CustomField{}, fields)
+
+	_, err = service.GetCustomFields("p1", "u3")
+	assert.ErrorIs(t, err, projecterrors.ErrProjectNotFound)
+}
+
+func TestCreateCustomField(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		req      customfieldmodels.CustomFieldRequest
+		existing int
+		dbMock   bool
+		dbErr    error
+		want     customfieldmodels.CustomField
+		wantErr  error
+	}{
+		{
+			name:   "select",
+			userID: "u1",
+			req: customfieldmodels.CustomFieldRequest{
+				Name: " Size ", Type: customfieldmodels.TypeSelect, Options: []string{"S", " M", "S"},
+			},
+			dbMock: true,
+			want: customfieldmodels.CustomField{
+				ProjectID: "p1", Name: "Size", Type: customfieldmodels.TypeSelect, Options: []string{"S", "M"},
+			},
+		},
+		{
+			name:   "number",
+			userID: "u1",
+			req:    customfieldmodels.CustomFieldRequest{Name: "Estimate", Type: customfieldmodels.TypeNumber},
+			dbMock: true,
+			want: customfieldmodels.CustomField{
+				ProjectID: "p1", Name: "Estimate", Type: customfieldmodels.TypeNumber,
+			},
+		},
+		{
+			name:    "not owner",
+			userID:  "u2",
+			req:     customfieldmodels.CustomFieldRequest{Name: "Estimate", Type: customfieldmodels.TypeNumber},
+			wantErr: projecterrors.ErrNotProjectOwner,
+		},
+		{
+			name:    "unknown type",
+			userID:  "u1",
+			req:     customfieldmodels.CustomFieldRequest{Name: "Estimate", Type: "money"},
+			wantErr: customfielderrors.ErrWrongCustomField,
+		},
+		{
+			name:    "long name",
+			userID:  "u1",
+			req:     customfieldmodels.CustomFieldRequest{Name: strings.Repeat("ы", 51), Type: "text"},
+			wantErr: customfielderrors.ErrWrongCustomField,
+		},
+		{
+			name:   "options for text",
+			userID: "u1",
+			req: customfieldmodels.CustomFieldRequest{
+				Name: "Notes", Type: customfieldmodels.TypeText, Options: []string{"a"},
+			},
+			wantErr: customfielderrors.ErrWrongCustomField,
+		},
+		{
+			name:   "select without options",
+			userID: "u1",
+			req: customfieldmodels.CustomFieldRequest{
+				Name: "Size", Type: customfieldmodels.TypeMultiSelect, Options: []string{" "},
+			},
+			wantErr: customfielderrors.ErrWrongCustomField,
+		},
+		{
+			name:     "too many fields",
+			userID:   "u1",
+			req:      customfieldmodels.CustomFieldRequest{Name: "Estimate", Type: customfieldmodels.TypeNumber},
+			existing: customfieldmodels.MaxFieldsPerProject,
+			wantErr:  customfielderrors.ErrWrongCustomField,
+		},
+		{
+			name:    "duplicate name",
+			userID:  "u1",
+			req:     customfieldmodels.CustomFieldRequest{Name: "Estimate", Type: customfieldmodels.TypeNumber},
+			dbMock:  true,
+			dbErr:   customfielderrors.ErrCustomFieldIsAlreadyExist,
+			wantErr: customfielderrors.ErrCustomFieldIsAlreadyExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewCustomFieldService(repo)
+
+			repo.On("GetProjectByID", "p1").Return(project, nil)
+			if tt.dbMock || tt.existing != 0 {
+				repo.On("GetCustomFields", "p1").
+					Return(make([]customfieldmodels.CustomField, tt.existing), nil)
+			}
+			if tt.dbMock {
+				repo.On("AddCustomField", mock.MatchedBy(func(f customfieldmodels.CustomField) bool {
+					return f.ID != "" && !f.CreatedAt.IsZero()
+				})).Return(tt.dbErr)
+			}
+
+			field, err := service.CreateCustomField("p1", tt.userID, tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			assert.NotEmpty(t, field.ID)
+			field.ID, field.CreatedAt = "", tt.want.CreatedAt
+			assert.Equal(t, tt.want, field)
+		})
+	}
+}
+
+func TestDeleteCustomField(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewCustomFieldService(repo)
+
+	repo.On("GetProjectByID", "p1").Return(project, nil)
+	repo.On("DeleteCustomField", "f1", "p1").Return(customfielderrors.ErrCustomFieldNotFound).Once()
+
+	assert.ErrorIs(t, service.DeleteCustomField("p1", "f1", "u2"), projecterrors.ErrNotProjectOwner)
+	assert.ErrorIs(t, service.DeleteCustomField("p1", "f1", "u1"), customfielderrors.ErrCustomFieldNotFound)
+}
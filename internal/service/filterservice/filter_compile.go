@@ -0,0 +1,255 @@
+package filterservice
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/pkg/filterexpr"
+	"toDoList/pkg/fulltext"
+)
+
+// Особые значения условий.
+const (
+	valueNone      = "none"
+	valueNow       = "now"
+	valueToday     = "today"
+	valueTomorrow  = "tomorrow"
+	valueYesterday = "yesterday"
+)
+
+// statuses - значения status, совпадающие с ними без учёта регистра, приводятся к ним. Остальные
+// сравниваются как есть: в workflow проекта могут быть свои статусы.
+var statuses = []taskmodels.TaskStatus{taskmodels.StatusNew, taskmodels.StatusInProgress, taskmodels.StatusCompleted}
+
+// priorityOrder - приоритеты по возрастанию, сравнения priority раскрываются в OR равенств.
+var priorityOrder = []taskmodels.TaskPriority{
+	taskmodels.PriorityNone,
+	taskmodels.PriorityLow,
+	taskmodels.PriorityMedium,
+	taskmodels.PriorityHigh,
+	taskmodels.PriorityUrgent,
+}
+
+// relativeTime - смещение от текущего момента: 12h, 7d, -2w.
+var relativeTime = regexp.MustCompile(`^([+-]?\d{1,4})([hdw])$`)
+
+// Compile - разбирает и проверяет выражение фильтра. Относительные даты отсчитываются от now,
+// assignee:me заменяется на userID. Все ошибки оборачивают ErrWrongFilterQuery.
+func Compile(query string, userID string, now time.Time) (filtermodels.Expr, error) {
+	node, err := filterexpr.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", filtererrors.ErrWrongFilterQuery, err)
+	}
+
+	c := compiler{userID: userID, now: now}
+	return c.compile(node)
+}
+
+type compiler struct {
+	userID string
+	now    time.Time
+}
+
+func (c compiler) compile(node filterexpr.Node) (filtermodels.Expr, error) {
+	switch n := node.(type) {
+	case filterexpr.And:
+		left, right, err := c.compilePair(n.Left, n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return filtermodels.And{Left: left, Right: right}, nil
+	case filterexpr.Or:
+		left, right, err := c.compilePair(n.Left, n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return filtermodels.Or{Left: left, Right: right}, nil
+	case filterexpr.Not:
+		expr, err := c.compile(n.Node)
+		if err != nil {
+			return nil, err
+		}
+		return filtermodels.Not{Expr: expr}, nil
+	case filterexpr.Cond:
+		expr, err := c.compileCond(n)
+		if err != nil {
+			return nil, fmt.Errorf("%w: position %d: %w", filtererrors.ErrWrongFilterQuery, n.Pos, err)
+		}
+		return expr, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported node %T", filtererrors.ErrWrongFilterQuery, node)
+	}
+}
+
+func (c compiler) compilePair(left, right filterexpr.Node) (filtermodels.Expr, filtermodels.Expr, error) {
+	leftExpr, err := c.compile(left)
+	if err != nil {
+		return nil, nil, err
+	}
+	rightExpr, err := c.compile(right)
+	if err != nil {
+		return nil, nil, err
+	}
+	return leftExpr, rightExpr, nil
+}
+
+func (c compiler) compileCond(cond filterexpr.Cond) (filtermodels.Expr, error) {
+	field := filtermodels.Field(cond.Field)
+	op := filtermodels.Operator(cond.Op)
+	if field == "" {
+		field, op = filtermodels.FieldText, filtermodels.OpEq
+	}
+
+	if field == filtermodels.FieldDue {
+		return c.compileDue(op, cond.Value)
+	}
+	if field == filtermodels.FieldPriority {
+		return compilePriority(op, cond.Value)
+	}
+	if op != filtermodels.OpEq {
+		return nil, fmt.Errorf("field %q supports only %q", field, filtermodels.OpEq)
+	}
+
+	value := cond.Value
+	switch field {
+	case filtermodels.FieldStatus:
+		i := slices.IndexFunc(statuses, func(s taskmodels.TaskStatus) bool {
+			return strings.EqualFold(string(s), value)
+		})
+		if i >= 0 {
+			value = string(statuses[i])
+		}
+	case filtermodels.FieldTag:
+	case filtermodels.FieldProject:
+		if strings.EqualFold(value, valueNone) {
+			value = ""
+		}
+	case filtermodels.FieldAssignee:
+		switch {
+		case strings.EqualFold(value, taskmodels.AssigneeMe):
+			value = c.userID
+		case strings.EqualFold(value, valueNone):
+			value = ""
+		}
+	case filtermodels.FieldText:
+		if fulltext.ParseQuery(value, string(taskmodels.DefaultSearchLanguage)).IsEmpty() {
+			return nil, fmt.Errorf("text %q has no searchable words", value)
+		}
+		return filtermodels.Condition{
+			Field: field, Op: filtermodels.OpEq, Value: value, Language: taskmodels.DefaultSearchLanguage,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+
+	return filtermodels.Condition{Field: field, Op: filtermodels.OpEq, Value: value}, nil
+}
+
+// compilePriority - priority>=high превращается в priority:high OR priority:urgent.
+func compilePriority(op filtermodels.Operator, value string) (filtermodels.Expr, error) {
+	i := slices.Index(priorityOrder, taskmodels.TaskPriority(strings.ToLower(value)))
+	if i < 0 {
+		return nil, fmt.Errorf("unknown priority %q", value)
+	}
+
+	var matched []taskmodels.TaskPriority
+	switch op {
+	case filtermodels.OpEq:
+		matched = priorityOrder[i : i+1]
+	case filtermodels.OpLess:
+		matched = priorityOrder[:i]
+	case filtermodels.OpLessEq:
+		matched = priorityOrder[:i+1]
+	case filtermodels.OpGreater:
+		matched = priorityOrder[i+1:]
+	case filtermodels.OpGreaterEq:
+		matched = priorityOrder[i:]
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no priority is %s %q", op, value)
+	}
+
+	var expr filtermodels.Expr
+	for _, priority := range matched {
+		cond := filtermodels.Condition{
+			Field: filtermodels.FieldPriority, Op: filtermodels.OpEq, Value: string(priority),
+		}
+		if expr == nil {
+			expr = cond
+		} else {
+			expr = filtermodels.Or{Left: expr, Right: cond}
+		}
+	}
+	return expr, nil
+}
+
+// compileDue - due:none - задачи без срока, due:today и due:2026-10-18 - срок в пределах суток,
+// сравнения принимают дату, время RFC 3339, now, today, tomorrow, yesterday или смещение вроде 7d.
+func (c compiler) compileDue(op filtermodels.Operator, value string) (filtermodels.Expr, error) {
+	if op == filtermodels.OpEq {
+		if strings.EqualFold(value, valueNone) {
+			return filtermodels.Condition{Field: filtermodels.FieldDue, Op: filtermodels.OpEq}, nil
+		}
+
+		day, ok := c.parseDay(value)
+		if !ok {
+			return nil, fmt.Errorf("due:%s expects none, a day name or a date", value)
+		}
+		nextDay := day.Add(internal.DayOne)
+		return filtermodels.And{
+			Left:  filtermodels.Condition{Field: filtermodels.FieldDue, Op: filtermodels.OpGreaterEq, Time: day},
+			Right: filtermodels.Condition{Field: filtermodels.FieldDue, Op: filtermodels.OpLess, Time: nextDay},
+		}, nil
+	}
+
+	bound, err := c.parseTime(value)
+	if err != nil {
+		return nil, err
+	}
+	return filtermodels.Condition{Field: filtermodels.FieldDue, Op: op, Time: bound}, nil
+}
+
+// parseDay - начало дня в UTC для today, tomorrow, yesterday и дат вида 2006-01-02.
+func (c compiler) parseDay(value string) (time.Time, bool) {
+	today := c.now.UTC().Truncate(internal.DayOne)
+
+	switch strings.ToLower(value) {
+	case valueToday:
+		return today, true
+	case valueTomorrow:
+		return today.Add(internal.DayOne), true
+	case valueYesterday:
+		return today.Add(-internal.DayOne), true
+	}
+
+	day, err := time.Parse(time.DateOnly, value)
+	return day, err == nil
+}
+
+func (c compiler) parseTime(value string) (time.Time, error) {
+	if strings.EqualFold(value, valueNow) {
+		return c.now, nil
+	}
+	if day, ok := c.parseDay(value); ok {
+		return day, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	match := relativeTime.FindStringSubmatch(strings.ToLower(value))
+	if match == nil {
+		return time.Time{}, fmt.Errorf("wrong time %q", value)
+	}
+
+	amount, _ := strconv.Atoi(match[1])
+	unit := map[string]time.Duration{"h": time.Hour, "d": internal.DayOne, "w": 7 * internal.DayOne}[match[2]]
+	return c.now.Add(time.Duration(amount) * unit), nil
+}
@@ -0,0 +1,115 @@
+package filterservice
+
+import (
+	"time"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type FilterStorage interface {
+	AddFilter(filter filtermodels.Filter) error
+	GetFiltersByUser(userID string) ([]filtermodels.Filter, error)
+	GetFilterByID(filterID string, userID string) (filtermodels.Filter, error)
+	UpdateFilter(filter filtermodels.Filter) error
+	DeleteFilter(filterID string, userID string) error
+	FindTasksByFilter(userID string, expr filtermodels.Expr) ([]taskmodels.Task, error)
+}
+
+type FilterService struct {
+	db    FilterStorage
+	valid *validator.Validate
+	now   func() time.Time
+}
+
+func NewFilterService(db FilterStorage) *FilterService {
+	return &FilterService{db: db, valid: validator.New(), now: time.Now}
+}
+
+func (fs *FilterService) GetFilters(userID string) ([]filtermodels.Filter, error) {
+	return fs.db.GetFiltersByUser(userID)
+}
+
+func (fs *FilterService) GetFilter(filterID string, userID string) (filtermodels.Filter, error) {
+	return fs.db.GetFilterByID(filterID, userID)
+}
+
+// CreateFilter - выражение проверяется при сохранении, чтобы ошибку увидел автор фильтра.
+func (fs *FilterService) CreateFilter(req filtermodels.FilterRequest, userID string) (filtermodels.Filter, error) {
+	err := fs.validate(req, userID)
+	if err != nil {
+		return filtermodels.Filter{}, err
+	}
+
+	filter := filtermodels.Filter{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      req.Name,
+		Query:     req.Query,
+		CreatedAt: fs.now().UTC(),
+	}
+
+	err = fs.db.AddFilter(filter)
+	if err != nil {
+		return filtermodels.Filter{}, err
+	}
+
+	return filter, nil
+}
+
+func (fs *FilterService) UpdateFilter(
+	filterID string,
+	userID string,
+	req filtermodels.FilterRequest,
+) (filtermodels.Filter, error) {
+	err := fs.validate(req, userID)
+	if err != nil {
+		return filtermodels.Filter{}, err
+	}
+
+	filter, err := fs.db.GetFilterByID(filterID, userID)
+	if err != nil {
+		return filtermodels.Filter{}, err
+	}
+
+	filter.Name = req.Name
+	filter.Query = req.Query
+
+	err = fs.db.UpdateFilter(filter)
+	if err != nil {
+		return filtermodels.Filter{}, err
+	}
+
+	return filter, nil
+}
+
+func (fs *FilterService) DeleteFilter(filterID string, userID string) error {
+	return fs.db.DeleteFilter(filterID, userID)
+}
+
+// FilterTasks - задачи, подходящие под сохранённый фильтр на текущий момент.
+func (fs *FilterService) FilterTasks(filterID string, userID string) ([]taskmodels.Task, error) {
+	filter, err := fs.db.GetFilterByID(filterID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := Compile(filter.Query, userID, fs.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return fs.db.FindTasksByFilter(userID, expr)
+}
+
+func (fs *FilterService) validate(req filtermodels.FilterRequest, userID string) error {
+	err := fs.valid.Struct(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = Compile(req.Query, userID, fs.now().UTC())
+	return err
+}
@@ -0,0 +1,163 @@
+package filterservice
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/filter/filtererrors"
+	"toDoList/internal/domain/filter/filtermodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func eq(field filtermodels.Field, value string) filtermodels.Condition {
+	return filtermodels.Condition{Field: field, Op: filtermodels.OpEq, Value: value}
+}
+
+func due(op filtermodels.Operator, t time.Time) filtermodels.Condition {
+	return filtermodels.Condition{Field: filtermodels.FieldDue, Op: op, Time: t}
+}
+
+func TestCompile(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		query string
+		want  filtermodels.Expr
+	}{
+		{
+			query: `status:"in progress" AND due<7d AND tag:urgent`,
+			want: filtermodels.And{
+				Left: filtermodels.And{
+					Left:  eq(filtermodels.FieldStatus, string(taskmodels.StatusInProgress)),
+					Right: due(filtermodels.OpLess, now.Add(7*24*time.Hour)),
+				},
+				Right: eq(filtermodels.FieldTag, "urgent"),
+			},
+		},
+		{
+			query: `assignee:me OR project:none`,
+			want: filtermodels.Or{
+				Left:  eq(filtermodels.FieldAssignee, "u1"),
+				Right: eq(filtermodels.FieldProject, ""),
+			},
+		},
+		{
+			query: `priority>=High`,
+			want: filtermodels.Or{
+				Left:  eq(filtermodels.FieldPriority, string(taskmodels.PriorityHigh)),
+				Right: eq(filtermodels.FieldPriority, string(taskmodels.PriorityUrgent)),
+			},
+		},
+		{
+			query: `due:today`,
+			want: filtermodels.And{
+				Left:  due(filtermodels.OpGreaterEq, today),
+				Right: due(filtermodels.OpLess, today.Add(24*time.Hour)),
+			},
+		},
+		{
+			query: `NOT due:none счета`,
+			want: filtermodels.And{
+				Left: filtermodels.Not{
+					Expr: filtermodels.Condition{Field: filtermodels.FieldDue, Op: filtermodels.OpEq},
+				},
+				Right: filtermodels.Condition{
+					Field: filtermodels.FieldText, Op: filtermodels.OpEq, Value: "счета",
+					Language: taskmodels.DefaultSearchLanguage,
+				},
+			},
+		},
+		{query: `status:"In Review"`, want: eq(filtermodels.FieldStatus, "In Review")},
+		{query: `due>=-12h`, want: due(filtermodels.OpGreaterEq, now.Add(-12*time.Hour))},
+		{query: `due<2026-11-01`, want: due(filtermodels.OpLess, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := Compile(tt.query, "u1", now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr)
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	for _, query := range []string{
+		`status:`,
+		`tag<urgent`,
+		`color:red`,
+		`priority>urgent`,
+		`due<soon`,
+		`due:7d`,
+		`text:"the"`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := Compile(query, "u1", time.Now())
+			assert.ErrorIs(t, err, filtererrors.ErrWrongFilterQuery)
+		})
+	}
+}
+
+func TestCreateFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     filtermodels.FilterRequest
+		dbMock  bool
+		wantErr error
+	}{
+		{name: "success", req: filtermodels.FilterRequest{Name: "Urgent", Query: "tag:urgent"}, dbMock: true},
+		{
+			name:    "wrong query",
+			req:     filtermodels.FilterRequest{Name: "Urgent", Query: "tag:urgent AND"},
+			wantErr: filtererrors.ErrWrongFilterQuery,
+		},
+		{
+			name:    "duplicate",
+			req:     filtermodels.FilterRequest{Name: "Urgent", Query: "tag:urgent"},
+			dbMock:  true,
+			wantErr: filtererrors.ErrFilterIsAlreadyExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewFilterService(repo)
+
+			if tt.dbMock {
+				repo.On("AddFilter", mock.MatchedBy(func(f filtermodels.Filter) bool {
+					return f.UserID == "u1" && f.Name == tt.req.Name && f.Query == tt.req.Query && f.ID != ""
+				})).Return(tt.wantErr)
+			}
+
+			filter, err := service.CreateFilter(tt.req, "u1")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.req.Query, filter.Query)
+		})
+	}
+}
+
+func TestFilterTasks(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewFilterService(repo)
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	repo.On("GetFilterByID", "f1", "u1").
+		Return(filtermodels.Filter{ID: "f1", UserID: "u1", Name: "Overdue", Query: "due<now"}, nil)
+	repo.On("FindTasksByFilter", "u1", due(filtermodels.OpLess, now)).
+		Return([]taskmodels.Task{{ID: "t1"}}, nil)
+
+	tasks, err := service.FilterTasks("f1", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []taskmodels.Task{{ID: "t1"}}, tasks)
+}
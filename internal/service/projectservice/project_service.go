@@ -0,0 +1,116 @@
+package projectservice
+
+import (
+	"slices"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/user/usermodels"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ProjectStorage interface {
+	AddProject(project projectmodels.Project) error
+	GetProjectsByUser(userID string) ([]projectmodels.Project, error)
+	GetProjectByID(projectID string) (projectmodels.Project, error)
+	AddProjectMember(projectID string, userID string) error
+	RemoveProjectMember(projectID string, userID string) error
+	GetUserByID(userID string) (usermodels.User, error)
+}
+
+type ProjectService struct {
+	db    ProjectStorage
+	valid *validator.Validate
+}
+
+func NewProjectService(db ProjectStorage) *ProjectService {
+	return &ProjectService{db: db, valid: validator.New()}
+}
+
+func (ps *ProjectService) CreateProject(req projectmodels.ProjectRequest, userID string) (projectmodels.Project, error) {
+	err := ps.valid.Struct(req)
+	if err != nil {
+		return projectmodels.Project{}, err
+	}
+
+	project := projectmodels.Project{
+		ID:      uuid.New().String(),
+		OwnerID: userID,
+		Name:    req.Name,
+	}
+
+	err = ps.db.AddProject(project)
+	if err != nil {
+		return projectmodels.Project{}, err
+	}
+
+	project.Members = []string{userID}
+	return project, nil
+}
+
+func (ps *ProjectService) GetProjects(userID string) ([]projectmodels.Project, error) {
+	return ps.db.GetProjectsByUser(userID)
+}
+
+// GetProjectByID - проект доступен только его участникам.
+func (ps *ProjectService) GetProjectByID(projectID string, userID string) (projectmodels.Project, error) {
+	project, err := ps.db.GetProjectByID(projectID)
+	if err != nil {
+		return projectmodels.Project{}, err
+	}
+
+	if !slices.Contains(project.Members, userID) {
+		return projectmodels.Project{}, projecterrors.ErrProjectNotFound
+	}
+
+	return project, nil
+}
+
+func (ps *ProjectService) AddMember(projectID string, userID string, req projectmodels.ProjectMemberRequest) error {
+	err := ps.valid.Struct(req)
+	if err != nil {
+		return err
+	}
+
+	if _, err = ps.ownedProject(projectID, userID); err != nil {
+		return err
+	}
+
+	if _, err = ps.db.GetUserByID(req.UserID); err != nil {
+		return err
+	}
+
+	return ps.db.AddProjectMember(projectID, req.UserID)
+}
+
+// RemoveMember - владелец может исключить любого участника, кроме себя, участник - выйти сам.
+func (ps *ProjectService) RemoveMember(projectID string, userID string, memberID string) error {
+	project, err := ps.GetProjectByID(projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	if memberID == project.OwnerID {
+		return projecterrors.ErrCantRemoveOwner
+	}
+
+	if userID != project.OwnerID && userID != memberID {
+		return projecterrors.ErrNotProjectOwner
+	}
+
+	return ps.db.RemoveProjectMember(projectID, memberID)
+}
+
+func (ps *ProjectService) ownedProject(projectID string, userID string) (projectmodels.Project, error) {
+	project, err := ps.GetProjectByID(projectID, userID)
+	if err != nil {
+		return projectmodels.Project{}, err
+	}
+
+	if project.OwnerID != userID {
+		return projectmodels.Project{}, projecterrors.ErrNotProjectOwner
+	}
+
+	return project, nil
+}
@@ -0,0 +1,177 @@
+package projectservice
+
+import (
+	"testing"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/user/usererrors"
+	"toDoList/internal/domain/user/usermodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     projectmodels.ProjectRequest
+		dbMock  bool
+		dbErr   error
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			req:    projectmodels.ProjectRequest{Name: "Backend"},
+			dbMock: true,
+		},
+		{
+			name:    "empty name",
+			req:     projectmodels.ProjectRequest{},
+			wantErr: true,
+		},
+		{
+			name:    "db error",
+			req:     projectmodels.ProjectRequest{Name: "Backend"},
+			dbMock:  true,
+			dbErr:   projecterrors.ErrProjectIsAlreadyExist,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewProjectService(repo)
+
+			if tt.dbMock {
+				repo.On("AddProject", mock.MatchedBy(func(p projectmodels.Project) bool {
+					return p.Name == tt.req.Name && p.OwnerID == "u1" && p.ID != ""
+				})).Return(tt.dbErr)
+			}
+
+			project, err := service.CreateProject(tt.req, "u1")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"u1"}, project.Members)
+		})
+	}
+}
+
+func TestGetProjectByID(t *testing.T) {
+	project := projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend", Members: []string{"u1", "u2"}}
+
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		{name: "member", userID: "u2"},
+		{name: "stranger", userID: "u3", wantErr: projecterrors.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewProjectService(repo)
+
+			repo.On("GetProjectByID", "p1").Return(project, nil)
+
+			got, err := service.GetProjectByID("p1", tt.userID)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, project, got)
+			}
+		})
+	}
+}
+
+func TestAddMember(t *testing.T) {
+	project := projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend", Members: []string{"u1", "u2"}}
+
+	tests := []struct {
+		name      string
+		userID    string
+		memberID  string
+		userErr   error
+		checkUser bool
+		wantAdd   bool
+		wantErr   error
+	}{
+		{
+			name:      "owner adds user",
+			userID:    "u1",
+			memberID:  "u3",
+			checkUser: true,
+			wantAdd:   true,
+		},
+		{
+			name:     "member can't add",
+			userID:   "u2",
+			memberID: "u3",
+			wantErr:  projecterrors.ErrNotProjectOwner,
+		},
+		{
+			name:      "unknown user",
+			userID:    "u1",
+			memberID:  "u404",
+			checkUser: true,
+			userErr:   usererrors.ErrUserNotExist,
+			wantErr:   usererrors.ErrUserNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewProjectService(repo)
+
+			repo.On("GetProjectByID", "p1").Return(project, nil)
+			if tt.checkUser {
+				repo.On("GetUserByID", tt.memberID).Return(usermodels.User{UUID: tt.memberID}, tt.userErr)
+			}
+			if tt.wantAdd {
+				repo.On("AddProjectMember", "p1", tt.memberID).Return(nil)
+			}
+
+			err := service.AddMember("p1", tt.userID, projectmodels.ProjectMemberRequest{UserID: tt.memberID})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	project := projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend", Members: []string{"u1", "u2", "u3"}}
+
+	tests := []struct {
+		name     string
+		userID   string
+		memberID string
+		wantDel  bool
+		wantErr  error
+	}{
+		{name: "owner removes member", userID: "u1", memberID: "u2", wantDel: true},
+		{name: "member leaves", userID: "u2", memberID: "u2", wantDel: true},
+		{name: "member removes other", userID: "u2", memberID: "u3", wantErr: projecterrors.ErrNotProjectOwner},
+		{name: "owner can't leave", userID: "u1", memberID: "u1", wantErr: projecterrors.ErrCantRemoveOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewProjectService(repo)
+
+			repo.On("GetProjectByID", "p1").Return(project, nil)
+			if tt.wantDel {
+				repo.On("RemoveProjectMember", "p1", tt.memberID).Return(nil)
+			}
+
+			err := service.RemoveMember("p1", tt.userID, tt.memberID)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package reminderservice
+
+import (
+	"time"
+	"toDoList/internal/domain/reminder/remindererrors"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/domain/task/taskmodels"
+
+	"github.com/google/uuid"
+)
+
+type ReminderStorage interface {
+	GetTaskByID(taskID string, userID string) (taskmodels.Task, error)
+	AddReminder(reminder remindermodels.Reminder) error
+	GetTaskReminders(taskID string) ([]remindermodels.Reminder, error)
+	DeleteReminder(reminderID string, taskID string) error
+}
+
+type ReminderService struct {
+	db  ReminderStorage
+	now func() time.Time
+}
+
+func NewReminderService(db ReminderStorage) *ReminderService {
+	return &ReminderService{db: db, now: time.Now}
+}
+
+// AddReminder - напоминание на задачу, видимую пользователю. Напоминание получит сам пользователь.
+func (rs *ReminderService) AddReminder(taskID string, userID string, req remindermodels.ReminderRequest) (
+	remindermodels.Reminder, error,
+) {
+	if (req.At == nil) == (req.BeforeMinutes == nil) || req.BeforeMinutes != nil && *req.BeforeMinutes < 0 {
+		return remindermodels.Reminder{}, remindererrors.ErrWrongReminder
+	}
+
+	task, err := rs.db.GetTaskByID(taskID, userID)
+	if err != nil {
+		return remindermodels.Reminder{}, err
+	}
+
+	if req.At != nil && req.At.Before(rs.now()) {
+		return remindermodels.Reminder{}, remindererrors.ErrReminderInPast
+	}
+
+	if req.BeforeMinutes != nil && task.Attributes.DueDate == nil {
+		return remindermodels.Reminder{}, remindererrors.ErrReminderNeedsDueDate
+	}
+
+	reminder := remindermodels.Reminder{
+		ID:            uuid.New().String(),
+		TaskID:        taskID,
+		UserID:        userID,
+		At:            req.At,
+		BeforeMinutes: req.BeforeMinutes,
+		Status:        remindermodels.StatusPending,
+	}
+	if reminder.At != nil {
+		at := reminder.At.UTC()
+		reminder.At = &at
+	}
+
+	err = rs.db.AddReminder(reminder)
+	if err != nil {
+		return remindermodels.Reminder{}, err
+	}
+
+	return reminder, nil
+}
+
+func (rs *ReminderService) GetReminders(taskID string, userID string) ([]remindermodels.Reminder, error) {
+	if _, err := rs.db.GetTaskByID(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return rs.db.GetTaskReminders(taskID)
+}
+
+func (rs *ReminderService) DeleteReminder(taskID string, reminderID string, userID string) error {
+	if _, err := rs.db.GetTaskByID(taskID, userID); err != nil {
+		return err
+	}
+
+	return rs.db.DeleteReminder(reminderID, taskID)
+}
@@ -0,0 +1,80 @@
+package reminderservice
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/reminder/remindererrors"
+	"toDoList/internal/domain/reminder/remindermodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddReminder(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+	minutes := 30
+	negative := -1
+
+	dated := taskmodels.Task{ID: "t1", UserID: "u1", Attributes: taskmodels.TaskAttributes{DueDate: &future}}
+	undated := taskmodels.Task{ID: "t1", UserID: "u1"}
+
+	tests := []struct {
+		name    string
+		req     remindermodels.ReminderRequest
+		task    *taskmodels.Task
+		taskErr error
+		wantErr error
+	}{
+		{"absolute", remindermodels.ReminderRequest{At: &future}, &undated, nil, nil},
+		{"relative", remindermodels.ReminderRequest{BeforeMinutes: &minutes}, &dated, nil, nil},
+		{"both", remindermodels.ReminderRequest{At: &future, BeforeMinutes: &minutes}, nil, nil,
+			remindererrors.ErrWrongReminder},
+		{"none", remindermodels.ReminderRequest{}, nil, nil, remindererrors.ErrWrongReminder},
+		{"negative offset", remindermodels.ReminderRequest{BeforeMinutes: &negative}, nil, nil,
+			remindererrors.ErrWrongReminder},
+		{"in past", remindermodels.ReminderRequest{At: &past}, &undated, nil, remindererrors.ErrReminderInPast},
+		{"relative without due date", remindermodels.ReminderRequest{BeforeMinutes: &minutes}, &undated, nil,
+			remindererrors.ErrReminderNeedsDueDate},
+		{"foreign task", remindermodels.ReminderRequest{At: &future}, &undated, taskerrors.ErrFoundNothing,
+			taskerrors.ErrFoundNothing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewReminderService(repo)
+			service.now = func() time.Time { return now }
+
+			if tt.task != nil {
+				repo.On("GetTaskByID", "t1", "u1").Return(*tt.task, tt.taskErr)
+			}
+			if tt.wantErr == nil {
+				repo.On("AddReminder", mock.MatchedBy(func(r remindermodels.Reminder) bool {
+					return r.ID != "" && r.TaskID == "t1" && r.UserID == "u1" &&
+						r.Status == remindermodels.StatusPending
+				})).Return(nil)
+			}
+
+			reminder, err := service.AddReminder("t1", "u1", tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.req.BeforeMinutes, reminder.BeforeMinutes)
+			}
+		})
+	}
+}
+
+func TestDeleteReminder(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewReminderService(repo)
+
+	repo.On("GetTaskByID", "t1", "u1").Return(taskmodels.Task{ID: "t1"}, nil)
+	repo.On("DeleteReminder", "r1", "t1").Return(remindererrors.ErrReminderNotFound)
+
+	assert.ErrorIs(t, service.DeleteReminder("t1", "r1", "u1"), remindererrors.ErrReminderNotFound)
+}
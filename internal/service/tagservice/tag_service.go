@@ -0,0 +1,82 @@
+package tagservice
+
+import (
+	"toDoList/internal/domain/tag/tagmodels"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type TagStorage interface {
+	AddTag(tag tagmodels.Tag) error
+	GetTagsByUser(userID string) ([]tagmodels.Tag, error)
+	GetTagByID(tagID string, userID string) (tagmodels.Tag, error)
+	UpdateTag(tag tagmodels.Tag) error
+	DeleteTag(tagID string, userID string) error
+}
+
+type TagService struct {
+	db    TagStorage
+	valid *validator.Validate
+}
+
+func NewTagService(db TagStorage) *TagService {
+	return &TagService{db: db, valid: validator.New()}
+}
+
+func (ts *TagService) GetTags(userID string) ([]tagmodels.Tag, error) {
+	return ts.db.GetTagsByUser(userID)
+}
+
+func (ts *TagService) CreateTag(req tagmodels.TagRequest, userID string) (tagmodels.Tag, error) {
+	err := ts.valid.Struct(req)
+	if err != nil {
+		return tagmodels.Tag{}, err
+	}
+
+	tag := tagmodels.Tag{
+		ID:     uuid.New().String(),
+		UserID: userID,
+		Name:   req.Name,
+		Color:  req.Color,
+	}
+	if tag.Color == "" {
+		tag.Color = tagmodels.DefaultColor
+	}
+
+	err = ts.db.AddTag(tag)
+	if err != nil {
+		return tagmodels.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// UpdateTag - переименование или смена цвета. Пустой цвет оставляет прежний.
+func (ts *TagService) UpdateTag(tagID string, userID string, req tagmodels.TagRequest) (tagmodels.Tag, error) {
+	err := ts.valid.Struct(req)
+	if err != nil {
+		return tagmodels.Tag{}, err
+	}
+
+	tag, err := ts.db.GetTagByID(tagID, userID)
+	if err != nil {
+		return tagmodels.Tag{}, err
+	}
+
+	tag.Name = req.Name
+	if req.Color != "" {
+		tag.Color = req.Color
+	}
+
+	err = ts.db.UpdateTag(tag)
+	if err != nil {
+		return tagmodels.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+func (ts *TagService) DeleteTag(tagID string, userID string) error {
+	return ts.db.DeleteTag(tagID, userID)
+}
@@ -0,0 +1,111 @@
+package tagservice
+
+import (
+	"testing"
+	"toDoList/internal/domain/tag/tagerrors"
+	"toDoList/internal/domain/tag/tagmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       tagmodels.TagRequest
+		wantColor string
+		dbMock    bool
+		dbErr     error
+		wantErr   bool
+	}{
+		{
+			name:      "default color",
+			req:       tagmodels.TagRequest{Name: "urgent"},
+			wantColor: tagmodels.DefaultColor,
+			dbMock:    true,
+		},
+		{
+			name:      "custom color",
+			req:       tagmodels.TagRequest{Name: "urgent", Color: "#ff0000"},
+			wantColor: "#ff0000",
+			dbMock:    true,
+		},
+		{
+			name:    "wrong color",
+			req:     tagmodels.TagRequest{Name: "urgent", Color: "red"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate",
+			req:     tagmodels.TagRequest{Name: "urgent"},
+			dbMock:  true,
+			dbErr:   tagerrors.ErrTagIsAlreadyExist,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTagService(repo)
+
+			if tt.dbMock {
+				repo.On("AddTag", mock.MatchedBy(func(tag tagmodels.Tag) bool {
+					return tag.Name == tt.req.Name && tag.UserID == "u1" && tag.ID != ""
+				})).Return(tt.dbErr)
+			}
+
+			tag, err := service.CreateTag(tt.req, "u1")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantColor, tag.Color)
+		})
+	}
+}
+
+func TestUpdateTag(t *testing.T) {
+	existing := tagmodels.Tag{ID: "t1", UserID: "u1", Name: "bug", Color: "#ff0000"}
+
+	tests := []struct {
+		name    string
+		req     tagmodels.TagRequest
+		getErr  error
+		want    tagmodels.Tag
+		wantErr error
+	}{
+		{
+			name: "rename keeps color",
+			req:  tagmodels.TagRequest{Name: "defect"},
+			want: tagmodels.Tag{ID: "t1", UserID: "u1", Name: "defect", Color: "#ff0000"},
+		},
+		{
+			name:    "not found",
+			req:     tagmodels.TagRequest{Name: "defect"},
+			getErr:  tagerrors.ErrTagNotFound,
+			wantErr: tagerrors.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTagService(repo)
+
+			repo.On("GetTagByID", "t1", "u1").Return(existing, tt.getErr)
+			if tt.wantErr == nil {
+				repo.On("UpdateTag", tt.want).Return(nil)
+			}
+
+			got, err := service.UpdateTag("t1", "u1", tt.req)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package taskservice
+
+import (
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+// GetBoard - доска проекта для его участника, задачи в колонках идут в ручном порядке.
+// Помеченные на удаление задачи на доску не попадают.
+func (ts *TaskService) GetBoard(projectID string, userID string) (taskmodels.Board, error) {
+	isMember, err := ts.db.IsProjectMember(projectID, userID)
+	if err != nil {
+		return taskmodels.Board{}, err
+	}
+	if !isMember {
+		return taskmodels.Board{}, projecterrors.ErrProjectNotFound
+	}
+
+	workflow, err := ts.workflow(projectID)
+	if err != nil {
+		return taskmodels.Board{}, err
+	}
+
+	tasks, err := ts.db.FindTasks(userID, taskmodels.TaskFilter{ProjectID: projectID})
+	if err != nil {
+		return taskmodels.Board{}, err
+	}
+
+	board := taskmodels.Board{ProjectID: projectID, Columns: make([]taskmodels.BoardColumn, len(workflow.Statuses))}
+	columns := make(map[taskmodels.TaskStatus]int, len(workflow.Statuses))
+	for i, status := range workflow.Statuses {
+		board.Columns[i] = taskmodels.BoardColumn{
+			Status:   status.Name,
+			Category: status.Category,
+			WIPLimit: status.WIPLimit,
+			Tasks:    []taskmodels.Task{},
+		}
+		columns[status.Name] = i
+	}
+
+	for _, task := range tasks {
+		i, ok := columns[task.Attributes.Status]
+		if !ok || task.Deleted {
+			continue
+		}
+		board.Columns[i].Tasks = append(board.Columns[i].Tasks, task)
+		board.Columns[i].Count++
+	}
+	return board, nil
+}
+
+// MoveOnBoard - смена колонки и позиции задачи одной транзакцией через runInTx: если переход
+// запрещён workflow или WIP-лимит колонки исчерпан, позиция тоже не меняется. Возвращает задачу
+// после перемещения.
+func (ts *TaskService) MoveOnBoard(userID string, req taskmodels.BoardMoveRequest, runInTx TxRunner,
+) (taskmodels.Task, error) {
+	if err := ts.valid.Struct(req); err != nil {
+		return taskmodels.Task{}, err
+	}
+	if req.Status == "" && req.Before == "" && req.After == "" {
+		return taskmodels.Task{}, taskerrors.ErrWrongBoardMove
+	}
+
+	var task taskmodels.Task
+	err := runInTx(func(tx TaskStorage) error {
+		service := NewTaskService(tx, nil)
+
+		var err error
+		if req.Status != "" {
+			err = service.updateTaskStatus(req.TaskID, userID, req.Status, req.Version)
+		} else {
+			_, err = service.getTaskForUpdate(req.TaskID, userID, req.Version)
+		}
+		if err != nil {
+			return err
+		}
+
+		if req.Before != "" || req.After != "" {
+			move := taskmodels.MoveTaskRequest{Before: req.Before, After: req.After}
+			if _, err = service.MoveTask(req.TaskID, userID, move); err != nil {
+				return err
+			}
+		}
+
+		task, err = tx.GetTaskByID(req.TaskID, userID)
+		return err
+	})
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+	return task, nil
+}
@@ -0,0 +1,146 @@
+package taskservice
+
+import (
+	"testing"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowmodels"
+	"toDoList/internal/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBoardStorage - проект p1 с колонками Todo, Doing (WIP-лимит 1) и Done: t1 и t2 в Todo, t3 в Doing.
+func newBoardStorage(t *testing.T) *inmemory.Storage {
+	storage := inmemory.NewInMemoryStorage()
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend"}))
+
+	workflow := workflowmodels.Workflow{
+		ProjectID: "p1",
+		Statuses: []workflowmodels.Status{
+			{Name: "Todo", Category: taskmodels.CategoryTodo},
+			{Name: "Doing", Category: taskmodels.CategoryDoing, WIPLimit: 1},
+			{Name: "Done", Category: taskmodels.CategoryDone},
+		},
+	}
+	for _, from := range workflow.Statuses {
+		for _, to := range workflow.Statuses {
+			workflow.Transitions = append(workflow.Transitions, workflowmodels.Transition{From: from.Name, To: to.Name})
+		}
+	}
+	require.NoError(t, storage.SaveWorkflow(workflow))
+
+	for _, task := range []struct {
+		id       string
+		status   taskmodels.TaskStatus
+		category taskmodels.StatusCategory
+		position string
+	}{
+		{"t1", "Todo", taskmodels.CategoryTodo, "b"},
+		{"t2", "Todo", taskmodels.CategoryTodo, "c"},
+		{"t3", "Doing", taskmodels.CategoryDoing, "a"},
+	} {
+		require.NoError(t, storage.AddTask(taskmodels.Task{
+			ID:       task.id,
+			UserID:   "u1",
+			Category: task.category,
+			Position: task.position,
+			Attributes: taskmodels.TaskAttributes{
+				Status: task.status, Title: task.id, Description: "D", ProjectID: "p1",
+			},
+		}))
+	}
+	return storage
+}
+
+func boardRunInTx(storage *inmemory.Storage) TxRunner {
+	return func(fn func(tx TaskStorage) error) error {
+		return storage.InTx(func(tx *inmemory.Storage) error { return fn(tx) })
+	}
+}
+
+func TestGetBoard(t *testing.T) {
+	service := NewTaskService(newBoardStorage(t), nil)
+
+	board, err := service.GetBoard("p1", "u1")
+	require.NoError(t, err)
+
+	type column struct {
+		status taskmodels.TaskStatus
+		count  int
+		tasks  []string
+	}
+	var got []column
+	for _, c := range board.Columns {
+		ids := []string{}
+		for _, task := range c.Tasks {
+			ids = append(ids, task.ID)
+		}
+		got = append(got, column{status: c.Status, count: c.Count, tasks: ids})
+	}
+	assert.Equal(t, []column{
+		{status: "Todo", count: 2, tasks: []string{"t1", "t2"}},
+		{status: "Doing", count: 1, tasks: []string{"t3"}},
+		{status: "Done", count: 0, tasks: []string{}},
+	}, got)
+	assert.Equal(t, 1, board.Columns[1].WIPLimit)
+
+	_, err = service.GetBoard("p1", "u2")
+	assert.ErrorIs(t, err, projecterrors.ErrProjectNotFound)
+}
+
+func TestMoveOnBoard(t *testing.T) {
+	storage := newBoardStorage(t)
+	service := NewTaskService(storage, nil)
+	runInTx := boardRunInTx(storage)
+
+	_, err := service.MoveOnBoard("u1", taskmodels.BoardMoveRequest{TaskID: "t1"}, runInTx)
+	assert.ErrorIs(t, err, taskerrors.ErrWrongBoardMove)
+
+	// Колонка Doing заполнена: ни статус, ни позиция t2 не меняются.
+	_, err = service.MoveOnBoard("u1", taskmodels.BoardMoveRequest{TaskID: "t2", Status: "Doing", Before: "t1"},
+		runInTx)
+	assert.ErrorIs(t, err, taskerrors.ErrWIPLimitReached)
+
+	t2, err := storage.GetTaskByID("t2", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, taskmodels.TaskStatus("Todo"), t2.Attributes.Status)
+	assert.Equal(t, "c", t2.Position)
+
+	// Соседа нет: смена статуса t1 откатывается вместе с транзакцией.
+	_, err = service.MoveOnBoard("u1", taskmodels.BoardMoveRequest{TaskID: "t1", Status: "Done", After: "nope"},
+		runInTx)
+	assert.ErrorIs(t, err, taskerrors.ErrFoundNothing)
+
+	t1, err := storage.GetTaskByID("t1", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, taskmodels.TaskStatus("Todo"), t1.Attributes.Status)
+
+	t3, err := service.MoveOnBoard("u1", taskmodels.BoardMoveRequest{TaskID: "t3", Status: "Done"}, runInTx)
+	require.NoError(t, err)
+	assert.Equal(t, taskmodels.CategoryDone, t3.Category)
+
+	t2, err = service.MoveOnBoard("u1", taskmodels.BoardMoveRequest{TaskID: "t2", Status: "Doing", Before: "t1"},
+		runInTx)
+	require.NoError(t, err)
+	assert.Equal(t, taskmodels.TaskStatus("Doing"), t2.Attributes.Status)
+	assert.Less(t, t2.Position, "b")
+
+	_, err = service.MoveOnBoard("u1", taskmodels.BoardMoveRequest{TaskID: "t1", After: "t2", Version: 99}, runInTx)
+	assert.ErrorIs(t, err, taskerrors.ErrVersionConflict)
+}
+
+func TestCreateTaskWIPLimit(t *testing.T) {
+	service := NewTaskService(newBoardStorage(t), nil)
+
+	attributes := taskmodels.TaskAttributes{Status: "Doing", Title: "T", Description: "D", ProjectID: "p1"}
+	_, err := service.CreateTask(attributes, "u1")
+	assert.ErrorIs(t, err, taskerrors.ErrWIPLimitReached)
+
+	attributes.Status = "Todo"
+	_, err = service.CreateTask(attributes, "u1")
+	assert.NoError(t, err)
+}
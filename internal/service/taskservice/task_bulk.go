@@ -0,0 +1,158 @@
+package taskservice
+
+import (
+	"errors"
+	"fmt"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+// TxRunner - выполняет fn над хранилищем внутри одной транзакции, ошибка fn откатывает её целиком.
+type TxRunner func(fn func(tx TaskStorage) error) error
+
+// errBulkItem - ошибка операции пакета с её индексом, прерывает транзакцию atomic.
+type errBulkItem struct {
+	index int
+	err   error
+}
+
+func (e errBulkItem) Error() string {
+	return fmt.Sprintf("operation %d: %s", e.index, e.err)
+}
+
+func (e errBulkItem) Unwrap() error {
+	return e.err
+}
+
+// BulkTasks - пакет операций над задачами. В режиме atomic все операции идут одной транзакцией
+// через runInTx и первая ошибка откатывает остальные, в best_effort каждая операция выполняется
+// отдельно. Помеченные на удаление задачи передаются TaskBatchDeleter один раз на весь пакет.
+// Ошибка возвращается, только если запрос неверен целиком или транзакция atomic откатилась.
+func (ts *TaskService) BulkTasks(userID string, req taskmodels.BulkRequest, runInTx TxRunner,
+) ([]taskmodels.BulkResult, error) {
+	if req.Mode == "" {
+		req.Mode = taskmodels.BulkModeAtomic
+	}
+	if !req.Mode.IsValid() {
+		return nil, taskerrors.ErrWrongBulkMode
+	}
+	if len(req.Operations) == 0 || len(req.Operations) > taskmodels.MaxBulkOperations {
+		return nil, fmt.Errorf("%w: expected from 1 to %d", taskerrors.ErrWrongBulkSize, taskmodels.MaxBulkOperations)
+	}
+
+	results := make([]taskmodels.BulkResult, len(req.Operations))
+	for i, operation := range req.Operations {
+		results[i] = taskmodels.BulkResult{Index: i, TaskID: operation.TaskID, Status: taskmodels.BulkItemSkipped}
+	}
+
+	deleted := 0
+	run := func(service *TaskService, i int) error {
+		taskID, err := service.bulkOperation(userID, req.Operations[i])
+		if err != nil {
+			results[i].Status = taskmodels.BulkItemFailed
+			results[i].Error = err.Error()
+			return errBulkItem{index: i, err: err}
+		}
+
+		results[i].TaskID = taskID
+		results[i].Status = taskmodels.BulkItemDone
+		if req.Operations[i].Action == taskmodels.BulkDelete {
+			deleted++
+		}
+		return nil
+	}
+
+	var err error
+	if req.Mode == taskmodels.BulkModeAtomic {
+		err = runInTx(func(tx TaskStorage) error {
+			service := NewTaskService(tx, nil)
+			for i := range req.Operations {
+				if errRun := run(service, i); errRun != nil {
+					return errRun
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			deleted = 0
+			for i := range results {
+				if results[i].Status == taskmodels.BulkItemDone {
+					results[i].Status = taskmodels.BulkItemRolledBack
+				}
+			}
+			// Ошибка самой транзакции, а не операции, тоже относится ко всему пакету.
+			var itemErr errBulkItem
+			if !errors.As(err, &itemErr) {
+				for i := range results {
+					if results[i].Error == "" {
+						results[i].Error = err.Error()
+					}
+				}
+			}
+		}
+	} else {
+		for i := range req.Operations {
+			_ = run(ts, i)
+		}
+	}
+
+	if deleted != 0 && ts.taskDeleter != nil {
+		ts.taskDeleter.Notify()
+	}
+
+	return results, err
+}
+
+// bulkOperation - выполнение одной операции пакета через обычные методы сервиса,
+// возвращает ID задачи, к которой она относится.
+func (ts *TaskService) bulkOperation(userID string, operation taskmodels.BulkOperation) (string, error) {
+	if operation.Action != taskmodels.BulkCreate && operation.TaskID == "" {
+		return "", fmt.Errorf("%w: task_id is required for %s", taskerrors.ErrWrongBulkOperation, operation.Action)
+	}
+
+	switch operation.Action {
+	case taskmodels.BulkCreate:
+		if operation.Attributes == nil {
+			return "", fmt.Errorf("%w: attributes are required for create", taskerrors.ErrWrongBulkOperation)
+		}
+		return ts.CreateTask(*operation.Attributes, userID)
+	case taskmodels.BulkUpdate:
+		if operation.Attributes == nil {
+			return "", fmt.Errorf("%w: attributes are required for update", taskerrors.ErrWrongBulkOperation)
+		}
+		err := ts.UpdateTask(operation.TaskID, userID, *operation.Attributes, operation.Version, false)
+		return operation.TaskID, err
+	case taskmodels.BulkStatus:
+		return operation.TaskID, ts.updateTaskStatus(operation.TaskID, userID, operation.Status, operation.Version)
+	case taskmodels.BulkDelete:
+		return operation.TaskID, ts.db.MarkTaskToDelete(operation.TaskID, userID)
+	case taskmodels.BulkMove:
+		if operation.Move == nil {
+			return "", fmt.Errorf("%w: move is required for move", taskerrors.ErrWrongBulkOperation)
+		}
+		_, err := ts.MoveTask(operation.TaskID, userID, *operation.Move)
+		return operation.TaskID, err
+	default:
+		return "", fmt.Errorf("%w: unknown action %q", taskerrors.ErrWrongBulkOperation, operation.Action)
+	}
+}
+
+// updateTaskStatus - смена одного статуса с теми же проверками, что и в UpdateTask.
+func (ts *TaskService) updateTaskStatus(taskID string, userID string, status taskmodels.TaskStatus,
+	version int64,
+) error {
+	task, err := ts.getTaskForUpdate(taskID, userID, version)
+	if err != nil {
+		return err
+	}
+
+	attributes := task.Attributes
+	attributes.Status = status
+
+	attributes, err = ts.normalizeAttributes(attributes)
+	if err != nil {
+		return err
+	}
+
+	return ts.saveAttributes(task, attributes, userID, false, true)
+}
@@ -0,0 +1,151 @@
+package taskservice
+
+import (
+	"context"
+	"testing"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/repository/inmemory"
+	"toDoList/internal/server/workers"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTasks(t *testing.T) {
+	newAttributes := func(title string) *taskmodels.TaskAttributes {
+		return &taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: title, Description: "D"}
+	}
+
+	tests := []struct {
+		name       string
+		mode       taskmodels.BulkMode
+		operations []taskmodels.BulkOperation
+		want       []taskmodels.BulkItemStatus
+		wantErr    error
+		// wantTitles - заголовки задач пользователя после пакета по порядку.
+		wantTitles []string
+	}{
+		{
+			name: "atomic",
+			mode: taskmodels.BulkModeAtomic,
+			operations: []taskmodels.BulkOperation{
+				{Action: taskmodels.BulkCreate, Attributes: newAttributes("Third")},
+				{Action: taskmodels.BulkStatus, TaskID: "first", Status: taskmodels.StatusCompleted},
+				{Action: taskmodels.BulkMove, TaskID: "second", Move: &taskmodels.MoveTaskRequest{Before: "first"}},
+				{Action: taskmodels.BulkUpdate, TaskID: "first", Attributes: newAttributes("First v2"), Version: 2},
+			},
+			want: []taskmodels.BulkItemStatus{
+				taskmodels.BulkItemDone, taskmodels.BulkItemDone, taskmodels.BulkItemDone, taskmodels.BulkItemDone,
+			},
+			wantTitles: []string{"Second", "First v2", "Third"},
+		},
+		{
+			name: "atomic rolls back",
+			mode: taskmodels.BulkModeAtomic,
+			operations: []taskmodels.BulkOperation{
+				{Action: taskmodels.BulkCreate, Attributes: newAttributes("Third")},
+				{Action: taskmodels.BulkDelete, TaskID: "first"},
+				{Action: taskmodels.BulkStatus, TaskID: "first", Status: "Paused"},
+				{Action: taskmodels.BulkDelete, TaskID: "second"},
+			},
+			want: []taskmodels.BulkItemStatus{
+				taskmodels.BulkItemRolledBack, taskmodels.BulkItemRolledBack, taskmodels.BulkItemFailed,
+				taskmodels.BulkItemSkipped,
+			},
+			wantErr:    taskerrors.ErrWrongStatus,
+			wantTitles: []string{"First", "Second"},
+		},
+		{
+			name: "best effort",
+			mode: taskmodels.BulkModeBestEffort,
+			operations: []taskmodels.BulkOperation{
+				{Action: taskmodels.BulkUpdate, TaskID: "first", Attributes: newAttributes("First v2"), Version: 7},
+				{Action: taskmodels.BulkDelete, TaskID: "second"},
+				{Action: taskmodels.BulkCreate},
+				{Action: taskmodels.BulkCreate, Attributes: newAttributes("Third")},
+			},
+			want: []taskmodels.BulkItemStatus{
+				taskmodels.BulkItemFailed, taskmodels.BulkItemDone, taskmodels.BulkItemFailed, taskmodels.BulkItemDone,
+			},
+			wantTitles: []string{"First", "Third"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := inmemory.NewInMemoryStorage()
+			require.NoError(t, storage.AddTask(taskmodels.Task{
+				ID: "first", UserID: "u1", Position: "a", Attributes: *newAttributes("First"),
+			}))
+			require.NoError(t, storage.AddTask(taskmodels.Task{
+				ID: "second", UserID: "u1", Position: "b", Attributes: *newAttributes("Second"),
+			}))
+			events := relayed(t, storage)
+
+			deleter := workers.NewTaskBatchDeleter(context.Background(), storage, 10, zerolog.Nop())
+			service := NewTaskService(storage, deleter)
+
+			runInTx := func(fn func(tx TaskStorage) error) error {
+				return storage.InTx(func(tx *inmemory.Storage) error { return fn(tx) })
+			}
+
+			results, err := service.BulkTasks("u1", taskmodels.BulkRequest{Mode: tt.mode, Operations: tt.operations},
+				runInTx)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			statuses := make([]taskmodels.BulkItemStatus, 0, len(results))
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				assert.Equal(t, result.Status == taskmodels.BulkItemFailed, result.Error != "", result)
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tt.want, statuses)
+
+			tasks, err := storage.FindTasks("u1", taskmodels.TaskFilter{})
+			require.NoError(t, err)
+			var titles []string
+			for _, task := range tasks {
+				if !task.Deleted {
+					titles = append(titles, task.Attributes.Title)
+				}
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+
+			// Откаченные операции не оставляют событий.
+			if tt.wantErr != nil {
+				assert.Empty(t, relayed(t, storage))
+			} else {
+				assert.NotEmpty(t, relayed(t, storage))
+			}
+			assert.NotEmpty(t, events)
+		})
+	}
+}
+
+func TestBulkTasksRequest(t *testing.T) {
+	service := NewTaskService(inmemory.NewInMemoryStorage(), nil)
+
+	_, err := service.BulkTasks("u1", taskmodels.BulkRequest{Mode: "all"}, nil)
+	assert.ErrorIs(t, err, taskerrors.ErrWrongBulkMode)
+
+	_, err = service.BulkTasks("u1", taskmodels.BulkRequest{}, nil)
+	assert.ErrorIs(t, err, taskerrors.ErrWrongBulkSize)
+
+	operations := make([]taskmodels.BulkOperation, taskmodels.MaxBulkOperations+1)
+	_, err = service.BulkTasks("u1", taskmodels.BulkRequest{Operations: operations}, nil)
+	assert.ErrorIs(t, err, taskerrors.ErrWrongBulkSize)
+}
+
+// relayed - события, которые релей опубликовал бы сейчас.
+func relayed(t *testing.T, storage *inmemory.Storage) []eventmodels.Event {
+	var events []eventmodels.Event
+	_, err := storage.RelayOutboxEvents(1000, func(event eventmodels.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+	return events
+}
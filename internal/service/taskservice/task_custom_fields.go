@@ -0,0 +1,225 @@
+package taskservice
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"unicode/utf8"
+)
+
+// customFields - определения пользовательских полей проекта по ID.
+func (ts *TaskService) customFields(projectID string) (map[string]customfieldmodels.CustomField, error) {
+	fields, err := ts.db.GetCustomFields(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]customfieldmodels.CustomField, len(fields))
+	for _, field := range fields {
+		byID[field.ID] = field
+	}
+	return byID, nil
+}
+
+// normalizeCustomFields - проверка значений пользовательских полей по типам полей проекта задачи.
+// Значение null и пустой список удаляют значение поля. dropUnknown - задача переезжает из другого
+// проекта: значения полей, которых нет в новом проекте, отбрасываются, а не считаются ошибкой.
+// Результат - новый map, значения из атрибутов старой задачи не меняются.
+func (ts *TaskService) normalizeCustomFields(attributes taskmodels.TaskAttributes, dropUnknown bool,
+) (map[string]any, error) {
+	if len(attributes.CustomFields) == 0 || attributes.ProjectID == "" && dropUnknown {
+		return nil, nil
+	}
+	if attributes.ProjectID == "" {
+		return nil, fmt.Errorf("%w: task without project has no custom fields",
+			customfielderrors.ErrWrongCustomFieldValue)
+	}
+
+	fields, err := ts.customFields(attributes.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any, len(attributes.CustomFields))
+	for id, value := range attributes.CustomFields {
+		if value == nil {
+			continue
+		}
+		field, ok := fields[id]
+		if !ok {
+			if dropUnknown {
+				continue
+			}
+			return nil, fmt.Errorf("%w: unknown field %q", customfielderrors.ErrWrongCustomFieldValue, id)
+		}
+
+		value, err = ts.normalizeCustomValue(field, value)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			values[id] = value
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, nil
+}
+
+// normalizeCustomValue - значение в том виде, в котором оно хранится: числа - float64, даты - строки
+// DateLayout, списки вариантов - []any без повторов. nil - значение пустое и не хранится.
+func (ts *TaskService) normalizeCustomValue(field customfieldmodels.CustomField, value any) (any, error) {
+	wrong := func(reason string) error {
+		return fmt.Errorf("%w: field %q %s", customfielderrors.ErrWrongCustomFieldValue, field.Name, reason)
+	}
+
+	switch field.Type {
+	case customfieldmodels.TypeNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return nil, wrong("expects a number")
+		}
+		return number, nil
+	case customfieldmodels.TypeMultiSelect:
+		list, ok := value.([]any)
+		if !ok {
+			return nil, wrong("expects a list of options")
+		}
+
+		options := make([]any, 0, len(list))
+		for _, item := range list {
+			option, isString := item.(string)
+			if !isString || !field.HasOption(option) {
+				return nil, wrong(fmt.Sprintf("has no option %v", item))
+			}
+			if !slices.Contains(options, any(option)) {
+				options = append(options, option)
+			}
+		}
+		if len(options) == 0 {
+			return nil, nil
+		}
+		return options, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return nil, wrong("expects a string")
+	}
+	if text == "" {
+		return nil, nil
+	}
+
+	switch field.Type {
+	case customfieldmodels.TypeText:
+		if utf8.RuneCountInString(text) > customfieldmodels.MaxTextLength {
+			return nil, wrong(fmt.Sprintf("is longer than %d characters", customfieldmodels.MaxTextLength))
+		}
+	case customfieldmodels.TypeDate:
+		date, err := time.Parse(customfieldmodels.DateLayout, text)
+		if err != nil {
+			return nil, wrong("expects a date in YYYY-MM-DD format")
+		}
+		text = date.Format(customfieldmodels.DateLayout)
+	case customfieldmodels.TypeSelect:
+		if !field.HasOption(text) {
+			return nil, wrong(fmt.Sprintf("has no option %q", text))
+		}
+	case customfieldmodels.TypeUser:
+		isMember, err := ts.db.IsProjectMember(field.ProjectID, text)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, wrong(fmt.Sprintf("user %q is not a project member", text))
+		}
+	}
+	return text, nil
+}
+
+// customFieldsFilter - значения фильтра из строк запроса в типы полей проекта: фильтровать и сортировать
+// по пользовательским полям можно только внутри проекта. Для multi_select значение фильтра - один
+// вариант, который должен быть среди выбранных, user "me" означает самого пользователя.
+func (ts *TaskService) customFieldsFilter(userID string, filter taskmodels.TaskFilter) (taskmodels.TaskFilter, error) {
+	if len(filter.CustomFields) == 0 && filter.SortField == "" {
+		if filter.SortDesc {
+			return filter, fmt.Errorf("%w: order without field", customfielderrors.ErrWrongCustomFieldSort)
+		}
+		return filter, nil
+	}
+	if filter.ProjectID == "" {
+		return filter, fmt.Errorf("%w: project is required to filter or sort by custom fields",
+			customfielderrors.ErrWrongCustomFieldValue)
+	}
+
+	fields, err := ts.customFields(filter.ProjectID)
+	if err != nil {
+		return filter, err
+	}
+
+	if filter.SortField != "" {
+		field, ok := fields[filter.SortField]
+		switch {
+		case !ok:
+			return filter, fmt.Errorf("%w: unknown field %q", customfielderrors.ErrWrongCustomFieldSort,
+				filter.SortField)
+		case field.Type == customfieldmodels.TypeMultiSelect:
+			return filter, fmt.Errorf("%w: %s field %q is not sortable", customfielderrors.ErrWrongCustomFieldSort,
+				field.Type, field.Name)
+		}
+	}
+
+	values := make(map[string]any, len(filter.CustomFields))
+	for id, raw := range filter.CustomFields {
+		field, ok := fields[id]
+		if !ok {
+			return filter, fmt.Errorf("%w: unknown field %q", customfielderrors.ErrWrongCustomFieldValue, id)
+		}
+
+		text, ok := raw.(string)
+		if !ok {
+			return filter, fmt.Errorf("%w: field %q expects a string in filter",
+				customfielderrors.ErrWrongCustomFieldValue, field.Name)
+		}
+
+		values[id], err = filterValue(field, text, userID)
+		if err != nil {
+			return filter, err
+		}
+	}
+	filter.CustomFields = values
+	return filter, nil
+}
+
+// filterValue - значение фильтра в том виде, в котором оно хранится у задач.
+func filterValue(field customfieldmodels.CustomField, text string, userID string) (any, error) {
+	switch field.Type {
+	case customfieldmodels.TypeNumber:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %q expects a number", customfielderrors.ErrWrongCustomFieldValue,
+				field.Name)
+		}
+		return number, nil
+	case customfieldmodels.TypeDate:
+		date, err := time.Parse(customfieldmodels.DateLayout, text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %q expects a date in YYYY-MM-DD format",
+				customfielderrors.ErrWrongCustomFieldValue, field.Name)
+		}
+		return date.Format(customfieldmodels.DateLayout), nil
+	case customfieldmodels.TypeMultiSelect:
+		return []any{text}, nil
+	case customfieldmodels.TypeUser:
+		if text == taskmodels.AssigneeMe {
+			return userID, nil
+		}
+	}
+	return text, nil
+}
@@ -0,0 +1,170 @@
+package taskservice
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/customfield/customfielderrors"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCustomFieldStorage - проекты p1 (участники u1 и u2) и p2, у p1 по полю каждого типа с ID, равным типу.
+func newCustomFieldStorage(t *testing.T) *inmemory.Storage {
+	storage := inmemory.NewInMemoryStorage()
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend"}))
+	require.NoError(t, storage.AddProjectMember("p1", "u2"))
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p2", OwnerID: "u1", Name: "Frontend"}))
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	for i, fieldType := range []customfieldmodels.FieldType{
+		customfieldmodels.TypeText, customfieldmodels.TypeNumber, customfieldmodels.TypeDate,
+		customfieldmodels.TypeSelect, customfieldmodels.TypeMultiSelect, customfieldmodels.TypeUser,
+	} {
+		field := customfieldmodels.CustomField{
+			ID: string(fieldType), ProjectID: "p1", Name: string(fieldType), Type: fieldType,
+			CreatedAt: now.Add(time.Duration(i) * time.Minute),
+		}
+		if fieldType.HasOptions() {
+			field.Options = []string{"a", "b", "c"}
+		}
+		require.NoError(t, storage.AddCustomField(field))
+	}
+	return storage
+}
+
+func TestCreateTaskCustomFields(t *testing.T) {
+	storage := newCustomFieldStorage(t)
+	service := NewTaskService(storage, nil)
+
+	attributes := func(projectID string, values map[string]any) taskmodels.TaskAttributes {
+		return taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "T", Description: "D", ProjectID: projectID, CustomFields: values,
+		}
+	}
+
+	taskID, err := service.CreateTask(attributes("p1", map[string]any{
+		"text":         "notes",
+		"number":       2.5,
+		"date":         "2026-03-01",
+		"select":       "b",
+		"multi_select": []any{"c", "a", "c"},
+		"user":         "u2",
+		"nope":         nil,
+	}), "u1")
+	require.NoError(t, err)
+
+	task, err := storage.GetTaskByID(taskID, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"text": "notes", "number": 2.5, "date": "2026-03-01", "select": "b", "multi_select": []any{"c", "a"},
+		"user": "u2",
+	}, task.Attributes.CustomFields)
+
+	for name, values := range map[string]map[string]any{
+		"unknown field":   {"nope": "x"},
+		"text as number":  {"text": 1.0},
+		"number as text":  {"number": "1"},
+		"bad date":        {"date": "01.03.2026"},
+		"unknown option":  {"select": "z"},
+		"unknown options": {"multi_select": []any{"a", "z"}},
+		"not a member":    {"user": "u3"},
+	} {
+		_, err = service.CreateTask(attributes("p1", values), "u1")
+		assert.ErrorIs(t, err, customfielderrors.ErrWrongCustomFieldValue, name)
+	}
+
+	_, err = service.CreateTask(attributes("", map[string]any{"text": "x"}), "u1")
+	assert.ErrorIs(t, err, customfielderrors.ErrWrongCustomFieldValue)
+}
+
+func TestUpdateTaskCustomFields(t *testing.T) {
+	storage := newCustomFieldStorage(t)
+	service := NewTaskService(storage, nil)
+
+	taskID, err := service.CreateTask(taskmodels.TaskAttributes{
+		Status: taskmodels.StatusNew, Title: "T", Description: "D", ProjectID: "p1",
+		CustomFields: map[string]any{"text": "notes", "number": 1.0},
+	}, "u1")
+	require.NoError(t, err)
+
+	task, err := service.PatchTask(taskID, "u1", taskmodels.PatchTypeMerge,
+		[]byte(`{"custom_fields": {"text": null, "select": "a"}}`), 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"number": 1.0, "select": "a"}, task.Attributes.CustomFields)
+
+	task, err = service.PatchTask(taskID, "u1", taskmodels.PatchTypeJSON,
+		[]byte(`[{"op": "add", "path": "/custom_fields/date", "value": "2026-03-01"}]`), 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01", task.Attributes.CustomFields["date"])
+
+	// Значения полей старого проекта не переезжают вместе с задачей.
+	task, err = service.PatchTask(taskID, "u1", taskmodels.PatchTypeMerge, []byte(`{"project_id": "p2"}`), 0, false)
+	require.NoError(t, err)
+	assert.Empty(t, task.Attributes.CustomFields)
+}
+
+func TestGetTasksByCustomFields(t *testing.T) {
+	storage := newCustomFieldStorage(t)
+	service := NewTaskService(storage, nil)
+
+	for _, values := range []map[string]any{
+		{"number": 10.0, "multi_select": []any{"a", "b"}, "user": "u1"},
+		{"number": 2.0, "multi_select": []any{"b"}, "user": "u2"},
+		{"multi_select": []any{"a"}, "user": "u1"},
+	} {
+		_, err := service.CreateTask(taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "T", Description: "D", ProjectID: "p1", CustomFields: values,
+		}, "u1")
+		require.NoError(t, err)
+	}
+
+	numbers := func(filter taskmodels.TaskFilter) []any {
+		tasks, err := service.GetTasks("u1", filter)
+		require.NoError(t, err)
+		got := []any{}
+		for _, task := range tasks {
+			got = append(got, task.Attributes.CustomFields["number"])
+		}
+		return got
+	}
+
+	assert.Equal(t, []any{10.0, nil}, numbers(taskmodels.TaskFilter{
+		ProjectID: "p1", CustomFields: map[string]any{"multi_select": "a", "user": "me"},
+	}))
+	assert.Equal(t, []any{2.0}, numbers(taskmodels.TaskFilter{
+		ProjectID: "p1", CustomFields: map[string]any{"number": "2"},
+	}))
+	assert.Equal(t, []any{10.0, 2.0, nil}, numbers(taskmodels.TaskFilter{
+		ProjectID: "p1", SortField: "number", SortDesc: true,
+	}))
+
+	for name, tt := range map[string]struct {
+		filter  taskmodels.TaskFilter
+		wantErr error
+	}{
+		"without project": {
+			filter:  taskmodels.TaskFilter{CustomFields: map[string]any{"number": "2"}},
+			wantErr: customfielderrors.ErrWrongCustomFieldValue,
+		},
+		"bad number": {
+			filter:  taskmodels.TaskFilter{ProjectID: "p1", CustomFields: map[string]any{"number": "two"}},
+			wantErr: customfielderrors.ErrWrongCustomFieldValue,
+		},
+		"sort by multi_select": {
+			filter:  taskmodels.TaskFilter{ProjectID: "p1", SortField: "multi_select"},
+			wantErr: customfielderrors.ErrWrongCustomFieldSort,
+		},
+		"sort by unknown field": {
+			filter:  taskmodels.TaskFilter{ProjectID: "p1", SortField: "nope"},
+			wantErr: customfielderrors.ErrWrongCustomFieldSort,
+		},
+	} {
+		_, err := service.GetTasks("u1", tt.filter)
+		assert.ErrorIs(t, err, tt.wantErr, name)
+	}
+}
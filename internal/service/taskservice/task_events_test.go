@@ -0,0 +1,70 @@
+package taskservice
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+	"toDoList/internal/domain/event/eventmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/webhook/webhookmodels"
+	"toDoList/internal/repository/inmemory"
+	"toDoList/internal/server/workers"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskEventsReachWebhooks(t *testing.T) {
+	storage := inmemory.NewInMemoryStorage()
+	require.NoError(t, storage.AddWebhook(webhookmodels.Webhook{
+		ID: "w1", UserID: "u1", URL: "http://localhost/hook", Secret: "secret",
+		Events: []webhookmodels.EventType{
+			webhookmodels.EventTaskCreated,
+			webhookmodels.EventTaskStatusChanged,
+			webhookmodels.EventTaskDeleted,
+		},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dispatcher := workers.NewWebhookDispatcher(ctx, storage, time.Hour, zerolog.Nop())
+	deleter := workers.NewTaskBatchDeleter(ctx, storage, 10, zerolog.Nop())
+	service := NewTaskService(storage, deleter)
+
+	attrs := taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "Report", Description: "D"}
+	taskID, err := service.CreateTask(attrs, "u1")
+	require.NoError(t, err)
+
+	// Смена заголовка даёт только task.updated, на который вебхук не подписан.
+	attrs.Title = "Quarterly report"
+	require.NoError(t, service.UpdateTask(taskID, "u1", attrs, 0, false))
+
+	attrs.Status = taskmodels.StatusInProgress
+	require.NoError(t, service.UpdateTask(taskID, "u1", attrs, 0, false))
+
+	require.NoError(t, service.MarkTaskToDeleteByID(taskID, "u1"))
+
+	_, err = storage.RelayOutboxEvents(100, func(event eventmodels.Event) error {
+		return dispatcher.Publish(ctx, event)
+	})
+	require.NoError(t, err)
+
+	deliveries, err := storage.GetWebhookDeliveries("w1", 10)
+	require.NoError(t, err)
+
+	got := make(map[webhookmodels.EventType]webhookmodels.Event)
+	for _, delivery := range deliveries {
+		var event webhookmodels.Event
+		require.NoError(t, json.Unmarshal(delivery.Payload, &event))
+		assert.Equal(t, delivery.EventType, event.Type)
+		got[event.Type] = event
+	}
+
+	require.Len(t, got, 3)
+	assert.Equal(t, "Report", got[webhookmodels.EventTaskCreated].Task.Attributes.Title)
+	assert.Equal(t, taskmodels.StatusInProgress, got[webhookmodels.EventTaskStatusChanged].Task.Attributes.Status)
+	assert.Equal(t, taskID, got[webhookmodels.EventTaskDeleted].Task.ID)
+}
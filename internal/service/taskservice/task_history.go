@@ -0,0 +1,69 @@
+package taskservice
+
+import (
+	"slices"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+)
+
+// GetTaskHistory - история задачи, видимой пользователю.
+func (ts *TaskService) GetTaskHistory(taskID string, userID string) ([]taskmodels.HistoryEntry, error) {
+	_, err := ts.GetTaskByID(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.db.GetTaskHistory(taskID)
+}
+
+// RevertTask - возвращает атрибуты задачи к ревизии revision, откатывая изменения более поздних
+// ревизий от последней к первой. Серия задачи не откатывается. Откат - обычное изменение
+// со всеми проверками UpdateTask и собственной записью в истории, version и force - как в UpdateTask.
+func (ts *TaskService) RevertTask(taskID string, userID string, revision int64, version int64, force bool,
+) (taskmodels.Task, error) {
+	task, err := ts.getTaskForUpdate(taskID, userID, version)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+	if task.Deleted {
+		return taskmodels.Task{}, taskerrors.ErrFoundNothing
+	}
+
+	history, err := ts.db.GetTaskHistory(taskID)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	if !slices.ContainsFunc(history, func(entry taskmodels.HistoryEntry) bool {
+		return entry.Revision == revision && entry.Action != taskmodels.HistoryDeleted
+	}) {
+		return taskmodels.Task{}, taskerrors.ErrRevisionNotFound
+	}
+
+	reverted := task
+	for _, entry := range slices.Backward(history) {
+		if entry.Revision <= revision {
+			break
+		}
+		for _, change := range entry.Changes {
+			if change.Field == taskmodels.FieldSeries {
+				continue
+			}
+			if err = reverted.SetField(change.Field, change.Before); err != nil {
+				return taskmodels.Task{}, err
+			}
+		}
+	}
+
+	newAttributes, err := ts.normalizeAttributes(reverted.Attributes)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	err = ts.saveAttributes(task, newAttributes, userID, force, true)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	return ts.db.GetTaskByID(taskID, userID)
+}
@@ -0,0 +1,87 @@
+package taskservice
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevertTask(t *testing.T) {
+	task := taskmodels.Task{
+		ID: "t1", UserID: "u1", Version: 3,
+		Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusCompleted, Title: "Annual report", Description: "Quarterly",
+			Priority: taskmodels.PriorityHigh,
+		},
+	}
+	history := []taskmodels.HistoryEntry{
+		{TaskID: "t1", Revision: 1, Action: taskmodels.HistoryCreated},
+		{TaskID: "t1", Revision: 2, Action: taskmodels.HistoryUpdated, Changes: []taskmodels.FieldChange{
+			{
+				Field:  taskmodels.FieldTitle,
+				Before: json.RawMessage(`"Report"`),
+				After:  json.RawMessage(`"Annual report"`),
+			},
+			{Field: taskmodels.FieldPriority, Before: json.RawMessage(`"none"`), After: json.RawMessage(`"high"`)},
+		}},
+		{TaskID: "t1", Revision: 3, Action: taskmodels.HistoryUpdated, Changes: []taskmodels.FieldChange{
+			{Field: taskmodels.FieldStatus, Before: json.RawMessage(`"New"`), After: json.RawMessage(`"Done"`)},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		revision   int64
+		version    int64
+		wantFields []taskmodels.TaskField
+		wantErr    error
+	}{
+		{
+			name:       "to previous revision",
+			revision:   2,
+			wantFields: []taskmodels.TaskField{taskmodels.FieldStatus},
+		},
+		{
+			name:     "to first revision",
+			revision: 1,
+			version:  3,
+			wantFields: []taskmodels.TaskField{
+				taskmodels.FieldStatus, taskmodels.FieldTitle, taskmodels.FieldPriority,
+			},
+		},
+		{name: "current revision", revision: 3},
+		{name: "unknown revision", revision: 7, wantErr: taskerrors.ErrRevisionNotFound},
+		{name: "stale version", revision: 1, version: 2, wantErr: taskerrors.ErrVersionConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			repo.On("GetTaskByID", "t1", "u1").Return(task, nil)
+			if !errors.Is(tt.wantErr, taskerrors.ErrVersionConflict) {
+				repo.On("GetTaskHistory", "t1").Return(history, nil)
+			}
+			if tt.wantFields != nil {
+				repo.On("UpdateTaskFields", mock.MatchedBy(func(updated taskmodels.Task) bool {
+					return updated.ChangedBy == "u1" && updated.Attributes.Status == taskmodels.StatusNew
+				}), tt.wantFields).Return(nil)
+			}
+
+			_, err := service.RevertTask("t1", "u1", tt.revision, tt.version, false)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package taskservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/pkg/jsonpatch"
+)
+
+// PatchTask - частичное изменение атрибутов задачи патчем в формате JSON Merge Patch или JSON Patch.
+// Патч применяется к атрибутам задачи, результат проверяется так же, как в UpdateTask,
+// а хранилище пишет только изменившиеся поля. Возвращает задачу после изменения.
+func (ts *TaskService) PatchTask(taskID string, userID string, patchType taskmodels.PatchType, patch []byte,
+	version int64, force bool,
+) (taskmodels.Task, error) {
+	if !patchType.IsValid() {
+		return taskmodels.Task{}, taskerrors.ErrWrongPatchType
+	}
+
+	task, err := ts.getTaskForUpdate(taskID, userID, version)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	newAttributes, err := patchAttributes(task.Attributes, patchType, patch)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	newAttributes, err = ts.normalizeAttributes(newAttributes)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	err = ts.saveAttributes(task, newAttributes, userID, force, true)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	return ts.db.GetTaskByID(taskID, userID)
+}
+
+func patchAttributes(attributes taskmodels.TaskAttributes, patchType taskmodels.PatchType, patch []byte,
+) (taskmodels.TaskAttributes, error) {
+	doc, err := attributesDocument(attributes)
+	if err != nil {
+		return attributes, err
+	}
+
+	var patched []byte
+	if patchType == taskmodels.PatchTypeMerge {
+		patched, err = jsonpatch.MergePatch(doc, patch)
+	} else {
+		patched, err = jsonpatch.Apply(doc, patch)
+	}
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return attributes, fmt.Errorf("%w: %w", taskerrors.ErrPatchTestFailed, err)
+		}
+		return attributes, fmt.Errorf("%w: %w", taskerrors.ErrWrongPatch, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+
+	var newAttributes taskmodels.TaskAttributes
+	if err = decoder.Decode(&newAttributes); err != nil {
+		return attributes, fmt.Errorf("%w: %w", taskerrors.ErrWrongPatch, err)
+	}
+	return newAttributes, nil
+}
+
+// attributesDocument - атрибуты в JSON, пустые поля присутствуют со значением null,
+// чтобы операции replace и test JSON Patch работали и для них.
+func attributesDocument(attributes taskmodels.TaskAttributes) ([]byte, error) {
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	for _, field := range taskmodels.AllTaskFields {
+		if _, ok := doc[string(field)]; !ok && field != taskmodels.FieldSeries {
+			doc[string(field)] = nil
+		}
+	}
+	// Пустые пользовательские поля - пустой объект, чтобы операция add JSON Patch могла добавить значение.
+	if doc[string(taskmodels.FieldCustomFields)] == nil {
+		doc[string(taskmodels.FieldCustomFields)] = map[string]any{}
+	}
+	return json.Marshal(doc)
+}
@@ -0,0 +1,121 @@
+package taskservice
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchTask(t *testing.T) {
+	due := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	task := taskmodels.Task{
+		ID: "t1", UserID: "u1", Version: 2,
+		Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "Report", Description: "Quarterly", Priority: taskmodels.PriorityNone,
+			DueDate: &due,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		patchType  taskmodels.PatchType
+		patch      string
+		wantFields []taskmodels.TaskField
+		wantErr    error
+	}{
+		{
+			name:       "merge status",
+			patchType:  taskmodels.PatchTypeMerge,
+			patch:      `{"status": "In Progress"}`,
+			wantFields: []taskmodels.TaskField{taskmodels.FieldStatus},
+		},
+		{
+			name:       "merge removes due date",
+			patchType:  taskmodels.PatchTypeMerge,
+			patch:      `{"due_date": null, "priority": "high"}`,
+			wantFields: []taskmodels.TaskField{taskmodels.FieldPriority, taskmodels.FieldDueDate},
+		},
+		{
+			name:      "json patch",
+			patchType: taskmodels.PatchTypeJSON,
+			patch: `[{"op": "test", "path": "/title", "value": "Report"},
+				{"op": "replace", "path": "/title", "value": "Annual report"},
+				{"op": "replace", "path": "/project_id", "value": ""}]`,
+			wantFields: []taskmodels.TaskField{taskmodels.FieldTitle},
+		},
+		{
+			name:      "nothing changed",
+			patchType: taskmodels.PatchTypeMerge,
+			patch:     `{"title": "Report"}`,
+		},
+		{
+			name:      "test failed",
+			patchType: taskmodels.PatchTypeJSON,
+			patch:     `[{"op": "test", "path": "/status", "value": "Done"}]`,
+			wantErr:   taskerrors.ErrPatchTestFailed,
+		},
+		{
+			name:      "unknown field",
+			patchType: taskmodels.PatchTypeMerge,
+			patch:     `{"titel": "Typo"}`,
+			wantErr:   taskerrors.ErrWrongPatch,
+		},
+		{
+			name:      "invalid result",
+			patchType: taskmodels.PatchTypeMerge,
+			patch:     `{"status": "Paused"}`,
+			wantErr:   taskerrors.ErrWrongStatus,
+		},
+		{
+			name:      "wrong patch type",
+			patchType: "application/json",
+			patch:     `{}`,
+			wantErr:   taskerrors.ErrWrongPatchType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			if tt.patchType.IsValid() {
+				repo.On("GetTaskByID", "t1", "u1").Return(task, nil)
+			}
+			if tt.wantFields != nil {
+				repo.On("UpdateTaskFields", mock.MatchedBy(func(updated taskmodels.Task) bool {
+					return updated.Version == task.Version
+				}), tt.wantFields).Return(nil)
+			}
+
+			_, err := service.PatchTask("t1", "u1", tt.patchType, []byte(tt.patch), 2, false)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPatchTaskRequiresFields(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewTaskService(repo, nil)
+
+	repo.On("GetTaskByID", "t1", "u1").Return(taskmodels.Task{
+		ID: "t1", UserID: "u1",
+		Attributes: taskmodels.TaskAttributes{Status: taskmodels.StatusNew, Title: "Report", Description: "D"},
+	}, nil)
+
+	_, err := service.PatchTask("t1", "u1", taskmodels.PatchTypeMerge, []byte(`{"title": null}`), 0, false)
+	assert.Error(t, err)
+
+	_, err = service.PatchTask("t1", "u1", taskmodels.PatchTypeMerge, []byte(`{}`), 5, false)
+	assert.ErrorIs(t, err, taskerrors.ErrVersionConflict)
+}
@@ -0,0 +1,171 @@
+package taskservice
+
+import (
+	"fmt"
+	"slices"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/pkg/rank"
+	"toDoList/pkg/rrule"
+
+	"github.com/google/uuid"
+)
+
+// normalizeRRule - проверка правила повторения, возвращает его каноническую запись.
+func normalizeRRule(attributes taskmodels.TaskAttributes) (string, error) {
+	if attributes.RRule == "" {
+		return "", nil
+	}
+
+	rule, err := rrule.Parse(attributes.RRule)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", taskerrors.ErrWrongRRule, err)
+	}
+
+	if attributes.DueDate == nil {
+		return "", taskerrors.ErrRRuleNeedsDueDate
+	}
+
+	return rule.String(), nil
+}
+
+// scheduleNextOccurrence - создаёт следующее повторение завершённой задачи со сдвинутым сроком
+// в начальном статусе workflow.
+// Повторное завершение того же повторения новую задачу не создаёт.
+func (ts *TaskService) scheduleNextOccurrence(task taskmodels.Task, userID string) error {
+	rule, err := rrule.Parse(task.Attributes.RRule)
+	if err != nil {
+		return fmt.Errorf("%w: %w", taskerrors.ErrWrongRRule, err)
+	}
+
+	next, ok := rule.Next(*task.Attributes.DueDate, task.Occurrence)
+	if !ok {
+		return nil
+	}
+
+	series, err := ts.db.GetSeriesTasks(task.SeriesID)
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(series, func(t taskmodels.Task) bool { return t.Occurrence > task.Occurrence }) {
+		return nil
+	}
+
+	workflow, err := ts.workflow(task.Attributes.ProjectID)
+	if err != nil {
+		return err
+	}
+	initial := workflow.Initial()
+
+	occurrence := taskmodels.Task{
+		ID:         uuid.New().String(),
+		UserID:     task.UserID,
+		Attributes: task.Attributes,
+		Category:   initial.Category,
+		SeriesID:   task.SeriesID,
+		Occurrence: task.Occurrence + 1,
+		ChangedBy:  userID,
+	}
+	occurrence.Attributes.Status = initial.Name
+	occurrence.Attributes.DueDate = &next
+
+	for _, item := range task.Checklist {
+		item.Done = false
+		occurrence.Checklist = append(occurrence.Checklist, item)
+	}
+
+	lastPosition, err := ts.db.GetLastTaskPosition(task.UserID)
+	if err != nil {
+		return err
+	}
+
+	occurrence.Position, err = rank.After(lastPosition)
+	if err != nil {
+		return err
+	}
+
+	err = ts.db.AddTask(occurrence)
+	if err != nil {
+		return err
+	}
+
+	if occurrence.Attributes.AssigneeID != "" {
+		return ts.recordAssignment(occurrence.ID, occurrence.Attributes.AssigneeID, userID)
+	}
+
+	return nil
+}
+
+// UpdateTaskSeries - изменение всей серии в одной транзакции: задача обновляется как в UpdateTask,
+// а незавершённые повторения получают те же атрибуты, сохраняя свой статус и родителя, и проходят
+// те же проверки. Сдвиг срока переносится на все незавершённые повторения.
+func (ts *TaskService) UpdateTaskSeries(taskID string, userID string, newAttributes taskmodels.TaskAttributes,
+	version int64, force bool, runInTx TxRunner,
+) error {
+	task, err := ts.GetTaskByID(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if task.SeriesID == "" {
+		return ts.UpdateTask(taskID, userID, newAttributes, version, force)
+	}
+
+	return runInTx(func(tx TaskStorage) error {
+		return NewTaskService(tx, nil).updateSeries(task, userID, newAttributes, version, force)
+	})
+}
+
+// updateSeries - тело UpdateTaskSeries, вызывается внутри транзакции.
+func (ts *TaskService) updateSeries(task taskmodels.Task, userID string, newAttributes taskmodels.TaskAttributes,
+	version int64, force bool,
+) error {
+	err := ts.UpdateTask(task.ID, userID, newAttributes, version, force)
+	if err != nil {
+		return err
+	}
+
+	updated, err := ts.db.GetTaskByID(task.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	series, err := ts.db.GetSeriesTasks(task.SeriesID)
+	if err != nil {
+		return err
+	}
+
+	workflow, err := ts.workflow(updated.Attributes.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	for _, occurrence := range series {
+		if occurrence.ID == task.ID || occurrence.Category == taskmodels.CategoryDone {
+			continue
+		}
+
+		attributes := updated.Attributes
+		attributes.Status = occurrence.Attributes.Status
+
+		// Перенесённое в другой проект повторение, статуса которого нет в новом workflow, начинает сначала.
+		if _, ok := workflow.Status(attributes.Status); !ok {
+			attributes.Status = workflow.Initial().Name
+		}
+		attributes.ParentID = occurrence.Attributes.ParentID
+		attributes.DueDate = occurrence.Attributes.DueDate
+
+		if due := occurrence.Attributes.DueDate; due != nil && task.Attributes.DueDate != nil &&
+			updated.Attributes.DueDate != nil {
+			shifted := due.Add(updated.Attributes.DueDate.Sub(*task.Attributes.DueDate))
+			attributes.DueDate = &shifted
+		}
+
+		if err = ts.saveAttributes(occurrence, attributes, userID, force, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
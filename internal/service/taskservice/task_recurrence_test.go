@@ -0,0 +1,188 @@
+package taskservice
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowmodels"
+	"toDoList/internal/repository/inmemory"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestCreateRecurringTask(t *testing.T) {
+	due := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rrule   string
+		due     *time.Time
+		wantErr error
+	}{
+		{"weekly", "freq=weekly;byday=mo", &due, nil},
+		{"wrong rule", "FREQ=HOURLY", &due, taskerrors.ErrWrongRRule},
+		{"without due date", "FREQ=DAILY", nil, taskerrors.ErrRRuleNeedsDueDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			if tt.wantErr == nil {
+				repo.On("GetLastTaskPosition", "u1").Return("", nil)
+				repo.On("AddTask", mock.MatchedBy(func(task taskmodels.Task) bool {
+					return task.SeriesID == task.ID && task.Occurrence == 1 &&
+						task.Attributes.RRule == "FREQ=WEEKLY;BYDAY=MO"
+				})).Return(nil)
+			}
+
+			_, err := service.CreateTask(taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: "T", Description: "D", RRule: tt.rrule, DueDate: tt.due,
+			}, "u1")
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCompleteRecurringTask(t *testing.T) {
+	due := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	task := taskmodels.Task{
+		ID: "t1", UserID: "u1", SeriesID: "t1", Occurrence: 1,
+		Checklist: []taskmodels.ChecklistItem{{ID: "i1", Title: "step", Done: true}},
+		Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusInProgress, Title: "Chore", Description: "D", Priority: taskmodels.PriorityNone,
+			DueDate: &due, RRule: "FREQ=WEEKLY;COUNT=2",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		occurrence int
+		series     []taskmodels.Task
+		wantNext   bool
+	}{
+		{"next instance", 1, []taskmodels.Task{task}, true},
+		{"already scheduled", 1, []taskmodels.Task{task, {ID: "t2", SeriesID: "t1", Occurrence: 2}}, false},
+		{"count reached", 2, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			current := task
+			current.Occurrence = tt.occurrence
+
+			repo.On("GetTaskByID", "t1", "u1").Return(current, nil)
+			repo.On("UpdateTaskAttributes", mock.Anything).Return(nil).Once()
+			if tt.series != nil {
+				repo.On("GetSeriesTasks", "t1").Return(tt.series, nil)
+			}
+			if tt.wantNext {
+				repo.On("GetLastTaskPosition", "u1").Return("m", nil)
+				repo.On("AddTask", mock.MatchedBy(func(next taskmodels.Task) bool {
+					return next.ID != "t1" && next.SeriesID == "t1" && next.Occurrence == 2 &&
+						next.Attributes.Status == taskmodels.StatusNew &&
+						next.Attributes.DueDate.Equal(due.AddDate(0, 0, 7)) &&
+						!next.Checklist[0].Done && next.Position > "m"
+				})).Return(nil)
+			}
+
+			attributes := current.Attributes
+			attributes.Status = taskmodels.StatusCompleted
+			assert.NoError(t, service.UpdateTask("t1", "u1", attributes, 0, false))
+		})
+	}
+}
+
+func TestUpdateTaskSeries(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewTaskService(repo, nil)
+
+	attrs := taskmodels.TaskAttributes{
+		Status: taskmodels.StatusNew, Title: "Chore", Description: "D", Priority: taskmodels.PriorityNone,
+		RRule: "FREQ=DAILY",
+	}
+	occurrence := func(id string, n int, status taskmodels.TaskStatus, day int) taskmodels.Task {
+		a := attrs
+		a.Status = status
+		a.DueDate = timePtr(time.Date(2026, 1, day, 9, 0, 0, 0, time.UTC))
+		return taskmodels.Task{
+			ID: id, UserID: "u1", SeriesID: "s", Occurrence: n, Attributes: a, Category: categoryOf(status),
+		}
+	}
+
+	done := occurrence("s", 1, taskmodels.StatusCompleted, 1)
+	current := occurrence("t2", 2, taskmodels.StatusNew, 2)
+	future := occurrence("t3", 3, taskmodels.StatusInProgress, 3)
+
+	newAttributes := current.Attributes
+	newAttributes.Title = "Renamed"
+	newAttributes.DueDate = timePtr(current.Attributes.DueDate.Add(2 * time.Hour))
+
+	updated := current
+	updated.Attributes = newAttributes
+
+	repo.On("GetTaskByID", "t2", "u1").Return(current, nil).Twice()
+	repo.On("GetTaskByID", "t2", "u1").Return(updated, nil).Once()
+	repo.On("UpdateTaskAttributes", mock.MatchedBy(func(task taskmodels.Task) bool {
+		return task.ID == "t2" && task.Attributes.Title == "Renamed"
+	})).Return(nil)
+	repo.On("GetSeriesTasks", "s").Return([]taskmodels.Task{done, updated, future}, nil)
+	repo.On("UpdateTaskAttributes", mock.MatchedBy(func(task taskmodels.Task) bool {
+		return task.ID == "t3" && task.Attributes.Title == "Renamed" &&
+			task.Attributes.Status == taskmodels.StatusInProgress &&
+			task.Attributes.DueDate.Equal(time.Date(2026, 1, 3, 11, 0, 0, 0, time.UTC))
+	})).Return(nil)
+
+	runInTx := func(fn func(tx TaskStorage) error) error { return fn(repo) }
+	assert.NoError(t, service.UpdateTaskSeries("t2", "u1", newAttributes, 0, false, runInTx))
+}
+
+func TestUpdateTaskSeriesRollback(t *testing.T) {
+	storage := inmemory.NewInMemoryStorage()
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend"}))
+	require.NoError(t, storage.SaveWorkflow(workflowmodels.Workflow{
+		ProjectID: "p1",
+		Statuses: []workflowmodels.Status{
+			{Name: "Todo", Category: taskmodels.CategoryTodo, WIPLimit: 1},
+			{Name: "Done", Category: taskmodels.CategoryDone},
+		},
+	}))
+
+	due := timePtr(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	for i, id := range []string{"s", "t2"} {
+		require.NoError(t, storage.AddTask(taskmodels.Task{
+			ID: id, UserID: "u1", SeriesID: "s", Occurrence: i + 1, Category: taskmodels.CategoryTodo,
+			Attributes: taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: "Chore", Description: "D", RRule: "FREQ=DAILY", DueDate: due,
+			},
+		}))
+	}
+
+	service := NewTaskService(storage, nil)
+	newAttributes := taskmodels.TaskAttributes{
+		Status: "Todo", Title: "Chore", Description: "D", RRule: "FREQ=DAILY", DueDate: due, ProjectID: "p1",
+	}
+
+	// Второе повторение переносится в тот же статус проекта и упирается в WIP-лимит, поэтому
+	// откатывается и перенос первого.
+	err := service.UpdateTaskSeries("s", "u1", newAttributes, 0, false, boardRunInTx(storage))
+	assert.ErrorIs(t, err, taskerrors.ErrWIPLimitReached)
+
+	task, err := storage.GetTaskByID("s", "u1")
+	require.NoError(t, err)
+	assert.Empty(t, task.Attributes.ProjectID)
+	assert.Equal(t, taskmodels.StatusNew, task.Attributes.Status)
+}
@@ -0,0 +1,35 @@
+package taskservice
+
+import (
+	"strings"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/pkg/fulltext"
+)
+
+// SearchTasks - полнотекстовый поиск по заголовкам и описаниям видимых пользователю задач.
+// По умолчанию запрос разбирается русской конфигурацией, она понимает и английские слова.
+func (ts *TaskService) SearchTasks(userID string, query taskmodels.SearchQuery) ([]taskmodels.SearchResult, error) {
+	query.Query = strings.TrimSpace(query.Query)
+
+	if query.Language == "" {
+		query.Language = taskmodels.DefaultSearchLanguage
+	}
+	if !query.Language.IsValid() {
+		return nil, taskerrors.ErrWrongSearchLang
+	}
+
+	if query.Limit == 0 {
+		query.Limit = taskmodels.DefaultSearchLimit
+	}
+	if query.Limit < 0 || query.Limit > taskmodels.MaxSearchLimit {
+		return nil, taskerrors.ErrWrongSearchLimit
+	}
+
+	// Запрос из одних стоп-слов Postgres превращает в пустой и ничего не находит.
+	if fulltext.ParseQuery(query.Query, string(query.Language)).IsEmpty() {
+		return nil, taskerrors.ErrEmptySearchQuery
+	}
+
+	return ts.db.SearchTasks(userID, query)
+}
@@ -0,0 +1,71 @@
+package taskservice
+
+import (
+	"testing"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTasks(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   taskmodels.SearchQuery
+		want    taskmodels.SearchQuery
+		wantErr error
+	}{
+		{
+			name:  "defaults",
+			query: taskmodels.SearchQuery{Query: "  счета за март "},
+			want: taskmodels.SearchQuery{
+				Query: "счета за март", Language: taskmodels.SearchRussian, Limit: taskmodels.DefaultSearchLimit,
+			},
+		},
+		{
+			name:  "english",
+			query: taskmodels.SearchQuery{Query: "that task about invoices", Language: "english", Limit: 5},
+			want:  taskmodels.SearchQuery{Query: "that task about invoices", Language: "english", Limit: 5},
+		},
+		{
+			name:    "only stop words",
+			query:   taskmodels.SearchQuery{Query: "the and of", Language: "english"},
+			wantErr: taskerrors.ErrEmptySearchQuery,
+		},
+		{
+			name:    "empty",
+			query:   taskmodels.SearchQuery{Query: " "},
+			wantErr: taskerrors.ErrEmptySearchQuery,
+		},
+		{
+			name:    "wrong language",
+			query:   taskmodels.SearchQuery{Query: "invoices", Language: "german"},
+			wantErr: taskerrors.ErrWrongSearchLang,
+		},
+		{
+			name:    "wrong limit",
+			query:   taskmodels.SearchQuery{Query: "invoices", Limit: taskmodels.MaxSearchLimit + 1},
+			wantErr: taskerrors.ErrWrongSearchLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			if tt.wantErr == nil {
+				repo.On("SearchTasks", "u1", tt.want).Return([]taskmodels.SearchResult{}, nil)
+			}
+
+			_, err := service.SearchTasks("u1", tt.query)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
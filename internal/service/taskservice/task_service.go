@@ -1,9 +1,21 @@
 package taskservice
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/customfield/customfieldmodels"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/tag/tagerrors"
+	"toDoList/internal/domain/tag/tagmodels"
 	"toDoList/internal/domain/task/taskerrors"
 	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templatemodels"
+	"toDoList/internal/domain/workflow/workflowmodels"
 	"toDoList/internal/server/workers"
+	"toDoList/pkg/rank"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -14,8 +26,34 @@ type TaskStorage interface {
 	GetTaskByID(taskID string, userID string) (taskmodels.Task, error)
 	AddTask(newTask taskmodels.Task) error
 	UpdateTaskAttributes(task taskmodels.Task) error
+	UpdateTaskFields(task taskmodels.Task, fields []taskmodels.TaskField) error
 	DeleteTask(taskID string, userID string) error
 	MarkTaskToDelete(taskID string, userID string) error
+	FindTasks(userID string, filter taskmodels.TaskFilter) ([]taskmodels.Task, error)
+	SearchTasks(userID string, query taskmodels.SearchQuery) ([]taskmodels.SearchResult, error)
+	AddTaskAssignment(assignment taskmodels.Assignment) error
+	GetTaskAssignments(taskID string) ([]taskmodels.Assignment, error)
+	AddTaskWatcher(taskID string, userID string) error
+	RemoveTaskWatcher(taskID string, userID string) error
+	GetTaskWatchers(taskID string) ([]string, error)
+	IsProjectMember(projectID string, userID string) (bool, error)
+	GetTagByID(tagID string, userID string) (tagmodels.Tag, error)
+	SetTaskTags(taskID string, userID string, tagIDs []string) error
+	GetLastTaskPosition(userID string) (string, error)
+	UpdateTaskPosition(taskID string, userID string, position string) error
+	GetSubtasks(taskID string) ([]taskmodels.Task, error)
+	UpdateTaskChecklist(taskID string, checklist []taskmodels.ChecklistItem) error
+	AddTaskDependency(taskID string, blockerID string) error
+	RemoveTaskDependency(taskID string, blockerID string) error
+	HasDependencyPath(fromID string, toID string) (bool, error)
+	GetUnfinishedBlockers(taskID string) ([]string, error)
+	GetSeriesTasks(seriesID string) ([]taskmodels.Task, error)
+	GetTaskHistory(taskID string) ([]taskmodels.HistoryEntry, error)
+	GetWorkflow(projectID string) (workflowmodels.Workflow, error)
+	CountTasksInStatus(projectID string, status taskmodels.TaskStatus) (int, error)
+	GetCustomFields(projectID string) ([]customfieldmodels.CustomField, error)
+	GetTagsByUser(userID string) ([]tagmodels.Tag, error)
+	GetTemplateByID(templateID string, userID string) (templatemodels.Template, error)
 }
 
 type TaskService struct {
@@ -32,6 +70,36 @@ func (ts *TaskService) GetAllTasks(userID string) ([]taskmodels.Task, error) {
 	return ts.db.GetAllTasks(userID)
 }
 
+// GetTasks - список задач по фильтру. Без фильтров возвращает задачи пользователя,
+// assignee "me" означает самого пользователя.
+func (ts *TaskService) GetTasks(userID string, filter taskmodels.TaskFilter) ([]taskmodels.Task, error) {
+	if filter.IsEmpty() {
+		return ts.GetAllTasks(userID)
+	}
+
+	if filter.AssigneeID == taskmodels.AssigneeMe {
+		filter.AssigneeID = userID
+	}
+
+	if len(filter.Tags) != 0 {
+		if filter.TagMode == "" {
+			filter.TagMode = taskmodels.TagModeAny
+		}
+		if !filter.TagMode.IsValid() {
+			return nil, tagerrors.ErrWrongTagMode
+		}
+		slices.Sort(filter.Tags)
+		filter.Tags = slices.Compact(filter.Tags)
+	}
+
+	filter, err := ts.customFieldsFilter(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.db.FindTasks(userID, filter)
+}
+
 func (ts *TaskService) GetTaskByID(taskID string, userID string) (taskmodels.Task, error) {
 	if taskID == "" {
 		return taskmodels.Task{}, taskerrors.ErrEmptyString
@@ -51,10 +119,43 @@ func (ts *TaskService) CreateTask(newTaskAttributes taskmodels.TaskAttributes, u
 		return "", err
 	}
 
-	taskStatusValid := newTaskAttributes.Status.IsValid()
+	newTaskAttributes.Priority, err = normalizePriority(newTaskAttributes.Priority)
+	if err != nil {
+		return "", err
+	}
+
+	newTaskAttributes.RRule, err = normalizeRRule(newTaskAttributes)
+	if err != nil {
+		return "", err
+	}
+
+	err = ts.validateMembership(newTaskAttributes, userID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	err = ts.validateParent(newTaskAttributes, "", userID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	newTaskAttributes.CustomFields, err = ts.normalizeCustomFields(newTaskAttributes, false)
+	if err != nil {
+		return "", err
+	}
+
+	workflow, err := ts.workflow(newTaskAttributes.ProjectID)
+	if err != nil {
+		return "", err
+	}
+
+	status, err := workflowStatus(workflow, newTaskAttributes.Status)
+	if err != nil {
+		return "", err
+	}
 
-	if !taskStatusValid {
-		return "", taskerrors.ErrWrongStatus
+	if err = ts.checkWIPLimit(newTaskAttributes.ProjectID, status); err != nil {
+		return "", err
 	}
 
 	var newTask taskmodels.Task
@@ -62,42 +163,709 @@ func (ts *TaskService) CreateTask(newTaskAttributes taskmodels.TaskAttributes, u
 	newTask.ID = uuid.New().String()
 	newTask.UserID = userID
 	newTask.Attributes = newTaskAttributes
+	newTask.Category = status.Category
+
+	if newTaskAttributes.RRule != "" {
+		newTask.SeriesID = newTask.ID
+		newTask.Occurrence = 1
+	}
+
+	lastPosition, err := ts.db.GetLastTaskPosition(userID)
+	if err != nil {
+		return "", err
+	}
+
+	newTask.Position, err = rank.After(lastPosition)
+	if err != nil {
+		return "", err
+	}
 
 	err = ts.db.AddTask(newTask)
 	if err != nil {
 		return "", err
 	}
 
+	if newTaskAttributes.AssigneeID != "" {
+		err = ts.recordAssignment(newTask.ID, newTaskAttributes.AssigneeID, userID)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	return newTask.ID, nil
 }
 
-func (ts *TaskService) UpdateTask(taskID string, userID string, newAttributes taskmodels.TaskAttributes) error {
-	err := ts.valid.Struct(newAttributes)
+// UpdateTask - замена атрибутов задачи. Новый статус должен быть в workflow проекта задачи, переход
+// в него - разрешён workflow, а WIP-лимит статуса - не исчерпан. Перевести задачу в статус не из
+// категории todo, пока не завершены блокирующие её задачи, можно только с force. Ненулевая version -
+// версия, которую видел клиент: если задачу с тех пор изменили, возвращается ErrVersionConflict.
+func (ts *TaskService) UpdateTask(taskID string, userID string, newAttributes taskmodels.TaskAttributes,
+	version int64, force bool,
+) error {
+	newAttributes, err := ts.normalizeAttributes(newAttributes)
+	if err != nil {
+		return err
+	}
+
+	task, err := ts.getTaskForUpdate(taskID, userID, version)
 	if err != nil {
 		return err
 	}
 
-	taskStatusValid := newAttributes.Status.IsValid()
+	return ts.saveAttributes(task, newAttributes, userID, force, false)
+}
 
-	if !taskStatusValid {
-		return taskerrors.ErrWrongStatus
+// normalizeAttributes - проверка атрибутов перед записью и приведение приоритета и правила повторения
+// к каноническому виду.
+func (ts *TaskService) normalizeAttributes(attributes taskmodels.TaskAttributes) (taskmodels.TaskAttributes, error) {
+	err := ts.valid.Struct(attributes)
+	if err != nil {
+		return attributes, err
+	}
+
+	attributes.Priority, err = normalizePriority(attributes.Priority)
+	if err != nil {
+		return attributes, err
 	}
 
+	attributes.RRule, err = normalizeRRule(attributes)
+	if err != nil {
+		return attributes, err
+	}
+
+	return attributes, nil
+}
+
+// getTaskForUpdate - задача, которую собираются изменить, с проверкой версии клиента.
+func (ts *TaskService) getTaskForUpdate(taskID string, userID string, version int64) (taskmodels.Task, error) {
 	task, err := ts.db.GetTaskByID(taskID, userID)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	// Хранилище ещё раз сверит прочитанную версию при записи, поэтому изменения,
+	// сделанные между чтением и записью, тоже не потеряются.
+	if version != 0 && version != task.Version {
+		return taskmodels.Task{}, taskerrors.ErrVersionConflict
+	}
+
+	return task, nil
+}
+
+// saveAttributes - запись новых атрибутов задачи со всеми проверками и последствиями: участники
+// проекта, родитель, workflow, блокеры, история назначений, следующее повторение и автозавершение родителя.
+// partial - писать только изменившиеся поля.
+func (ts *TaskService) saveAttributes(task taskmodels.Task, newAttributes taskmodels.TaskAttributes, userID string,
+	force bool, partial bool,
+) error {
+	var err error
+	oldTask := task
+	oldAttributes := task.Attributes
+
+	if oldAttributes.ProjectID != newAttributes.ProjectID || oldAttributes.AssigneeID != newAttributes.AssigneeID {
+		err = ts.validateMembership(newAttributes, task.UserID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if oldAttributes.ProjectID != newAttributes.ProjectID || oldAttributes.ParentID != newAttributes.ParentID {
+		err = ts.validateParent(newAttributes, task.ID, task.UserID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Неизменившиеся значения не проверяются повторно: например, пользователь из поля типа user мог
+	// с тех пор покинуть проект.
+	projectChanged := oldAttributes.ProjectID != newAttributes.ProjectID
+	if projectChanged || !taskmodels.EqualCustomFields(oldAttributes.CustomFields, newAttributes.CustomFields) {
+		newAttributes.CustomFields, err = ts.normalizeCustomFields(newAttributes, projectChanged)
+		if err != nil {
+			return err
+		}
+	}
+
+	workflow, err := ts.workflow(newAttributes.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	status, err := workflowStatus(workflow, newAttributes.Status)
 	if err != nil {
 		return err
 	}
 
+	if err = checkTransition(workflow, oldAttributes, newAttributes); err != nil {
+		return err
+	}
+
+	if oldAttributes.Status != newAttributes.Status || oldAttributes.ProjectID != newAttributes.ProjectID {
+		if err = ts.checkWIPLimit(newAttributes.ProjectID, status); err != nil {
+			return err
+		}
+	}
+
+	if !force && len(task.BlockedBy) != 0 && oldAttributes.Status != newAttributes.Status &&
+		status.Category != taskmodels.CategoryTodo {
+		blockers, errBlockers := ts.db.GetUnfinishedBlockers(task.ID)
+		if errBlockers != nil {
+			return errBlockers
+		}
+		if len(blockers) != 0 {
+			return fmt.Errorf("%w: %s", taskerrors.ErrTaskBlocked, strings.Join(blockers, ", "))
+		}
+	}
+
 	task.Attributes = newAttributes
+	task.Category = status.Category
+	task.ChangedBy = userID
+
+	// Задача, ставшая повторяющейся, начинает собственную серию.
+	if newAttributes.RRule != "" && task.SeriesID == "" {
+		task.SeriesID = task.ID
+		task.Occurrence = 1
+	}
 
-	err = ts.db.UpdateTaskAttributes(task)
+	if partial {
+		fields := taskmodels.ChangedFields(oldTask, task)
+		// Категория статуса зависит от workflow проекта, поэтому при переносе задачи в другой проект
+		// она может измениться вместе с проектом, а не со статусом.
+		if slices.Contains(fields, taskmodels.FieldProjectID) && !slices.Contains(fields, taskmodels.FieldStatus) &&
+			task.Category != oldTask.Category {
+			fields = append([]taskmodels.TaskField{taskmodels.FieldStatus}, fields...)
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		err = ts.db.UpdateTaskFields(task, fields)
+	} else {
+		err = ts.db.UpdateTaskAttributes(task)
+	}
 	if err != nil {
 		return err
 	}
 
+	if oldAttributes.AssigneeID != newAttributes.AssigneeID {
+		err = ts.recordAssignment(task.ID, newAttributes.AssigneeID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if task.Category != taskmodels.CategoryDone || oldTask.Category == taskmodels.CategoryDone {
+		return nil
+	}
+
+	if newAttributes.RRule != "" {
+		err = ts.scheduleNextOccurrence(task, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if newAttributes.ParentID != "" {
+		return ts.autoCompleteParent(newAttributes.ParentID, userID)
+	}
+
+	return nil
+}
+
+// validateParent - родитель должен быть виден пользователю и лежать в том же проекте,
+// задача не может оказаться внутри своих подзадач, вложенность ограничена MaxTaskDepth.
+// taskID пустой для новой задачи.
+func (ts *TaskService) validateParent(attributes taskmodels.TaskAttributes, taskID string, ownerID string,
+	actorID string,
+) error {
+	if attributes.ParentID == "" {
+		return nil
+	}
+
+	if attributes.ParentID == taskID {
+		return taskerrors.ErrSubtaskCycle
+	}
+
+	parent, err := ts.db.GetTaskByID(attributes.ParentID, actorID)
+	if err != nil {
+		return err
+	}
+
+	if parent.Attributes.ProjectID != attributes.ProjectID ||
+		attributes.ProjectID == "" && parent.UserID != ownerID {
+		return taskerrors.ErrWrongParent
+	}
+
+	depth := 1
+	for ancestor := parent; ancestor.Attributes.ParentID != ""; depth++ {
+		if depth >= taskmodels.MaxTaskDepth {
+			return taskerrors.ErrSubtaskTooDeep
+		}
+		if ancestor.Attributes.ParentID == taskID {
+			return taskerrors.ErrSubtaskCycle
+		}
+		ancestor, err = ts.db.GetTaskByID(ancestor.Attributes.ParentID, actorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	height := 1
+	if taskID != "" {
+		subtasks, errSub := ts.db.GetSubtasks(taskID)
+		if errSub != nil {
+			return errSub
+		}
+		height = treeHeight(taskID, childrenByParent(subtasks))
+	}
+
+	if depth+height > taskmodels.MaxTaskDepth {
+		return taskerrors.ErrSubtaskTooDeep
+	}
+
 	return nil
 }
 
+// autoCompleteParent - завершает родителя с AutoComplete, если завершены все его подзадачи: родитель
+// получает первый статус категории done из workflow своего проекта. Родитель обновляется через UpdateTask,
+// поэтому завершение поднимается вверх по дереву.
+func (ts *TaskService) autoCompleteParent(parentID string, userID string) error {
+	parent, err := ts.db.GetTaskByID(parentID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !parent.Attributes.AutoComplete || parent.Category == taskmodels.CategoryDone {
+		return nil
+	}
+
+	subtasks, err := ts.db.GetSubtasks(parentID)
+	if err != nil {
+		return err
+	}
+
+	for _, subtask := range childrenByParent(subtasks)[parentID] {
+		if subtask.Category != taskmodels.CategoryDone {
+			return nil
+		}
+	}
+
+	workflow, err := ts.workflow(parent.Attributes.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	done, ok := workflow.FirstInCategory(taskmodels.CategoryDone)
+	if !ok {
+		return nil
+	}
+
+	attributes := parent.Attributes
+	attributes.Status = done.Name
+
+	// Заблокированный родитель или родитель, которого workflow не даёт перевести в done, остаётся как есть,
+	// завершение подзадачи от этого не откатывается.
+	err = ts.UpdateTask(parent.ID, userID, attributes, 0, false)
+	if errors.Is(err, taskerrors.ErrTaskBlocked) || errors.Is(err, taskerrors.ErrTransitionDenied) {
+		return nil
+	}
+	return err
+}
+
+// GetTaskSubtree - задача со всеми подзадачами, вложенными в поле Subtasks.
+func (ts *TaskService) GetTaskSubtree(taskID string, userID string) (taskmodels.Task, error) {
+	task, err := ts.GetTaskByID(taskID, userID)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	subtasks, err := ts.db.GetSubtasks(taskID)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	return withSubtasks(task, childrenByParent(subtasks)), nil
+}
+
+func childrenByParent(tasks []taskmodels.Task) map[string][]taskmodels.Task {
+	children := make(map[string][]taskmodels.Task)
+	for _, task := range tasks {
+		children[task.Attributes.ParentID] = append(children[task.Attributes.ParentID], task)
+	}
+	return children
+}
+
+func withSubtasks(task taskmodels.Task, children map[string][]taskmodels.Task) taskmodels.Task {
+	for _, child := range children[task.ID] {
+		task.Subtasks = append(task.Subtasks, withSubtasks(child, children))
+	}
+	return task
+}
+
+func treeHeight(taskID string, children map[string][]taskmodels.Task) int {
+	height := 0
+	for _, child := range children[taskID] {
+		height = max(height, treeHeight(child.ID, children))
+	}
+	return height + 1
+}
+
+func (ts *TaskService) AddChecklistItem(taskID string, userID string, req taskmodels.ChecklistItemRequest) (
+	taskmodels.ChecklistItem, error,
+) {
+	if err := ts.valid.Struct(req); err != nil {
+		return taskmodels.ChecklistItem{}, err
+	}
+
+	task, err := ts.GetTaskByID(taskID, userID)
+	if err != nil {
+		return taskmodels.ChecklistItem{}, err
+	}
+
+	item := taskmodels.ChecklistItem{ID: uuid.New().String(), Title: req.Title, Done: req.Done}
+
+	err = ts.db.UpdateTaskChecklist(taskID, append(task.Checklist, item))
+	if err != nil {
+		return taskmodels.ChecklistItem{}, err
+	}
+
+	return item, nil
+}
+
+func (ts *TaskService) UpdateChecklistItem(taskID string, itemID string, userID string,
+	req taskmodels.ChecklistItemRequest,
+) error {
+	if err := ts.valid.Struct(req); err != nil {
+		return err
+	}
+
+	task, err := ts.GetTaskByID(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(task.Checklist, func(item taskmodels.ChecklistItem) bool { return item.ID == itemID })
+	if i == -1 {
+		return taskerrors.ErrChecklistNotFound
+	}
+
+	task.Checklist[i].Title = req.Title
+	task.Checklist[i].Done = req.Done
+
+	return ts.db.UpdateTaskChecklist(taskID, task.Checklist)
+}
+
+func (ts *TaskService) DeleteChecklistItem(taskID string, itemID string, userID string) error {
+	task, err := ts.GetTaskByID(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	checklist := slices.DeleteFunc(task.Checklist, func(item taskmodels.ChecklistItem) bool {
+		return item.ID == itemID
+	})
+	if len(checklist) == len(task.Checklist) {
+		return taskerrors.ErrChecklistNotFound
+	}
+
+	return ts.db.UpdateTaskChecklist(taskID, checklist)
+}
+
+// normalizePriority - пустой приоритет означает none.
+func normalizePriority(priority taskmodels.TaskPriority) (taskmodels.TaskPriority, error) {
+	if priority == "" {
+		return taskmodels.PriorityNone, nil
+	}
+	if !priority.IsValid() {
+		return "", taskerrors.ErrWrongPriority
+	}
+	return priority, nil
+}
+
+// MoveTask - перемещение задачи между соседями. Меняется только ранг самой задачи,
+// остальные задачи списка не переписываются.
+func (ts *TaskService) MoveTask(taskID string, userID string, req taskmodels.MoveTaskRequest) (string, error) {
+	if req.Before == "" && req.After == "" || req.Before == taskID || req.After == taskID {
+		return "", taskerrors.ErrWrongMove
+	}
+
+	tasks, err := ts.db.GetAllTasks(userID)
+	if err != nil {
+		return "", err
+	}
+
+	positions := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		positions[task.ID] = task.Position
+	}
+
+	if _, ok := positions[taskID]; !ok {
+		return "", taskerrors.ErrFoundNothing
+	}
+
+	var lower, upper string
+
+	if req.After != "" {
+		var ok bool
+		if lower, ok = positions[req.After]; !ok {
+			return "", taskerrors.ErrFoundNothing
+		}
+	}
+
+	if req.Before != "" {
+		var ok bool
+		if upper, ok = positions[req.Before]; !ok {
+			return "", taskerrors.ErrFoundNothing
+		}
+	}
+
+	// Если указан только один сосед, второй - ближайшая к нему задача, кроме перемещаемой.
+	switch {
+	case req.Before == "":
+		upper = nextPosition(tasks, taskID, lower)
+	case req.After == "":
+		lower = prevPosition(tasks, taskID, upper)
+	}
+
+	position, err := rank.Between(lower, upper)
+	if err != nil {
+		return "", taskerrors.ErrWrongMove
+	}
+
+	err = ts.db.UpdateTaskPosition(taskID, userID, position)
+	if err != nil {
+		return "", err
+	}
+
+	return position, nil
+}
+
+// nextPosition - наименьший ранг больше position, пустая строка если такого нет.
+func nextPosition(tasks []taskmodels.Task, skipID string, position string) string {
+	var next string
+	for _, task := range tasks {
+		if task.ID == skipID || task.Position <= position {
+			continue
+		}
+		if next == "" || task.Position < next {
+			next = task.Position
+		}
+	}
+	return next
+}
+
+// prevPosition - наибольший ранг меньше position, пустая строка если такого нет.
+func prevPosition(tasks []taskmodels.Task, skipID string, position string) string {
+	var prev string
+	for _, task := range tasks {
+		if task.ID == skipID || task.Position >= position {
+			continue
+		}
+		if task.Position > prev {
+			prev = task.Position
+		}
+	}
+	return prev
+}
+
+// AddTaskDependency - задача taskID не может начаться, пока не завершена blockerID.
+func (ts *TaskService) AddTaskDependency(taskID string, blockerID string, userID string) error {
+	if blockerID == taskID {
+		return taskerrors.ErrDependencyCycle
+	}
+
+	if _, err := ts.GetTaskByID(taskID, userID); err != nil {
+		return err
+	}
+	if _, err := ts.GetTaskByID(blockerID, userID); err != nil {
+		return err
+	}
+
+	cycle, err := ts.db.HasDependencyPath(blockerID, taskID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return taskerrors.ErrDependencyCycle
+	}
+
+	return ts.db.AddTaskDependency(taskID, blockerID)
+}
+
+func (ts *TaskService) RemoveTaskDependency(taskID string, blockerID string, userID string) error {
+	if _, err := ts.GetTaskByID(taskID, userID); err != nil {
+		return err
+	}
+
+	return ts.db.RemoveTaskDependency(taskID, blockerID)
+}
+
+// GetTasksInDependencyOrder - задачи пользователя в топологическом порядке: блокеры раньше
+// зависящих от них задач, в остальном сохраняется ручной порядок.
+func (ts *TaskService) GetTasksInDependencyOrder(userID string) ([]taskmodels.Task, error) {
+	tasks, err := ts.db.GetAllTasks(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		index[task.ID] = i
+	}
+
+	// Учитываются только связи внутри списка, внешние блокеры порядок не меняют.
+	inDegree := make([]int, len(tasks))
+	for i, task := range tasks {
+		for _, blockerID := range task.BlockedBy {
+			if _, ok := index[blockerID]; ok {
+				inDegree[i]++
+			}
+		}
+	}
+
+	ordered := make([]taskmodels.Task, 0, len(tasks))
+	done := make([]bool, len(tasks))
+
+	// Каждый проход берёт первую по позиции готовую задачу: O(n^2), но порядок предсказуемый.
+	for len(ordered) < len(tasks) {
+		next := slices.IndexFunc(tasks, func(task taskmodels.Task) bool {
+			i := index[task.ID]
+			return !done[i] && inDegree[i] == 0
+		})
+		if next == -1 {
+			return nil, taskerrors.ErrDependencyCycle
+		}
+
+		done[next] = true
+		ordered = append(ordered, tasks[next])
+
+		for _, blockedID := range tasks[next].Blocking {
+			if i, ok := index[blockedID]; ok {
+				inDegree[i]--
+			}
+		}
+	}
+
+	return ordered, nil
+}
+
+func (ts *TaskService) GetTaskAssignments(taskID string, userID string) ([]taskmodels.Assignment, error) {
+	if _, err := ts.GetTaskByID(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return ts.db.GetTaskAssignments(taskID)
+}
+
+func (ts *TaskService) GetTaskWatchers(taskID string, userID string) ([]string, error) {
+	if _, err := ts.GetTaskByID(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return ts.db.GetTaskWatchers(taskID)
+}
+
+// AddTaskWatcher - подписка на задачу. Следить может владелец или участник проекта задачи.
+func (ts *TaskService) AddTaskWatcher(taskID string, userID string, watcherID string) error {
+	task, err := ts.GetTaskByID(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if watcherID == "" {
+		watcherID = userID
+	}
+
+	if watcherID != task.UserID {
+		if task.Attributes.ProjectID == "" {
+			return taskerrors.ErrWatcherNotMember
+		}
+
+		isMember, errMember := ts.db.IsProjectMember(task.Attributes.ProjectID, watcherID)
+		if errMember != nil {
+			return errMember
+		}
+		if !isMember {
+			return taskerrors.ErrWatcherNotMember
+		}
+	}
+
+	return ts.db.AddTaskWatcher(taskID, watcherID)
+}
+
+func (ts *TaskService) RemoveTaskWatcher(taskID string, userID string, watcherID string) error {
+	if _, err := ts.GetTaskByID(taskID, userID); err != nil {
+		return err
+	}
+
+	if watcherID == "" {
+		watcherID = userID
+	}
+
+	return ts.db.RemoveTaskWatcher(taskID, watcherID)
+}
+
+// SetTaskTags - заменяет теги пользователя на задаче. Все теги должны принадлежать пользователю.
+func (ts *TaskService) SetTaskTags(taskID string, userID string, tagIDs []string) error {
+	if _, err := ts.GetTaskByID(taskID, userID); err != nil {
+		return err
+	}
+
+	slices.Sort(tagIDs)
+	tagIDs = slices.Compact(tagIDs)
+
+	for _, tagID := range tagIDs {
+		if _, err := ts.db.GetTagByID(tagID, userID); err != nil {
+			return err
+		}
+	}
+
+	return ts.db.SetTaskTags(taskID, userID, tagIDs)
+}
+
+// validateMembership - автор должен состоять в проекте задачи, исполнитель - тоже.
+// Задачу без проекта можно назначить только на её владельца.
+func (ts *TaskService) validateMembership(attributes taskmodels.TaskAttributes, ownerID string, actorID string) error {
+	if attributes.ProjectID != "" {
+		isMember, err := ts.db.IsProjectMember(attributes.ProjectID, actorID)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			return projecterrors.ErrNotProjectMember
+		}
+	}
+
+	if attributes.AssigneeID == "" {
+		return nil
+	}
+
+	if attributes.ProjectID == "" {
+		if attributes.AssigneeID != ownerID {
+			return taskerrors.ErrAssigneeNotMember
+		}
+		return nil
+	}
+
+	isMember, err := ts.db.IsProjectMember(attributes.ProjectID, attributes.AssigneeID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return taskerrors.ErrAssigneeNotMember
+	}
+
+	return nil
+}
+
+func (ts *TaskService) recordAssignment(taskID string, assigneeID string, actorID string) error {
+	return ts.db.AddTaskAssignment(taskmodels.Assignment{
+		TaskID:     taskID,
+		AssigneeID: assigneeID,
+		AssignedBy: actorID,
+		AssignedAt: time.Now().UTC(),
+	})
+}
+
 func (ts *TaskService) DeleteTaskByID(taskID string, userID string) error {
 	err := ts.db.DeleteTask(taskID, userID)
 	if err != nil {
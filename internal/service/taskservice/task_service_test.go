@@ -3,9 +3,15 @@ package taskservice
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/tag/tagerrors"
+	"toDoList/internal/domain/tag/tagmodels"
 	"toDoList/internal/domain/task/taskerrors"
 	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowerrors"
+	"toDoList/internal/domain/workflow/workflowmodels"
 	"toDoList/internal/server/mocks"
 	"toDoList/internal/server/workers"
 
@@ -14,6 +20,12 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// categoryOf - категория статуса в workflow по умолчанию.
+func categoryOf(status taskmodels.TaskStatus) taskmodels.StatusCategory {
+	s, _ := workflowmodels.Default().Status(status)
+	return s.Category
+}
+
 func TestGetAllTasks(t *testing.T) {
 	type want struct {
 		tasks []taskmodels.Task
@@ -216,13 +228,14 @@ func TestCreateTask(t *testing.T) {
 			service := NewTaskService(repo, &workers.TaskBatchDeleter{})
 
 			if tt.dbMock {
+				repo.On("GetLastTaskPosition", tt.userID).Return("", nil)
 				repo.On("AddTask", mock.Anything).Return(tt.dbErr)
 			}
 
 			taskID, err := service.CreateTask(tt.attributes, tt.userID)
 
 			if tt.want.err != nil {
-				assert.Equal(t, tt.want.err, err)
+				assert.ErrorIs(t, err, tt.want.err)
 			} else {
 				assert.NotEmpty(t, taskID)
 			}
@@ -240,6 +253,7 @@ func TestUpdateTask(t *testing.T) {
 		taskID        string
 		userID        string
 		newAttributes taskmodels.TaskAttributes
+		version       int64
 		existingTask  taskmodels.Task
 		getTaskErr    error
 		updateTaskErr error
@@ -273,6 +287,23 @@ func TestUpdateTask(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name:   "stale_version",
+			taskID: "1",
+			userID: "user1",
+			newAttributes: taskmodels.TaskAttributes{
+				Status:      taskmodels.StatusNew,
+				Title:       "Updated Title",
+				Description: "Updated Description",
+			},
+			version:      2,
+			existingTask: taskmodels.Task{ID: "1", UserID: "user1", Version: 3},
+			dbMockGet:    true,
+			dbMockUpdate: false,
+			want: want{
+				err: taskerrors.ErrVersionConflict,
+			},
+		},
 		{
 			name:   "invalid_status",
 			taskID: "1",
@@ -282,7 +313,8 @@ func TestUpdateTask(t *testing.T) {
 				Title:       "Title",
 				Description: "Description",
 			},
-			dbMockGet:    false,
+			existingTask: taskmodels.Task{ID: "1", UserID: "user1"},
+			dbMockGet:    true,
 			dbMockUpdate: false,
 			want: want{
 				err: taskerrors.ErrWrongStatus,
@@ -344,9 +376,9 @@ func TestUpdateTask(t *testing.T) {
 				repo.On("UpdateTaskAttributes", mock.Anything).Return(tc.updateTaskErr)
 			}
 
-			err := service.UpdateTask(tc.taskID, tc.userID, tc.newAttributes)
+			err := service.UpdateTask(tc.taskID, tc.userID, tc.newAttributes, tc.version, false)
 
-			assert.Equal(t, tc.want.err, err)
+			assert.ErrorIs(t, err, tc.want.err)
 		})
 	}
 }
@@ -440,3 +472,627 @@ func TestMarkTaskToDeleteByID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     taskmodels.TaskFilter
+		userID     string
+		wantFilter *taskmodels.TaskFilter
+		wantAll    bool
+		wantErr    error
+	}{
+		{
+			name:    "empty filter",
+			userID:  "u1",
+			wantAll: true,
+		},
+		{
+			name:       "assignee me",
+			filter:     taskmodels.TaskFilter{AssigneeID: taskmodels.AssigneeMe},
+			userID:     "u1",
+			wantFilter: &taskmodels.TaskFilter{AssigneeID: "u1"},
+		},
+		{
+			name:   "tags default to any mode",
+			filter: taskmodels.TaskFilter{Tags: []string{"work", "home", "work"}},
+			userID: "u1",
+			wantFilter: &taskmodels.TaskFilter{
+				Tags: []string{"home", "work"}, TagMode: taskmodels.TagModeAny,
+			},
+		},
+		{
+			name:    "wrong tag mode",
+			filter:  taskmodels.TaskFilter{Tags: []string{"work"}, TagMode: "some"},
+			userID:  "u1",
+			wantErr: tagerrors.ErrWrongTagMode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, &workers.TaskBatchDeleter{})
+
+			tasks := []taskmodels.Task{{ID: "1", UserID: "owner"}}
+			if tt.wantAll {
+				repo.On("GetAllTasks", tt.userID).Return(tasks, nil)
+			}
+			if tt.wantFilter != nil {
+				repo.On("FindTasks", tt.userID, *tt.wantFilter).Return(tasks, nil)
+			}
+
+			got, err := service.GetTasks(tt.userID, tt.filter)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tasks, got)
+			}
+		})
+	}
+}
+
+func TestSetTaskTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tagIDs  []string
+		tagErr  error
+		wantSet []string
+		wantErr error
+	}{
+		{
+			name:    "success",
+			tagIDs:  []string{"t2", "t1", "t2"},
+			wantSet: []string{"t1", "t2"},
+		},
+		{
+			name:    "foreign tag",
+			tagIDs:  []string{"t1"},
+			tagErr:  tagerrors.ErrTagNotFound,
+			wantErr: tagerrors.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			repo.On("GetTaskByID", "1", "u1").Return(taskmodels.Task{ID: "1", UserID: "u1"}, nil)
+			repo.On("GetTagByID", mock.Anything, "u1").Return(tagmodels.Tag{}, tt.tagErr)
+			if tt.wantSet != nil {
+				repo.On("SetTaskTags", "1", "u1", tt.wantSet).Return(nil)
+			}
+
+			err := service.SetTaskTags("1", "u1", tt.tagIDs)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestCreateTaskWithAssignee(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		attributes     taskmodels.TaskAttributes
+		actorMember    *bool
+		assigneeMember *bool
+		wantAdd        bool
+		wantErr        error
+	}{
+		{
+			name:   "self assign without project",
+			userID: "u1",
+			attributes: taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: "T", Description: "D", AssigneeID: "u1",
+			},
+			wantAdd: true,
+		},
+		{
+			name:   "assign other without project",
+			userID: "u1",
+			attributes: taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: "T", Description: "D", AssigneeID: "u2",
+			},
+			wantErr: taskerrors.ErrAssigneeNotMember,
+		},
+		{
+			name:   "assign project member",
+			userID: "u1",
+			attributes: taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: "T", Description: "D", ProjectID: "p1", AssigneeID: "u2",
+			},
+			actorMember:    boolPtr(true),
+			assigneeMember: boolPtr(true),
+			wantAdd:        true,
+		},
+		{
+			name:   "assignee outside project",
+			userID: "u1",
+			attributes: taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: "T", Description: "D", ProjectID: "p1", AssigneeID: "u3",
+			},
+			actorMember:    boolPtr(true),
+			assigneeMember: boolPtr(false),
+			wantErr:        taskerrors.ErrAssigneeNotMember,
+		},
+		{
+			name:   "author outside project",
+			userID: "u1",
+			attributes: taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: "T", Description: "D", ProjectID: "p1",
+			},
+			actorMember: boolPtr(false),
+			wantErr:     projecterrors.ErrNotProjectMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, &workers.TaskBatchDeleter{})
+
+			if tt.actorMember != nil {
+				repo.On("IsProjectMember", tt.attributes.ProjectID, tt.userID).Return(*tt.actorMember, nil)
+			}
+			if tt.assigneeMember != nil {
+				repo.On("IsProjectMember", tt.attributes.ProjectID, tt.attributes.AssigneeID).
+					Return(*tt.assigneeMember, nil)
+			}
+			if tt.wantAdd && tt.attributes.ProjectID != "" {
+				repo.On("GetWorkflow", tt.attributes.ProjectID).
+					Return(workflowmodels.Workflow{}, workflowerrors.ErrWorkflowNotFound)
+			}
+			if tt.wantAdd {
+				repo.On("GetLastTaskPosition", tt.userID).Return("", nil)
+				repo.On("AddTask", mock.Anything).Return(nil)
+				repo.On("AddTaskAssignment", mock.MatchedBy(func(a taskmodels.Assignment) bool {
+					return a.AssigneeID == tt.attributes.AssigneeID && a.AssignedBy == tt.userID && a.TaskID != ""
+				})).Return(nil)
+			}
+
+			taskID, err := service.CreateTask(tt.attributes, tt.userID)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, taskID)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskRecordsAssignment(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewTaskService(repo, nil)
+
+	existing := taskmodels.Task{
+		ID:     "1",
+		UserID: "u1",
+		Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "T", Description: "D", ProjectID: "p1", AssigneeID: "u1",
+		},
+	}
+	newAttributes := existing.Attributes
+	newAttributes.AssigneeID = "u2"
+
+	repo.On("GetTaskByID", "1", "u1").Return(existing, nil)
+	repo.On("IsProjectMember", "p1", "u1").Return(true, nil)
+	repo.On("IsProjectMember", "p1", "u2").Return(true, nil)
+	repo.On("GetWorkflow", "p1").Return(workflowmodels.Workflow{}, workflowerrors.ErrWorkflowNotFound)
+	repo.On("UpdateTaskAttributes", mock.Anything).Return(nil)
+	repo.On("AddTaskAssignment", mock.MatchedBy(func(a taskmodels.Assignment) bool {
+		return a.TaskID == "1" && a.AssigneeID == "u2" && a.AssignedBy == "u1"
+	})).Return(nil)
+
+	err := service.UpdateTask("1", "u1", newAttributes, 0, false)
+	assert.NoError(t, err)
+}
+
+func TestAddTaskWatcher(t *testing.T) {
+	tests := []struct {
+		name      string
+		task      taskmodels.Task
+		userID    string
+		watcherID string
+		isMember  *bool
+		wantAdd   string
+		wantErr   error
+	}{
+		{
+			name:    "owner watches own task",
+			task:    taskmodels.Task{ID: "1", UserID: "u1"},
+			userID:  "u1",
+			wantAdd: "u1",
+		},
+		{
+			name:      "project member",
+			task:      taskmodels.Task{ID: "1", UserID: "u1", Attributes: taskmodels.TaskAttributes{ProjectID: "p1"}},
+			userID:    "u1",
+			watcherID: "u2",
+			isMember:  boolPtr(true),
+			wantAdd:   "u2",
+		},
+		{
+			name:      "not a member",
+			task:      taskmodels.Task{ID: "1", UserID: "u1", Attributes: taskmodels.TaskAttributes{ProjectID: "p1"}},
+			userID:    "u1",
+			watcherID: "u3",
+			isMember:  boolPtr(false),
+			wantErr:   taskerrors.ErrWatcherNotMember,
+		},
+		{
+			name:      "task without project",
+			task:      taskmodels.Task{ID: "1", UserID: "u1"},
+			userID:    "u1",
+			watcherID: "u2",
+			wantErr:   taskerrors.ErrWatcherNotMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			repo.On("GetTaskByID", tt.task.ID, tt.userID).Return(tt.task, nil)
+			if tt.isMember != nil {
+				repo.On("IsProjectMember", tt.task.Attributes.ProjectID, tt.watcherID).Return(*tt.isMember, nil)
+			}
+			if tt.wantAdd != "" {
+				repo.On("AddTaskWatcher", tt.task.ID, tt.wantAdd).Return(nil)
+			}
+
+			err := service.AddTaskWatcher(tt.task.ID, tt.userID, tt.watcherID)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestCreateTaskPriority(t *testing.T) {
+	tests := []struct {
+		name         string
+		priority     taskmodels.TaskPriority
+		lastPosition string
+		wantPriority taskmodels.TaskPriority
+		wantErr      error
+	}{
+		{"default none", "", "", taskmodels.PriorityNone, nil},
+		{"urgent appended to the end", taskmodels.PriorityUrgent, "m", taskmodels.PriorityUrgent, nil},
+		{"wrong priority", "asap", "", "", taskerrors.ErrWrongPriority},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			if tt.wantErr == nil {
+				repo.On("GetLastTaskPosition", "u1").Return(tt.lastPosition, nil)
+				repo.On("AddTask", mock.MatchedBy(func(task taskmodels.Task) bool {
+					return task.Attributes.Priority == tt.wantPriority && task.Position > tt.lastPosition
+				})).Return(nil)
+			}
+
+			_, err := service.CreateTask(taskmodels.TaskAttributes{
+				Status: taskmodels.StatusNew, Title: "T", Description: "D", Priority: tt.priority,
+			}, "u1")
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	tasks := []taskmodels.Task{
+		{ID: "1", UserID: "u1", Position: "a"},
+		{ID: "2", UserID: "u1", Position: "b"},
+		{ID: "3", UserID: "u1", Position: "c"},
+	}
+
+	tests := []struct {
+		name      string
+		taskID    string
+		req       taskmodels.MoveTaskRequest
+		wantAfter string
+		wantUpTo  string
+		wantErr   error
+	}{
+		{"between neighbours", "3", taskmodels.MoveTaskRequest{After: "1", Before: "2"}, "a", "b", nil},
+		{"to the top", "3", taskmodels.MoveTaskRequest{Before: "1"}, "", "a", nil},
+		{"after only", "1", taskmodels.MoveTaskRequest{After: "2"}, "b", "c", nil},
+		{"to the bottom", "1", taskmodels.MoveTaskRequest{After: "3"}, "c", "", nil},
+		{"no neighbours", "1", taskmodels.MoveTaskRequest{}, "", "", taskerrors.ErrWrongMove},
+		{"relative to itself", "1", taskmodels.MoveTaskRequest{After: "1"}, "", "", taskerrors.ErrWrongMove},
+		{"wrong order", "1", taskmodels.MoveTaskRequest{After: "3", Before: "2"}, "", "", taskerrors.ErrWrongMove},
+		{"unknown neighbour", "1", taskmodels.MoveTaskRequest{After: "404"}, "", "", taskerrors.ErrFoundNothing},
+		{"unknown task", "404", taskmodels.MoveTaskRequest{After: "1"}, "", "", taskerrors.ErrFoundNothing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			if tt.req.After != "" || tt.req.Before != "" {
+				if tt.req.After != tt.taskID && tt.req.Before != tt.taskID {
+					repo.On("GetAllTasks", "u1").Return(tasks, nil)
+				}
+			}
+			if tt.wantErr == nil {
+				repo.On("UpdateTaskPosition", tt.taskID, "u1", mock.Anything).Return(nil)
+			}
+
+			position, err := service.MoveTask(tt.taskID, "u1", tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			assert.Greater(t, position, tt.wantAfter)
+			if tt.wantUpTo != "" {
+				assert.Less(t, position, tt.wantUpTo)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskParent(t *testing.T) {
+	attrs := func(parentID string) taskmodels.TaskAttributes {
+		return taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "T", Description: "D", Priority: taskmodels.PriorityNone,
+			ParentID: parentID,
+		}
+	}
+	// Цепочка p1 <- p2 <- p3 <- p4, у задачи "1" одна подзадача "1a".
+	chain := map[string]taskmodels.Task{
+		"1":  {ID: "1", UserID: "u1", Attributes: attrs("")},
+		"p1": {ID: "p1", UserID: "u1", Attributes: attrs("")},
+		"p2": {ID: "p2", UserID: "u1", Attributes: attrs("p1")},
+		"p3": {ID: "p3", UserID: "u1", Attributes: attrs("p2")},
+		"p4": {ID: "p4", UserID: "u1", Attributes: attrs("p3")},
+		"1a": {ID: "1a", UserID: "u1", Attributes: attrs("1")},
+		"x":  {ID: "x", UserID: "u1", Attributes: taskmodels.TaskAttributes{ProjectID: "pr1"}},
+		"u2": {ID: "u2", UserID: "u2", Attributes: attrs("")},
+	}
+
+	tests := []struct {
+		name     string
+		parentID string
+		wantErr  error
+	}{
+		{"under root", "p1", nil},
+		{"deepest allowed", "p3", nil},
+		{"too deep", "p4", taskerrors.ErrSubtaskTooDeep},
+		{"itself", "1", taskerrors.ErrSubtaskCycle},
+		{"under own subtask", "1a", taskerrors.ErrSubtaskCycle},
+		{"other project", "x", taskerrors.ErrWrongParent},
+		{"other owner", "u2", taskerrors.ErrWrongParent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			repo.On("GetTaskByID", mock.Anything, "u1").Return(func(taskID string, _ string) (taskmodels.Task, error) {
+				return chain[taskID], nil
+			}).Maybe()
+			repo.On("GetSubtasks", "1").Return([]taskmodels.Task{chain["1a"]}, nil).Maybe()
+			if tt.wantErr == nil {
+				repo.On("UpdateTaskAttributes", mock.Anything).Return(nil)
+			}
+
+			err := service.UpdateTask("1", "u1", attrs(tt.parentID), 0, false)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUpdateTaskAutoCompletesParent(t *testing.T) {
+	parentAttrs := taskmodels.TaskAttributes{
+		Status: taskmodels.StatusInProgress, Title: "P", Description: "D", Priority: taskmodels.PriorityNone,
+		AutoComplete: true,
+	}
+	childAttrs := taskmodels.TaskAttributes{
+		Status: taskmodels.StatusInProgress, Title: "C", Description: "D", Priority: taskmodels.PriorityNone,
+		ParentID: "p",
+	}
+
+	tests := []struct {
+		name          string
+		autoComplete  bool
+		siblingStatus taskmodels.TaskStatus
+		wantCompleted bool
+	}{
+		{"all subtasks done", true, taskmodels.StatusCompleted, true},
+		{"sibling in progress", true, taskmodels.StatusInProgress, false},
+		{"auto complete disabled", false, taskmodels.StatusCompleted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			parent := taskmodels.Task{ID: "p", UserID: "u1", Attributes: parentAttrs}
+			parent.Attributes.AutoComplete = tt.autoComplete
+			child := taskmodels.Task{ID: "c", UserID: "u1", Attributes: childAttrs}
+			sibling := taskmodels.Task{ID: "s", UserID: "u1", Attributes: childAttrs}
+			sibling.Attributes.Status = tt.siblingStatus
+
+			repo.On("GetTaskByID", "c", "u1").Return(child, nil)
+			repo.On("GetTaskByID", "p", "u1").Return(parent, nil)
+			repo.On("UpdateTaskAttributes", mock.MatchedBy(func(task taskmodels.Task) bool {
+				return task.ID == "c"
+			})).Return(nil)
+
+			if tt.autoComplete {
+				doneChild := child
+				doneChild.Attributes.Status = taskmodels.StatusCompleted
+				doneChild.Category = taskmodels.CategoryDone
+				sibling.Category = categoryOf(sibling.Attributes.Status)
+				repo.On("GetSubtasks", "p").Return([]taskmodels.Task{doneChild, sibling}, nil)
+			}
+			if tt.wantCompleted {
+				repo.On("UpdateTaskAttributes", mock.MatchedBy(func(task taskmodels.Task) bool {
+					return task.ID == "p" && task.Attributes.Status == taskmodels.StatusCompleted
+				})).Return(nil)
+			}
+
+			done := childAttrs
+			done.Status = taskmodels.StatusCompleted
+			assert.NoError(t, service.UpdateTask("c", "u1", done, 0, false))
+		})
+	}
+}
+
+func TestGetTaskSubtree(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewTaskService(repo, nil)
+
+	repo.On("GetTaskByID", "1", "u1").Return(taskmodels.Task{ID: "1"}, nil)
+	repo.On("GetSubtasks", "1").Return([]taskmodels.Task{
+		{ID: "3", Attributes: taskmodels.TaskAttributes{ParentID: "2"}},
+		{ID: "2", Attributes: taskmodels.TaskAttributes{ParentID: "1"}},
+		{ID: "4", Attributes: taskmodels.TaskAttributes{ParentID: "1"}},
+	}, nil)
+
+	task, err := service.GetTaskSubtree("1", "u1")
+	assert.NoError(t, err)
+	assert.Len(t, task.Subtasks, 2)
+	assert.Equal(t, "2", task.Subtasks[0].ID)
+	assert.Equal(t, "3", task.Subtasks[0].Subtasks[0].ID)
+	assert.Empty(t, task.Subtasks[1].Subtasks)
+}
+
+func TestChecklist(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewTaskService(repo, nil)
+
+	task := taskmodels.Task{ID: "1", Checklist: []taskmodels.ChecklistItem{{ID: "i1", Title: "step"}}}
+	repo.On("GetTaskByID", "1", "u1").Return(func(string, string) (taskmodels.Task, error) {
+		task.Checklist = slices.Clone(task.Checklist)
+		return task, nil
+	})
+	repo.On("UpdateTaskChecklist", "1", mock.Anything).Return(func(_ string, items []taskmodels.ChecklistItem) error {
+		task.Checklist = items
+		return nil
+	})
+
+	item, err := service.AddChecklistItem("1", "u1", taskmodels.ChecklistItemRequest{Title: "second"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, item.ID)
+	assert.Len(t, task.Checklist, 2)
+
+	_, err = service.AddChecklistItem("1", "u1", taskmodels.ChecklistItemRequest{})
+	assert.Error(t, err)
+
+	done := taskmodels.ChecklistItemRequest{Title: "step", Done: true}
+	assert.NoError(t, service.UpdateChecklistItem("1", "i1", "u1", done))
+	assert.True(t, task.Checklist[0].Done)
+
+	assert.NoError(t, service.DeleteChecklistItem("1", "i1", "u1"))
+	assert.Equal(t, []taskmodels.ChecklistItem{item}, task.Checklist)
+
+	assert.ErrorIs(t, service.DeleteChecklistItem("1", "i1", "u1"), taskerrors.ErrChecklistNotFound)
+	assert.ErrorIs(t, service.UpdateChecklistItem("1", "i404", "u1", taskmodels.ChecklistItemRequest{Title: "x"}),
+		taskerrors.ErrChecklistNotFound)
+}
+
+func TestUpdateTaskBlocked(t *testing.T) {
+	blocked := taskmodels.Task{
+		ID: "1", UserID: "u1", BlockedBy: []string{"2", "3"},
+		Attributes: taskmodels.TaskAttributes{
+			Status: taskmodels.StatusNew, Title: "T", Description: "D", Priority: taskmodels.PriorityNone,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		status     taskmodels.TaskStatus
+		force      bool
+		unfinished []string
+		wantErr    error
+	}{
+		{"start with unfinished blockers", taskmodels.StatusInProgress, false, []string{"3"},
+			taskerrors.ErrTaskBlocked},
+		{"finish with unfinished blockers", taskmodels.StatusCompleted, false, []string{"2"},
+			taskerrors.ErrTaskBlocked},
+		{"all blockers done", taskmodels.StatusInProgress, false, nil, nil},
+		{"forced", taskmodels.StatusCompleted, true, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			repo.On("GetTaskByID", "1", "u1").Return(blocked, nil)
+			if !tt.force {
+				repo.On("GetUnfinishedBlockers", "1").Return(tt.unfinished, nil)
+			}
+			if tt.wantErr == nil {
+				repo.On("UpdateTaskAttributes", mock.Anything).Return(nil)
+			}
+
+			attributes := blocked.Attributes
+			attributes.Status = tt.status
+			err := service.UpdateTask("1", "u1", attributes, 0, tt.force)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAddTaskDependency(t *testing.T) {
+	tests := []struct {
+		name      string
+		blockerID string
+		cycle     bool
+		wantErr   error
+	}{
+		{"success", "2", false, nil},
+		{"self", "1", false, taskerrors.ErrDependencyCycle},
+		{"cycle", "2", true, taskerrors.ErrDependencyCycle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			if tt.blockerID != "1" {
+				repo.On("GetTaskByID", mock.Anything, "u1").Return(taskmodels.Task{}, nil)
+				repo.On("HasDependencyPath", tt.blockerID, "1").Return(tt.cycle, nil)
+			}
+			if tt.wantErr == nil {
+				repo.On("AddTaskDependency", "1", tt.blockerID).Return(nil)
+			}
+
+			err := service.AddTaskDependency("1", tt.blockerID, "u1")
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGetTasksInDependencyOrder(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewTaskService(repo, nil)
+
+	// Ручной порядок a, b, c, d; c блокирует a, d блокирует c, внешний x не влияет.
+	repo.On("GetAllTasks", "u1").Return([]taskmodels.Task{
+		{ID: "a", BlockedBy: []string{"c", "x"}},
+		{ID: "b"},
+		{ID: "c", BlockedBy: []string{"d"}, Blocking: []string{"a"}},
+		{ID: "d", Blocking: []string{"c"}},
+	}, nil)
+
+	tasks, err := service.GetTasksInDependencyOrder("u1")
+	assert.NoError(t, err)
+
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []string{"b", "d", "c", "a"}, ids)
+}
@@ -0,0 +1,148 @@
+package taskservice
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+
+	"github.com/google/uuid"
+)
+
+// instantiation - общие для всех задач дерева параметры создания по шаблону.
+type instantiation struct {
+	userID    string
+	projectID string
+	status    taskmodels.TaskStatus
+	start     time.Time
+	variables map[string]string
+	// tags - ID тегов пользователя по названию.
+	tags map[string]string
+}
+
+// InstantiateTemplate - дерево задач по шаблону, видимому пользователю, одной транзакцией через runInTx:
+// если не удалось создать хотя бы одну задачу, не создаётся ни одна. Задачи получают начальный статус
+// workflow проекта, плейсхолдеры заполняются из req.Variables, а сроки отсчитываются от req.Start.
+// Теги шаблона, которых у пользователя нет, пропускаются. Возвращает корневую задачу с подзадачами.
+func (ts *TaskService) InstantiateTemplate(templateID string, userID string, req templatemodels.InstantiateRequest,
+	runInTx TxRunner,
+) (taskmodels.Task, error) {
+	template, err := ts.db.GetTemplateByID(templateID, userID)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	var missing []string
+	for _, name := range template.Variables {
+		if _, ok := req.Variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		return taskmodels.Task{}, fmt.Errorf("%w: %s", templateerrors.ErrMissingTemplateVariable,
+			strings.Join(missing, ", "))
+	}
+
+	params := instantiation{
+		userID:    userID,
+		projectID: req.ProjectID,
+		start:     time.Now().UTC(),
+		variables: req.Variables,
+		tags:      make(map[string]string),
+	}
+	if params.projectID == "" {
+		params.projectID = template.ProjectID
+	}
+	if req.Start != nil {
+		params.start = req.Start.UTC()
+	}
+
+	tags, err := ts.db.GetTagsByUser(userID)
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+	for _, tag := range tags {
+		params.tags[tag.Name] = tag.ID
+	}
+
+	var rootID string
+	err = runInTx(func(tx TaskStorage) error {
+		service := NewTaskService(tx, nil)
+
+		workflow, errTx := service.workflow(params.projectID)
+		if errTx != nil {
+			return errTx
+		}
+		params.status = workflow.Statuses[0].Name
+
+		rootID, errTx = service.createFromTemplate(template.Task, "", params)
+		return errTx
+	})
+	if err != nil {
+		return taskmodels.Task{}, err
+	}
+
+	return ts.GetTaskSubtree(rootID, userID)
+}
+
+// createFromTemplate - задача шаблона с подзадачами через CreateTask, со всеми его проверками.
+func (ts *TaskService) createFromTemplate(task templatemodels.TemplateTask, parentID string, params instantiation,
+) (string, error) {
+	attributes := taskmodels.TaskAttributes{
+		Status:       params.status,
+		Title:        fillPlaceholders(task.Title, params.variables),
+		Description:  fillPlaceholders(task.Description, params.variables),
+		ProjectID:    params.projectID,
+		Priority:     task.Priority,
+		ParentID:     parentID,
+		AutoComplete: task.AutoComplete,
+		Estimate:     task.Estimate,
+	}
+	if task.DueOffset != nil {
+		dueDate := params.start.Add(time.Duration(*task.DueOffset) * time.Minute)
+		attributes.DueDate = &dueDate
+	}
+
+	taskID, err := ts.CreateTask(attributes, params.userID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(task.Checklist) != 0 {
+		checklist := make([]taskmodels.ChecklistItem, 0, len(task.Checklist))
+		for _, title := range task.Checklist {
+			checklist = append(checklist, taskmodels.ChecklistItem{ID: uuid.New().String(), Title: title})
+		}
+		if err = ts.db.UpdateTaskChecklist(taskID, checklist); err != nil {
+			return "", err
+		}
+	}
+
+	var tagIDs []string
+	for _, name := range task.Tags {
+		if tagID, ok := params.tags[name]; ok {
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+	if len(tagIDs) != 0 {
+		if err = ts.db.SetTaskTags(taskID, params.userID, tagIDs); err != nil {
+			return "", err
+		}
+	}
+
+	for _, subtask := range task.Subtasks {
+		if _, err = ts.createFromTemplate(subtask, taskID, params); err != nil {
+			return "", err
+		}
+	}
+	return taskID, nil
+}
+
+// fillPlaceholders - подстановка значений переменных, подставленные значения повторно не разбираются.
+func fillPlaceholders(text string, variables map[string]string) string {
+	return templatemodels.Placeholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return variables[templatemodels.Placeholder.FindStringSubmatch(placeholder)[1]]
+	})
+}
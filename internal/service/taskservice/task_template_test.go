@@ -0,0 +1,102 @@
+package taskservice
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/tag/tagmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+	"toDoList/internal/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstantiateTemplate(t *testing.T) {
+	week := 7 * 24 * 60
+	twoWeeks := 2 * week
+
+	storage := inmemory.NewInMemoryStorage()
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend"}))
+	require.NoError(t, storage.AddTag(tagmodels.Tag{ID: "tag1", UserID: "u1", Name: "release", Color: "#ff0000"}))
+	require.NoError(t, storage.AddTemplate(templatemodels.Template{
+		ID:        "tpl1",
+		UserID:    "u1",
+		ProjectID: "p1",
+		Name:      "Release",
+		Task: templatemodels.TemplateTask{
+			Title:       "Release {{version}}",
+			Description: "Ship {{ version }} to {{env}}",
+			Priority:    taskmodels.PriorityHigh,
+			DueOffset:   &twoWeeks,
+			Checklist:   []string{"Tag", "Deploy"},
+			Tags:        []string{"release", "hotfix"},
+			Subtasks: []templatemodels.TemplateTask{
+				{Title: "Changelog for {{version}}", Description: "Write it", DueOffset: &week},
+				{Title: "Announce", Description: "{{notes}}"},
+			},
+		},
+		Variables: []string{"env", "notes", "version"},
+	}))
+
+	service := NewTaskService(storage, nil)
+	runInTx := func(fn func(tx TaskStorage) error) error {
+		return storage.InTx(func(tx *inmemory.Storage) error { return fn(tx) })
+	}
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	_, err := service.InstantiateTemplate("tpl1", "u1", templatemodels.InstantiateRequest{
+		Variables: map[string]string{"version": "1.4"},
+	}, runInTx)
+	require.ErrorIs(t, err, templateerrors.ErrMissingTemplateVariable)
+	assert.ErrorContains(t, err, "env, notes")
+
+	// Пустое описание последней подзадачи откатывает всё дерево.
+	_, err = service.InstantiateTemplate("tpl1", "u1", templatemodels.InstantiateRequest{
+		Variables: map[string]string{"version": "1.4", "env": "prod", "notes": ""},
+		Start:     &start,
+	}, runInTx)
+	require.Error(t, err)
+	tasks, err := storage.FindTasks("u1", taskmodels.TaskFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	task, err := service.InstantiateTemplate("tpl1", "u1", templatemodels.InstantiateRequest{
+		Variables: map[string]string{"version": "1.4", "env": "prod", "notes": "{{version}}"},
+		Start:     &start,
+	}, runInTx)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Release 1.4", task.Attributes.Title)
+	assert.Equal(t, "Ship 1.4 to prod", task.Attributes.Description)
+	assert.Equal(t, taskmodels.StatusNew, task.Attributes.Status)
+	assert.Equal(t, "p1", task.Attributes.ProjectID)
+	assert.Equal(t, taskmodels.PriorityHigh, task.Attributes.Priority)
+	assert.Equal(t, start.AddDate(0, 0, 14), *task.Attributes.DueDate)
+	require.Len(t, task.Checklist, 2)
+	assert.Equal(t, "Deploy", task.Checklist[1].Title)
+	assert.False(t, task.Checklist[1].Done)
+	require.Len(t, task.Tags, 1)
+	assert.Equal(t, "tag1", task.Tags[0].ID)
+
+	require.Len(t, task.Subtasks, 2)
+	assert.Equal(t, "Changelog for 1.4", task.Subtasks[0].Attributes.Title)
+	assert.Equal(t, task.ID, task.Subtasks[0].Attributes.ParentID)
+	assert.Equal(t, start.AddDate(0, 0, 7), *task.Subtasks[0].Attributes.DueDate)
+	// Подставленные значения повторно не разбираются.
+	assert.Equal(t, "{{version}}", task.Subtasks[1].Attributes.Description)
+	assert.Nil(t, task.Subtasks[1].Attributes.DueDate)
+
+	// Шаблон проекта виден участникам, но создавать задачи в проекте можно только участнику.
+	_, err = service.InstantiateTemplate("tpl1", "u2", templatemodels.InstantiateRequest{}, runInTx)
+	assert.ErrorIs(t, err, templateerrors.ErrTemplateNotFound)
+	require.NoError(t, storage.AddProject(projectmodels.Project{ID: "p2", OwnerID: "u2", Name: "Other"}))
+	_, err = service.InstantiateTemplate("tpl1", "u1", templatemodels.InstantiateRequest{
+		Variables: map[string]string{"version": "1.5", "env": "prod", "notes": "-"},
+		ProjectID: "p2",
+	}, runInTx)
+	assert.ErrorIs(t, err, projecterrors.ErrNotProjectMember)
+}
@@ -0,0 +1,61 @@
+package taskservice
+
+import (
+	"errors"
+	"fmt"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowerrors"
+	"toDoList/internal/domain/workflow/workflowmodels"
+)
+
+// workflow - workflow проекта. У задач без проекта и у проектов, где workflow не настраивали,
+// действует workflow по умолчанию.
+func (ts *TaskService) workflow(projectID string) (workflowmodels.Workflow, error) {
+	if projectID == "" {
+		return workflowmodels.Default(), nil
+	}
+
+	workflow, err := ts.db.GetWorkflow(projectID)
+	if errors.Is(err, workflowerrors.ErrWorkflowNotFound) {
+		return workflowmodels.Default(), nil
+	}
+	return workflow, err
+}
+
+// workflowStatus - статус из workflow, ErrWrongStatus, если в workflow такого статуса нет.
+func workflowStatus(workflow workflowmodels.Workflow, name taskmodels.TaskStatus) (workflowmodels.Status, error) {
+	status, ok := workflow.Status(name)
+	if !ok {
+		return workflowmodels.Status{}, fmt.Errorf("%w: %q is not in the project workflow",
+			taskerrors.ErrWrongStatus, name)
+	}
+	return status, nil
+}
+
+// checkTransition - переход между статусами проверяется, только если задача осталась в том же проекте:
+// при переносе в другой проект статус лишь должен быть в его workflow.
+func checkTransition(workflow workflowmodels.Workflow, old taskmodels.TaskAttributes,
+	updated taskmodels.TaskAttributes,
+) error {
+	if old.ProjectID != updated.ProjectID || workflow.CanTransition(old.Status, updated.Status) {
+		return nil
+	}
+	return fmt.Errorf("%w: %q -> %q", taskerrors.ErrTransitionDenied, old.Status, updated.Status)
+}
+
+// checkWIPLimit - в статус с WIP-лимитом нельзя добавить задачу, если лимит исчерпан.
+func (ts *TaskService) checkWIPLimit(projectID string, status workflowmodels.Status) error {
+	if status.WIPLimit == 0 || projectID == "" {
+		return nil
+	}
+
+	count, err := ts.db.CountTasksInStatus(projectID, status.Name)
+	if err != nil {
+		return err
+	}
+	if count >= status.WIPLimit {
+		return fmt.Errorf("%w: %q allows %d tasks", taskerrors.ErrWIPLimitReached, status.Name, status.WIPLimit)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package taskservice
+
+import (
+	"testing"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateTaskWorkflow(t *testing.T) {
+	workflow := workflowmodels.Workflow{
+		ProjectID: "p1",
+		Statuses: []workflowmodels.Status{
+			{Name: "Open", Category: taskmodels.CategoryTodo},
+			{Name: "In Review", Category: taskmodels.CategoryDoing},
+			{Name: "Shipped", Category: taskmodels.CategoryDone},
+		},
+		Transitions: []workflowmodels.Transition{
+			{From: "Open", To: "In Review"},
+			{From: "In Review", To: "Shipped"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		status       taskmodels.TaskStatus
+		wantCategory taskmodels.StatusCategory
+		wantErr      error
+	}{
+		{name: "allowed", status: "In Review", wantCategory: taskmodels.CategoryDoing},
+		{name: "same status", status: "Open", wantCategory: taskmodels.CategoryTodo},
+		{name: "denied", status: "Shipped", wantErr: taskerrors.ErrTransitionDenied},
+		{name: "unknown status", status: taskmodels.StatusCompleted, wantErr: taskerrors.ErrWrongStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewTaskService(repo, nil)
+
+			existing := taskmodels.Task{
+				ID:     "1",
+				UserID: "u1",
+				Attributes: taskmodels.TaskAttributes{
+					Status: "Open", Title: "T", Description: "D", Priority: taskmodels.PriorityNone, ProjectID: "p1",
+				},
+				Category: taskmodels.CategoryTodo,
+			}
+			attributes := existing.Attributes
+			attributes.Status = tt.status
+			attributes.Title = "Renamed"
+
+			repo.On("GetTaskByID", "1", "u1").Return(existing, nil)
+			repo.On("GetWorkflow", "p1").Return(workflow, nil)
+			if tt.wantErr == nil {
+				repo.On("UpdateTaskAttributes", mock.MatchedBy(func(task taskmodels.Task) bool {
+					return task.Attributes.Status == tt.status && task.Category == tt.wantCategory
+				})).Return(nil)
+			}
+
+			assert.ErrorIs(t, service.UpdateTask("1", "u1", attributes, 0, false), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package templateservice
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// TemplateStorage - GetTemplateByID возвращает только шаблоны, видимые пользователю, а DeleteTemplate
+// удаляет только шаблоны автора.
+type TemplateStorage interface {
+	GetTaskByID(taskID string, userID string) (taskmodels.Task, error)
+	GetSubtasks(taskID string) ([]taskmodels.Task, error)
+	IsProjectMember(projectID string, userID string) (bool, error)
+	AddTemplate(template templatemodels.Template) error
+	GetTemplatesByUser(userID string) ([]templatemodels.Template, error)
+	GetTemplateByID(templateID string, userID string) (templatemodels.Template, error)
+	DeleteTemplate(templateID string, userID string) error
+}
+
+type TemplateService struct {
+	db    TemplateStorage
+	valid *validator.Validate
+	now   func() time.Time
+}
+
+func NewTemplateService(db TemplateStorage) *TemplateService {
+	return &TemplateService{db: db, valid: validator.New(), now: time.Now}
+}
+
+func (ts *TemplateService) GetTemplates(userID string) ([]templatemodels.Template, error) {
+	templates, err := ts.db.GetTemplatesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if templates == nil {
+		templates = []templatemodels.Template{}
+	}
+	return templates, nil
+}
+
+func (ts *TemplateService) GetTemplate(templateID string, userID string) (templatemodels.Template, error) {
+	return ts.db.GetTemplateByID(templateID, userID)
+}
+
+// CreateTemplate - шаблон из видимой пользователю задачи со всеми подзадачами, пунктами чеклиста и тегами
+// пользователя. Делиться шаблоном можно только с проектом, в котором пользователь состоит.
+func (ts *TemplateService) CreateTemplate(userID string, req templatemodels.TemplateRequest) (
+	templatemodels.Template, error,
+) {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := ts.valid.Struct(req); err != nil {
+		return templatemodels.Template{}, err
+	}
+
+	if req.ProjectID != "" {
+		isMember, err := ts.db.IsProjectMember(req.ProjectID, userID)
+		if err != nil {
+			return templatemodels.Template{}, err
+		}
+		if !isMember {
+			return templatemodels.Template{}, projecterrors.ErrNotProjectMember
+		}
+	}
+
+	task, err := ts.db.GetTaskByID(req.TaskID, userID)
+	if err != nil {
+		return templatemodels.Template{}, err
+	}
+
+	subtasks, err := ts.db.GetSubtasks(task.ID)
+	if err != nil {
+		return templatemodels.Template{}, err
+	}
+	if len(subtasks)+1 > templatemodels.MaxTemplateTasks {
+		return templatemodels.Template{}, fmt.Errorf("%w: template can contain up to %d tasks",
+			templateerrors.ErrWrongTemplate, templatemodels.MaxTemplateTasks)
+	}
+
+	children := make(map[string][]taskmodels.Task)
+	for _, subtask := range subtasks {
+		children[subtask.Attributes.ParentID] = append(children[subtask.Attributes.ParentID], subtask)
+	}
+
+	now := ts.now().UTC()
+	start := now
+	if req.Start != nil {
+		start = *req.Start
+	}
+
+	root := templateTask(task, children, userID, start)
+	template := templatemodels.Template{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		ProjectID: req.ProjectID,
+		Name:      req.Name,
+		Task:      root,
+		Variables: root.Variables(),
+		CreatedAt: now,
+	}
+
+	if err = ts.db.AddTemplate(template); err != nil {
+		return templatemodels.Template{}, err
+	}
+	return template, nil
+}
+
+// templateTask - задача шаблона с подзадачами. Срок сохраняется смещением от start, теги - названиями:
+// в шаблон попадают только теги пользователя.
+func templateTask(task taskmodels.Task, children map[string][]taskmodels.Task, userID string,
+	start time.Time,
+) templatemodels.TemplateTask {
+	result := templatemodels.TemplateTask{
+		Title:        task.Attributes.Title,
+		Description:  task.Attributes.Description,
+		Priority:     task.Attributes.Priority,
+		AutoComplete: task.Attributes.AutoComplete,
+		Estimate:     task.Attributes.Estimate,
+	}
+
+	if task.Attributes.DueDate != nil {
+		offset := int(task.Attributes.DueDate.Sub(start) / time.Minute)
+		result.DueOffset = &offset
+	}
+	for _, item := range task.Checklist {
+		result.Checklist = append(result.Checklist, item.Title)
+	}
+	for _, tag := range task.Tags {
+		if tag.UserID == userID {
+			result.Tags = append(result.Tags, tag.Name)
+		}
+	}
+	for _, child := range children[task.ID] {
+		result.Subtasks = append(result.Subtasks, templateTask(child, children, userID, start))
+	}
+	return result
+}
+
+// DeleteTemplate - удалить шаблон может только его автор, участникам проекта он лишь виден.
+func (ts *TemplateService) DeleteTemplate(templateID string, userID string) error {
+	template, err := ts.db.GetTemplateByID(templateID, userID)
+	if err != nil {
+		return err
+	}
+	if template.UserID != userID {
+		return templateerrors.ErrNotTemplateOwner
+	}
+
+	return ts.db.DeleteTemplate(templateID, userID)
+}
@@ -0,0 +1,93 @@
+package templateservice
+
+import (
+	"testing"
+	"time"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/tag/tagmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/template/templateerrors"
+	"toDoList/internal/domain/template/templatemodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTemplate(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := now.Add(14 * 24 * time.Hour)
+	subtaskDue := now.Add(-time.Hour)
+
+	root := taskmodels.Task{
+		ID:     "t1",
+		UserID: "u1",
+		Attributes: taskmodels.TaskAttributes{
+			Title: "Release {{version}}", Description: "Ship {{ version }}", DueDate: &due,
+			Priority: taskmodels.PriorityHigh,
+		},
+		Checklist: []taskmodels.ChecklistItem{{ID: "c1", Title: "Tag", Done: true}},
+		Tags: []tagmodels.Tag{
+			{ID: "tag1", UserID: "u1", Name: "release"},
+			{ID: "tag2", UserID: "u2", Name: "theirs"},
+		},
+	}
+	subtasks := []taskmodels.Task{
+		{ID: "t2", Attributes: taskmodels.TaskAttributes{
+			Title: "Changelog", Description: "For {{env}}", ParentID: "t1", DueDate: &subtaskDue,
+		}},
+		{ID: "t3", Attributes: taskmodels.TaskAttributes{Title: "Notes", Description: "D", ParentID: "t2"}},
+	}
+
+	repo := mocks.NewStorage(t)
+	service := NewTemplateService(repo)
+	service.now = func() time.Time { return now }
+
+	repo.On("IsProjectMember", "p1", "u1").Return(true, nil)
+	repo.On("IsProjectMember", "p2", "u1").Return(false, nil)
+	repo.On("GetTaskByID", "t1", "u1").Return(root, nil)
+	repo.On("GetSubtasks", "t1").Return(subtasks, nil)
+	repo.On("AddTemplate", mock.Anything).Return(nil).Once()
+	repo.On("AddTemplate", mock.Anything).Return(templateerrors.ErrTemplateIsAlreadyExist).Once()
+
+	req := templatemodels.TemplateRequest{TaskID: "t1", Name: " Release ", ProjectID: "p1"}
+	template, err := service.CreateTemplate("u1", req)
+	require.NoError(t, err)
+
+	twoWeeks, minusHour := 14*24*60, -60
+	assert.Equal(t, "Release", template.Name)
+	assert.Equal(t, "p1", template.ProjectID)
+	assert.Equal(t, []string{"env", "version"}, template.Variables)
+	assert.Equal(t, templatemodels.TemplateTask{
+		Title:       "Release {{version}}",
+		Description: "Ship {{ version }}",
+		Priority:    taskmodels.PriorityHigh,
+		DueOffset:   &twoWeeks,
+		Checklist:   []string{"Tag"},
+		Tags:        []string{"release"},
+		Subtasks: []templatemodels.TemplateTask{{
+			Title: "Changelog", Description: "For {{env}}", DueOffset: &minusHour,
+			Subtasks: []templatemodels.TemplateTask{{Title: "Notes", Description: "D"}},
+		}},
+	}, template.Task)
+
+	_, err = service.CreateTemplate("u1", req)
+	assert.ErrorIs(t, err, templateerrors.ErrTemplateIsAlreadyExist)
+
+	req.ProjectID = "p2"
+	_, err = service.CreateTemplate("u1", req)
+	assert.ErrorIs(t, err, projecterrors.ErrNotProjectMember)
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewTemplateService(repo)
+
+	template := templatemodels.Template{ID: "tpl1", UserID: "u1", ProjectID: "p1"}
+	repo.On("GetTemplateByID", "tpl1", mock.Anything).Return(template, nil)
+	repo.On("DeleteTemplate", "tpl1", "u1").Return(nil)
+
+	assert.ErrorIs(t, service.DeleteTemplate("tpl1", "u2"), templateerrors.ErrNotTemplateOwner)
+	assert.NoError(t, service.DeleteTemplate("tpl1", "u1"))
+}
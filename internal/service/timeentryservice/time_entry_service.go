@@ -0,0 +1,318 @@
+package timeentryservice
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/timeentry/timeentryerrors"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// TimeEntryStorage - AddTimeEntry не даёт запустить второй таймер пользователя, а UpdateTimeEntry
+// не меняет запущенные таймеры.
+type TimeEntryStorage interface {
+	GetTaskByID(taskID string, userID string) (taskmodels.Task, error)
+	GetProjectByID(projectID string) (projectmodels.Project, error)
+	AddTimeEntry(entry timeentrymodels.TimeEntry) error
+	GetRunningTimer(userID string) (timeentrymodels.TimeEntry, error)
+	StopTimer(userID string, taskID string, endedAt time.Time) (timeentrymodels.TimeEntry, error)
+	GetTaskTimeEntries(taskID string) ([]timeentrymodels.TimeEntry, error)
+	GetTimeEntryByID(entryID string, taskID string) (timeentrymodels.TimeEntry, error)
+	UpdateTimeEntry(entry timeentrymodels.TimeEntry) error
+	DeleteTimeEntry(entryID string, taskID string) error
+	GetReportEntries(userID string, from time.Time, to time.Time) ([]timeentrymodels.ReportEntry, error)
+}
+
+type TimeEntryService struct {
+	db    TimeEntryStorage
+	valid *validator.Validate
+	now   func() time.Time
+}
+
+func NewTimeEntryService(db TimeEntryStorage) *TimeEntryService {
+	return &TimeEntryService{db: db, valid: validator.New(), now: time.Now}
+}
+
+// StartTimer - таймер на задаче, видимой пользователю. Пока запущен другой таймер пользователя,
+// возвращается ErrTimerAlreadyRunning.
+func (es *TimeEntryService) StartTimer(taskID string, userID string, req timeentrymodels.TimerRequest) (
+	timeentrymodels.TimeEntry, error,
+) {
+	if err := validateNote(req.Note); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+
+	if _, err := es.db.GetTaskByID(taskID, userID); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+
+	entry := timeentrymodels.TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: es.now().UTC(),
+		Note:      req.Note,
+	}
+
+	if err := es.db.AddTimeEntry(entry); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// StopTimer - остановить свой таймер можно, даже если задача больше не видна.
+func (es *TimeEntryService) StopTimer(taskID string, userID string) (timeentrymodels.TimeEntry, error) {
+	return es.db.StopTimer(userID, taskID, es.now().UTC())
+}
+
+func (es *TimeEntryService) GetRunningTimer(userID string) (timeentrymodels.TimeEntry, error) {
+	return es.db.GetRunningTimer(userID)
+}
+
+// GetTimeEntries - записи всех пользователей по задаче, видимой пользователю, по времени начала.
+func (es *TimeEntryService) GetTimeEntries(taskID string, userID string) ([]timeentrymodels.TimeEntry, error) {
+	if _, err := es.db.GetTaskByID(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	entries, err := es.db.GetTaskTimeEntries(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []timeentrymodels.TimeEntry{}
+	}
+	return entries, nil
+}
+
+// AddTimeEntry - ручная запись уже потраченного времени.
+func (es *TimeEntryService) AddTimeEntry(taskID string, userID string, req timeentrymodels.TimeEntryRequest) (
+	timeentrymodels.TimeEntry, error,
+) {
+	if err := es.validateEntry(req); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+
+	if _, err := es.db.GetTaskByID(taskID, userID); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+
+	endedAt := req.EndedAt.UTC()
+	entry := timeentrymodels.TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: req.StartedAt.UTC(),
+		EndedAt:   &endedAt,
+		Note:      req.Note,
+	}
+
+	if err := es.db.AddTimeEntry(entry); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// UpdateTimeEntry - менять запись может только её автор, запущенный таймер сначала нужно остановить.
+func (es *TimeEntryService) UpdateTimeEntry(
+	taskID string,
+	entryID string,
+	userID string,
+	req timeentrymodels.TimeEntryRequest,
+) (timeentrymodels.TimeEntry, error) {
+	if err := es.validateEntry(req); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+
+	entry, err := es.getOwnEntry(taskID, entryID, userID)
+	if err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+	if entry.IsRunning() {
+		return timeentrymodels.TimeEntry{}, timeentryerrors.ErrTimeEntryIsRunning
+	}
+
+	endedAt := req.EndedAt.UTC()
+	entry.StartedAt = req.StartedAt.UTC()
+	entry.EndedAt = &endedAt
+	entry.Note = req.Note
+
+	if err = es.db.UpdateTimeEntry(entry); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// DeleteTimeEntry - удалить можно и запущенный таймер, тогда время не учитывается.
+func (es *TimeEntryService) DeleteTimeEntry(taskID string, entryID string, userID string) error {
+	if _, err := es.getOwnEntry(taskID, entryID, userID); err != nil {
+		return err
+	}
+
+	return es.db.DeleteTimeEntry(entryID, taskID)
+}
+
+func (es *TimeEntryService) getOwnEntry(taskID string, entryID string, userID string) (
+	timeentrymodels.TimeEntry, error,
+) {
+	if _, err := es.db.GetTaskByID(taskID, userID); err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+
+	entry, err := es.db.GetTimeEntryByID(entryID, taskID)
+	if err != nil {
+		return timeentrymodels.TimeEntry{}, err
+	}
+	if entry.UserID != userID {
+		return timeentrymodels.TimeEntry{}, timeentryerrors.ErrNotTimeEntryOwner
+	}
+	return entry, nil
+}
+
+func (es *TimeEntryService) validateEntry(req timeentrymodels.TimeEntryRequest) error {
+	if err := es.valid.Struct(req); err != nil {
+		return err
+	}
+	if err := validateNote(req.Note); err != nil {
+		return err
+	}
+
+	duration := req.EndedAt.Sub(req.StartedAt)
+	switch {
+	case duration <= 0:
+		return fmt.Errorf("%w: ended_at must be after started_at", timeentryerrors.ErrWrongTimeEntry)
+	case duration > timeentrymodels.MaxEntryDuration:
+		return fmt.Errorf("%w: entry is longer than %s", timeentryerrors.ErrWrongTimeEntry,
+			timeentrymodels.MaxEntryDuration)
+	case req.EndedAt.After(es.now()):
+		return fmt.Errorf("%w: entry ends in the future", timeentryerrors.ErrWrongTimeEntry)
+	}
+	return nil
+}
+
+func validateNote(note string) error {
+	if utf8.RuneCountInString(note) > timeentrymodels.MaxNoteLength {
+		return fmt.Errorf("%w: note is longer than %d characters", timeentryerrors.ErrWrongTimeEntry,
+			timeentrymodels.MaxNoteLength)
+	}
+	return nil
+}
+
+// GetReport - суммарное время по записям, начатым в период запроса, по задачам, видимым пользователю.
+// Запущенные таймеры учитываются до текущего момента. Дни считаются в UTC, без group_by записи
+// группируются по проектам.
+func (es *TimeEntryService) GetReport(userID string, query timeentrymodels.ReportQuery) (
+	timeentrymodels.Report, error,
+) {
+	if query.GroupBy == "" {
+		query.GroupBy = timeentrymodels.GroupByProject
+	}
+	if !query.GroupBy.IsValid() {
+		return timeentrymodels.Report{}, fmt.Errorf("%w: unknown group_by %q",
+			timeentryerrors.ErrWrongTimeReportQuery, query.GroupBy)
+	}
+
+	from, err := time.Parse(timeentrymodels.ReportDateLayout, query.From)
+	if err != nil {
+		return timeentrymodels.Report{}, fmt.Errorf("%w: from must be a date in YYYY-MM-DD format",
+			timeentryerrors.ErrWrongTimeReportQuery)
+	}
+	to, err := time.Parse(timeentrymodels.ReportDateLayout, query.To)
+	if err != nil {
+		return timeentrymodels.Report{}, fmt.Errorf("%w: to must be a date in YYYY-MM-DD format",
+			timeentryerrors.ErrWrongTimeReportQuery)
+	}
+
+	// to включительно: в отчёт попадают записи, начатые до конца этого дня.
+	to = to.AddDate(0, 0, 1)
+	switch {
+	case !from.Before(to):
+		return timeentrymodels.Report{}, fmt.Errorf("%w: from is after to", timeentryerrors.ErrWrongTimeReportQuery)
+	case to.Sub(from) > timeentrymodels.MaxReportDays*24*time.Hour:
+		return timeentrymodels.Report{}, fmt.Errorf("%w: period is longer than %d days",
+			timeentryerrors.ErrWrongTimeReportQuery, timeentrymodels.MaxReportDays)
+	}
+
+	entries, err := es.db.GetReportEntries(userID, from, to)
+	if err != nil {
+		return timeentrymodels.Report{}, err
+	}
+
+	report := timeentrymodels.Report{
+		From:    query.From,
+		To:      query.To,
+		GroupBy: query.GroupBy,
+		Rows:    []timeentrymodels.ReportRow{},
+	}
+
+	now := es.now().UTC()
+	rows := make(map[string]int)
+	for _, entry := range entries {
+		row := reportRow(entry, query.GroupBy)
+
+		i, ok := rows[row.Key]
+		if !ok {
+			i = len(report.Rows)
+			rows[row.Key] = i
+			report.Rows = append(report.Rows, row)
+		}
+
+		seconds := int64(entry.Duration(now) / time.Second)
+		report.Rows[i].Seconds += seconds
+		report.TotalSeconds += seconds
+	}
+
+	if query.GroupBy == timeentrymodels.GroupByProject {
+		if err = es.setProjectTitles(report.Rows); err != nil {
+			return timeentrymodels.Report{}, err
+		}
+	}
+
+	slices.SortFunc(report.Rows, func(a, b timeentrymodels.ReportRow) int {
+		if c := strings.Compare(a.Title, b.Title); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	return report, nil
+}
+
+// reportRow - строка отчёта, в которую попадает запись.
+func reportRow(entry timeentrymodels.ReportEntry, groupBy timeentrymodels.GroupBy) timeentrymodels.ReportRow {
+	switch groupBy {
+	case timeentrymodels.GroupByTask:
+		return timeentrymodels.ReportRow{Key: entry.TaskID, Title: entry.TaskTitle, Estimate: entry.TaskEstimate}
+	case timeentrymodels.GroupByDay:
+		return timeentrymodels.ReportRow{Key: entry.StartedAt.UTC().Format(timeentrymodels.ReportDateLayout)}
+	default:
+		return timeentrymodels.ReportRow{Key: entry.ProjectID}
+	}
+}
+
+// setProjectTitles - названия проектов в строках отчёта. Строка задач без проекта остаётся без названия.
+func (es *TimeEntryService) setProjectTitles(rows []timeentrymodels.ReportRow) error {
+	for i := range rows {
+		if rows[i].Key == "" {
+			continue
+		}
+
+		project, err := es.db.GetProjectByID(rows[i].Key)
+		if err != nil {
+			if errors.Is(err, projecterrors.ErrProjectNotFound) {
+				continue
+			}
+			return err
+		}
+		rows[i].Title = project.Name
+	}
+	return nil
+}
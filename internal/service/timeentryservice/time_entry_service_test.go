@@ -0,0 +1,211 @@
+package timeentryservice
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskerrors"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/timeentry/timeentryerrors"
+	"toDoList/internal/domain/timeentry/timeentrymodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+func newService(repo *mocks.Storage) *TimeEntryService {
+	service := NewTimeEntryService(repo)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestStartTimer(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := newService(repo)
+
+	repo.On("GetTaskByID", "t1", "u1").Return(taskmodels.Task{ID: "t1"}, nil)
+	repo.On("GetTaskByID", "t2", "u1").Return(taskmodels.Task{}, taskerrors.ErrFoundNothing)
+	repo.On("AddTimeEntry", mock.MatchedBy(func(entry timeentrymodels.TimeEntry) bool {
+		return entry.TaskID == "t1" && entry.UserID == "u1" && entry.StartedAt.Equal(now) && entry.IsRunning()
+	})).Return(nil).Once()
+	repo.On("AddTimeEntry", mock.Anything).Return(timeentryerrors.ErrTimerAlreadyRunning).Once()
+
+	entry, err := service.StartTimer("t1", "u1", timeentrymodels.TimerRequest{Note: "review"})
+	require.NoError(t, err)
+	assert.Equal(t, "review", entry.Note)
+	assert.NotEmpty(t, entry.ID)
+
+	_, err = service.StartTimer("t1", "u1", timeentrymodels.TimerRequest{})
+	assert.ErrorIs(t, err, timeentryerrors.ErrTimerAlreadyRunning)
+
+	_, err = service.StartTimer("t2", "u1", timeentrymodels.TimerRequest{})
+	assert.ErrorIs(t, err, taskerrors.ErrFoundNothing)
+
+	long := timeentrymodels.TimerRequest{Note: strings.Repeat("ы", timeentrymodels.MaxNoteLength+1)}
+	_, err = service.StartTimer("t1", "u1", long)
+	assert.ErrorIs(t, err, timeentryerrors.ErrWrongTimeEntry)
+}
+
+func TestAddTimeEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     timeentrymodels.TimeEntryRequest
+		wantErr error
+	}{
+		{
+			name: "added",
+			req:  timeentrymodels.TimeEntryRequest{StartedAt: now.Add(-2 * time.Hour), EndedAt: now.Add(-time.Hour)},
+		},
+		{
+			name:    "ends before start",
+			req:     timeentrymodels.TimeEntryRequest{StartedAt: now.Add(-time.Hour), EndedAt: now.Add(-2 * time.Hour)},
+			wantErr: timeentryerrors.ErrWrongTimeEntry,
+		},
+		{
+			name:    "too long",
+			req:     timeentrymodels.TimeEntryRequest{StartedAt: now.Add(-25 * time.Hour), EndedAt: now},
+			wantErr: timeentryerrors.ErrWrongTimeEntry,
+		},
+		{
+			name:    "in the future",
+			req:     timeentrymodels.TimeEntryRequest{StartedAt: now, EndedAt: now.Add(time.Hour)},
+			wantErr: timeentryerrors.ErrWrongTimeEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := newService(repo)
+
+			if tt.wantErr == nil {
+				repo.On("GetTaskByID", "t1", "u1").Return(taskmodels.Task{ID: "t1"}, nil)
+				repo.On("AddTimeEntry", mock.Anything).Return(nil)
+			}
+
+			entry, err := service.AddTimeEntry("t1", "u1", tt.req)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.req.EndedAt, *entry.EndedAt)
+			}
+		})
+	}
+}
+
+func TestUpdateTimeEntry(t *testing.T) {
+	ended := now.Add(-time.Hour)
+	stopped := timeentrymodels.TimeEntry{ID: "e1", TaskID: "t1", UserID: "u1", StartedAt: now.Add(-2 * time.Hour),
+		EndedAt: &ended}
+	running := timeentrymodels.TimeEntry{ID: "e2", TaskID: "t1", UserID: "u1", StartedAt: now.Add(-time.Hour)}
+	req := timeentrymodels.TimeEntryRequest{StartedAt: now.Add(-3 * time.Hour), EndedAt: ended, Note: "fixed"}
+
+	repo := mocks.NewStorage(t)
+	service := newService(repo)
+
+	repo.On("GetTaskByID", "t1", mock.Anything).Return(taskmodels.Task{ID: "t1"}, nil)
+	repo.On("GetTimeEntryByID", "e1", "t1").Return(stopped, nil)
+	repo.On("GetTimeEntryByID", "e2", "t1").Return(running, nil)
+	repo.On("UpdateTimeEntry", mock.MatchedBy(func(entry timeentrymodels.TimeEntry) bool {
+		return entry.ID == "e1" && entry.Note == "fixed" && entry.StartedAt.Equal(req.StartedAt)
+	})).Return(nil).Once()
+
+	entry, err := service.UpdateTimeEntry("t1", "e1", "u1", req)
+	require.NoError(t, err)
+	assert.Equal(t, "fixed", entry.Note)
+
+	_, err = service.UpdateTimeEntry("t1", "e1", "u2", req)
+	assert.ErrorIs(t, err, timeentryerrors.ErrNotTimeEntryOwner)
+
+	_, err = service.UpdateTimeEntry("t1", "e2", "u1", req)
+	assert.ErrorIs(t, err, timeentryerrors.ErrTimeEntryIsRunning)
+
+	assert.ErrorIs(t, service.DeleteTimeEntry("t1", "e1", "u2"), timeentryerrors.ErrNotTimeEntryOwner)
+}
+
+func TestGetReport(t *testing.T) {
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	entry := func(id string, taskID string, start time.Time, minutes int) timeentrymodels.TimeEntry {
+		e := timeentrymodels.TimeEntry{ID: id, TaskID: taskID, UserID: "u1", StartedAt: start}
+		if minutes > 0 {
+			ended := start.Add(time.Duration(minutes) * time.Minute)
+			e.EndedAt = &ended
+		}
+		return e
+	}
+	entries := []timeentrymodels.ReportEntry{
+		{TimeEntry: entry("e1", "t1", day.AddDate(0, 0, -1).Add(9*time.Hour), 30), ProjectID: "p1", TaskTitle: "Deploy",
+			TaskEstimate: 60},
+		{TimeEntry: entry("e2", "t2", day.Add(9*time.Hour), 15), TaskTitle: "Inbox"},
+		// Запущенный таймер учитывается до текущего момента: 12:00 - 11:00.
+		{TimeEntry: entry("e3", "t1", day.Add(11*time.Hour), 0), ProjectID: "p1", TaskTitle: "Deploy",
+			TaskEstimate: 60},
+	}
+
+	tests := []struct {
+		name    string
+		groupBy timeentrymodels.GroupBy
+		want    []timeentrymodels.ReportRow
+	}{
+		{
+			name: "by project",
+			want: []timeentrymodels.ReportRow{
+				{Key: "", Seconds: 15 * 60},
+				{Key: "p1", Title: "Backend", Seconds: 90 * 60},
+			},
+		},
+		{
+			name:    "by task",
+			groupBy: timeentrymodels.GroupByTask,
+			want: []timeentrymodels.ReportRow{
+				{Key: "t1", Title: "Deploy", Seconds: 90 * 60, Estimate: 60},
+				{Key: "t2", Title: "Inbox", Seconds: 15 * 60},
+			},
+		},
+		{
+			name:    "by day",
+			groupBy: timeentrymodels.GroupByDay,
+			want: []timeentrymodels.ReportRow{
+				{Key: "2026-01-04", Seconds: 30 * 60},
+				{Key: "2026-01-05", Seconds: 75 * 60},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := newService(repo)
+
+			repo.On("GetReportEntries", "u1", day.AddDate(0, 0, -1), day.AddDate(0, 0, 1)).Return(entries, nil)
+			if tt.groupBy == "" {
+				repo.On("GetProjectByID", "p1").Return(projectmodels.Project{ID: "p1", Name: "Backend"}, nil)
+			}
+
+			report, err := service.GetReport("u1", timeentrymodels.ReportQuery{
+				From: "2026-01-04", To: "2026-01-05", GroupBy: tt.groupBy,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, report.Rows)
+			assert.Equal(t, int64(105*60), report.TotalSeconds)
+		})
+	}
+}
+
+func TestGetReportWrongQuery(t *testing.T) {
+	service := newService(mocks.NewStorage(t))
+
+	for _, query := range []timeentrymodels.ReportQuery{
+		{From: "2026-01-05", To: "2026-01-04"},
+		{From: "2025-01-01", To: "2026-01-05"},
+		{From: "05.01.2026", To: "2026-01-05"},
+		{From: "2026-01-04", To: "2026-01-05", GroupBy: "user"},
+	} {
+		_, err := service.GetReport("u1", query)
+		assert.ErrorIs(t, err, timeentryerrors.ErrWrongTimeReportQuery, query)
+	}
+}
@@ -0,0 +1,180 @@
+package webhookservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"time"
+	"toDoList/internal"
+	"toDoList/internal/domain/webhook/webhookerrors"
+	"toDoList/internal/domain/webhook/webhookmodels"
+	"toDoList/internal/server/workers"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// DeliveryLogLimit - сколько последних доставок отдаёт журнал.
+const DeliveryLogLimit = 100
+
+type WebhookStorage interface {
+	AddWebhook(webhook webhookmodels.Webhook) error
+	GetWebhooksByUser(userID string) ([]webhookmodels.Webhook, error)
+	GetWebhookByID(webhookID string, userID string) (webhookmodels.Webhook, error)
+	DeleteWebhook(webhookID string, userID string) error
+	GetWebhookDeliveries(webhookID string, limit int) ([]webhookmodels.Delivery, error)
+	GetWebhookDelivery(deliveryID string, webhookID string) (webhookmodels.Delivery, error)
+	UpdateWebhookDelivery(delivery webhookmodels.Delivery) error
+}
+
+type WebhookService struct {
+	db         WebhookStorage
+	valid      *validator.Validate
+	dispatcher *workers.WebhookDispatcher
+	lookupIP   func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+func NewWebhookService(db WebhookStorage, dispatcher *workers.WebhookDispatcher) *WebhookService {
+	return &WebhookService{
+		db:         db,
+		valid:      validator.New(),
+		dispatcher: dispatcher,
+		lookupIP:   net.DefaultResolver.LookupIPAddr,
+	}
+}
+
+// CreateWebhook - регистрирует вебхук. Если секрет не задан, он генерируется;
+// секрет возвращается только в ответе на создание.
+func (ws *WebhookService) CreateWebhook(userID string, req webhookmodels.WebhookRequest) (
+	webhookmodels.Webhook, error,
+) {
+	if err := ws.valid.Struct(req); err != nil {
+		return webhookmodels.Webhook{}, err
+	}
+
+	target, err := url.Parse(req.URL)
+	if err != nil || target.Scheme != "http" && target.Scheme != "https" || target.Host == "" {
+		return webhookmodels.Webhook{}, webhookerrors.ErrWrongWebhookURL
+	}
+	if err = ws.checkHost(target.Hostname()); err != nil {
+		return webhookmodels.Webhook{}, err
+	}
+
+	for _, event := range req.Events {
+		if !event.IsValid() {
+			return webhookmodels.Webhook{}, webhookerrors.ErrWrongEventType
+		}
+	}
+
+	events := slices.Clone(req.Events)
+	slices.Sort(events)
+
+	secret := req.Secret
+	if secret == "" {
+		secret, err = newSecret()
+		if err != nil {
+			return webhookmodels.Webhook{}, err
+		}
+	}
+
+	webhook := webhookmodels.Webhook{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    slices.Compact(events),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err = ws.db.AddWebhook(webhook); err != nil {
+		return webhookmodels.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+// checkHost - все адреса хоста вебхука должны быть публичными. Диспетчер проверяет адрес ещё раз
+// при подключении: DNS может начать отвечать иначе уже после регистрации.
+func (ws *WebhookService) checkHost(host string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.SecFive)
+	defer cancel()
+
+	addrs, err := ws.lookupIP(ctx, host)
+	if err != nil {
+		return fmt.Errorf("%w: %v", webhookerrors.ErrWrongWebhookURL, err)
+	}
+	for _, addr := range addrs {
+		if !webhookmodels.PublicIP(addr.IP) {
+			return webhookerrors.ErrWebhookAddressNotAllowed
+		}
+	}
+	return nil
+}
+
+func (ws *WebhookService) GetWebhooks(userID string) ([]webhookmodels.Webhook, error) {
+	webhooks, err := ws.db.GetWebhooksByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (ws *WebhookService) DeleteWebhook(webhookID string, userID string) error {
+	return ws.db.DeleteWebhook(webhookID, userID)
+}
+
+func (ws *WebhookService) GetDeliveries(webhookID string, userID string) ([]webhookmodels.Delivery, error) {
+	if _, err := ws.db.GetWebhookByID(webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	return ws.db.GetWebhookDeliveries(webhookID, DeliveryLogLimit)
+}
+
+// Redeliver - возвращает dead доставку в очередь с новым счётчиком попыток.
+func (ws *WebhookService) Redeliver(webhookID string, deliveryID string, userID string) (
+	webhookmodels.Delivery, error,
+) {
+	if _, err := ws.db.GetWebhookByID(webhookID, userID); err != nil {
+		return webhookmodels.Delivery{}, err
+	}
+
+	delivery, err := ws.db.GetWebhookDelivery(deliveryID, webhookID)
+	if err != nil {
+		return webhookmodels.Delivery{}, err
+	}
+
+	if delivery.Status != webhookmodels.DeliveryDead {
+		return webhookmodels.Delivery{}, webhookerrors.ErrDeliveryNotDead
+	}
+
+	delivery.Status = webhookmodels.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now().UTC()
+
+	if err = ws.db.UpdateWebhookDelivery(delivery); err != nil {
+		return webhookmodels.Delivery{}, err
+	}
+
+	if ws.dispatcher != nil {
+		ws.dispatcher.Notify()
+	}
+
+	return delivery, nil
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
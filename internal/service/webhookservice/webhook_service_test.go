@@ -0,0 +1,129 @@
+package webhookservice
+
+import (
+	"context"
+	"net"
+	"testing"
+	"toDoList/internal/domain/webhook/webhookerrors"
+	"toDoList/internal/domain/webhook/webhookmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// lookupIP - DNS для тестов: адреса литералов возвращаются как есть, имена разрешаются по таблице.
+func lookupIP(_ context.Context, host string) ([]net.IPAddr, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IPAddr{{IP: ip}}, nil
+	}
+
+	hosts := map[string][]string{
+		"ci.example.com":    {"93.184.216.34"},
+		"bot.local":         {"2606:2800:220:1:248:1893:25c8:1946"},
+		"localhost":         {"127.0.0.1", "::1"},
+		"mixed.example.com": {"93.184.216.34", "192.168.1.10"},
+	}
+	addrs, ok := hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	var result []net.IPAddr
+	for _, addr := range addrs {
+		result = append(result, net.IPAddr{IP: net.ParseIP(addr)})
+	}
+	return result, nil
+}
+
+func TestCreateWebhook(t *testing.T) {
+	created := []webhookmodels.EventType{webhookmodels.EventTaskCreated}
+
+	tests := []struct {
+		name    string
+		req     webhookmodels.WebhookRequest
+		wantErr error
+	}{
+		{"success", webhookmodels.WebhookRequest{URL: "https://ci.example.com/hook", Events: created}, nil},
+		{"custom secret", webhookmodels.WebhookRequest{
+			URL: "http://bot.local:8080/events", Events: created, Secret: "0123456789abcdef",
+		}, nil},
+		{"wrong scheme", webhookmodels.WebhookRequest{URL: "ftp://example.com", Events: created},
+			webhookerrors.ErrWrongWebhookURL},
+		{"unknown event", webhookmodels.WebhookRequest{
+			URL: "https://ci.example.com/hook", Events: []webhookmodels.EventType{"user.created"},
+		}, webhookerrors.ErrWrongEventType},
+		{"loopback", webhookmodels.WebhookRequest{URL: "http://localhost:8080/hook", Events: created},
+			webhookerrors.ErrWebhookAddressNotAllowed},
+		{"private address", webhookmodels.WebhookRequest{URL: "http://10.0.0.5/hook", Events: created},
+			webhookerrors.ErrWebhookAddressNotAllowed},
+		{"link-local address", webhookmodels.WebhookRequest{URL: "http://169.254.169.254/latest", Events: created},
+			webhookerrors.ErrWebhookAddressNotAllowed},
+		{"one of addresses is private", webhookmodels.WebhookRequest{URL: "https://mixed.example.com", Events: created},
+			webhookerrors.ErrWebhookAddressNotAllowed},
+		{"unresolvable host", webhookmodels.WebhookRequest{URL: "https://missing.example.com", Events: created},
+			webhookerrors.ErrWrongWebhookURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewWebhookService(repo, nil)
+			service.lookupIP = lookupIP
+
+			if tt.wantErr == nil {
+				repo.On("AddWebhook", mock.MatchedBy(func(w webhookmodels.Webhook) bool {
+					return w.UserID == "u1" && w.URL == tt.req.URL && len(w.Secret) >= 16
+				})).Return(nil)
+			}
+
+			webhook, err := service.CreateWebhook("u1", tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil && tt.req.Secret != "" {
+				assert.Equal(t, tt.req.Secret, webhook.Secret)
+			}
+		})
+	}
+}
+
+func TestGetWebhooksHidesSecret(t *testing.T) {
+	repo := mocks.NewStorage(t)
+	service := NewWebhookService(repo, nil)
+
+	repo.On("GetWebhooksByUser", "u1").Return([]webhookmodels.Webhook{{ID: "w1", Secret: "secret"}}, nil)
+
+	webhooks, err := service.GetWebhooks("u1")
+	assert.NoError(t, err)
+	assert.Empty(t, webhooks[0].Secret)
+}
+
+func TestRedeliver(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  webhookmodels.DeliveryStatus
+		wantErr error
+	}{
+		{"dead", webhookmodels.DeliveryDead, nil},
+		{"pending", webhookmodels.DeliveryPending, webhookerrors.ErrDeliveryNotDead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewWebhookService(repo, nil)
+
+			repo.On("GetWebhookByID", "w1", "u1").Return(webhookmodels.Webhook{ID: "w1"}, nil)
+			repo.On("GetWebhookDelivery", "d1", "w1").Return(webhookmodels.Delivery{
+				ID: "d1", WebhookID: "w1", Status: tt.status, Attempts: webhookmodels.MaxDeliveryAttempts,
+			}, nil)
+			if tt.wantErr == nil {
+				repo.On("UpdateWebhookDelivery", mock.MatchedBy(func(d webhookmodels.Delivery) bool {
+					return d.Status == webhookmodels.DeliveryPending && d.Attempts == 0
+				})).Return(nil)
+			}
+
+			_, err := service.Redeliver("w1", "d1", "u1")
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
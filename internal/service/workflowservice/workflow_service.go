@@ -0,0 +1,144 @@
+package workflowservice
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowerrors"
+	"toDoList/internal/domain/workflow/workflowmodels"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// WorkflowStorage - SaveWorkflow отклоняет workflow без статусов, которые ещё есть у задач проекта,
+// и пересчитывает категории статусов задач.
+type WorkflowStorage interface {
+	GetProjectByID(projectID string) (projectmodels.Project, error)
+	GetWorkflow(projectID string) (workflowmodels.Workflow, error)
+	SaveWorkflow(workflow workflowmodels.Workflow) error
+}
+
+type WorkflowService struct {
+	db    WorkflowStorage
+	valid *validator.Validate
+	now   func() time.Time
+}
+
+func NewWorkflowService(db WorkflowStorage) *WorkflowService {
+	return &WorkflowService{db: db, valid: validator.New(), now: time.Now}
+}
+
+// GetWorkflow - workflow доступен участникам проекта. Пока его не настраивали, действует
+// workflowmodels.Default.
+func (ws *WorkflowService) GetWorkflow(projectID string, userID string) (workflowmodels.Workflow, error) {
+	if _, err := ws.project(projectID, userID); err != nil {
+		return workflowmodels.Workflow{}, err
+	}
+
+	workflow, err := ws.db.GetWorkflow(projectID)
+	if errors.Is(err, workflowerrors.ErrWorkflowNotFound) {
+		workflow, err = workflowmodels.Default(), nil
+		workflow.ProjectID = projectID
+	}
+	return workflow, err
+}
+
+// UpdateWorkflow - менять workflow может только владелец проекта.
+func (ws *WorkflowService) UpdateWorkflow(
+	projectID string,
+	userID string,
+	req workflowmodels.WorkflowRequest,
+) (workflowmodels.Workflow, error) {
+	if err := ws.valid.Struct(req); err != nil {
+		return workflowmodels.Workflow{}, err
+	}
+
+	project, err := ws.project(projectID, userID)
+	if err != nil {
+		return workflowmodels.Workflow{}, err
+	}
+	if project.OwnerID != userID {
+		return workflowmodels.Workflow{}, projecterrors.ErrNotProjectOwner
+	}
+
+	workflow, err := newWorkflow(req)
+	if err != nil {
+		return workflowmodels.Workflow{}, err
+	}
+
+	now := ws.now().UTC()
+	workflow.ProjectID = projectID
+	workflow.UpdatedAt = &now
+
+	if err = ws.db.SaveWorkflow(workflow); err != nil {
+		return workflowmodels.Workflow{}, err
+	}
+	return workflow, nil
+}
+
+func (ws *WorkflowService) project(projectID string, userID string) (projectmodels.Project, error) {
+	project, err := ws.db.GetProjectByID(projectID)
+	if err != nil {
+		return projectmodels.Project{}, err
+	}
+
+	if !slices.Contains(project.Members, userID) {
+		return projectmodels.Project{}, projecterrors.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// newWorkflow - проверяет статусы и переходы запроса. Повторяющиеся переходы схлопываются,
+// переход в тот же статус не нужен: оставить задачу в её статусе можно всегда.
+func newWorkflow(req workflowmodels.WorkflowRequest) (workflowmodels.Workflow, error) {
+	if len(req.Statuses) > workflowmodels.MaxStatuses {
+		return workflowmodels.Workflow{}, fmt.Errorf("%w: more than %d statuses",
+			workflowerrors.ErrWrongWorkflow, workflowmodels.MaxStatuses)
+	}
+
+	var workflow workflowmodels.Workflow
+	for _, status := range req.Statuses {
+		status.Name = taskmodels.TaskStatus(strings.TrimSpace(string(status.Name)))
+
+		switch {
+		case status.Name == "":
+			return workflowmodels.Workflow{}, fmt.Errorf("%w: status name is empty", workflowerrors.ErrWrongWorkflow)
+		case utf8.RuneCountInString(string(status.Name)) > workflowmodels.MaxStatusNameLength:
+			return workflowmodels.Workflow{}, fmt.Errorf("%w: status %q is longer than %d characters",
+				workflowerrors.ErrWrongWorkflow, status.Name, workflowmodels.MaxStatusNameLength)
+		case !status.Category.IsValid():
+			return workflowmodels.Workflow{}, fmt.Errorf("%w: status %q has unknown category %q",
+				workflowerrors.ErrWrongWorkflow, status.Name, status.Category)
+		case status.WIPLimit < 0:
+			return workflowmodels.Workflow{}, fmt.Errorf("%w: status %q has negative WIP limit",
+				workflowerrors.ErrWrongWorkflow, status.Name)
+		}
+
+		if _, ok := workflow.Status(status.Name); ok {
+			return workflowmodels.Workflow{}, fmt.Errorf("%w: duplicate status %q",
+				workflowerrors.ErrWrongWorkflow, status.Name)
+		}
+		workflow.Statuses = append(workflow.Statuses, status)
+	}
+
+	workflow.Transitions = []workflowmodels.Transition{}
+	for _, transition := range req.Transitions {
+		for _, name := range []taskmodels.TaskStatus{transition.From, transition.To} {
+			if _, ok := workflow.Status(name); !ok {
+				return workflowmodels.Workflow{}, fmt.Errorf("%w: transition %q -> %q uses unknown status %q",
+					workflowerrors.ErrWrongWorkflow, transition.From, transition.To, name)
+			}
+		}
+
+		if transition.From != transition.To && !slices.Contains(workflow.Transitions, transition) {
+			workflow.Transitions = append(workflow.Transitions, transition)
+		}
+	}
+	return workflow, nil
+}
@@ -0,0 +1,179 @@
+package workflowservice
+
+import (
+	"strings"
+	"testing"
+	"toDoList/internal/domain/project/projecterrors"
+	"toDoList/internal/domain/project/projectmodels"
+	"toDoList/internal/domain/task/taskmodels"
+	"toDoList/internal/domain/workflow/workflowerrors"
+	"toDoList/internal/domain/workflow/workflowmodels"
+	"toDoList/internal/server/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var project = projectmodels.Project{ID: "p1", OwnerID: "u1", Name: "Backend", Members: []string{"u1", "u2"}}
+
+func TestGetWorkflow(t *testing.T) {
+	saved := workflowmodels.Workflow{
+		ProjectID: "p1",
+		Statuses:  []workflowmodels.Status{{Name: "Open", Category: taskmodels.CategoryTodo}},
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		dbMock  bool
+		dbErr   error
+		want    workflowmodels.Workflow
+		wantErr error
+	}{
+		{name: "saved", userID: "u2", dbMock: true, want: saved},
+		{
+			name:   "default",
+			userID: "u2",
+			dbMock: true,
+			dbErr:  workflowerrors.ErrWorkflowNotFound,
+			want: func() workflowmodels.Workflow {
+				w := workflowmodels.Default()
+				w.ProjectID = "p1"
+				return w
+			}(),
+		},
+		{name: "not a member", userID: "u3", wantErr: projecterrors.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewWorkflowService(repo)
+
+			repo.On("GetProjectByID", "p1").Return(project, nil)
+			if tt.dbMock {
+				repo.On("GetWorkflow", "p1").Return(saved, tt.dbErr)
+			}
+
+			workflow, err := service.GetWorkflow("p1", tt.userID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, workflow)
+			}
+		})
+	}
+}
+
+func TestUpdateWorkflow(t *testing.T) {
+	statuses := []workflowmodels.Status{
+		{Name: "Open", Category: taskmodels.CategoryTodo},
+		{Name: " In Review ", Category: taskmodels.CategoryDoing},
+		{Name: "Closed", Category: taskmodels.CategoryDone},
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		req     workflowmodels.WorkflowRequest
+		dbMock  bool
+		wantErr error
+	}{
+		{
+			name:   "success",
+			userID: "u1",
+			req: workflowmodels.WorkflowRequest{
+				Statuses: statuses,
+				Transitions: []workflowmodels.Transition{
+					{From: "Open", To: "In Review"},
+					{From: "Open", To: "In Review"},
+					{From: "In Review", To: "In Review"},
+					{From: "In Review", To: "Closed"},
+				},
+			},
+			dbMock: true,
+		},
+		{
+			name:    "not owner",
+			userID:  "u2",
+			req:     workflowmodels.WorkflowRequest{Statuses: statuses},
+			wantErr: projecterrors.ErrNotProjectOwner,
+		},
+		{
+			name:   "duplicate status",
+			userID: "u1",
+			req: workflowmodels.WorkflowRequest{Statuses: []workflowmodels.Status{
+				{Name: "Open", Category: taskmodels.CategoryTodo},
+				{Name: "Open ", Category: taskmodels.CategoryDone},
+			}},
+			wantErr: workflowerrors.ErrWrongWorkflow,
+		},
+		{
+			name:   "unknown category",
+			userID: "u1",
+			req: workflowmodels.WorkflowRequest{Statuses: []workflowmodels.Status{
+				{Name: "Open", Category: "later"},
+			}},
+			wantErr: workflowerrors.ErrWrongWorkflow,
+		},
+		{
+			name:   "negative WIP limit",
+			userID: "u1",
+			req: workflowmodels.WorkflowRequest{Statuses: []workflowmodels.Status{
+				{Name: "Open", Category: taskmodels.CategoryTodo, WIPLimit: -1},
+			}},
+			wantErr: workflowerrors.ErrWrongWorkflow,
+		},
+		{
+			name:   "long name",
+			userID: "u1",
+			req: workflowmodels.WorkflowRequest{Statuses: []workflowmodels.Status{
+				{Name: taskmodels.TaskStatus(strings.Repeat("ы", 51)), Category: taskmodels.CategoryTodo},
+			}},
+			wantErr: workflowerrors.ErrWrongWorkflow,
+		},
+		{
+			name:   "transition to unknown status",
+			userID: "u1",
+			req: workflowmodels.WorkflowRequest{
+				Statuses:    statuses,
+				Transitions: []workflowmodels.Transition{{From: "Open", To: "Done"}},
+			},
+			wantErr: workflowerrors.ErrWrongWorkflow,
+		},
+		{
+			name:    "status in use",
+			userID:  "u1",
+			req:     workflowmodels.WorkflowRequest{Statuses: statuses},
+			dbMock:  true,
+			wantErr: workflowerrors.ErrStatusInUse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewStorage(t)
+			service := NewWorkflowService(repo)
+
+			repo.On("GetProjectByID", "p1").Return(project, nil)
+			if tt.dbMock {
+				repo.On("SaveWorkflow", mock.MatchedBy(func(w workflowmodels.Workflow) bool {
+					return w.ProjectID == "p1" && w.UpdatedAt != nil && len(w.Statuses) == 3
+				})).Return(tt.wantErr)
+			}
+
+			workflow, err := service.UpdateWorkflow("p1", tt.userID, tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			require.Len(t, workflow.Statuses, 3)
+			assert.Equal(t, taskmodels.TaskStatus("In Review"), workflow.Statuses[1].Name)
+			assert.Equal(t, []workflowmodels.Transition{
+				{From: "Open", To: "In Review"},
+				{From: "In Review", To: "Closed"},
+			}, workflow.Transitions)
+		})
+	}
+}
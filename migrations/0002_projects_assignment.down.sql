@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS task_assignments;
+
+DROP TABLE IF EXISTS task_watchers;
+
+DROP INDEX IF EXISTS tasks_projectid_idx;
+DROP INDEX IF EXISTS tasks_assigneeid_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS assigneeid;
+ALTER TABLE tasks DROP COLUMN IF EXISTS projectid;
+
+DROP TABLE IF EXISTS project_members;
+
+DROP TABLE IF EXISTS projects;
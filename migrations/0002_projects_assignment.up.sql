@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    ownerid varchar(36) NOT NULL,
+    name text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS project_members (
+    projectid varchar(36) NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    userid varchar(36) NOT NULL,
+    PRIMARY KEY (projectid, userid)
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS projectid varchar(36) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assigneeid varchar(36) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS tasks_assigneeid_idx ON tasks (assigneeid);
+CREATE INDEX IF NOT EXISTS tasks_projectid_idx ON tasks (projectid);
+
+CREATE TABLE IF NOT EXISTS task_watchers (
+    taskid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    userid varchar(36) NOT NULL,
+    PRIMARY KEY (taskid, userid)
+);
+
+CREATE TABLE IF NOT EXISTS task_assignments (
+    id bigserial PRIMARY KEY,
+    taskid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    assigneeid varchar(36) NOT NULL,
+    assignedby varchar(36) NOT NULL,
+    assignedat timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    userid varchar(36) NOT NULL,
+    name text NOT NULL,
+    color varchar(7) NOT NULL,
+    UNIQUE (userid, name)
+);
+
+CREATE TABLE IF NOT EXISTS task_tags (
+    taskid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    tagid varchar(36) NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (taskid, tagid)
+);
+
+CREATE INDEX IF NOT EXISTS task_tags_tagid_idx ON task_tags (tagid);
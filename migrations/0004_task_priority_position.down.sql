@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS tasks_userid_position_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS position;
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
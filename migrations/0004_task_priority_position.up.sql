@@ -0,0 +1,10 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS priority text NOT NULL DEFAULT 'none';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS position text NOT NULL DEFAULT '';
+
+-- Ранги существующих задач: фиксированная ширина и суффикс 'i', чтобы ранг не заканчивался на '0'.
+UPDATE tasks t
+SET position = 'a' || lpad(r.rn::text, 10, '0') || 'i'
+FROM (SELECT id, row_number() OVER (PARTITION BY userid ORDER BY id) AS rn FROM tasks) r
+WHERE t.id = r.id AND t.position = '';
+
+CREATE INDEX IF NOT EXISTS tasks_userid_position_idx ON tasks (userid, position COLLATE "C");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tasks_parentid_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS checklist;
+ALTER TABLE tasks DROP COLUMN IF EXISTS autocomplete;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parentid;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    taskid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    blockerid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    PRIMARY KEY (taskid, blockerid),
+    CHECK (taskid <> blockerid)
+);
+
+CREATE INDEX IF NOT EXISTS task_dependencies_blockerid_idx ON task_dependencies (blockerid);
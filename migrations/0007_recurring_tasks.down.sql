@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS tasks_seriesid_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS occurrence;
+ALTER TABLE tasks DROP COLUMN IF EXISTS seriesid;
+ALTER TABLE tasks DROP COLUMN IF EXISTS rrule;
+ALTER TABLE tasks DROP COLUMN IF EXISTS dueat;
@@ -0,0 +1,6 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS dueat timestamptz NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS rrule text NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS seriesid varchar(36) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS occurrence integer NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS tasks_seriesid_idx ON tasks (seriesid) WHERE seriesid <> '';
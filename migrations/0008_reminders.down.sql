@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tasks_dueat_idx;
+DROP TABLE IF EXISTS reminders;
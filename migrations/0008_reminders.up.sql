@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS reminders (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    taskid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    userid varchar(36) NOT NULL,
+    remindat timestamptz NULL,
+    beforeminutes integer NULL,
+    status text NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    sentat timestamptz NULL,
+    CHECK ((remindat IS NULL) <> (beforeminutes IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS reminders_taskid_idx ON reminders (taskid);
+CREATE INDEX IF NOT EXISTS reminders_pending_idx ON reminders (taskid) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS tasks_dueat_idx ON tasks (dueat) WHERE dueat IS NOT NULL;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    userid varchar(36) NOT NULL,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text[] NOT NULL,
+    createdat timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhooks_userid_idx ON webhooks (userid);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    webhookid varchar(36) NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    eventid varchar(36) NOT NULL,
+    eventtype text NOT NULL,
+    payload jsonb NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    nextattemptat timestamptz NOT NULL,
+    lasterror text NOT NULL DEFAULT '',
+    responsecode integer NOT NULL DEFAULT 0,
+    createdat timestamptz NOT NULL,
+    deliveredat timestamptz NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhookid_idx ON webhook_deliveries (webhookid, createdat);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (nextattemptat) WHERE status = 'pending';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    seq bigserial PRIMARY KEY,
+    id varchar(36) NOT NULL UNIQUE,
+    aggregatetype text NOT NULL,
+    aggregateid varchar(36) NOT NULL,
+    eventtype text NOT NULL,
+    userid varchar(36) NOT NULL,
+    payload jsonb NOT NULL,
+    occurredat timestamptz NOT NULL,
+    publishedat timestamptz NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    lasterror text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON outbox (seq) WHERE publishedat IS NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    userid varchar(36) NOT NULL,
+    key varchar(255) NOT NULL,
+    fingerprint text NOT NULL,
+    statuscode integer NOT NULL DEFAULT 0,
+    header jsonb NULL,
+    body bytea NULL,
+    createdat timestamptz NOT NULL,
+    expiresat timestamptz NOT NULL,
+    PRIMARY KEY (userid, key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expiresat_idx ON idempotency_keys (expiresat);
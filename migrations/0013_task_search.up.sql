@@ -0,0 +1,11 @@
+-- Термы simple сохраняют исходные формы слов, russian сводит русские слова к основе русским
+-- стеммером, а латинские - английским, поэтому по колонке ищут во всех трёх конфигурациях.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')) || to_tsvector('russian', coalesce(title, '')), 'A') ||
+    setweight(
+        to_tsvector('simple', coalesce(description, '')) || to_tsvector('russian', coalesce(description, '')),
+        'B'
+    )
+) STORED;
+
+CREATE INDEX IF NOT EXISTS tasks_search_idx ON tasks USING GIN (search);
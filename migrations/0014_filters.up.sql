@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS filters (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    userid varchar(36) NOT NULL,
+    name text NOT NULL,
+    query text NOT NULL,
+    createdat timestamptz NOT NULL,
+    UNIQUE (userid, name)
+);
@@ -0,0 +1,12 @@
+-- История не ссылается на tasks: записи остаются и после окончательного удаления задачи.
+CREATE TABLE IF NOT EXISTS task_history (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    taskid varchar(36) NOT NULL,
+    revision bigint NOT NULL,
+    actorid varchar(36) NOT NULL,
+    action text NOT NULL,
+    changes jsonb NOT NULL DEFAULT '[]',
+    changedat timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS task_history_taskid_revision_idx ON task_history (taskid, revision);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS comments (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    taskid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    parentid varchar(36) NOT NULL DEFAULT '',
+    authorid varchar(36) NOT NULL,
+    body text NOT NULL,
+    mentions text[] NOT NULL DEFAULT '{}',
+    deleted boolean NOT NULL DEFAULT false,
+    createdat timestamptz NOT NULL,
+    editedat timestamptz NULL
+);
+
+CREATE INDEX IF NOT EXISTS comments_taskid_createdat_idx ON comments (taskid, createdat);
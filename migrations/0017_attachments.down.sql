@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS attachments;
+DROP FUNCTION IF EXISTS attachments_orphan_blob();
+DROP TABLE IF EXISTS orphaned_blobs;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS attachments (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    taskid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    userid varchar(36) NOT NULL,
+    filename text NOT NULL,
+    contenttype text NOT NULL,
+    size bigint NOT NULL,
+    blobkey text NOT NULL UNIQUE,
+    createdat timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS attachments_taskid_idx ON attachments (taskid);
+
+-- Ключи содержимого удалённых вложений, в том числе удалённых каскадом вместе с задачами.
+-- Сами объекты удаляет сборщик в приложении: хранилище объектов не участвует в транзакциях.
+CREATE TABLE IF NOT EXISTS orphaned_blobs (
+    blobkey text NOT NULL PRIMARY KEY,
+    orphanedat timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE OR REPLACE FUNCTION attachments_orphan_blob() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO orphaned_blobs (blobkey) VALUES (OLD.blobkey) ON CONFLICT DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS attachments_orphan_blob ON attachments;
+CREATE TRIGGER attachments_orphan_blob AFTER DELETE ON attachments
+    FOR EACH ROW EXECUTE FUNCTION attachments_orphan_blob();
@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS statuscategory;
+
+DROP TABLE IF EXISTS workflows;
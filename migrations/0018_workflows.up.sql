@@ -0,0 +1,16 @@
+-- Проекты без строки в workflows используют workflow по умолчанию: New, In Progress и Done.
+CREATE TABLE IF NOT EXISTS workflows (
+    projectid varchar(36) NOT NULL PRIMARY KEY REFERENCES projects (id) ON DELETE CASCADE,
+    statuses jsonb NOT NULL,
+    transitions jsonb NOT NULL,
+    updatedat timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS statuscategory text NOT NULL DEFAULT 'todo';
+
+-- Существующие задачи получают категории своих статусов в workflow по умолчанию.
+UPDATE tasks SET statuscategory = CASE status
+    WHEN 'Done' THEN 'done'
+    WHEN 'In Progress' THEN 'doing'
+    ELSE 'todo'
+END;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tasks_customfields_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS customfields;
+
+DROP TABLE IF EXISTS custom_fields;
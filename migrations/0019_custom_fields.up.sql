@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    projectid varchar(36) NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    type text NOT NULL,
+    options jsonb,
+    createdat timestamptz NOT NULL,
+    UNIQUE (projectid, name)
+);
+
+-- Значения пользовательских полей задачи по ID определений: {"<id>": <значение>}.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS customfields jsonb NOT NULL DEFAULT '{}';
+
+-- Фильтры по значениям полей - проверки вхождения customfields @> '{"<id>": ...}'.
+CREATE INDEX IF NOT EXISTS tasks_customfields_idx ON tasks USING gin (customfields jsonb_path_ops);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS time_entries;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate;
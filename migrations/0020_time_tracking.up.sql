@@ -0,0 +1,16 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate integer NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS time_entries (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    taskid varchar(36) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    userid varchar(36) NOT NULL,
+    startedat timestamptz NOT NULL,
+    endedat timestamptz,
+    note text NOT NULL DEFAULT '',
+    CHECK (endedat IS NULL OR endedat >= startedat)
+);
+
+-- Запущенный таймер - запись без endedat, у пользователя он может быть только один.
+CREATE UNIQUE INDEX IF NOT EXISTS time_entries_running_idx ON time_entries (userid) WHERE endedat IS NULL;
+CREATE INDEX IF NOT EXISTS time_entries_taskid_idx ON time_entries (taskid);
+CREATE INDEX IF NOT EXISTS time_entries_startedat_idx ON time_entries (startedat);
@@ -0,0 +1,13 @@
+-- Шаблоны деревьев задач. Пустой projectid - личный шаблон автора, как и у задач.
+CREATE TABLE IF NOT EXISTS templates (
+    id varchar(36) NOT NULL PRIMARY KEY,
+    userid varchar(36) NOT NULL,
+    projectid varchar(36) NOT NULL DEFAULT '',
+    name text NOT NULL,
+    task jsonb NOT NULL,
+    variables text[] NOT NULL DEFAULT '{}',
+    createdat timestamptz NOT NULL,
+    UNIQUE (userid, name)
+);
+
+CREATE INDEX IF NOT EXISTS templates_projectid_idx ON templates (projectid);
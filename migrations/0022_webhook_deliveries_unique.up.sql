@@ -0,0 +1,7 @@
+DELETE FROM webhook_deliveries d
+USING webhook_deliveries o
+WHERE d.webhookid = o.webhookid
+  AND d.eventid = o.eventid
+  AND (d.createdat, d.id) > (o.createdat, o.id);
+
+ALTER TABLE webhook_deliveries ADD CONSTRAINT webhook_deliveries_webhookid_eventid_key UNIQUE (webhookid, eventid);
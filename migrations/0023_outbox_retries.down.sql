@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS outbox_pending_aggregate_idx;
+DROP INDEX IF EXISTS outbox_pending_idx;
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON outbox (seq) WHERE publishedat IS NULL;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS deadat;
+ALTER TABLE outbox DROP COLUMN IF EXISTS nextattemptat;
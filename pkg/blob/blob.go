@@ -0,0 +1,39 @@
+// Package blob - хранилище двоичных объектов по ключу с драйверами для локального диска
+// и S3-совместимых сервисов. Ключ - путь из сегментов через "/", без "." и "..".
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Store - хранилище объектов. Put перезаписывает объект целиком, Delete отсутствующего объекта
+// ошибкой не считается. Open возвращает объект с произвольным доступом, чтобы отдавать диапазоны.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Object - открытый объект, Size известен без чтения содержимого.
+type Object interface {
+	io.ReadSeekCloser
+	Size() int64
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || key == "." ||
+		strings.HasPrefix(key, "../") || key == ".." || strings.ContainsAny(key, "\\\x00") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+// Package filterexpr - разбор выражений фильтров вида `status:"In Progress" AND due<7d AND tag:urgent`.
+// Условие - это поле, оператор и значение, условия объединяются AND, OR и NOT со скобками,
+// а AND между соседними условиями можно не писать. Слово без поля и оператора - условие
+// с пустым полем. Смысл полей и значений пакет не проверяет, это дело вызывающего кода.
+package filterexpr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Операторы условий. "=" разбирается как ":", а "a!=b" - как NOT a:b.
+const (
+	OpEq        = ":"
+	OpLess      = "<"
+	OpLessEq    = "<="
+	OpGreater   = ">"
+	OpGreaterEq = ">="
+)
+
+// maxDepth - ограничение вложенности скобок и NOT, чтобы разбор не переполнил стек.
+const maxDepth = 64
+
+// SyntaxError - ошибка разбора, Pos - смещение в байтах от начала выражения.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// Node - узел дерева выражения: And, Or, Not или Cond.
+type Node interface {
+	String() string
+}
+
+type And struct {
+	Left  Node
+	Right Node
+}
+
+type Or struct {
+	Left  Node
+	Right Node
+}
+
+type Not struct {
+	Node Node
+}
+
+// Cond - условие. Field приводится к нижнему регистру, у слова без поля Field и Op пустые.
+type Cond struct {
+	Field string
+	Op    string
+	Value string
+	Pos   int
+}
+
+func (n And) String() string { return "(" + n.Left.String() + " AND " + n.Right.String() + ")" }
+func (n Or) String() string  { return "(" + n.Left.String() + " OR " + n.Right.String() + ")" }
+func (n Not) String() string { return "NOT " + n.Node.String() }
+
+func (n Cond) String() string {
+	return n.Field + n.Op + fmt.Sprintf("%q", n.Value)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// isWordRune - символы, из которых состоят слова без кавычек.
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`()":<>=!`, r)
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	// offsets - смещения рун в байтах, чтобы позиции ошибок совпадали со строкой.
+	offsets := make([]int, len(runes)+1)
+	offset := 0
+	for i, r := range runes {
+		offsets[i] = offset
+		offset += len(string(r))
+	}
+	offsets[len(runes)] = offset
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := offsets[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == '"':
+			var b strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					b.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, &SyntaxError{Pos: pos, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: b.String(), pos: pos})
+		case strings.ContainsRune(":<>=!", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != ':' && r != '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, &SyntaxError{Pos: pos, Msg: `unexpected "!", expected "!="`}
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: pos})
+			i += len(op)
+		default:
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i]), pos: pos})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// Parse - разбирает выражение. Приоритет операторов: NOT, затем AND, затем OR.
+func Parse(input string) (Node, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, &SyntaxError{Pos: 0, Msg: "empty expression"}
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword - AND, OR и NOT в любом регистре. Чтобы искать само слово, его берут в кавычки.
+func (p *parser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenWord && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("OR") {
+		p.next()
+		right, errRight := p.parseAnd()
+		if errRight != nil {
+			return nil, errRight
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if p.isKeyword("AND") {
+			p.next()
+		} else if tok := p.peek(); tok.kind == tokenEOF || tok.kind == tokenRParen || p.isKeyword("OR") {
+			return left, nil
+		}
+
+		right, errRight := p.parseUnary()
+		if errRight != nil {
+			return nil, errRight
+		}
+		left = And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, &SyntaxError{Pos: p.peek().pos, Msg: "expression is nested too deep"}
+	}
+
+	if p.isKeyword("NOT") {
+		p.next()
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Node: node}, nil
+	}
+
+	tok := p.next()
+	switch tok.kind {
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, &SyntaxError{Pos: closing.pos, Msg: `expected ")"`}
+		}
+		return node, nil
+	case tokenString:
+		return Cond{Value: tok.text, Pos: tok.pos}, nil
+	case tokenWord:
+		return p.parseCond(tok)
+	case tokenEOF:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "unexpected end of expression"}
+	default:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+}
+
+// parseCond - field, затем необязательные оператор и значение.
+func (p *parser) parseCond(field token) (Node, error) {
+	if p.peek().kind != tokenOp {
+		return Cond{Value: field.text, Pos: field.pos}, nil
+	}
+
+	op := p.next()
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("expected value after %q", op.text)}
+	}
+
+	cond := Cond{Field: strings.ToLower(field.text), Op: op.text, Value: value.text, Pos: field.pos}
+	switch op.text {
+	case "=":
+		cond.Op = OpEq
+	case "!=":
+		cond.Op = OpEq
+		return Not{Node: cond}, nil
+	}
+	return cond, nil
+}
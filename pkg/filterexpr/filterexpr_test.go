@@ -0,0 +1,72 @@
+package filterexpr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`tag:urgent`, `tag:"urgent"`},
+		{`status:"In Progress" AND due<7d AND tag:urgent`, `((status:"In Progress" AND due<"7d") AND tag:"urgent")`},
+		{`Status="New" tag:home`, `(status:"New" AND tag:"home")`},
+		{`a:1 OR b:2 c:3`, `(a:"1" OR (b:"2" AND c:"3"))`},
+		{`(a:1 or b:2) and not c:3`, `((a:"1" OR b:"2") AND NOT c:"3")`},
+		{`due>=-1d due<=now`, `(due>="-1d" AND due<="now")`},
+		{`priority!=low`, `NOT priority:"low"`},
+		{`invoice "or" "a \"b\""`, `(("invoice" AND "or") AND "a \"b\"")`},
+		{`счёт tag:дом`, `("счёт" AND tag:"дом")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			node, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, node.String())
+		})
+	}
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{``, 0},
+		{`   `, 0},
+		{`tag:`, 4},
+		{`tag:urgent AND`, 14},
+		{`(tag:urgent`, 11},
+		{`tag:urgent)`, 10},
+		{`status:"New`, 7},
+		{`a!b`, 1},
+		{`tag::x`, 4},
+		{`дом OR`, 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var syntaxErr *SyntaxError
+			require.True(t, errors.As(err, &syntaxErr), "got %v", err)
+			assert.Equal(t, tt.pos, syntaxErr.Pos)
+		})
+	}
+}
+
+func TestParse_TooDeep(t *testing.T) {
+	input := ""
+	for range maxDepth + 1 {
+		input += "("
+	}
+
+	_, err := Parse(input + "a")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nested too deep")
+}
@@ -0,0 +1,328 @@
+// Package fulltext - полнотекстовый поиск в памяти, повторяющий конфигурации Postgres simple,
+// english и russian: слова приводятся к нижнему регистру, стоп-слова отбрасываются,
+// остальные сводятся к основе стеммером snowball.
+package fulltext
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kljensen/snowball/english"
+	"github.com/kljensen/snowball/russian"
+)
+
+const (
+	Simple  = "simple"
+	English = "english"
+	Russian = "russian"
+)
+
+// indexLanguages - конфигурации, термы которых попадают в индекс. В russian латинские слова
+// обрабатываются английским стеммером, поэтому по индексу можно искать и в english.
+var indexLanguages = []string{Simple, Russian}
+
+// word - слово текста, start и end - его границы в байтах.
+type word struct {
+	text  string
+	start int
+	end   int
+}
+
+// words - последовательности букв и цифр, остальные символы считаются разделителями.
+func words(text string) []word {
+	var result []word
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			result = append(result, word{text: text[start:i], start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		result = append(result, word{text: text[start:], start: start, end: len(text)})
+	}
+	return result
+}
+
+// term - терм слова в конфигурации language, false для стоп-слов.
+func term(w string, language string) (string, bool) {
+	w = strings.ToLower(w)
+
+	switch {
+	case language == Simple || strings.ContainsFunc(w, unicode.IsDigit):
+		return w, true
+	case language == English || isASCII(w):
+		if english.IsStopWord(w) {
+			return "", false
+		}
+		return english.Stem(w, false), true
+	default:
+		if russian.IsStopWord(w) {
+			return "", false
+		}
+		// Стеммер snowball не различает е и ё, а пишут их вперемешку.
+		return russian.Stem(strings.ReplaceAll(w, "ё", "е"), false), true
+	}
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Terms - термы текста в конфигурации language по порядку слов.
+func Terms(text string, language string) []string {
+	var terms []string
+	for _, w := range words(text) {
+		if t, ok := term(w.text, language); ok {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// Query - разобранный запрос в синтаксисе websearch_to_tsquery: варианты разделяются словом or,
+// в варианте обязательны все термы, а термы со знаком минус должны отсутствовать.
+// Кавычки допускаются, но фраза ищется как набор слов без учёта порядка.
+type Query struct {
+	language string
+	groups   []queryGroup
+}
+
+type queryGroup struct {
+	include []string
+	exclude []string
+}
+
+func ParseQuery(query string, language string) Query {
+	parsed := Query{language: language}
+
+	var group queryGroup
+	flush := func() {
+		if len(group.include) != 0 {
+			parsed.groups = append(parsed.groups, group)
+		}
+		group = queryGroup{}
+	}
+
+	for _, field := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if strings.EqualFold(field, "or") {
+			flush()
+			continue
+		}
+
+		negated := strings.HasPrefix(field, "-")
+		for _, w := range words(field) {
+			t, ok := term(w.text, language)
+			if !ok {
+				continue
+			}
+			if negated {
+				group.exclude = append(group.exclude, t)
+			} else {
+				group.include = append(group.include, t)
+			}
+		}
+	}
+	flush()
+
+	return parsed
+}
+
+// IsEmpty - в запросе нет ни одного искомого терма, например он состоит из одних стоп-слов.
+func (q Query) IsEmpty() bool {
+	return len(q.groups) == 0
+}
+
+// Matches - хотя бы один вариант запроса подходит к совокупности texts, как если бы они
+// были одним документом. Термы берутся из тех же конфигураций, что и в Index.
+func (q Query) Matches(texts ...string) bool {
+	terms := make(map[string]bool)
+	for _, text := range texts {
+		for _, language := range indexLanguages {
+			for _, t := range Terms(text, language) {
+				terms[t] = true
+			}
+		}
+	}
+
+	for _, group := range q.groups {
+		if !slices.ContainsFunc(group.include, func(t string) bool { return !terms[t] }) &&
+			!slices.ContainsFunc(group.exclude, func(t string) bool { return terms[t] }) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q Query) matches(t string) bool {
+	for _, group := range q.groups {
+		if slices.Contains(group.include, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Field - поле документа, каждое совпадение в нём добавляет к рангу Weight.
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Index - инвертированный индекс: терм -> документы с весом совпадений терма в них.
+// Index не потокобезопасен.
+type Index struct {
+	postings map[string]map[string]float64
+	docs     map[string][]string
+}
+
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[string]float64),
+		docs:     make(map[string][]string),
+	}
+}
+
+// Add - индексирует документ, прежние термы документа с тем же ID удаляются.
+func (idx *Index) Add(docID string, fields ...Field) {
+	idx.Remove(docID)
+
+	weights := make(map[string]float64)
+	for _, field := range fields {
+		// Слово, одинаковое в нескольких конфигурациях, считается один раз.
+		counts := make(map[string]int)
+		for _, language := range indexLanguages {
+			languageCounts := make(map[string]int)
+			for _, t := range Terms(field.Text, language) {
+				languageCounts[t]++
+			}
+			for t, count := range languageCounts {
+				counts[t] = max(counts[t], count)
+			}
+		}
+
+		for t, count := range counts {
+			weights[t] += field.Weight * float64(count)
+		}
+	}
+
+	for t, weight := range weights {
+		if idx.postings[t] == nil {
+			idx.postings[t] = make(map[string]float64)
+		}
+		idx.postings[t][docID] = weight
+	}
+	idx.docs[docID] = slices.Collect(maps.Keys(weights))
+}
+
+// Clone - независимая копия индекса: изменения копии не видны в исходном индексе.
+func (idx *Index) Clone() *Index {
+	cloned := &Index{
+		postings: make(map[string]map[string]float64, len(idx.postings)),
+		docs:     maps.Clone(idx.docs),
+	}
+	for t, docs := range idx.postings {
+		cloned.postings[t] = maps.Clone(docs)
+	}
+	return cloned
+}
+
+func (idx *Index) Remove(docID string) {
+	for _, t := range idx.docs[docID] {
+		delete(idx.postings[t], docID)
+		if len(idx.postings[t]) == 0 {
+			delete(idx.postings, t)
+		}
+	}
+	delete(idx.docs, docID)
+}
+
+// Search - подходящие под запрос документы с рангом, равным сумме весов совпавших термов.
+func (idx *Index) Search(query Query) map[string]float64 {
+	found := make(map[string]float64)
+
+	for _, group := range query.groups {
+		candidates := maps.Clone(idx.postings[group.include[0]])
+		for docID := range candidates {
+			candidates[docID] = 0
+		}
+
+		for _, t := range group.include {
+			for docID, rank := range candidates {
+				weight, ok := idx.postings[t][docID]
+				if !ok {
+					delete(candidates, docID)
+					continue
+				}
+				candidates[docID] = rank + weight
+			}
+		}
+
+		for _, t := range group.exclude {
+			for docID := range idx.postings[t] {
+				delete(candidates, docID)
+			}
+		}
+
+		for docID, rank := range candidates {
+			found[docID] = max(found[docID], rank)
+		}
+	}
+	return found
+}
+
+// Highlight - text, в котором слова, совпавшие с термами запроса, обёрнуты в start и stop.
+// При maxWords > 0 остаётся только фрагмент из maxWords слов вокруг первого совпадения.
+func Highlight(text string, query Query, maxWords int, start string, stop string) string {
+	ws := words(text)
+	if len(ws) == 0 {
+		return text
+	}
+
+	matched := make([]bool, len(ws))
+	first := -1
+	for i, w := range ws {
+		if t, ok := term(w.text, query.language); ok && query.matches(t) {
+			matched[i] = true
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+
+	from, to := 0, len(ws)
+	begin, end := 0, len(text)
+	if maxWords > 0 && len(ws) > maxWords {
+		from = max(0, first-maxWords/2)
+		to = min(len(ws), from+maxWords)
+		from = max(0, to-maxWords)
+		begin, end = ws[from].start, ws[to-1].end
+	}
+
+	var b strings.Builder
+	pos := begin
+	for i := from; i < to; i++ {
+		if !matched[i] {
+			continue
+		}
+		b.WriteString(text[pos:ws[i].start])
+		b.WriteString(start)
+		b.WriteString(ws[i].text)
+		b.WriteString(stop)
+		pos = ws[i].end
+	}
+	b.WriteString(text[pos:end])
+	return b.String()
+}
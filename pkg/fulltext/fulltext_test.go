@@ -0,0 +1,123 @@
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		text     string
+		language string
+		want     []string
+	}{
+		{"Pay the Invoices", Simple, []string{"pay", "the", "invoices"}},
+		{"Pay the Invoices", English, []string{"pay", "invoic"}},
+		{"Pay the Invoices", Russian, []string{"pay", "invoic"}},
+		{"Оплатить счета за март", Russian, []string{"оплат", "счет", "март"}},
+		{"Отчёт Q3-2026", Russian, []string{"отчет", "q3", "2026"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text+"/"+tt.language, func(t *testing.T) {
+			assert.Equal(t, tt.want, Terms(tt.text, tt.language))
+		})
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := NewIndex()
+	idx.Add("t1", Field{Text: "Invoices for March", Weight: 1}, Field{Text: "Send to accounting", Weight: 0.4})
+	idx.Add("t2", Field{Text: "Call accounting", Weight: 1}, Field{Text: "Ask about the invoice", Weight: 0.4})
+	idx.Add("t3", Field{Text: "Оплатить счета", Weight: 1}, Field{Text: "Счёт от поставщика", Weight: 0.4})
+
+	tests := []struct {
+		name     string
+		query    string
+		language string
+		want     map[string]float64
+	}{
+		{
+			name:     "all terms required",
+			query:    "that task about invoices",
+			language: English,
+			want:     map[string]float64{},
+		},
+		{
+			name:     "stemmed",
+			query:    "invoice",
+			language: English,
+			want:     map[string]float64{"t1": 1, "t2": 0.4},
+		},
+		{
+			name:     "simple keeps word forms",
+			query:    "invoice",
+			language: Simple,
+			want:     map[string]float64{"t2": 0.4},
+		},
+		{
+			name:     "russian",
+			query:    "счёт",
+			language: Russian,
+			want:     map[string]float64{"t3": 1.4},
+		},
+		{
+			name:     "excluded",
+			query:    "accounting -march",
+			language: English,
+			want:     map[string]float64{"t2": 1},
+		},
+		{
+			name:     "or",
+			query:    `"call accounting" or счета`,
+			language: Russian,
+			want:     map[string]float64{"t2": 2, "t3": 1.4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := idx.Search(ParseQuery(tt.query, tt.language))
+			assert.InDeltaMapValues(t, tt.want, got, 1e-9)
+		})
+	}
+
+	cloned := idx.Clone()
+	cloned.Add("t4", Field{Text: "Invoice", Weight: 1})
+	cloned.Remove("t2")
+	assert.Equal(t, map[string]float64{"t4": 1}, cloned.Search(ParseQuery("invoice", Simple)))
+
+	idx.Remove("t1")
+	assert.Equal(t, map[string]float64{"t2": 0.4}, idx.Search(ParseQuery("invoice", English)))
+	assert.True(t, ParseQuery("the and of", English).IsEmpty())
+}
+
+func TestHighlight(t *testing.T) {
+	query := ParseQuery("invoice", English)
+
+	assert.Equal(t, "Pay <b>invoices</b>!", Highlight("Pay invoices!", query, 0, "<b>", "</b>"))
+	assert.Equal(t, "c d <b>invoice</b> e f",
+		Highlight("a b c d invoice e f g h", query, 5, "<b>", "</b>"))
+	assert.Equal(t, "a b c", Highlight("a b c d e", query, 3, "<b>", "</b>"))
+}
+
+func TestQuery_Matches(t *testing.T) {
+	tests := []struct {
+		query string
+		texts []string
+		want  bool
+	}{
+		{"invoice", []string{"Pay invoices", ""}, true},
+		{"оплата счетов", []string{"Оплатить", "счёт от поставщика"}, true},
+		{"invoice -march", []string{"Invoices for March"}, false},
+		{"march or april", []string{"Report for April"}, true},
+		{"report", []string{"Invoices for March"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseQuery(tt.query, Russian).Matches(tt.texts...))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Package mention - поиск упоминаний вида @name в тексте Markdown. Упоминания внутри блоков кода
+// и `кода в строке` не считаются, как и @ внутри слова, например в адресе почты.
+package mention
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Names - имена из упоминаний, приведённые через Key, без повторов в порядке появления.
+func Names(markdown string) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	for _, line := range textLines(markdown) {
+		runes := []rune(line)
+		for i := 0; i < len(runes); i++ {
+			if runes[i] != '@' || i > 0 && isNameRune(runes[i-1]) {
+				continue
+			}
+
+			end := i + 1
+			for end < len(runes) && isNameRune(runes[end]) {
+				end++
+			}
+			// Точка или дефис в конце - это знак препинания после имени.
+			name := Key(strings.TrimRight(string(runes[i+1:end]), ".-"))
+			i = end - 1
+
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// Key - имя в том виде, в каком его сравнивают с упоминанием: без пробелов и в нижнем регистре.
+// Пользователя "Anna Smith" упоминают как @AnnaSmith или @annasmith.
+func Key(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), ""))
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
+
+// textLines - строки вне блоков кода, из которых вырезан код в строке.
+func textLines(markdown string) []string {
+	var lines []string
+	fence := ""
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		lines = append(lines, stripCodeSpans(line))
+	}
+	return lines
+}
+
+// stripCodeSpans - код в строке ограничен одинаковыми сериями обратных кавычек,
+// незакрытая серия остаётся обычным текстом.
+func stripCodeSpans(line string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(line, "`")
+		if start < 0 {
+			b.WriteString(line)
+			return b.String()
+		}
+
+		run := len(line[start:]) - len(strings.TrimLeft(line[start:], "`"))
+		closing := closingRun(line[start+run:], run)
+		if closing < 0 {
+			b.WriteString(line[:start+run])
+			line = line[start+run:]
+			continue
+		}
+
+		b.WriteString(line[:start])
+		b.WriteString(" ")
+		line = line[start+run+closing+run:]
+	}
+}
+
+// closingRun - смещение серии ровно из run обратных кавычек или -1.
+func closingRun(s string, run int) int {
+	for i := 0; i < len(s); {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && s[j] == '`' {
+			j++
+		}
+		if j-i == run {
+			return i
+		}
+		i = j
+	}
+	return -1
+}
@@ -0,0 +1,35 @@
+package mention
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"@anna, посмотри", []string{"anna"}},
+		{"cc @Anna and @bob.", []string{"anna", "bob"}},
+		{"@anna @ANNA @anna_s", []string{"anna", "anna_s"}},
+		{"(@иван) @j.doe-", []string{"иван", "j.doe"}},
+		{"mail anna@example.com", nil},
+		{"@ alone @", nil},
+		{"`@anna` and ``a ` @bob`` @carl", []string{"carl"}},
+		{"unclosed ` @anna", []string{"anna"}},
+		{"before\n```go\n@anna\n```\n@bob\n~~~\n@carl", []string{"bob"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, Names(tt.input))
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "annasmith", Key(" Anna  Smith "))
+	assert.Equal(t, "иван", Key("Иван"))
+}
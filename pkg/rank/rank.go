@@ -0,0 +1,146 @@
+// Package rank - лексикографические ранги для ручной сортировки.
+// Между любыми двумя рангами всегда найдётся ещё один, поэтому перемещение
+// элемента меняет только его собственный ранг, остальной список не трогается.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var (
+	ErrWrongOrder = errors.New("lower rank must be less than upper rank")
+	ErrBadRank    = errors.New("rank contains invalid characters or trailing zero")
+)
+
+// Between - ранг строго между lower и upper. Пустой lower - начало списка, пустой upper - конец:
+// тогда ранг строится через Before и After, чтобы вставки в край списка не удлиняли ранги.
+func Between(lower string, upper string) (string, error) {
+	if !valid(lower) || !valid(upper) {
+		return "", ErrBadRank
+	}
+
+	switch {
+	case lower != "" && upper == "":
+		return increment(lower), nil
+	case lower == "" && upper != "":
+		return decrement(upper), nil
+	case upper != "" && lower >= upper:
+		return "", ErrWrongOrder
+	}
+
+	return midpoint(lower, upper), nil
+}
+
+// After - ранг после last, для добавления в конец списка. Пустой last - первый элемент списка.
+func After(last string) (string, error) {
+	return Between(last, "")
+}
+
+// Before - ранг перед first, для добавления в начало списка. Пустой first - первый элемент списка.
+func Before(first string) (string, error) {
+	return Between("", first)
+}
+
+// increment - следующий ранг той же длины: к рангу как к числу прибавляется единица с переносом.
+// Ранг из одних 'z' увеличить нельзя, тогда он удлиняется вдвое, поэтому длина рангов при
+// добавлении в конец растёт логарифмически от числа элементов.
+func increment(r string) string {
+	next := []byte(r)
+	for i := len(next) - 1; i >= 0; i-- {
+		d := strings.IndexByte(digits, next[i])
+		if d < len(digits)-1 {
+			next[i] = digits[d+1]
+			// Ранг не может заканчиваться на '0', а перенос обнулил младшие разряды.
+			if i < len(next)-1 {
+				next[len(next)-1] = digits[1]
+			}
+			return string(next)
+		}
+		next[i] = digits[0]
+	}
+
+	return r + strings.Repeat(digits[:1], len(r)) + digits[1:2]
+}
+
+// decrement - предыдущий ранг той же длины, симметрично increment: из ранга вычитается единица
+// с заёмом, а '0' на конце пропускается. Ранг "0...01" удлиняется вдвое.
+func decrement(r string) string {
+	prev := []byte(r)
+	for i := len(prev) - 1; i >= 0; i-- {
+		d := strings.IndexByte(digits, prev[i])
+		if d > 1 || d == 1 && i < len(prev)-1 {
+			prev[i] = digits[d-1]
+			return string(prev)
+		}
+		prev[i] = digits[len(digits)-1]
+	}
+
+	return strings.Repeat(digits[:1], len(r)) + strings.Repeat(digits[len(digits)-1:], len(r))
+}
+
+// midpoint - ранги не заканчиваются на '0', иначе между "x" и "x0" места бы не осталось.
+func midpoint(lower string, upper string) string {
+	if upper != "" {
+		n := 0
+		for n < len(upper) && digitAt(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			return upper[:n] + midpoint(suffix(lower, n), upper[n:])
+		}
+	}
+
+	digitLower := 0
+	if lower != "" {
+		digitLower = strings.IndexByte(digits, lower[0])
+	}
+
+	digitUpper := len(digits)
+	if upper != "" {
+		digitUpper = strings.IndexByte(digits, upper[0])
+	}
+
+	if digitUpper-digitLower > 1 {
+		return string(digits[(digitLower+digitUpper+1)/2])
+	}
+
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+
+	return string(digits[digitLower]) + midpoint(suffix(lower, 1), "")
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return digits[0]
+}
+
+func suffix(s string, n int) string {
+	if n >= len(s) {
+		return ""
+	}
+	return s[n:]
+}
+
+func valid(r string) bool {
+	if r == "" {
+		return true
+	}
+
+	if r[len(r)-1] == digits[0] {
+		return false
+	}
+
+	for i := range len(r) {
+		if strings.IndexByte(digits, r[i]) == -1 {
+			return false
+		}
+	}
+	return true
+}
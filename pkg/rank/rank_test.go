@@ -0,0 +1,89 @@
+package rank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		lower   string
+		upper   string
+		wantErr error
+	}{
+		{name: "empty list", lower: "", upper: ""},
+		{name: "append", lower: "i", upper: ""},
+		{name: "prepend", lower: "", upper: "i"},
+		{name: "between far", lower: "a", upper: "z"},
+		{name: "between adjacent", lower: "a", upper: "b"},
+		{name: "between prefix", lower: "a", upper: "a1"},
+		{name: "migrated ranks", lower: "a0000000009i", upper: "a0000000010i"},
+		{name: "wrong order", lower: "b", upper: "a", wantErr: ErrWrongOrder},
+		{name: "equal", lower: "b", upper: "b", wantErr: ErrWrongOrder},
+		{name: "trailing zero", lower: "a0", upper: "", wantErr: ErrBadRank},
+		{name: "bad char", lower: "A", upper: "", wantErr: ErrBadRank},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.lower, tt.upper)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Greater(t, got, tt.lower)
+			if tt.upper != "" {
+				assert.Less(t, got, tt.upper)
+			}
+			assert.True(t, valid(got))
+		})
+	}
+}
+
+func TestBetweenRepeatedInserts(t *testing.T) {
+	// Постоянная вставка в одно и то же место не должна упираться в тупик.
+	lower, upper := "a", "b"
+	for range 200 {
+		mid, err := Between(lower, upper)
+		require.NoError(t, err)
+		require.Greater(t, mid, lower)
+		require.Less(t, mid, upper)
+		upper = mid
+	}
+
+	last := ""
+	for range 200 {
+		next, err := Between(last, "")
+		require.NoError(t, err)
+		require.Greater(t, next, last)
+		last = next
+	}
+}
+
+func TestAfterBeforeKeepRanksShort(t *testing.T) {
+	// Ранги при добавлении в края списка растут логарифмически: на 5000 вставок хватает 8 символов.
+	last := ""
+	for range 5000 {
+		next, err := After(last)
+		require.NoError(t, err)
+		require.Greater(t, next, last)
+		require.True(t, valid(next))
+		last = next
+	}
+	assert.LessOrEqual(t, len(last), 8)
+
+	first := "i"
+	for range 5000 {
+		prev, err := Before(first)
+		require.NoError(t, err)
+		require.Less(t, prev, first)
+		require.True(t, valid(prev))
+		first = prev
+	}
+	assert.LessOrEqual(t, len(first), 8)
+}
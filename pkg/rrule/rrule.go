@@ -0,0 +1,268 @@
+// Package rrule - подмножество правил повторения RFC 5545: FREQ=DAILY/WEEKLY/MONTHLY,
+// INTERVAL, BYDAY, COUNT и UNTIL. Следующее повторение считается от предыдущего,
+// поэтому правилу не нужен исходный DTSTART.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxPeriods - сколько периодов перебирать в поисках следующего повторения,
+// например правило "пятый понедельник месяца" иногда пропускает несколько месяцев.
+const maxPeriods = 1000
+
+var (
+	ErrBadRule     = errors.New("invalid recurrence rule")
+	ErrUnsupported = errors.New("unsupported recurrence rule part")
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Weekday - элемент BYDAY. N - порядковый номер дня в месяце (-1 - последний), 0 - любой.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []Weekday
+	Count    int
+	Until    time.Time
+}
+
+// Parse - разбор строки вида "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", префикс "RRULE:" допускается.
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrBadRule)
+	}
+
+	for part := range strings.SplitSeq(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Rule{}, fmt.Errorf("%w: %q", ErrBadRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly {
+				return Rule{}, fmt.Errorf("%w: FREQ=%s", ErrUnsupported, value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = positive(value)
+		case "COUNT":
+			rule.Count, err = positive(value)
+		case "UNTIL":
+			rule.Until, err = parseUntil(value)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				err = fmt.Errorf("%w: WKST=%s", ErrUnsupported, value)
+			}
+		default:
+			err = fmt.Errorf("%w: %s", ErrUnsupported, key)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrBadRule)
+	}
+	if rule.Count != 0 && !rule.Until.IsZero() {
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrBadRule)
+	}
+	if rule.Freq != Monthly && slices.ContainsFunc(rule.ByDay, func(d Weekday) bool { return d.N != 0 }) {
+		return Rule{}, fmt.Errorf("%w: numbered BYDAY is allowed only for MONTHLY", ErrBadRule)
+	}
+
+	return rule, nil
+}
+
+func positive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w: expected positive number, got %q", ErrBadRule, value)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// Дата без времени включает весь день.
+				until = until.Add(24*time.Hour - time.Nanosecond)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL=%s", ErrBadRule, value)
+}
+
+func parseByDay(value string) ([]Weekday, error) {
+	var days []Weekday
+	for item := range strings.SplitSeq(strings.ToUpper(value), ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrBadRule, value)
+		}
+
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrBadRule, value)
+		}
+
+		var n int
+		if ordinal := item[:len(item)-2]; ordinal != "" {
+			var err error
+			n, err = strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("%w: BYDAY=%s", ErrBadRule, value)
+			}
+		}
+
+		days = append(days, Weekday{Day: day, N: n})
+	}
+	return days, nil
+}
+
+// String - каноническая запись правила без префикса "RRULE:".
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) != 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, d := range r.ByDay {
+			name := strings.ToUpper(d.Day.String()[:2])
+			if d.N != 0 {
+				name = strconv.Itoa(d.N) + name
+			}
+			days = append(days, name)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count != 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next - повторение, следующее за prev. occurrence - порядковый номер prev в серии начиная с 1,
+// нужен для COUNT. false означает, что серия закончилась.
+func (r Rule) Next(prev time.Time, occurrence int) (time.Time, bool) {
+	if r.Count != 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	interval := max(r.Interval, 1)
+
+	for period := 0; period < maxPeriods; period++ {
+		for _, candidate := range r.candidates(prev, period*interval) {
+			if !candidate.After(prev) {
+				continue
+			}
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return time.Time{}, false
+			}
+			return candidate, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// candidates - возможные повторения в периоде, отстоящем от периода prev на shift единиц FREQ.
+func (r Rule) candidates(prev time.Time, shift int) []time.Time {
+	switch r.Freq {
+	case Daily:
+		day := prev.AddDate(0, 0, shift)
+		if len(r.ByDay) != 0 && !slices.ContainsFunc(r.ByDay, func(d Weekday) bool { return d.Day == day.Weekday() }) {
+			return nil
+		}
+		return []time.Time{day}
+	case Weekly:
+		monday := prev.AddDate(0, 0, -daysSinceMonday(prev.Weekday())+7*shift)
+		if len(r.ByDay) == 0 {
+			return []time.Time{monday.AddDate(0, 0, daysSinceMonday(prev.Weekday()))}
+		}
+		days := make([]time.Time, 0, len(r.ByDay))
+		for _, d := range r.ByDay {
+			days = append(days, monday.AddDate(0, 0, daysSinceMonday(d.Day)))
+		}
+		slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+		return days
+	case Monthly:
+		first := time.Date(prev.Year(), prev.Month()+time.Month(shift), 1,
+			prev.Hour(), prev.Minute(), prev.Second(), prev.Nanosecond(), prev.Location())
+		last := first.AddDate(0, 1, -1).Day()
+		if len(r.ByDay) == 0 {
+			if prev.Day() > last {
+				return nil
+			}
+			return []time.Time{first.AddDate(0, 0, prev.Day()-1)}
+		}
+		var days []time.Time
+		for day := 1; day <= last; day++ {
+			date := first.AddDate(0, 0, day-1)
+			if slices.ContainsFunc(r.ByDay, func(d Weekday) bool { return matchesMonthDay(d, date, last) }) {
+				days = append(days, date)
+			}
+		}
+		return days
+	default:
+		return nil
+	}
+}
+
+func daysSinceMonday(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// matchesMonthDay - подходит ли дата под элемент BYDAY с учётом порядкового номера в месяце.
+func matchesMonthDay(d Weekday, date time.Time, daysInMonth int) bool {
+	if d.Day != date.Weekday() {
+		return false
+	}
+	switch {
+	case d.N > 0:
+		return (date.Day()-1)/7+1 == d.N
+	case d.N < 0:
+		return (daysInMonth-date.Day())/7+1 == -d.N
+	default:
+		return true
+	}
+}
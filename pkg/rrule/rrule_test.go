@@ -0,0 +1,137 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr error
+	}{
+		{name: "daily", rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{
+			name: "prefix and case",
+			rule: "RRULE:freq=weekly;interval=2;byday=mo,we",
+			want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+		},
+		{name: "monthly ordinal", rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", want: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3"},
+		{name: "until date", rule: "FREQ=DAILY;UNTIL=20260105", want: "FREQ=DAILY;UNTIL=20260105T235959Z"},
+		{name: "empty", rule: "", wantErr: ErrBadRule},
+		{name: "no freq", rule: "INTERVAL=2", wantErr: ErrBadRule},
+		{name: "yearly", rule: "FREQ=YEARLY", wantErr: ErrUnsupported},
+		{name: "unknown part", rule: "FREQ=DAILY;BYHOUR=9", wantErr: ErrUnsupported},
+		{name: "zero interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: ErrBadRule},
+		{name: "count with until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20260105", wantErr: ErrBadRule},
+		{name: "bad day", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: ErrBadRule},
+		{name: "ordinal in weekly", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: ErrBadRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		// start - первое повторение, want - следующие за ним.
+		start string
+		want  []string
+	}{
+		{
+			name:  "every other day",
+			rule:  "FREQ=DAILY;INTERVAL=2",
+			start: "2026-01-30 09:00",
+			want:  []string{"2026-02-01 09:00", "2026-02-03 09:00"},
+		},
+		{
+			name:  "weekdays only",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			start: "2026-01-09 09:00", // пятница
+			want:  []string{"2026-01-12 09:00", "2026-01-13 09:00"},
+		},
+		{
+			name:  "biweekly on monday and wednesday",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+			start: "2026-01-05 18:30", // понедельник
+			want:  []string{"2026-01-07 18:30", "2026-01-19 18:30", "2026-01-21 18:30"},
+		},
+		{
+			name:  "weekly without byday",
+			rule:  "FREQ=WEEKLY",
+			start: "2026-01-08 10:00",
+			want:  []string{"2026-01-15 10:00", "2026-01-22 10:00"},
+		},
+		{
+			name:  "monthly skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: "2026-01-31 08:00",
+			want:  []string{"2026-03-31 08:00", "2026-05-31 08:00"},
+		},
+		{
+			name:  "last friday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: "2026-01-30 17:00",
+			want:  []string{"2026-02-27 17:00", "2026-03-27 17:00"},
+		},
+		{
+			name:  "count",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: "2026-01-01 09:00",
+			want:  []string{"2026-01-02 09:00", "2026-01-03 09:00"},
+		},
+		{
+			name:  "until",
+			rule:  "FREQ=WEEKLY;UNTIL=20260115",
+			start: "2026-01-01 09:00",
+			want:  []string{"2026-01-08 09:00", "2026-01-15 09:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			require.NoError(t, err)
+
+			prev := date(tt.start)
+			var got []string
+			for occurrence := 1; occurrence <= 10; occurrence++ {
+				next, ok := rule.Next(prev, occurrence)
+				if !ok {
+					break
+				}
+				got = append(got, next.Format("2006-01-02 15:04"))
+				if len(got) == len(tt.want) && rule.Count == 0 && rule.Until.IsZero() {
+					break
+				}
+				prev = next
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}